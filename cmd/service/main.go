@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"log/slog"
@@ -13,10 +14,14 @@ import (
 	"github.com/gofiber/swagger"
 	"github.com/samber/do/v2"
 	"github.com/zercle/template-go-fiber/internal/config"
+	"github.com/zercle/template-go-fiber/internal/domains"
 	"github.com/zercle/template-go-fiber/internal/handlers"
 	"github.com/zercle/template-go-fiber/internal/middleware"
 	"github.com/zercle/template-go-fiber/internal/repositories"
 	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/pkg/oidc"
+	"github.com/zercle/template-go-fiber/pkg/password"
 	_ "github.com/zercle/template-go-fiber/docs"
 )
 
@@ -49,10 +54,165 @@ func main() {
 	app.Use(middleware.LoggerMiddleware(logger))
 	app.Use(middleware.RateLimitMiddleware(middleware.NewRateLimiter(100, time.Minute)))
 
+	// Scope every request, and with it every user query, to a tenant
+	tenantRepo := do.MustInvoke[*repositories.TenantRepository](injector)
+	tenantUsecase := usecases.NewTenantUsecase(tenantRepo)
+	tenantHandler := handlers.NewTenantHandler(tenantUsecase)
+	app.Use(middleware.TenantMiddleware(tenantUsecase, middleware.TenantResolution{
+		Header:     cfg.Tenancy.Header,
+		BaseDomain: cfg.Tenancy.BaseDomain,
+	}))
+
 	// Initialize user repository and usecase from DI
 	userRepo := do.MustInvoke[*repositories.UserRepository](injector)
-	userUsecase := usecases.NewUserUsecase(userRepo)
-	userHandler := handlers.NewUserHandler(userUsecase)
+	hasher := do.MustInvoke[password.Hasher](injector)
+	refreshTokenRepo := do.MustInvoke[*repositories.RefreshTokenRepository](injector)
+	revocations := do.MustInvoke[domains.TokenRevocationStore](injector)
+	roleRepo := do.MustInvoke[*repositories.RoleRepository](injector)
+
+	// Authorize callers with the permissions they hold now, including those
+	// of their groups, rather than those their token was issued with
+	permissionCache := usecases.NewPermissionCache(roleRepo, usecases.PermissionCacheConfig{
+		TTL: cfg.RBAC.PermissionCacheTTL,
+	})
+	app.Use(middleware.ResolvePermissions(permissionCache))
+
+	// Initialize email verification with the configured mail sender
+	verificationTokenRepo := do.MustInvoke[*repositories.EmailVerificationTokenRepository](injector)
+	mailSender := do.MustInvoke[domains.MailSender](injector)
+	verificationUsecase := usecases.NewEmailVerificationUsecase(userRepo, verificationTokenRepo, mailSender, logger, usecases.EmailVerificationConfig{
+		TokenTTL:       cfg.EmailVerification.TokenTTL,
+		ResendInterval: cfg.EmailVerification.ResendInterval,
+		URL:            cfg.EmailVerification.URL,
+	})
+	verificationHandler := handlers.NewEmailVerificationHandler(verificationUsecase)
+
+	userUsecase := usecases.NewUserUsecase(userRepo, hasher, revocations, refreshTokenRepo, roleRepo, verificationUsecase, usecases.UserConfig{
+		CursorSecret: []byte(cfg.Pagination.CursorSecret),
+	})
+	userHandler := handlers.NewUserHandler(userUsecase, handlers.UserHandlerConfig{
+		RequireIfMatch: cfg.Concurrency.RequireIfMatch,
+	})
+
+	// Purge deleted users once their retention period has passed
+	if cfg.UserRetention.Retention > 0 {
+		retentionUsecase := usecases.NewUserRetentionUsecase(userRepo, tenantRepo, logger, usecases.UserRetentionConfig{
+			Retention: cfg.UserRetention.Retention,
+		})
+		go purgeDeletedUsers(retentionUsecase, cfg.UserRetention.PurgeInterval, logger)
+	}
+
+	// Initialize password change and reset
+	passwordResetTokenRepo := do.MustInvoke[*repositories.PasswordResetTokenRepository](injector)
+	passwordUsecase := usecases.NewPasswordUsecase(userRepo, passwordResetTokenRepo, hasher, revocations, refreshTokenRepo, mailSender, logger, usecases.PasswordResetConfig{
+		TokenTTL:        cfg.PasswordReset.TokenTTL,
+		RequestInterval: cfg.PasswordReset.RequestInterval,
+		URL:             cfg.PasswordReset.URL,
+	})
+	passwordHandler := handlers.NewPasswordHandler(passwordUsecase)
+
+	// Initialize invitations into the tenant
+	invitationRepo := do.MustInvoke[*repositories.InvitationRepository](injector)
+	invitationUsecase := usecases.NewInvitationUsecase(invitationRepo, userRepo, userUsecase, roleRepo, tenantRepo, mailSender, usecases.InvitationConfig{
+		TokenTTL: cfg.Invitation.TokenTTL,
+		URL:      cfg.Invitation.URL,
+	})
+	invitationHandler := handlers.NewInvitationHandler(invitationUsecase)
+
+	// Initialize groups, whose roles apply to all of their members
+	groupRepo := do.MustInvoke[*repositories.GroupRepository](injector)
+	groupUsecase := usecases.NewGroupUsecase(groupRepo, userRepo, roleRepo, permissionCache)
+	groupHandler := handlers.NewGroupHandler(groupUsecase)
+
+	// Initialize TOTP enrollment
+	mfaRepo := do.MustInvoke[*repositories.MFARepository](injector)
+	mfaUsecase := usecases.NewMFAUsecase(userRepo, mfaRepo, hasher, usecases.MFAConfig{
+		Issuer: cfg.MFA.Issuer,
+	})
+	mfaHandler := handlers.NewMFAHandler(mfaUsecase)
+
+	// Initialize brute-force protection for logins
+	loginAttempts := do.MustInvoke[domains.LoginAttemptStore](injector)
+	lockoutUsecase := usecases.NewLockoutUsecase(userRepo, loginAttempts, logger, usecases.LockoutPolicy{
+		FailureWindow:        cfg.Lockout.FailureWindow,
+		MaxAccountFailures:   cfg.Lockout.MaxAccountFailures,
+		MaxIPAccountFailures: cfg.Lockout.MaxIPAccountFailures,
+		Duration:             cfg.Lockout.Duration,
+		DelayAfter:           cfg.Lockout.DelayAfter,
+		BaseDelay:            cfg.Lockout.BaseDelay,
+		MaxDelay:             cfg.Lockout.MaxDelay,
+	})
+	lockoutHandler := handlers.NewLockoutHandler(lockoutUsecase)
+
+	// Initialize API keys for machine-to-machine clients
+	apiKeyRepo := do.MustInvoke[*repositories.APIKeyRepository](injector)
+	apiKeyUsecase := usecases.NewAPIKeyUsecase(userRepo, apiKeyRepo, roleRepo, usecases.APIKeyConfig{
+		LastUsedInterval: cfg.APIKey.LastUsedInterval,
+	})
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyUsecase)
+
+	// Initialize cookie sessions when browsers authenticate in session mode
+	var sessionUsecase domains.SessionUsecase
+	sessionCookie := &middleware.SessionCookie{
+		Name:     cfg.Session.CookieName,
+		CSRFName: cfg.Session.CSRFCookieName,
+		Domain:   cfg.Session.CookieDomain,
+		Secure:   cfg.Session.CookieSecure,
+		SameSite: cfg.Session.CookieSameSite,
+	}
+	if cfg.Auth.Mode == "session" {
+		sessionUsecase = usecases.NewSessionUsecase(do.MustInvoke[domains.SessionStore](injector), roleRepo, revocations, usecases.SessionConfig{
+			IdleTimeout:     cfg.Session.IdleTimeout,
+			AbsoluteTimeout: cfg.Session.AbsoluteTimeout,
+		})
+	}
+
+	// Initialize auth usecase with the JWT issuer AuthMiddleware validates against
+	keys := do.MustInvoke[*jwtkeys.KeySet](injector)
+	issuer := middleware.NewJWTIssuer(keys, cfg.JWT.Expiration)
+	authUsecase := usecases.NewAuthUsecase(userRepo, refreshTokenRepo, revocations, roleRepo, mfaRepo, lockoutUsecase, hasher, issuer, sessionUsecase, usecases.AuthConfig{
+		RefreshTokenTTL:      cfg.JWT.RefreshExpiration,
+		RequireVerifiedEmail: cfg.EmailVerification.Required,
+		MFAChallengeTTL:      cfg.MFA.ChallengeTTL,
+	})
+	authHandler := handlers.NewAuthHandler(authUsecase)
+	sessionHandler := handlers.NewSessionHandler(authUsecase, sessionUsecase, sessionCookie)
+	jwksHandler := handlers.NewJWKSHandler(keys)
+
+	// Initialize admin impersonation for support staff
+	impersonationUsecase := usecases.NewImpersonationUsecase(userRepo, roleRepo, issuer, logger, usecases.ImpersonationConfig{
+		TokenTTL: cfg.Impersonation.TokenTTL,
+	})
+	impersonationHandler := handlers.NewImpersonationHandler(impersonationUsecase)
+
+	// Initialize passwordless login on top of the auth usecase
+	magicLinkTokenRepo := do.MustInvoke[*repositories.MagicLinkTokenRepository](injector)
+	magicLinkUsecase := usecases.NewMagicLinkUsecase(userRepo, magicLinkTokenRepo, authUsecase, mailSender, logger, usecases.MagicLinkConfig{
+		TokenTTL:        cfg.MagicLink.TokenTTL,
+		RequestInterval: cfg.MagicLink.RequestInterval,
+		URL:             cfg.MagicLink.URL,
+	})
+	magicLinkHandler := handlers.NewMagicLinkHandler(magicLinkUsecase, handlers.MagicLinkCookie{
+		Name:   cfg.MagicLink.CookieName,
+		Secure: cfg.MagicLink.CookieSecure,
+		TTL:    cfg.MagicLink.TokenTTL,
+	})
+
+	// Accept tokens from an external OIDC issuer when one is configured
+	var externalAuth *middleware.OIDCAuthenticator
+	if verifier := do.MustInvoke[*oidc.Verifier](injector); verifier != nil {
+		var identities domains.IdentityUsecase
+		if cfg.OIDC.UserMapping != "subject" {
+			identityRepo := do.MustInvoke[*repositories.UserIdentityRepository](injector)
+			identities = usecases.NewIdentityUsecase(userRepo, identityRepo, roleRepo, hasher, usecases.IdentityConfig{
+				Provision: cfg.OIDC.JITProvisioning,
+			})
+		}
+		externalAuth = middleware.NewOIDCAuthenticator(verifier, identities, roleRepo, middleware.OIDCClaimMapping{
+			SubjectClaim: cfg.OIDC.SubjectClaim,
+			EmailClaim:   cfg.OIDC.EmailClaim,
+		})
+	}
 
 	// Health check endpoint (public)
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -62,21 +222,88 @@ func main() {
 		})
 	})
 
+	// Public keys for services that verify our tokens
+	app.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	// Swagger documentation
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
 	// Public routes (no auth required)
 	public := app.Group("/api")
 	public.Post("/users/register", userHandler.RegisterUser)
+	public.Post("/auth/login", authHandler.Login)
+	public.Post("/auth/mfa/verify", middleware.RateLimitMiddleware(middleware.NewRateLimiter(10, time.Minute)), authHandler.VerifyMFA)
+	public.Post("/auth/refresh", authHandler.Refresh)
+	public.Post("/auth/logout", middleware.OptionalAuthMiddleware(keys, revocations, externalAuth, apiKeyUsecase), authHandler.Logout)
+	public.Post("/auth/verify-email", verificationHandler.VerifyEmail)
+	public.Post("/auth/resend-verification", middleware.RateLimitMiddleware(middleware.NewRateLimiter(5, time.Hour)), verificationHandler.ResendVerification)
+	public.Post("/auth/forgot-password", middleware.RateLimitMiddleware(middleware.NewRateLimiter(5, time.Hour)), passwordHandler.ForgotPassword)
+	public.Post("/auth/reset-password", middleware.RateLimitMiddleware(middleware.NewRateLimiter(10, time.Hour)), passwordHandler.ResetPassword)
+	public.Post("/invitations/accept", middleware.RateLimitMiddleware(middleware.NewRateLimiter(10, time.Hour)), invitationHandler.AcceptInvitation)
+	public.Post("/invitations/decline", middleware.RateLimitMiddleware(middleware.NewRateLimiter(10, time.Hour)), invitationHandler.DeclineInvitation)
+	if cfg.MagicLink.Enabled {
+		public.Post("/auth/magic-link", middleware.RateLimitMiddleware(middleware.NewRateLimiter(5, time.Hour)), magicLinkHandler.RequestLink)
+		public.Post("/auth/magic-link/verify", middleware.RateLimitMiddleware(middleware.NewRateLimiter(10, time.Hour)), magicLinkHandler.Login)
+	}
+	if sessionUsecase != nil {
+		public.Post("/auth/session", sessionHandler.Login)
+		public.Post("/auth/session/mfa/verify", middleware.RateLimitMiddleware(middleware.NewRateLimiter(10, time.Minute)), sessionHandler.VerifyMFA)
+	}
+
+	// In session mode the session cookie authenticates, and bearer tokens
+	// and API keys are still accepted from non-browser clients
+	authMiddleware := middleware.AuthMiddleware(keys, revocations, externalAuth, apiKeyUsecase)
+	if sessionUsecase != nil {
+		authMiddleware = middleware.SessionMiddleware(sessionUsecase, sessionCookie, authMiddleware)
+	}
 
 	// Protected routes (auth required)
 	protected := app.Group("/api")
-	protected.Use(middleware.AuthMiddleware(cfg))
-	protected.Get("/users", userHandler.ListUsers)
+	protected.Use(authMiddleware)
+	protected.Post("/auth/change-password", middleware.DenyImpersonation(), middleware.RateLimitMiddleware(middleware.NewRateLimiter(10, time.Hour)), passwordHandler.ChangePassword)
+	protected.Get("/auth/mfa", mfaHandler.GetStatus)
+	protected.Post("/auth/mfa/enroll", middleware.DenyImpersonation(), mfaHandler.Enroll)
+	protected.Post("/auth/mfa/confirm", middleware.DenyImpersonation(), mfaHandler.ConfirmEnrollment)
+	protected.Post("/auth/mfa/recovery-codes", middleware.DenyImpersonation(), mfaHandler.RegenerateRecoveryCodes)
+	protected.Post("/auth/mfa/disable", middleware.DenyImpersonation(), mfaHandler.Disable)
+	protected.Get("/users", middleware.RequirePermission(domains.PermissionUsersRead), userHandler.ListUsers)
 	protected.Get("/users/email", userHandler.GetUserByEmail)
-	protected.Get("/users/:id", userHandler.GetUser)
-	protected.Put("/users/:id", userHandler.UpdateUser)
-	protected.Delete("/users/:id", userHandler.DeleteUser)
+	protected.Get("/users/deleted", middleware.RequirePermission(domains.PermissionUsersDelete), userHandler.ListDeletedUsers)
+	protected.Get("/users/:id", middleware.RequirePermission(domains.PermissionUsersRead, domains.PermissionSelfRead), userHandler.GetUser)
+	protected.Put("/users/:id", middleware.RequirePermission(domains.PermissionUsersWrite, domains.PermissionSelfWrite), userHandler.UpdateUser)
+	protected.Patch("/users/:id", middleware.RequirePermission(domains.PermissionUsersWrite, domains.PermissionSelfWrite), userHandler.PatchUser)
+	protected.Delete("/users/:id", middleware.RequirePermission(domains.PermissionUsersDelete), middleware.DenyImpersonation(), userHandler.DeleteUser)
+	protected.Post("/users/:id/restore", middleware.RequirePermission(domains.PermissionUsersDelete), middleware.DenyImpersonation(), userHandler.RestoreUser)
+	protected.Delete("/users/:id/purge", middleware.RequirePermission(domains.PermissionUsersPurge), middleware.DenyImpersonation(), userHandler.PurgeUser)
+	protected.Delete("/users/:id/mfa", middleware.RequirePermission(domains.PermissionUsersWrite), middleware.DenyImpersonation(), mfaHandler.Reset)
+	protected.Post("/users/:id/unlock", middleware.RequirePermission(domains.PermissionUsersWrite), middleware.DenyImpersonation(), lockoutHandler.Unlock)
+	protected.Post("/users/:id/impersonate", middleware.RequirePermission(domains.PermissionUsersImpersonate), middleware.DenyImpersonation(), impersonationHandler.Impersonate)
+	protected.Post("/tenants", middleware.RequirePermission(domains.PermissionTenantsWrite), tenantHandler.CreateTenant)
+	protected.Get("/tenants", middleware.RequirePermission(domains.PermissionTenantsRead), tenantHandler.ListTenants)
+	protected.Get("/tenants/:id", middleware.RequirePermission(domains.PermissionTenantsRead), tenantHandler.GetTenant)
+	protected.Post("/invitations", middleware.RequirePermission(domains.PermissionUsersWrite), middleware.DenyImpersonation(), invitationHandler.CreateInvitation)
+	protected.Get("/invitations", middleware.RequirePermission(domains.PermissionUsersRead), invitationHandler.ListInvitations)
+	protected.Post("/invitations/:id/resend", middleware.RequirePermission(domains.PermissionUsersWrite), middleware.DenyImpersonation(), invitationHandler.ResendInvitation)
+	protected.Delete("/invitations/:id", middleware.RequirePermission(domains.PermissionUsersWrite), middleware.DenyImpersonation(), invitationHandler.RevokeInvitation)
+	protected.Post("/groups", middleware.RequirePermission(domains.PermissionGroupsWrite), middleware.DenyImpersonation(), groupHandler.CreateGroup)
+	protected.Get("/groups", middleware.RequirePermission(domains.PermissionGroupsRead), groupHandler.ListGroups)
+	protected.Get("/groups/:id", middleware.RequirePermission(domains.PermissionGroupsRead), groupHandler.GetGroup)
+	protected.Put("/groups/:id", middleware.RequirePermission(domains.PermissionGroupsWrite), middleware.DenyImpersonation(), groupHandler.UpdateGroup)
+	protected.Delete("/groups/:id", middleware.RequirePermission(domains.PermissionGroupsWrite), middleware.DenyImpersonation(), groupHandler.DeleteGroup)
+	protected.Get("/groups/:id/members", middleware.RequirePermission(domains.PermissionGroupsRead), groupHandler.ListMembers)
+	protected.Put("/groups/:id/members/:userId", middleware.RequirePermission(domains.PermissionGroupsWrite), middleware.DenyImpersonation(), groupHandler.AddMember)
+	protected.Delete("/groups/:id/members/:userId", middleware.RequirePermission(domains.PermissionGroupsWrite), middleware.DenyImpersonation(), groupHandler.RemoveMember)
+	protected.Put("/groups/:id/roles/:role", middleware.RequirePermission(domains.PermissionGroupsWrite), middleware.DenyImpersonation(), groupHandler.AddRole)
+	protected.Delete("/groups/:id/roles/:role", middleware.RequirePermission(domains.PermissionGroupsWrite), middleware.DenyImpersonation(), groupHandler.RemoveRole)
+	protected.Post("/service-accounts", middleware.RequirePermission(domains.PermissionUsersWrite), middleware.DenyImpersonation(), apiKeyHandler.CreateServiceAccount)
+	protected.Post("/api-keys", middleware.DenyImpersonation(), apiKeyHandler.CreateAPIKey)
+	protected.Get("/api-keys", apiKeyHandler.ListAPIKeys)
+	protected.Get("/api-keys/:id", apiKeyHandler.GetAPIKey)
+	protected.Put("/api-keys/:id", middleware.DenyImpersonation(), apiKeyHandler.UpdateAPIKey)
+	protected.Delete("/api-keys/:id", middleware.DenyImpersonation(), apiKeyHandler.DeleteAPIKey)
+	if sessionUsecase != nil {
+		protected.Delete("/auth/session", sessionHandler.Logout)
+	}
 
 	// Start server in a goroutine
 	go func() {
@@ -99,3 +326,16 @@ func main() {
 
 	log.Println("Server stopped")
 }
+
+// purgeDeletedUsers runs the retention purge every interval until the
+// process exits
+func purgeDeletedUsers(retention domains.UserRetentionUsecase, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := retention.PurgeDeletedUsers(context.Background()); err != nil {
+			logger.Error("deleted user purge failed", slog.String("error", err.Error()))
+		}
+	}
+}
@@ -55,7 +55,7 @@ const docTemplate = `{
         "/users": {
             "get": {
                 "summary": "List users",
-                "description": "Retrieve a paginated list of users",
+                "description": "Retrieve a paginated list of users, optionally filtered and sorted, with the total number of matching users. Requires the users:read permission. With pagination=cursor, or a cursor, pages are read by keyset and sorted by created_at only. The page links are also sent in an RFC 8288 Link header.",
                 "produces": ["application/json"],
                 "tags": ["Users"],
                 "parameters": [
@@ -69,18 +69,120 @@ const docTemplate = `{
                         "name": "offset",
                         "in": "query",
                         "type": "integer",
+                        "description": "Number of users to skip (offset pagination only)",
                         "default": 0
+                    },
+                    {
+                        "name": "pagination",
+                        "in": "query",
+                        "type": "string",
+                        "description": "Pagination mode",
+                        "enum": ["offset", "cursor"],
+                        "default": "offset"
+                    },
+                    {
+                        "name": "cursor",
+                        "in": "query",
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous page; implies cursor pagination"
+                    },
+                    {
+                        "name": "is_active",
+                        "in": "query",
+                        "type": "boolean",
+                        "description": "Only active or only inactive users"
+                    },
+                    {
+                        "name": "created_after",
+                        "in": "query",
+                        "type": "string",
+                        "description": "Only users created at or after this RFC 3339 time or date (2026-01-31)"
+                    },
+                    {
+                        "name": "created_before",
+                        "in": "query",
+                        "type": "string",
+                        "description": "Only users created before this RFC 3339 time or date (2026-01-31)"
+                    },
+                    {
+                        "name": "email_domain",
+                        "in": "query",
+                        "type": "string",
+                        "description": "Only users whose email is at this domain",
+                        "example": "example.com"
+                    },
+                    {
+                        "name": "search",
+                        "in": "query",
+                        "type": "string",
+                        "description": "Text to find in the email, first or last name (at most 100 characters)"
+                    },
+                    {
+                        "name": "sort",
+                        "in": "query",
+                        "type": "string",
+                        "description": "Field to sort by; prefix with - for descending order",
+                        "enum": ["created_at", "-created_at", "updated_at", "-updated_at", "email", "-email", "first_name", "-first_name", "last_name", "-last_name"],
+                        "default": "-created_at"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of users",
+                        "description": "Page of users",
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "first, prev, next and last page links"
+                            }
+                        },
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/UserResponse"
+                            "$ref": "#/definitions/UserPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort field or cursor"
+                    },
+                    "403": {
+                        "description": "Missing users:read permission"
+                    }
+                }
+            }
+        },
+        "/users/deleted": {
+            "get": {
+                "summary": "List deleted users",
+                "description": "Retrieve a paginated list of deleted users that have not been purged yet, most recently deleted first. Requires the users:delete permission.",
+                "produces": ["application/json"],
+                "tags": ["Users"],
+                "parameters": [
+                    {
+                        "name": "limit",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 10
+                    },
+                    {
+                        "name": "offset",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 0
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of deleted users",
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "first, prev, next and last page links"
                             }
+                        },
+                        "schema": {
+                            "$ref": "#/definitions/UserPage"
                         }
+                    },
+                    "403": {
+                        "description": "Missing users:delete permission"
                     }
                 }
             }
@@ -102,10 +204,19 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "User information",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user, for If-Match"
+                            }
+                        },
                         "schema": {
                             "$ref": "#/definitions/UserResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not allowed to read this user"
+                    },
                     "404": {
                         "description": "User not found"
                     }
@@ -113,7 +224,7 @@ const docTemplate = `{
             },
             "put": {
                 "summary": "Update user",
-                "description": "Update an existing user's information",
+                "description": "Update an existing user's information. With If-Match, the update only applies while the user is still at that ETag.",
                 "consumes": ["application/json"],
                 "produces": ["application/json"],
                 "tags": ["Users"],
@@ -124,6 +235,13 @@ const docTemplate = `{
                         "required": true,
                         "type": "string"
                     },
+                    {
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": false,
+                        "type": "string",
+                        "description": "ETag of the user as last read; required when REQUIRE_IF_MATCH is set"
+                    },
                     {
                         "name": "request",
                         "in": "body",
@@ -136,18 +254,102 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "User updated successfully",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated user"
+                            }
+                        },
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or If-Match header"
+                    },
+                    "403": {
+                        "description": "Not allowed to update this user"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "412": {
+                        "description": "The user has been modified since the ETag was read"
+                    },
+                    "428": {
+                        "description": "If-Match header is required"
+                    }
+                }
+            },
+            "patch": {
+                "summary": "Patch user",
+                "description": "Apply a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) to the email, first_name, last_name and is_active of a user. Unlike PUT, a patch can clear the names by setting them to null. With If-Match, the patch only applies while the user is still at that ETag.",
+                "consumes": ["application/merge-patch+json", "application/json-patch+json"],
+                "produces": ["application/json"],
+                "tags": ["Users"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": false,
+                        "type": "string",
+                        "description": "ETag of the user as last read; required when REQUIRE_IF_MATCH is set"
+                    },
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "description": "Merge patch object, or array of JSON Patch operations",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User patched successfully",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the patched user"
+                            }
+                        },
                         "schema": {
                             "$ref": "#/definitions/UserResponse"
                         }
                     },
+                    "400": {
+                        "description": "Malformed patch, or a patched user that is not valid"
+                    },
+                    "403": {
+                        "description": "Not allowed to update this user"
+                    },
                     "404": {
                         "description": "User not found"
+                    },
+                    "409": {
+                        "description": "A JSON Patch test operation failed"
+                    },
+                    "412": {
+                        "description": "The user has been modified since the ETag was read"
+                    },
+                    "415": {
+                        "description": "Content-Type is not a supported patch format"
+                    },
+                    "428": {
+                        "description": "If-Match header is required"
                     }
                 }
             },
             "delete": {
                 "summary": "Delete user",
-                "description": "Delete an existing user account",
+                "description": "Delete an existing user account. With If-Match, the deletion only applies while the user is still at that ETag.",
                 "tags": ["Users"],
                 "parameters": [
                     {
@@ -155,93 +357,2333 @@ const docTemplate = `{
                         "in": "path",
                         "required": true,
                         "type": "string"
+                    },
+                    {
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": false,
+                        "type": "string",
+                        "description": "ETag of the user as last read; required when REQUIRE_IF_MATCH is set"
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "User deleted successfully"
                     },
+                    "400": {
+                        "description": "Invalid If-Match header"
+                    },
+                    "403": {
+                        "description": "Missing users:delete permission"
+                    },
                     "404": {
                         "description": "User not found"
+                    },
+                    "412": {
+                        "description": "The user has been modified since the ETag was read"
+                    },
+                    "428": {
+                        "description": "If-Match header is required"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "CreateUserRequest": {
-            "type": "object",
-            "required": ["email", "password"],
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
-                },
-                "password": {
-                    "type": "string",
-                    "example": "password123",
-                    "minLength": 8
-                },
-                "first_name": {
-                    "type": "string",
-                    "example": "John"
-                },
-                "last_name": {
-                    "type": "string",
-                    "example": "Doe"
+        },
+        "/auth/login": {
+            "post": {
+                "summary": "Log in",
+                "description": "Verify email and password and issue a JWT access token with a refresh token. Users with MFA enabled get an MFA token instead, to be exchanged at /auth/mfa/verify.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token issued",
+                        "schema": {
+                            "$ref": "#/definitions/TokenResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Second factor required",
+                        "schema": {
+                            "$ref": "#/definitions/MFAChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Invalid email or password"
+                    },
+                    "403": {
+                        "description": "User account is inactive"
+                    },
+                    "429": {
+                        "description": "Too many failed attempts; see the Retry-After header"
+                    }
                 }
             }
         },
-        "UpdateUserRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "newemail@example.com"
-                },
-                "first_name": {
-                    "type": "string"
-                },
-                "last_name": {
-                    "type": "string"
-                },
-                "is_active": {
-                    "type": "boolean"
+        "/auth/refresh": {
+            "post": {
+                "summary": "Refresh tokens",
+                "description": "Exchange a refresh token for a new access and refresh token pair. Replaying a rotated refresh token revokes its whole family.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tokens rotated",
+                        "schema": {
+                            "$ref": "#/definitions/TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Invalid, expired or reused refresh token"
+                    }
                 }
             }
         },
-        "UserResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
-                },
-                "first_name": {
-                    "type": "string",
-                    "example": "John"
-                },
-                "last_name": {
-                    "type": "string",
-                    "example": "Doe"
-                },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "created_at": {
-                    "type": "string",
-                    "format": "date-time",
-                    "example": "2025-10-25T12:00:00Z"
-                },
-                "updated_at": {
-                    "type": "string",
-                    "format": "date-time",
-                    "example": "2025-10-25T12:00:00Z"
+        "/auth/logout": {
+            "post": {
+                "summary": "Log out",
+                "description": "Revoke the refresh token family the given refresh token belongs to, and the bearer access token if one is sent",
+                "consumes": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Logged out"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "post": {
+                "summary": "Verify email address",
+                "description": "Consume the single-use token from a verification email and mark the address as verified",
+                "consumes": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Email address verified"
+                    },
+                    "400": {
+                        "description": "Invalid or expired verification token"
+                    }
+                }
+            }
+        },
+        "/auth/resend-verification": {
+            "post": {
+                "summary": "Resend verification email",
+                "description": "Send a new verification link to an unverified address. The response is the same whether or not the address is registered, and repeated requests within the resend interval are ignored.",
+                "consumes": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Request accepted"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/auth/change-password": {
+            "post": {
+                "summary": "Change password",
+                "description": "Replace the caller's password after checking the current one. Every session of the user, including the current one, is revoked.",
+                "consumes": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password changed"
+                    },
+                    "400": {
+                        "description": "Invalid request or incorrect current password"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/auth/forgot-password": {
+            "post": {
+                "summary": "Request password reset",
+                "description": "Send a single-use password reset link. The response is the same whether or not the address is registered, and repeated requests within the request interval are ignored.",
+                "consumes": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Request accepted"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "summary": "Reset password",
+                "description": "Consume the single-use token from a password reset email and set a new password. Every session of the user is revoked.",
+                "consumes": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password reset"
+                    },
+                    "400": {
+                        "description": "Invalid or expired reset token"
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/auth/magic-link": {
+            "post": {
+                "summary": "Request magic link",
+                "description": "Send a single-use login link and bind it to the calling client with an HttpOnly cookie. The response is the same whether or not the address is registered, and repeated requests within the request interval are ignored. Only available when MAGIC_LINK_ENABLED is set.",
+                "consumes": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MagicLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Request accepted; the binding cookie is set"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/auth/magic-link/verify": {
+            "post": {
+                "summary": "Log in with magic link",
+                "description": "Consume the single-use token from a magic link email and issue a JWT access token with a refresh token. The request must carry the binding cookie of the client that requested the link. Users with MFA enabled get an MFA token instead, to be exchanged at /auth/mfa/verify.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MagicLinkLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token issued",
+                        "schema": {
+                            "$ref": "#/definitions/TokenResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Second factor required",
+                        "schema": {
+                            "$ref": "#/definitions/MFAChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Invalid, expired or used link, or a link requested from another client"
+                    },
+                    "403": {
+                        "description": "User account is inactive"
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/auth/mfa/verify": {
+            "post": {
+                "summary": "Complete MFA login",
+                "description": "Exchange the MFA token from /auth/login and a TOTP or recovery code for a JWT access token with a refresh token. An MFA token stops working after five wrong codes.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/VerifyMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token issued",
+                        "schema": {
+                            "$ref": "#/definitions/TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Invalid code or invalid or expired MFA token"
+                    },
+                    "429": {
+                        "description": "Too many failed attempts; see the Retry-After header"
+                    }
+                }
+            }
+        },
+        "/auth/session": {
+            "post": {
+                "summary": "Log in with a session",
+                "description": "Verify email and password and start a server-side session held in an HttpOnly cookie. Send the returned CSRF token, also set in the csrf_token cookie, in the X-CSRF-Token header of every state-changing request. Users with MFA enabled get an MFA token instead, to be exchanged at /auth/session/mfa/verify. Only available when AUTH_MODE is session.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session started",
+                        "schema": {
+                            "$ref": "#/definitions/SessionResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Second factor required",
+                        "schema": {
+                            "$ref": "#/definitions/MFAChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Invalid email or password"
+                    },
+                    "403": {
+                        "description": "User account is inactive"
+                    },
+                    "429": {
+                        "description": "Too many failed attempts; see the Retry-After header"
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Log out of a session",
+                "description": "Destroy the current server-side session and clear its cookies. Requires the X-CSRF-Token header.",
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "X-CSRF-Token",
+                        "in": "header",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Session ended"
+                    },
+                    "400": {
+                        "description": "Request is not authenticated with a session"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Missing or invalid CSRF token"
+                    }
+                }
+            }
+        },
+        "/auth/session/mfa/verify": {
+            "post": {
+                "summary": "Complete MFA session login",
+                "description": "Exchange the MFA token from /auth/session and a TOTP or recovery code for a cookie session. Only available when AUTH_MODE is session.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Auth"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/VerifyMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session started",
+                        "schema": {
+                            "$ref": "#/definitions/SessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Invalid code or invalid or expired MFA token"
+                    },
+                    "429": {
+                        "description": "Too many failed attempts; see the Retry-After header"
+                    }
+                }
+            }
+        },
+        "/auth/mfa": {
+            "get": {
+                "summary": "Get MFA status",
+                "description": "Report whether MFA is enabled for the caller and how many recovery codes are left",
+                "produces": ["application/json"],
+                "tags": ["MFA"],
+                "responses": {
+                    "200": {
+                        "description": "MFA status",
+                        "schema": {
+                            "$ref": "#/definitions/MFAStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/auth/mfa/enroll": {
+            "post": {
+                "summary": "Start MFA enrollment",
+                "description": "Generate a new TOTP secret and its otpauth URI for an authenticator app. MFA is not active until the enrollment is confirmed with a code.",
+                "produces": ["application/json"],
+                "tags": ["MFA"],
+                "responses": {
+                    "200": {
+                        "description": "Enrollment started",
+                        "schema": {
+                            "$ref": "#/definitions/MFAEnrollmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "MFA is already enabled"
+                    }
+                }
+            }
+        },
+        "/auth/mfa/confirm": {
+            "post": {
+                "summary": "Confirm MFA enrollment",
+                "description": "Activate MFA with a code from the authenticator app. The response holds the recovery codes, which are not shown again.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["MFA"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MFA enabled",
+                        "schema": {
+                            "$ref": "#/definitions/RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code or enrollment not started"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "MFA is already enabled"
+                    }
+                }
+            }
+        },
+        "/auth/mfa/recovery-codes": {
+            "post": {
+                "summary": "Regenerate recovery codes",
+                "description": "Replace all recovery codes after checking a TOTP or recovery code. Earlier recovery codes stop working.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["MFA"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recovery codes replaced",
+                        "schema": {
+                            "$ref": "#/definitions/RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code or MFA not enabled"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/auth/mfa/disable": {
+            "post": {
+                "summary": "Disable MFA",
+                "description": "Turn MFA off after checking the password and a TOTP or recovery code",
+                "consumes": ["application/json"],
+                "tags": ["MFA"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/DisableMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "MFA disabled"
+                    },
+                    "400": {
+                        "description": "Invalid request, incorrect password or invalid code"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/users/{id}/mfa": {
+            "delete": {
+                "summary": "Reset a user's MFA",
+                "description": "Turn MFA off for a user who lost their authenticator, so they can sign in with the password alone and enroll again. Requires the users:write permission.",
+                "tags": ["Users"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "MFA reset"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    }
+                }
+            }
+        },
+        "/users/{id}/unlock": {
+            "post": {
+                "summary": "Unlock a user",
+                "description": "Clear the failed login counters of a user, lifting any lockout or delay from every IP address. Requires the users:write permission.",
+                "tags": ["Users"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Lockout lifted"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    }
+                }
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "summary": "Restore a deleted user",
+                "description": "Undo the deletion of a user account. Requires the users:delete permission.",
+                "produces": ["application/json"],
+                "tags": ["Users"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored user",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the restored user"
+                            }
+                        },
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Deleted user not found"
+                    },
+                    "409": {
+                        "description": "The email address has been registered again since the user was deleted"
+                    }
+                }
+            }
+        },
+        "/users/{id}/purge": {
+            "delete": {
+                "summary": "Purge a deleted user",
+                "description": "Permanently remove a deleted user account and everything that belongs to it. Live users must be deleted first. Requires the users:purge permission.",
+                "tags": ["Users"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "User purged"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Deleted user not found"
+                    }
+                }
+            }
+        },
+        "/users/{id}/impersonate": {
+            "post": {
+                "summary": "Impersonate a user",
+                "description": "Issue a short-lived, non-refreshable access token that acts as the user. The token names the admin in its act claim, every request made with it is logged, and sensitive operations are refused. Requires the users:impersonate permission.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Users"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ImpersonateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Impersonation token",
+                        "schema": {
+                            "$ref": "#/definitions/TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "summary": "List pending invitations",
+                "description": "Retrieve the pending invitations of the tenant, newest first, including expired ones that can still be resent. Requires the users:read permission.",
+                "produces": ["application/json"],
+                "tags": ["Invitations"],
+                "parameters": [
+                    {
+                        "name": "limit",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 10
+                    },
+                    {
+                        "name": "offset",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 0
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pending invitations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/InvitationResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Missing users:read permission"
+                    }
+                }
+            },
+            "post": {
+                "summary": "Invite someone",
+                "description": "Mail an invitation to join the tenant with a role. You can only invite with roles whose permissions you hold. Requires the users:write permission.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Invitations"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Invitation sent",
+                        "schema": {
+                            "$ref": "#/definitions/InvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or unknown role"
+                    },
+                    "403": {
+                        "description": "Missing users:write permission, or the role grants permissions you do not hold"
+                    },
+                    "409": {
+                        "description": "The address already has a pending invitation"
+                    }
+                }
+            }
+        },
+        "/invitations/{id}": {
+            "delete": {
+                "summary": "Revoke an invitation",
+                "description": "Withdraw a pending invitation; its link stops working. Requires the users:write permission.",
+                "tags": ["Invitations"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Invitation revoked"
+                    },
+                    "403": {
+                        "description": "Missing users:write permission"
+                    },
+                    "404": {
+                        "description": "Invitation not found"
+                    },
+                    "409": {
+                        "description": "Invitation is no longer pending"
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/resend": {
+            "post": {
+                "summary": "Resend an invitation",
+                "description": "Mail a pending invitation again with a new link and a fresh expiry; the previous link stops working. Requires the users:write permission.",
+                "produces": ["application/json"],
+                "tags": ["Invitations"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation resent",
+                        "schema": {
+                            "$ref": "#/definitions/InvitationResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing users:write permission"
+                    },
+                    "404": {
+                        "description": "Invitation not found"
+                    },
+                    "409": {
+                        "description": "Invitation is no longer pending"
+                    }
+                }
+            }
+        },
+        "/invitations/accept": {
+            "post": {
+                "summary": "Accept an invitation",
+                "description": "Grant the invited role to the account with the invited email address. Invitees without an account must choose a password and are registered with a verified address. The request must name the tenant from the link.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Invitations"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AcceptInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation accepted",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired invitation, or missing password"
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/invitations/decline": {
+            "post": {
+                "summary": "Decline an invitation",
+                "description": "Turn a pending invitation down; its link stops working. The request must name the tenant from the link.",
+                "consumes": ["application/json"],
+                "tags": ["Invitations"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/DeclineInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Invitation declined"
+                    },
+                    "400": {
+                        "description": "Invalid or expired invitation"
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "get": {
+                "summary": "List groups",
+                "description": "Retrieve the groups of the tenant ordered by name. Requires the groups:read permission.",
+                "produces": ["application/json"],
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "limit",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 10
+                    },
+                    {
+                        "name": "offset",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 0
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Groups",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GroupResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Missing groups:read permission"
+                    }
+                }
+            },
+            "post": {
+                "summary": "Create a group",
+                "description": "Create a group of users in the tenant. Roles and members are added separately. Requires the groups:write permission.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Group created",
+                        "schema": {
+                            "$ref": "#/definitions/GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "403": {
+                        "description": "Missing groups:write permission"
+                    },
+                    "409": {
+                        "description": "A group with the name already exists"
+                    }
+                }
+            }
+        },
+        "/groups/{id}": {
+            "get": {
+                "summary": "Get group by ID",
+                "description": "Retrieve a group and the roles it grants. Requires the groups:read permission.",
+                "produces": ["application/json"],
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group",
+                        "schema": {
+                            "$ref": "#/definitions/GroupResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing groups:read permission"
+                    },
+                    "404": {
+                        "description": "Group not found"
+                    }
+                }
+            },
+            "put": {
+                "summary": "Update a group",
+                "description": "Rename a group or change its description. Requires the groups:write permission.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group updated",
+                        "schema": {
+                            "$ref": "#/definitions/GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "403": {
+                        "description": "Missing groups:write permission"
+                    },
+                    "404": {
+                        "description": "Group not found"
+                    },
+                    "409": {
+                        "description": "A group with the name already exists"
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Delete a group",
+                "description": "Delete a group; its members lose the roles it granted. Requires the groups:write permission.",
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Group deleted"
+                    },
+                    "403": {
+                        "description": "Missing groups:write permission"
+                    },
+                    "404": {
+                        "description": "Group not found"
+                    }
+                }
+            }
+        },
+        "/groups/{id}/members": {
+            "get": {
+                "summary": "List group members",
+                "description": "Retrieve the members of a group ordered by email. Requires the groups:read permission.",
+                "produces": ["application/json"],
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "limit",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 10
+                    },
+                    {
+                        "name": "offset",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 0
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group members",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/UserResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Missing groups:read permission"
+                    },
+                    "404": {
+                        "description": "Group not found"
+                    }
+                }
+            }
+        },
+        "/groups/{id}/members/{userId}": {
+            "put": {
+                "summary": "Add a group member",
+                "description": "Add a user to a group, granting them its roles. You can only add members to groups whose roles grant nothing you do not hold. Requires the groups:write permission.",
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "userId",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Member added"
+                    },
+                    "403": {
+                        "description": "Missing groups:write permission, or the group grants permissions you do not hold"
+                    },
+                    "404": {
+                        "description": "Group or user not found"
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Remove a group member",
+                "description": "Remove a user from a group; they lose the roles it granted. Requires the groups:write permission.",
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "userId",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Member removed"
+                    },
+                    "403": {
+                        "description": "Missing groups:write permission"
+                    },
+                    "404": {
+                        "description": "Group not found, or the user is not a member"
+                    }
+                }
+            }
+        },
+        "/groups/{id}/roles/{role}": {
+            "put": {
+                "summary": "Add a group role",
+                "description": "Grant a role to every member of a group. You can only grant roles whose permissions you hold. Requires the groups:write permission.",
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "role",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Role granted"
+                    },
+                    "400": {
+                        "description": "Unknown role"
+                    },
+                    "403": {
+                        "description": "Missing groups:write permission, or the role grants permissions you do not hold"
+                    },
+                    "404": {
+                        "description": "Group not found"
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Remove a group role",
+                "description": "Withdraw a role from a group; members keep it only if they hold it otherwise. Requires the groups:write permission.",
+                "tags": ["Groups"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "role",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Role withdrawn"
+                    },
+                    "403": {
+                        "description": "Missing groups:write permission"
+                    },
+                    "404": {
+                        "description": "Group not found, or the group does not hold the role"
+                    }
+                }
+            }
+        },
+        "/tenants": {
+            "get": {
+                "summary": "List tenants",
+                "description": "Retrieve a paginated list of tenants ordered by slug. Requires the tenants:read permission.",
+                "produces": ["application/json"],
+                "tags": ["Tenants"],
+                "parameters": [
+                    {
+                        "name": "limit",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 10
+                    },
+                    {
+                        "name": "offset",
+                        "in": "query",
+                        "type": "integer",
+                        "default": 0
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tenants",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/TenantResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Missing tenants:read permission"
+                    }
+                }
+            },
+            "post": {
+                "summary": "Create a tenant",
+                "description": "Create a tenant whose users are isolated from every other tenant. The slug must be a DNS label. Requires the tenants:write permission.",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["Tenants"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateTenantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Tenant created",
+                        "schema": {
+                            "$ref": "#/definitions/TenantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "403": {
+                        "description": "Missing tenants:write permission"
+                    },
+                    "409": {
+                        "description": "Slug already taken"
+                    }
+                }
+            }
+        },
+        "/tenants/{id}": {
+            "get": {
+                "summary": "Get tenant by ID",
+                "description": "Retrieve a tenant by its ID. Requires the tenants:read permission.",
+                "produces": ["application/json"],
+                "tags": ["Tenants"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tenant found",
+                        "schema": {
+                            "$ref": "#/definitions/TenantResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing tenants:read permission"
+                    },
+                    "404": {
+                        "description": "Tenant not found"
+                    }
+                }
+            }
+        },
+        "/service-accounts": {
+            "post": {
+                "summary": "Create a service account",
+                "description": "Create a non-interactive account that cannot log in and acts only through its API keys. Requires the users:write permission.",
+                "consumes": ["application/json"],
+                "tags": ["API Keys"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Service account created",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "409": {
+                        "description": "Email already registered"
+                    }
+                }
+            }
+        },
+        "/api-keys": {
+            "get": {
+                "summary": "List API keys",
+                "description": "List the API keys of the caller, or of the user given by user_id (users:read)",
+                "tags": ["API Keys"],
+                "parameters": [
+                    {
+                        "name": "user_id",
+                        "in": "query",
+                        "required": false,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API keys",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/APIKeyResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    }
+                }
+            },
+            "post": {
+                "summary": "Create an API key",
+                "description": "Issue an API key for the caller, or for another user or service account given by user_id (users:write). Scopes are limited to permissions the caller holds. The key is only returned in this response; send it in the X-API-Key header or as Authorization: ApiKey <key>.",
+                "consumes": ["application/json"],
+                "tags": ["API Keys"],
+                "parameters": [
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "API key created",
+                        "schema": {
+                            "$ref": "#/definitions/CreatedAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden, or a scope the caller does not hold"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    }
+                }
+            }
+        },
+        "/api-keys/{id}": {
+            "get": {
+                "summary": "Get API key by ID",
+                "description": "Retrieve an API key's metadata by its ID",
+                "tags": ["API Keys"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API key",
+                        "schema": {
+                            "$ref": "#/definitions/APIKeyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "API key not found"
+                    }
+                }
+            },
+            "put": {
+                "summary": "Update an API key",
+                "description": "Change the name, scopes or expiry of an API key",
+                "consumes": ["application/json"],
+                "tags": ["API Keys"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    },
+                    {
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API key updated",
+                        "schema": {
+                            "$ref": "#/definitions/APIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "API key not found"
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Delete an API key",
+                "description": "Revoke an API key; requests using it are rejected immediately",
+                "tags": ["API Keys"],
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "type": "string"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "API key deleted"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "API key not found"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "CreateInvitationRequest": {
+            "type": "object",
+            "required": ["email", "role"],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "new.colleague@example.com"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                }
+            }
+        },
+        "AcceptInvitationRequest": {
+            "type": "object",
+            "required": ["token"],
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "description": "Required when the invitee has no account yet",
+                    "example": "password123"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Doe"
+                }
+            }
+        },
+        "DeclineInvitationRequest": {
+            "type": "object",
+            "required": ["token"],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "new.colleague@example.com"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": ["pending", "accepted", "declined", "revoked"]
+                },
+                "invited_by": {
+                    "type": "string",
+                    "description": "ID of the user who sent the invitation"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateGroupRequest": {
+            "type": "object",
+            "required": ["name"],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "support"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "First line support staff"
+                }
+            }
+        },
+        "UpdateGroupRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "support"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "First line support staff"
+                }
+            }
+        },
+        "GroupResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "support"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": ["admin"]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateTenantRequest": {
+            "type": "object",
+            "required": ["slug", "name"],
+            "properties": {
+                "slug": {
+                    "type": "string",
+                    "example": "acme"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acme Corporation"
+                }
+            }
+        },
+        "TenantResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "acme"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acme Corporation"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "CreateServiceAccountRequest": {
+            "type": "object",
+            "required": ["email"],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "nightly-export@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Nightly export"
+                }
+            }
+        },
+        "CreateAPIKeyRequest": {
+            "type": "object",
+            "required": ["name", "scopes"],
+            "properties": {
+                "user_id": {
+                    "type": "string",
+                    "description": "Owner of the key; defaults to the caller"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Nightly export"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": ["users:read"]
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "description": "Omit for a key that does not expire"
+                }
+            }
+        },
+        "UpdateAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Nightly export"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "ak_1f2e3d4c5b6a7988"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "CreatedAPIKeyResponse": {
+            "allOf": [
+                {
+                    "$ref": "#/definitions/APIKeyResponse"
+                },
+                {
+                    "type": "object",
+                    "properties": {
+                        "key": {
+                            "type": "string",
+                            "description": "The full key; it is not shown again",
+                            "example": "ak_1f2e3d4c5b6a7988.hV1m2b3X9kq0s7Zp4Yw6rT8uE5oN1aLcJdGfIiKj3Qe"
+                        }
+                    }
+                }
+            ]
+        },
+        "CreateUserRequest": {
+            "type": "object",
+            "required": ["email", "password"],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123",
+                    "minLength": 8
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Doe"
+                }
+            }
+        },
+        "UpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "newemail@example.com"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "UserResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "email_verified": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_service_account": {
+                    "type": "boolean",
+                    "description": "Present for accounts that act only through API keys"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2025-10-25T12:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2025-10-25T12:00:00Z"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "description": "Present for deleted users"
+                }
+            }
+        },
+        "UserPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "description": "Number of users matching the filters, across all pages",
+                    "example": 42
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "description": "Offset pagination only",
+                    "example": 0
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "description": "Cursor pagination only; omitted on the last page"
+                },
+                "prev_cursor": {
+                    "type": "string",
+                    "description": "Cursor pagination only; omitted on the first page"
+                },
+                "has_more": {
+                    "type": "boolean",
+                    "description": "Whether a following page exists"
+                },
+                "links": {
+                    "$ref": "#/definitions/PageLinks"
+                }
+            }
+        },
+        "PageLinks": {
+            "type": "object",
+            "description": "Relative URLs of the page and its neighbours; omitted when there is no such page",
+            "properties": {
+                "self": {
+                    "type": "string",
+                    "example": "/api/users?limit=10&offset=10"
+                },
+                "first": {
+                    "type": "string",
+                    "example": "/api/users?limit=10&offset=0"
+                },
+                "prev": {
+                    "type": "string",
+                    "example": "/api/users?limit=10&offset=0"
+                },
+                "next": {
+                    "type": "string",
+                    "example": "/api/users?limit=10&offset=20"
+                },
+                "last": {
+                    "type": "string",
+                    "description": "Offset pagination only",
+                    "example": "/api/users?limit=10&offset=40"
+                }
+            }
+        },
+        "LoginRequest": {
+            "type": "object",
+            "required": ["email", "password"],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 3600
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2025-10-25T13:00:00Z"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "RefreshTokenRequest": {
+            "type": "object",
+            "required": ["refresh_token"],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "VerifyEmailRequest": {
+            "type": "object",
+            "required": ["token"],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "ResendVerificationRequest": {
+            "type": "object",
+            "required": ["email"],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                }
+            }
+        },
+        "ImpersonateRequest": {
+            "type": "object",
+            "required": ["reason"],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Support ticket #1234"
+                }
+            }
+        },
+        "ChangePasswordRequest": {
+            "type": "object",
+            "required": ["current_password", "new_password"],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "new-password456"
+                }
+            }
+        },
+        "ForgotPasswordRequest": {
+            "type": "object",
+            "required": ["email"],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                }
+            }
+        },
+        "ResetPasswordRequest": {
+            "type": "object",
+            "required": ["token", "new_password"],
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "new-password456"
+                }
+            }
+        },
+        "MagicLinkRequest": {
+            "type": "object",
+            "required": ["email"],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                }
+            }
+        },
+        "MagicLinkLoginRequest": {
+            "type": "object",
+            "required": ["token"],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "VerifyMFARequest": {
+            "type": "object",
+            "required": ["mfa_token", "code"],
+            "properties": {
+                "mfa_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "SessionResponse": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2025-10-25T12:30:00Z"
+                },
+                "csrf_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "MFAChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "mfa_required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "MFACodeRequest": {
+            "type": "object",
+            "required": ["code"],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "DisableMFARequest": {
+            "type": "object",
+            "required": ["password", "code"],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "MFAStatusResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "recovery_codes_remaining": {
+                    "type": "integer"
+                }
+            }
+        },
+        "MFAEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXP"
+                },
+                "otpauth_uri": {
+                    "type": "string",
+                    "example": "otpauth://totp/Example:user@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"
+                }
+            }
+        },
+        "RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         }
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -9,11 +11,29 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
-	Logging  LoggingConfig
+	Server            ServerConfig
+	Database          DatabaseConfig
+	JWT               JWTConfig
+	OIDC              OIDCConfig
+	Password          PasswordConfig
+	Mail              MailConfig
+	EmailVerification EmailVerificationConfig
+	PasswordReset     PasswordResetConfig
+	MagicLink         MagicLinkConfig
+	Invitation        InvitationConfig
+	MFA               MFAConfig
+	Lockout           LockoutConfig
+	APIKey            APIKeyConfig
+	Impersonation     ImpersonationConfig
+	RBAC              RBACConfig
+	Tenancy           TenancyConfig
+	Pagination        PaginationConfig
+	UserRetention     UserRetentionConfig
+	Concurrency       ConcurrencyConfig
+	Auth              AuthConfig
+	Session           SessionConfig
+	CORS              CORSConfig
+	Logging           LoggingConfig
 }
 
 // ServerConfig contains server configuration
@@ -41,8 +61,163 @@ type DatabaseConfig struct {
 
 // JWTConfig contains JWT configuration
 type JWTConfig struct {
-	Secret     string
-	Expiration time.Duration
+	Secret            string
+	Expiration        time.Duration
+	RefreshExpiration time.Duration
+	RevocationStore   string // memory, sql
+	Keys              []JWTKeyConfig
+	RotationGrace     time.Duration // how long superseded keys keep verifying
+}
+
+// JWTKeyConfig describes an asymmetric signing key loaded from a PEM file.
+// When no keys are configured, tokens are signed with Secret using HS256.
+type JWTKeyConfig struct {
+	ID         string
+	Path       string
+	ActiveFrom time.Time
+}
+
+// OIDCConfig contains configuration for accepting tokens from an external
+// OIDC issuer. OIDC mode is disabled when Issuer is empty.
+type OIDCConfig struct {
+	Issuer          string
+	Audience        []string
+	JWKSURL         string // discovered from the issuer when empty
+	JWKSCacheTTL    time.Duration
+	ClockSkew       time.Duration
+	SubjectClaim    string
+	EmailClaim      string
+	UserMapping     string // identity, subject
+	JITProvisioning bool
+}
+
+// PasswordConfig contains password hashing configuration
+type PasswordConfig struct {
+	Algorithm        string // bcrypt, argon2id
+	BcryptCost       int
+	Argon2Time       uint32
+	Argon2Memory     uint32 // KiB
+	Argon2Threads    uint8
+	Argon2KeyLength  uint32
+	Argon2SaltLength uint32
+}
+
+// MailConfig contains outgoing mail configuration
+type MailConfig struct {
+	Driver       string // smtp, file, log
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	FileDir      string // where the file driver writes .eml files
+}
+
+// EmailVerificationConfig contains email verification configuration
+type EmailVerificationConfig struct {
+	Required       bool // block login until the email address is verified
+	TokenTTL       time.Duration
+	ResendInterval time.Duration
+	URL            string // page the token is appended to as the token query parameter
+}
+
+// PasswordResetConfig contains password reset configuration
+type PasswordResetConfig struct {
+	TokenTTL        time.Duration
+	RequestInterval time.Duration // minimum time between two reset emails to the same user
+	URL             string        // page the token is appended to as the token query parameter
+}
+
+// MagicLinkConfig contains passwordless login configuration
+type MagicLinkConfig struct {
+	Enabled         bool
+	TokenTTL        time.Duration
+	RequestInterval time.Duration // minimum time between two login emails to the same user
+	URL             string        // page the token is appended to as the token query parameter
+	CookieName      string        // carries the binding to the requesting browser
+	CookieSecure    bool
+}
+
+// InvitationConfig contains invitation configuration
+type InvitationConfig struct {
+	TokenTTL time.Duration
+	URL      string // page the token and tenant slug are appended to as query parameters
+}
+
+// MFAConfig contains multi-factor authentication configuration
+type MFAConfig struct {
+	Issuer       string        // name shown in authenticator apps
+	ChallengeTTL time.Duration // time allowed between the password and the second factor
+}
+
+// LockoutConfig contains the brute-force protection policy for logins.
+// A zero failure limit disables that counter; a zero DelayAfter disables delays.
+type LockoutConfig struct {
+	Store                string        // memory, sql
+	FailureWindow        time.Duration // failures older than this are forgotten
+	MaxAccountFailures   int           // failures from any IP before the account is locked
+	MaxIPAccountFailures int           // failures from one IP before that IP is locked out of the account
+	Duration             time.Duration // how long a lockout lasts
+	DelayAfter           int           // failures before progressive delays start
+	BaseDelay            time.Duration // first delay, doubled with every further failure
+	MaxDelay             time.Duration
+}
+
+// APIKeyConfig contains API key configuration
+type APIKeyConfig struct {
+	LastUsedInterval time.Duration // minimum time between two last-used updates of a key
+}
+
+// ImpersonationConfig contains admin impersonation configuration
+type ImpersonationConfig struct {
+	TokenTTL time.Duration // lifetime of impersonation tokens, which cannot be refreshed
+}
+
+// RBACConfig contains authorization configuration
+type RBACConfig struct {
+	PermissionCacheTTL time.Duration // how long looked up permissions are reused; zero disables the cache
+}
+
+// TenancyConfig contains tenant resolution configuration. Requests that name
+// no tenant by header or subdomain belong to the default tenant, or to the
+// tenant of their access token.
+type TenancyConfig struct {
+	Header     string // header carrying the tenant slug; empty disables it
+	BaseDomain string // requests to <slug>.<BaseDomain> belong to that tenant; empty disables subdomains
+}
+
+// PaginationConfig contains list pagination configuration
+type PaginationConfig struct {
+	CursorSecret string // signs the opaque cursors of keyset paginated lists
+}
+
+// UserRetentionConfig contains the purge policy for deleted users. A zero
+// retention keeps deleted users until they are purged by hand.
+type UserRetentionConfig struct {
+	Retention     time.Duration // how long deleted users are kept
+	PurgeInterval time.Duration // time between two purge runs
+}
+
+// ConcurrencyConfig contains the optimistic concurrency policy for writes
+type ConcurrencyConfig struct {
+	RequireIfMatch bool // reject user updates and deletions without If-Match
+}
+
+// AuthConfig selects how browser clients authenticate
+type AuthConfig struct {
+	Mode string // jwt, session
+}
+
+// SessionConfig contains cookie session configuration, used when the auth mode is session
+type SessionConfig struct {
+	Store           string        // memory, sql
+	IdleTimeout     time.Duration // sessions end after this long without a request
+	AbsoluteTimeout time.Duration // sessions end this long after login, however active
+	CookieName      string
+	CSRFCookieName  string // readable by scripts, so they can echo it in X-CSRF-Token
+	CookieDomain    string
+	CookieSecure    bool
+	CookieSameSite  string // Strict, Lax, None
 }
 
 // CORSConfig contains CORS configuration
@@ -50,6 +225,7 @@ type CORSConfig struct {
 	AllowedOrigins []string
 	AllowedMethods []string
 	AllowedHeaders []string
+	ExposedHeaders []string
 	AllowedCredentials bool
 }
 
@@ -72,6 +248,11 @@ func Load() (*Config, error) {
 	// Load config file if exists (but don't fail if it doesn't)
 	_ = viper.ReadInConfig()
 
+	jwtKeys, err := parseJWTKeys(viper.GetString("JWT_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
 		Server: ServerConfig{
 			Host:            viper.GetString("SERVER_HOST"),
@@ -93,13 +274,120 @@ func Load() (*Config, error) {
 			ConnMaxLifetime: viper.GetDuration("DB_CONN_MAX_LIFETIME"),
 		},
 		JWT: JWTConfig{
-			Secret:     viper.GetString("JWT_SECRET"),
-			Expiration: viper.GetDuration("JWT_EXPIRATION"),
+			Secret:            viper.GetString("JWT_SECRET"),
+			Expiration:        viper.GetDuration("JWT_EXPIRATION"),
+			RefreshExpiration: viper.GetDuration("JWT_REFRESH_EXPIRATION"),
+			RevocationStore:   viper.GetString("JWT_REVOCATION_STORE"),
+			Keys:              jwtKeys,
+			RotationGrace:     viper.GetDuration("JWT_KEY_ROTATION_GRACE"),
+		},
+		OIDC: OIDCConfig{
+			Issuer:          viper.GetString("OIDC_ISSUER"),
+			Audience:        splitList(viper.GetString("OIDC_AUDIENCE")),
+			JWKSURL:         viper.GetString("OIDC_JWKS_URL"),
+			JWKSCacheTTL:    viper.GetDuration("OIDC_JWKS_CACHE_TTL"),
+			ClockSkew:       viper.GetDuration("OIDC_CLOCK_SKEW"),
+			SubjectClaim:    viper.GetString("OIDC_SUBJECT_CLAIM"),
+			EmailClaim:      viper.GetString("OIDC_EMAIL_CLAIM"),
+			UserMapping:     viper.GetString("OIDC_USER_MAPPING"),
+			JITProvisioning: viper.GetBool("OIDC_JIT_PROVISIONING"),
+		},
+		Password: PasswordConfig{
+			Algorithm:        viper.GetString("PASSWORD_HASH_ALGORITHM"),
+			BcryptCost:       viper.GetInt("PASSWORD_BCRYPT_COST"),
+			Argon2Time:       viper.GetUint32("PASSWORD_ARGON2_TIME"),
+			Argon2Memory:     viper.GetUint32("PASSWORD_ARGON2_MEMORY"),
+			Argon2Threads:    uint8(viper.GetUint("PASSWORD_ARGON2_THREADS")),
+			Argon2KeyLength:  viper.GetUint32("PASSWORD_ARGON2_KEY_LENGTH"),
+			Argon2SaltLength: viper.GetUint32("PASSWORD_ARGON2_SALT_LENGTH"),
+		},
+		Mail: MailConfig{
+			Driver:       viper.GetString("MAIL_DRIVER"),
+			From:         viper.GetString("MAIL_FROM"),
+			SMTPHost:     viper.GetString("SMTP_HOST"),
+			SMTPPort:     viper.GetInt("SMTP_PORT"),
+			SMTPUsername: viper.GetString("SMTP_USERNAME"),
+			SMTPPassword: viper.GetString("SMTP_PASSWORD"),
+			FileDir:      viper.GetString("MAIL_FILE_DIR"),
+		},
+		EmailVerification: EmailVerificationConfig{
+			Required:       viper.GetBool("EMAIL_VERIFICATION_REQUIRED"),
+			TokenTTL:       viper.GetDuration("EMAIL_VERIFICATION_TOKEN_TTL"),
+			ResendInterval: viper.GetDuration("EMAIL_VERIFICATION_RESEND_INTERVAL"),
+			URL:            viper.GetString("EMAIL_VERIFICATION_URL"),
+		},
+		PasswordReset: PasswordResetConfig{
+			TokenTTL:        viper.GetDuration("PASSWORD_RESET_TOKEN_TTL"),
+			RequestInterval: viper.GetDuration("PASSWORD_RESET_REQUEST_INTERVAL"),
+			URL:             viper.GetString("PASSWORD_RESET_URL"),
+		},
+		MagicLink: MagicLinkConfig{
+			Enabled:         viper.GetBool("MAGIC_LINK_ENABLED"),
+			TokenTTL:        viper.GetDuration("MAGIC_LINK_TOKEN_TTL"),
+			RequestInterval: viper.GetDuration("MAGIC_LINK_REQUEST_INTERVAL"),
+			URL:             viper.GetString("MAGIC_LINK_URL"),
+			CookieName:      viper.GetString("MAGIC_LINK_COOKIE_NAME"),
+			CookieSecure:    viper.GetBool("MAGIC_LINK_COOKIE_SECURE"),
+		},
+		Invitation: InvitationConfig{
+			TokenTTL: viper.GetDuration("INVITATION_TOKEN_TTL"),
+			URL:      viper.GetString("INVITATION_URL"),
+		},
+		MFA: MFAConfig{
+			Issuer:       viper.GetString("MFA_ISSUER"),
+			ChallengeTTL: viper.GetDuration("MFA_CHALLENGE_TTL"),
+		},
+		Lockout: LockoutConfig{
+			Store:                viper.GetString("LOCKOUT_STORE"),
+			FailureWindow:        viper.GetDuration("LOCKOUT_FAILURE_WINDOW"),
+			MaxAccountFailures:   viper.GetInt("LOCKOUT_MAX_ACCOUNT_FAILURES"),
+			MaxIPAccountFailures: viper.GetInt("LOCKOUT_MAX_IP_ACCOUNT_FAILURES"),
+			Duration:             viper.GetDuration("LOCKOUT_DURATION"),
+			DelayAfter:           viper.GetInt("LOCKOUT_DELAY_AFTER"),
+			BaseDelay:            viper.GetDuration("LOCKOUT_BASE_DELAY"),
+			MaxDelay:             viper.GetDuration("LOCKOUT_MAX_DELAY"),
+		},
+		APIKey: APIKeyConfig{
+			LastUsedInterval: viper.GetDuration("API_KEY_LAST_USED_INTERVAL"),
+		},
+		Impersonation: ImpersonationConfig{
+			TokenTTL: viper.GetDuration("IMPERSONATION_TOKEN_TTL"),
+		},
+		RBAC: RBACConfig{
+			PermissionCacheTTL: viper.GetDuration("RBAC_PERMISSION_CACHE_TTL"),
+		},
+		Tenancy: TenancyConfig{
+			Header:     viper.GetString("TENANT_HEADER"),
+			BaseDomain: viper.GetString("TENANT_BASE_DOMAIN"),
+		},
+		Pagination: PaginationConfig{
+			CursorSecret: viper.GetString("PAGINATION_CURSOR_SECRET"),
+		},
+		UserRetention: UserRetentionConfig{
+			Retention:     viper.GetDuration("USER_RETENTION"),
+			PurgeInterval: viper.GetDuration("USER_PURGE_INTERVAL"),
+		},
+		Concurrency: ConcurrencyConfig{
+			RequireIfMatch: viper.GetBool("REQUIRE_IF_MATCH"),
+		},
+		Auth: AuthConfig{
+			Mode: viper.GetString("AUTH_MODE"),
+		},
+		Session: SessionConfig{
+			Store:           viper.GetString("SESSION_STORE"),
+			IdleTimeout:     viper.GetDuration("SESSION_IDLE_TIMEOUT"),
+			AbsoluteTimeout: viper.GetDuration("SESSION_ABSOLUTE_TIMEOUT"),
+			CookieName:      viper.GetString("SESSION_COOKIE_NAME"),
+			CSRFCookieName:  viper.GetString("SESSION_CSRF_COOKIE_NAME"),
+			CookieDomain:    viper.GetString("SESSION_COOKIE_DOMAIN"),
+			CookieSecure:    viper.GetBool("SESSION_COOKIE_SECURE"),
+			CookieSameSite:  viper.GetString("SESSION_COOKIE_SAMESITE"),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: viper.GetStringSlice("CORS_ALLOWED_ORIGINS"),
 			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-			AllowedHeaders: []string{"Content-Type", "Authorization"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "X-API-Key", "X-CSRF-Token", "If-Match"},
+			ExposedHeaders: []string{"ETag", "Link"},
 			AllowedCredentials: viper.GetBool("CORS_ALLOWED_CREDENTIALS"),
 		},
 		Logging: LoggingConfig{
@@ -108,6 +396,11 @@ func Load() (*Config, error) {
 		},
 	}
 
+	// Browsers may only send the tenant header cross-origin when CORS allows it
+	if cfg.Tenancy.Header != "" {
+		cfg.CORS.AllowedHeaders = append(cfg.CORS.AllowedHeaders, cfg.Tenancy.Header)
+	}
+
 	// Validate required configuration
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -137,6 +430,89 @@ func setDefaults() {
 
 	viper.SetDefault("JWT_SECRET", "your-super-secret-jwt-key")
 	viper.SetDefault("JWT_EXPIRATION", "3600s")
+	viper.SetDefault("JWT_REFRESH_EXPIRATION", "720h")
+	viper.SetDefault("JWT_REVOCATION_STORE", "sql")
+	viper.SetDefault("JWT_KEYS", "")
+	viper.SetDefault("JWT_KEY_ROTATION_GRACE", "0s")
+
+	viper.SetDefault("OIDC_ISSUER", "")
+	viper.SetDefault("OIDC_AUDIENCE", "")
+	viper.SetDefault("OIDC_JWKS_URL", "")
+	viper.SetDefault("OIDC_JWKS_CACHE_TTL", "1h")
+	viper.SetDefault("OIDC_CLOCK_SKEW", "60s")
+	viper.SetDefault("OIDC_SUBJECT_CLAIM", "sub")
+	viper.SetDefault("OIDC_EMAIL_CLAIM", "email")
+	viper.SetDefault("OIDC_USER_MAPPING", "identity")
+	viper.SetDefault("OIDC_JIT_PROVISIONING", false)
+
+	viper.SetDefault("PASSWORD_HASH_ALGORITHM", "bcrypt")
+	viper.SetDefault("PASSWORD_BCRYPT_COST", 12)
+	viper.SetDefault("PASSWORD_ARGON2_TIME", 3)
+	viper.SetDefault("PASSWORD_ARGON2_MEMORY", 65536)
+	viper.SetDefault("PASSWORD_ARGON2_THREADS", 2)
+	viper.SetDefault("PASSWORD_ARGON2_KEY_LENGTH", 32)
+	viper.SetDefault("PASSWORD_ARGON2_SALT_LENGTH", 16)
+
+	viper.SetDefault("MAIL_DRIVER", "log")
+	viper.SetDefault("MAIL_FROM", "no-reply@example.com")
+	viper.SetDefault("SMTP_HOST", "")
+	viper.SetDefault("SMTP_PORT", 587)
+	viper.SetDefault("SMTP_USERNAME", "")
+	viper.SetDefault("SMTP_PASSWORD", "")
+	viper.SetDefault("MAIL_FILE_DIR", "./tmp/mail")
+
+	viper.SetDefault("EMAIL_VERIFICATION_REQUIRED", false)
+	viper.SetDefault("EMAIL_VERIFICATION_TOKEN_TTL", "24h")
+	viper.SetDefault("EMAIL_VERIFICATION_RESEND_INTERVAL", "60s")
+	viper.SetDefault("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email")
+	viper.SetDefault("PASSWORD_RESET_TOKEN_TTL", "1h")
+	viper.SetDefault("PASSWORD_RESET_REQUEST_INTERVAL", "60s")
+	viper.SetDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+	viper.SetDefault("MAGIC_LINK_ENABLED", false)
+	viper.SetDefault("MAGIC_LINK_TOKEN_TTL", "15m")
+	viper.SetDefault("MAGIC_LINK_REQUEST_INTERVAL", "60s")
+	viper.SetDefault("MAGIC_LINK_URL", "http://localhost:3000/magic-link")
+	viper.SetDefault("MAGIC_LINK_COOKIE_NAME", "magic_link_binding")
+	viper.SetDefault("MAGIC_LINK_COOKIE_SECURE", true)
+	viper.SetDefault("INVITATION_TOKEN_TTL", "168h")
+	viper.SetDefault("INVITATION_URL", "http://localhost:3000/invitation")
+	viper.SetDefault("MFA_ISSUER", "Go Fiber Template")
+	viper.SetDefault("MFA_CHALLENGE_TTL", "5m")
+
+	viper.SetDefault("LOCKOUT_STORE", "sql")
+	viper.SetDefault("LOCKOUT_FAILURE_WINDOW", "15m")
+	viper.SetDefault("LOCKOUT_MAX_ACCOUNT_FAILURES", 20)
+	viper.SetDefault("LOCKOUT_MAX_IP_ACCOUNT_FAILURES", 5)
+	viper.SetDefault("LOCKOUT_DURATION", "15m")
+	viper.SetDefault("LOCKOUT_DELAY_AFTER", 3)
+	viper.SetDefault("LOCKOUT_BASE_DELAY", "1s")
+	viper.SetDefault("LOCKOUT_MAX_DELAY", "30s")
+
+	viper.SetDefault("API_KEY_LAST_USED_INTERVAL", "1m")
+
+	viper.SetDefault("IMPERSONATION_TOKEN_TTL", "15m")
+
+	viper.SetDefault("RBAC_PERMISSION_CACHE_TTL", "1m")
+
+	viper.SetDefault("TENANT_HEADER", "X-Tenant")
+	viper.SetDefault("TENANT_BASE_DOMAIN", "")
+
+	viper.SetDefault("PAGINATION_CURSOR_SECRET", "your-super-secret-cursor-key")
+
+	viper.SetDefault("USER_RETENTION", "0s")
+	viper.SetDefault("USER_PURGE_INTERVAL", "1h")
+
+	viper.SetDefault("REQUIRE_IF_MATCH", false)
+
+	viper.SetDefault("AUTH_MODE", "jwt")
+	viper.SetDefault("SESSION_STORE", "sql")
+	viper.SetDefault("SESSION_IDLE_TIMEOUT", "30m")
+	viper.SetDefault("SESSION_ABSOLUTE_TIMEOUT", "24h")
+	viper.SetDefault("SESSION_COOKIE_NAME", "session")
+	viper.SetDefault("SESSION_CSRF_COOKIE_NAME", "csrf_token")
+	viper.SetDefault("SESSION_COOKIE_DOMAIN", "")
+	viper.SetDefault("SESSION_COOKIE_SECURE", true)
+	viper.SetDefault("SESSION_COOKIE_SAMESITE", "Lax")
 
 	viper.SetDefault("CORS_ALLOWED_ORIGINS", []string{"*"})
 	viper.SetDefault("CORS_ALLOWED_CREDENTIALS", false)
@@ -155,9 +531,215 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("database host is required")
 	}
 
-	if c.JWT.Secret == "your-super-secret-jwt-key" && c.Server.Environment == "production" {
+	if len(c.JWT.Keys) == 0 && c.JWT.Secret == "your-super-secret-jwt-key" && c.Server.Environment == "production" {
 		return fmt.Errorf("JWT_SECRET must be changed in production")
 	}
 
+	if c.Pagination.CursorSecret == "your-super-secret-cursor-key" && c.Server.Environment == "production" {
+		return fmt.Errorf("PAGINATION_CURSOR_SECRET must be changed in production")
+	}
+
+	// Superseded keys must outlive the tokens they signed
+	if c.JWT.RotationGrace != 0 && c.JWT.RotationGrace < c.JWT.Expiration {
+		return fmt.Errorf("JWT key rotation grace %s is shorter than the token lifetime %s", c.JWT.RotationGrace, c.JWT.Expiration)
+	}
+
+	seen := make(map[string]bool, len(c.JWT.Keys))
+	for _, key := range c.JWT.Keys {
+		if key.ID == "" || key.Path == "" {
+			return fmt.Errorf("JWT key requires an id and a path")
+		}
+		if seen[key.ID] {
+			return fmt.Errorf("duplicate JWT key id: %s", key.ID)
+		}
+		seen[key.ID] = true
+	}
+
+	switch c.JWT.RevocationStore {
+	case "", "memory", "sql":
+	default:
+		return fmt.Errorf("unsupported JWT revocation store: %s", c.JWT.RevocationStore)
+	}
+
+	if c.OIDC.Issuer != "" {
+		if len(c.OIDC.Audience) == 0 {
+			return fmt.Errorf("OIDC_AUDIENCE is required when OIDC_ISSUER is set")
+		}
+
+		switch c.OIDC.UserMapping {
+		case "", "identity":
+		case "subject":
+			if c.OIDC.JITProvisioning {
+				return fmt.Errorf("OIDC just-in-time provisioning requires the identity user mapping")
+			}
+		default:
+			return fmt.Errorf("unsupported OIDC user mapping: %s", c.OIDC.UserMapping)
+		}
+	}
+
+	switch c.Mail.Driver {
+	case "", "log":
+	case "smtp":
+		if c.Mail.SMTPHost == "" {
+			return fmt.Errorf("SMTP_HOST is required for the smtp mail driver")
+		}
+	case "file":
+		if c.Mail.FileDir == "" {
+			return fmt.Errorf("MAIL_FILE_DIR is required for the file mail driver")
+		}
+	default:
+		return fmt.Errorf("unsupported mail driver: %s", c.Mail.Driver)
+	}
+
+	if c.EmailVerification.URL != "" {
+		if link, err := url.Parse(c.EmailVerification.URL); err != nil || !link.IsAbs() {
+			return fmt.Errorf("invalid email verification URL: %s", c.EmailVerification.URL)
+		}
+	}
+
+	if c.PasswordReset.URL != "" {
+		if link, err := url.Parse(c.PasswordReset.URL); err != nil || !link.IsAbs() {
+			return fmt.Errorf("invalid password reset URL: %s", c.PasswordReset.URL)
+		}
+	}
+
+	if c.Invitation.URL != "" {
+		if link, err := url.Parse(c.Invitation.URL); err != nil || !link.IsAbs() {
+			return fmt.Errorf("invalid invitation URL: %s", c.Invitation.URL)
+		}
+	}
+
+	if c.MagicLink.Enabled {
+		if link, err := url.Parse(c.MagicLink.URL); err != nil || !link.IsAbs() {
+			return fmt.Errorf("invalid magic link URL: %s", c.MagicLink.URL)
+		}
+		if c.MagicLink.TokenTTL <= 0 || c.MagicLink.CookieName == "" {
+			return fmt.Errorf("MAGIC_LINK_TOKEN_TTL and MAGIC_LINK_COOKIE_NAME are required when magic links are enabled")
+		}
+	}
+
+	switch c.Lockout.Store {
+	case "", "memory", "sql":
+	default:
+		return fmt.Errorf("unsupported lockout store: %s", c.Lockout.Store)
+	}
+
+	if c.Lockout.MaxAccountFailures < 0 || c.Lockout.MaxIPAccountFailures < 0 || c.Lockout.DelayAfter < 0 {
+		return fmt.Errorf("lockout failure limits must not be negative")
+	}
+
+	if (c.Lockout.MaxAccountFailures > 0 || c.Lockout.MaxIPAccountFailures > 0) && (c.Lockout.Duration <= 0 || c.Lockout.FailureWindow <= 0) {
+		return fmt.Errorf("LOCKOUT_DURATION and LOCKOUT_FAILURE_WINDOW are required when lockouts are enabled")
+	}
+
+	if c.UserRetention.Retention < 0 {
+		return fmt.Errorf("USER_RETENTION must not be negative")
+	}
+
+	if c.UserRetention.Retention > 0 && c.UserRetention.PurgeInterval <= 0 {
+		return fmt.Errorf("USER_PURGE_INTERVAL is required when USER_RETENTION is set")
+	}
+
+	switch c.Auth.Mode {
+	case "", "jwt":
+	case "session":
+		if err := c.Session.validate(c.Server.Environment); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported auth mode: %s", c.Auth.Mode)
+	}
+
+	switch c.Password.Algorithm {
+	case "", "bcrypt":
+		if c.Password.BcryptCost != 0 && (c.Password.BcryptCost < 4 || c.Password.BcryptCost > 31) {
+			return fmt.Errorf("invalid bcrypt cost: %d", c.Password.BcryptCost)
+		}
+	case "argon2id":
+	default:
+		return fmt.Errorf("unsupported password hash algorithm: %s", c.Password.Algorithm)
+	}
+
 	return nil
 }
+
+// validate checks the session settings used in session auth mode
+func (s SessionConfig) validate(environment string) error {
+	switch s.Store {
+	case "", "memory", "sql":
+	default:
+		return fmt.Errorf("unsupported session store: %s", s.Store)
+	}
+
+	if s.IdleTimeout <= 0 || s.AbsoluteTimeout < s.IdleTimeout {
+		return fmt.Errorf("SESSION_IDLE_TIMEOUT must be positive and no longer than SESSION_ABSOLUTE_TIMEOUT")
+	}
+
+	if s.CookieName == "" || s.CSRFCookieName == "" || s.CookieName == s.CSRFCookieName {
+		return fmt.Errorf("SESSION_COOKIE_NAME and SESSION_CSRF_COOKIE_NAME must be set and differ")
+	}
+
+	switch s.CookieSameSite {
+	case "Strict", "Lax":
+	case "None":
+		// Browsers drop SameSite=None cookies that are not Secure
+		if !s.CookieSecure {
+			return fmt.Errorf("SESSION_COOKIE_SAMESITE=None requires SESSION_COOKIE_SECURE")
+		}
+	default:
+		return fmt.Errorf("unsupported session cookie SameSite mode: %s", s.CookieSameSite)
+	}
+
+	if !s.CookieSecure && environment == "production" {
+		return fmt.Errorf("SESSION_COOKIE_SECURE must be enabled in production")
+	}
+
+	return nil
+}
+
+// parseJWTKeys parses JWT_KEYS, a comma separated list of kid=path entries
+// with an optional RFC 3339 activation time: kid=path@2026-01-01T00:00:00Z
+func parseJWTKeys(raw string) ([]JWTKeyConfig, error) {
+	var keys []JWTKeyConfig
+
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, path, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid JWT_KEYS entry %q: expected kid=path", entry)
+		}
+
+		key := JWTKeyConfig{
+			ID:   strings.TrimSpace(id),
+			Path: strings.TrimSpace(path),
+		}
+
+		if at := strings.LastIndex(key.Path, "@"); at >= 0 {
+			activeFrom, err := time.Parse(time.RFC3339, key.Path[at+1:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid activation time in JWT_KEYS entry %q: %w", entry, err)
+			}
+			key.Path = key.Path[:at]
+			key.ActiveFrom = activeFrom
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// splitList splits a comma separated value, dropping empty entries
+func splitList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
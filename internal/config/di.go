@@ -3,11 +3,17 @@ package config
 import (
 	"database/sql"
 	"fmt"
+	"log/slog"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/samber/do/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/infrastructure/mail"
 	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
 	"github.com/zercle/template-go-fiber/internal/repositories"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/pkg/oidc"
+	"github.com/zercle/template-go-fiber/pkg/password"
 )
 
 // InitializeDI initializes the dependency injection container
@@ -53,11 +59,181 @@ func InitializeDI(cfg *Config) (do.Injector, error) {
 		return db.New(database), nil
 	})
 
+	// Register password hasher
+	do.Provide(injector, func(i do.Injector) (password.Hasher, error) {
+		cfg := do.MustInvoke[*Config](i)
+		return password.New(password.Options{
+			Algorithm: cfg.Password.Algorithm,
+			Bcrypt: password.BcryptOptions{
+				Cost: cfg.Password.BcryptCost,
+			},
+			Argon2id: password.Argon2idOptions{
+				Time:       cfg.Password.Argon2Time,
+				Memory:     cfg.Password.Argon2Memory,
+				Threads:    cfg.Password.Argon2Threads,
+				KeyLength:  cfg.Password.Argon2KeyLength,
+				SaltLength: cfg.Password.Argon2SaltLength,
+			},
+		})
+	})
+
+	// Register JWT signing and verification keys
+	do.Provide(injector, func(i do.Injector) (*jwtkeys.KeySet, error) {
+		cfg := do.MustInvoke[*Config](i)
+		return newJWTKeySet(cfg)
+	})
+
+	// Register external OIDC token verifier; nil when OIDC mode is disabled
+	do.Provide(injector, func(i do.Injector) (*oidc.Verifier, error) {
+		cfg := do.MustInvoke[*Config](i)
+		if cfg.OIDC.Issuer == "" {
+			return nil, nil
+		}
+
+		return oidc.NewVerifier(oidc.Options{
+			Issuer:   cfg.OIDC.Issuer,
+			Audience: cfg.OIDC.Audience,
+			JWKSURL:  cfg.OIDC.JWKSURL,
+			CacheTTL: cfg.OIDC.JWKSCacheTTL,
+			Leeway:   cfg.OIDC.ClockSkew,
+		})
+	})
+
 	// Register repositories
 	do.Provide(injector, func(i do.Injector) (*repositories.UserRepository, error) {
 		queries := do.MustInvoke[*db.Queries](i)
 		return repositories.NewUserRepository(queries), nil
 	})
 
+	do.Provide(injector, func(i do.Injector) (*repositories.RefreshTokenRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewRefreshTokenRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.UserIdentityRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewUserIdentityRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.RoleRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewRoleRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.EmailVerificationTokenRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewEmailVerificationTokenRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.PasswordResetTokenRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewPasswordResetTokenRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.TenantRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewTenantRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.GroupRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewGroupRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.InvitationRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewInvitationRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.MagicLinkTokenRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewMagicLinkTokenRepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.MFARepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewMFARepository(queries), nil
+	})
+
+	do.Provide(injector, func(i do.Injector) (*repositories.APIKeyRepository, error) {
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewAPIKeyRepository(queries), nil
+	})
+
+	// Register mail sender
+	do.Provide(injector, func(i do.Injector) (domains.MailSender, error) {
+		cfg := do.MustInvoke[*Config](i)
+		switch cfg.Mail.Driver {
+		case "smtp":
+			return mail.NewSMTPSender(mail.SMTPOptions{
+				Host:     cfg.Mail.SMTPHost,
+				Port:     cfg.Mail.SMTPPort,
+				Username: cfg.Mail.SMTPUsername,
+				Password: cfg.Mail.SMTPPassword,
+				From:     cfg.Mail.From,
+			}), nil
+		case "file":
+			return mail.NewFileSender(cfg.Mail.FileDir, cfg.Mail.From), nil
+		default:
+			return mail.NewLogSender(slog.Default(), cfg.Mail.From), nil
+		}
+	})
+
+	// Register token revocation store
+	do.Provide(injector, func(i do.Injector) (domains.TokenRevocationStore, error) {
+		cfg := do.MustInvoke[*Config](i)
+		if cfg.JWT.RevocationStore == "memory" {
+			return repositories.NewMemoryTokenRevocationStore(), nil
+		}
+
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewTokenRevocationRepository(queries), nil
+	})
+
+	// Register login attempt store
+	do.Provide(injector, func(i do.Injector) (domains.LoginAttemptStore, error) {
+		cfg := do.MustInvoke[*Config](i)
+		if cfg.Lockout.Store == "memory" {
+			return repositories.NewMemoryLoginAttemptStore(), nil
+		}
+
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewLoginAttemptRepository(queries), nil
+	})
+
+	// Register session store
+	do.Provide(injector, func(i do.Injector) (domains.SessionStore, error) {
+		cfg := do.MustInvoke[*Config](i)
+		if cfg.Session.Store == "memory" {
+			return repositories.NewMemorySessionStore(), nil
+		}
+
+		queries := do.MustInvoke[*db.Queries](i)
+		return repositories.NewSessionRepository(queries), nil
+	})
+
 	return injector, nil
 }
+
+// newJWTKeySet loads the configured PEM keys, falling back to the HS256 secret
+func newJWTKeySet(cfg *Config) (*jwtkeys.KeySet, error) {
+	grace := cfg.JWT.RotationGrace
+	if grace == 0 {
+		grace = cfg.JWT.Expiration
+	}
+
+	if len(cfg.JWT.Keys) == 0 {
+		return jwtkeys.NewKeySet([]*jwtkeys.Key{jwtkeys.NewHMACKey("", []byte(cfg.JWT.Secret))}, grace)
+	}
+
+	keys := make([]*jwtkeys.Key, 0, len(cfg.JWT.Keys))
+	for _, keyCfg := range cfg.JWT.Keys {
+		key, err := jwtkeys.LoadPEMFile(keyCfg.ID, keyCfg.Path, keyCfg.ActiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return jwtkeys.NewKeySet(keys, grace)
+}
@@ -0,0 +1,97 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=api_key.go -destination=../../test/unit/mocks/mock_api_key.go -package=mocks
+
+// APIKeyRepository defines the contract for API key data access
+type APIKeyRepository interface {
+	// Create stores a new API key
+	Create(ctx context.Context, key *APIKey) error
+
+	// GetByID retrieves an API key by its ID
+	GetByID(ctx context.Context, id string) (*APIKey, error)
+
+	// GetByPrefix retrieves an API key by the public prefix of its value
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+
+	// ListByUser retrieves the API keys owned by a user, newest first
+	ListByUser(ctx context.Context, userID string) ([]*APIKey, error)
+
+	// Update stores the name, scopes and expiry of an API key
+	Update(ctx context.Context, key *APIKey) error
+
+	// Delete removes an API key
+	Delete(ctx context.Context, id string) error
+
+	// TouchLastUsed records a use of the key unless one was recorded after staleBefore
+	TouchLastUsed(ctx context.Context, id string, usedAt, staleBefore time.Time) error
+}
+
+// APIKeyUsecase defines the contract for managing and authenticating API keys
+type APIKeyUsecase interface {
+	// CreateServiceAccount creates a non-interactive account to own API keys
+	CreateServiceAccount(ctx context.Context, input *CreateServiceAccountInput) (*User, error)
+
+	// CreateAPIKey issues a new key; its value is only ever returned this once
+	CreateAPIKey(ctx context.Context, input *CreateAPIKeyInput) (*IssuedAPIKey, error)
+
+	// ListAPIKeys retrieves the keys owned by a user
+	ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error)
+
+	// GetAPIKey retrieves a key by ID
+	GetAPIKey(ctx context.Context, id string) (*APIKey, error)
+
+	// UpdateAPIKey changes the name, scopes or expiry of a key
+	UpdateAPIKey(ctx context.Context, id string, input *UpdateAPIKeyInput) (*APIKey, error)
+
+	// DeleteAPIKey revokes a key
+	DeleteAPIKey(ctx context.Context, id string) error
+
+	// Authenticate resolves a key presented by a client to the principal it acts as
+	Authenticate(ctx context.Context, key string) (*Principal, error)
+}
+
+// APIKey represents a persisted API key. The key handed to the client is the
+// prefix and a secret; only the hash of the secret is stored.
+type APIKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string
+	SecretHash string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// IssuedAPIKey is a newly created API key together with its value
+type IssuedAPIKey struct {
+	APIKey *APIKey
+	Key    string
+}
+
+// CreateServiceAccountInput is the input for creating a service account
+type CreateServiceAccountInput struct {
+	Email string
+	Name  string
+}
+
+// CreateAPIKeyInput is the input for creating an API key
+type CreateAPIKeyInput struct {
+	UserID    string // owner; the caller or a service account
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time // nil for a key that does not expire
+}
+
+// UpdateAPIKeyInput is the input for updating an API key
+type UpdateAPIKeyInput struct {
+	Name      *string
+	Scopes    []string // nil keeps the current scopes
+	ExpiresAt *time.Time
+}
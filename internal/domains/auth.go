@@ -0,0 +1,91 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=auth.go -destination=../../test/unit/mocks/mock_auth.go -package=mocks
+
+// AuthUsecase defines the contract for authentication business logic
+type AuthUsecase interface {
+	// Login verifies user credentials and issues an access and refresh token pair.
+	// Users with MFA enabled get an MFA challenge token instead.
+	Login(ctx context.Context, input *LoginInput) (*AuthToken, error)
+
+	// VerifyMFA completes a login with the second factor and issues the token pair
+	VerifyMFA(ctx context.Context, input *MFALoginInput) (*AuthToken, error)
+
+	// LoginWithoutPassword issues the token pair for a user who proved their
+	// identity by other means, such as a magic link. MFA still applies.
+	LoginWithoutPassword(ctx context.Context, user *User) (*AuthToken, error)
+
+	// Refresh rotates a refresh token and issues a new token pair
+	Refresh(ctx context.Context, refreshToken string) (*AuthToken, error)
+
+	// Logout revokes the current refresh token family and access token
+	Logout(ctx context.Context, input *LogoutInput) error
+}
+
+// TokenIssuer defines the contract for minting signed access tokens
+type TokenIssuer interface {
+	// Issue signs a new access token for the given subject
+	Issue(input *IssueTokenInput) (*AuthToken, error)
+}
+
+// LoginInput is the input for credential based login
+type LoginInput struct {
+	Email     string
+	Password  string
+	IPAddress string // client address, for brute-force protection
+
+	// StartSession starts a server-side session instead of issuing tokens
+	StartSession bool
+}
+
+// MFALoginInput is the input for the second step of a login
+type MFALoginInput struct {
+	MFAToken  string
+	Code      string // TOTP or recovery code
+	IPAddress string // client address, for brute-force protection
+
+	// StartSession starts a server-side session instead of issuing tokens
+	StartSession bool
+}
+
+// LogoutInput identifies the session to terminate. Either field may be empty.
+type LogoutInput struct {
+	RefreshToken         string
+	AccessTokenID        string
+	AccessTokenExpiresAt time.Time
+}
+
+// IssueTokenInput describes the subject an access token is issued for
+type IssueTokenInput struct {
+	UserID      string
+	TenantID    string
+	Email       string
+	Roles       []string
+	Permissions []string
+
+	// ActorID and ActorEmail name the admin acting as the subject, for
+	// impersonation tokens
+	ActorID    string
+	ActorEmail string
+
+	// TTL overrides the issuer's token lifetime when set
+	TTL time.Duration
+}
+
+// AuthToken represents an issued access token and, when applicable, its refresh token.
+// When MFAToken is set no access token was issued: the login waits for the second
+// factor and ExpiresAt is the deadline for it. When Session is set a server-side
+// session was started instead of issuing tokens.
+type AuthToken struct {
+	AccessToken  string
+	TokenType    string
+	ExpiresAt    time.Time
+	RefreshToken string
+	MFAToken     string
+	Session      *IssuedSession
+}
@@ -0,0 +1,52 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=email_verification.go -destination=../../test/unit/mocks/mock_email_verification.go -package=mocks
+
+// EmailVerificationTokenRepository defines the contract for email verification token data access
+type EmailVerificationTokenRepository interface {
+	// Create stores a new verification token
+	Create(ctx context.Context, token *EmailVerificationToken) error
+
+	// GetByHash retrieves a verification token by the hash of its opaque value
+	GetByHash(ctx context.Context, tokenHash string) (*EmailVerificationToken, error)
+
+	// GetLatestForUser retrieves the most recently issued token of a user
+	GetLatestForUser(ctx context.Context, userID string) (*EmailVerificationToken, error)
+
+	// MarkUsed consumes a token; it reports false if the token was already used
+	MarkUsed(ctx context.Context, id string) (bool, error)
+
+	// InvalidateForUser consumes every outstanding token of a user
+	InvalidateForUser(ctx context.Context, userID string) error
+}
+
+// EmailVerificationUsecase defines the contract for verifying user email addresses
+type EmailVerificationUsecase interface {
+	// SendVerification issues a new verification token and mails it to the user,
+	// invalidating any earlier ones
+	SendVerification(ctx context.Context, user *User) error
+
+	// VerifyEmail consumes a verification token and marks the address as verified
+	VerifyEmail(ctx context.Context, token string) error
+
+	// ResendVerification mails a new token to an unverified address. It does not
+	// reveal whether the address is registered.
+	ResendVerification(ctx context.Context, email string) error
+}
+
+// EmailVerificationToken represents a persisted verification token. Only the
+// hash of the opaque token mailed to the user is stored.
+type EmailVerificationToken struct {
+	ID        string
+	UserID    string
+	TenantID  string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,43 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=identity.go -destination=../../test/unit/mocks/mock_identity.go -package=mocks
+
+// UserIdentityRepository defines the contract for external identity data access
+type UserIdentityRepository interface {
+	// GetByIssuerSubject retrieves the identity an issuer asserts for a subject
+	GetByIssuerSubject(ctx context.Context, issuer, subject string) (*UserIdentity, error)
+
+	// Create links an external identity to a local user
+	Create(ctx context.Context, identity *UserIdentity) error
+}
+
+// IdentityUsecase defines the contract for mapping external identities onto local users
+type IdentityUsecase interface {
+	// ResolveExternalUser returns the local user linked to an external identity,
+	// provisioning one on first sight when enabled
+	ResolveExternalUser(ctx context.Context, input *ExternalIdentityInput) (*User, error)
+}
+
+// UserIdentity links an account at an external OIDC issuer to a local user
+type UserIdentity struct {
+	ID        string
+	UserID    string
+	Issuer    string
+	Subject   string
+	CreatedAt time.Time
+}
+
+// ExternalIdentityInput holds the verified claims of an external token
+type ExternalIdentityInput struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     *string
+	LastName      *string
+}
@@ -0,0 +1,18 @@
+package domains
+
+import "context"
+
+//go:generate mockgen -source=impersonation.go -destination=../../test/unit/mocks/mock_impersonation.go -package=mocks
+
+// ImpersonationUsecase defines the contract for admins acting as another user
+type ImpersonationUsecase interface {
+	// Impersonate issues a short-lived access token for the target user that
+	// also names the calling admin as its actor. No refresh token is issued.
+	Impersonate(ctx context.Context, input *ImpersonateInput) (*AuthToken, error)
+}
+
+// ImpersonateInput is the input for starting an impersonation
+type ImpersonateInput struct {
+	UserID string
+	Reason string // recorded in the audit log, e.g. a support ticket reference
+}
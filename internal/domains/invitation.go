@@ -0,0 +1,103 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=invitation.go -destination=../../test/unit/mocks/mock_invitation.go -package=mocks
+
+// Invitation states. Only pending invitations can be accepted, declined,
+// revoked or resent.
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+)
+
+// InvitationRepository defines the contract for invitation data access. Like
+// UserRepository, every method is scoped to the tenant of the context and
+// fails with ErrNoTenant when the context carries none.
+type InvitationRepository interface {
+	// Create stores a new pending invitation in the tenant of the context
+	Create(ctx context.Context, invitation *Invitation) error
+
+	// GetByID retrieves an invitation by its ID
+	GetByID(ctx context.Context, id string) (*Invitation, error)
+
+	// GetByHash retrieves an invitation by the hash of its opaque token
+	GetByHash(ctx context.Context, tokenHash string) (*Invitation, error)
+
+	// GetPendingByEmail retrieves the pending invitation of an email address
+	GetPendingByEmail(ctx context.Context, email string) (*Invitation, error)
+
+	// ListPending retrieves a paginated list of pending invitations, newest first
+	ListPending(ctx context.Context, limit, offset int32) ([]*Invitation, error)
+
+	// Renew replaces the token and expiry of a pending invitation; it reports
+	// false if the invitation is no longer pending
+	Renew(ctx context.Context, id, tokenHash string, expiresAt time.Time) (bool, error)
+
+	// Respond moves a pending invitation to a final status; it reports false
+	// if the invitation is no longer pending
+	Respond(ctx context.Context, id, status string) (bool, error)
+}
+
+// InvitationUsecase defines the contract for inviting people into a tenant
+type InvitationUsecase interface {
+	// CreateInvitation mails an invitation to join the tenant with a role
+	CreateInvitation(ctx context.Context, input *CreateInvitationInput) (*Invitation, error)
+
+	// ListPendingInvitations retrieves a paginated list of pending invitations
+	ListPendingInvitations(ctx context.Context, limit, offset int32) ([]*Invitation, error)
+
+	// ResendInvitation mails a pending invitation again with a new token and expiry
+	ResendInvitation(ctx context.Context, id string) (*Invitation, error)
+
+	// RevokeInvitation withdraws a pending invitation
+	RevokeInvitation(ctx context.Context, id string) error
+
+	// AcceptInvitation grants the invited role to the account with the
+	// invited email address, registering it first if there is none
+	AcceptInvitation(ctx context.Context, input *AcceptInvitationInput) (*User, error)
+
+	// DeclineInvitation turns a pending invitation down
+	DeclineInvitation(ctx context.Context, token string) error
+}
+
+// Invitation represents an invitation to join a tenant with a role. Only the
+// hash of the opaque token mailed to the invitee is stored.
+type Invitation struct {
+	ID          string
+	TenantID    string
+	Email       string
+	Role        string
+	TokenHash   string
+	InvitedBy   *string
+	Status      string
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Expired reports whether a pending invitation can no longer be accepted
+func (i *Invitation) Expired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+// CreateInvitationInput is the input for inviting someone
+type CreateInvitationInput struct {
+	Email string
+	Role  string
+}
+
+// AcceptInvitationInput is the input for accepting an invitation. The
+// password and names are only used when the invitee has no account yet.
+type AcceptInvitationInput struct {
+	Token     string
+	Password  string
+	FirstName *string
+	LastName  *string
+}
@@ -0,0 +1,54 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=lockout.go -destination=../../test/unit/mocks/mock_lockout.go -package=mocks
+
+// LoginAttempt counts failed logins against an account, either from a single
+// IP address or, when IPAddress is empty, from everywhere
+type LoginAttempt struct {
+	Account      string
+	IPAddress    string
+	Failures     int
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
+
+// LoginAttemptStore defines the contract for persisting failed login counters.
+// An empty ip selects the account-wide counter.
+type LoginAttemptStore interface {
+	// Get retrieves a counter; it returns nil if there were no recent failures
+	Get(ctx context.Context, account, ip string) (*LoginAttempt, error)
+
+	// RecordFailure counts a failed attempt and returns the updated counter.
+	// Failures made before windowStart are forgotten.
+	RecordFailure(ctx context.Context, account, ip string, windowStart time.Time) (*LoginAttempt, error)
+
+	// Lock rejects further attempts on a counter until the given time
+	Lock(ctx context.Context, account, ip string, until time.Time) error
+
+	// Reset clears a counter
+	Reset(ctx context.Context, account, ip string) error
+
+	// ResetAccount clears every counter of an account, from all IP addresses
+	ResetAccount(ctx context.Context, account string) error
+}
+
+// LockoutUsecase defines the contract for brute-force protection on logins
+type LockoutUsecase interface {
+	// Check rejects an attempt while the account, or the account from this IP,
+	// is locked or still has to wait out a progressive delay
+	Check(ctx context.Context, email, ip string) error
+
+	// RecordFailure counts a failed attempt and locks the account when the policy says so
+	RecordFailure(ctx context.Context, email, ip string) error
+
+	// RecordSuccess clears the counters after a completed login
+	RecordSuccess(ctx context.Context, email, ip string) error
+
+	// Unlock lifts every lockout of a user
+	Unlock(ctx context.Context, userID string) error
+}
@@ -0,0 +1,57 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=magic_link.go -destination=../../test/unit/mocks/mock_magic_link.go -package=mocks
+
+// MagicLinkTokenRepository defines the contract for magic link token data access
+type MagicLinkTokenRepository interface {
+	// Create stores a new login token
+	Create(ctx context.Context, token *MagicLinkToken) error
+
+	// GetByHash retrieves a login token by the hash of its opaque value
+	GetByHash(ctx context.Context, tokenHash string) (*MagicLinkToken, error)
+
+	// GetLatestForUser retrieves the most recently issued token of a user
+	GetLatestForUser(ctx context.Context, userID string) (*MagicLinkToken, error)
+
+	// MarkUsed consumes a token; it reports false if the token was already used
+	MarkUsed(ctx context.Context, id string) (bool, error)
+
+	// InvalidateForUser consumes every outstanding token of a user
+	InvalidateForUser(ctx context.Context, userID string) error
+}
+
+// MagicLinkUsecase defines the contract for passwordless login by email
+type MagicLinkUsecase interface {
+	// RequestLink mails a login link to a registered address and returns the
+	// binding the requesting client must present with it. It does not reveal
+	// whether the address is registered.
+	RequestLink(ctx context.Context, email string) (binding string, err error)
+
+	// Login consumes a login token presented together with its binding and
+	// signs the user in
+	Login(ctx context.Context, input *MagicLinkLoginInput) (*AuthToken, error)
+}
+
+// MagicLinkToken represents a persisted login token. Only the hashes of the
+// mailed token and of the binding handed to the requesting client are stored.
+type MagicLinkToken struct {
+	ID          string
+	UserID      string
+	TenantID    string
+	TokenHash   string
+	BindingHash string
+	ExpiresAt   time.Time
+	UsedAt      *time.Time
+	CreatedAt   time.Time
+}
+
+// MagicLinkLoginInput is the input for completing a magic link login
+type MagicLinkLoginInput struct {
+	Token   string // from the mailed link
+	Binding string // returned to the client that requested the link
+}
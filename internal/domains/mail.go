@@ -0,0 +1,18 @@
+package domains
+
+import "context"
+
+//go:generate mockgen -source=mail.go -destination=../../test/unit/mocks/mock_mail.go -package=mocks
+
+// MailSender defines the contract for delivering email
+type MailSender interface {
+	// Send delivers a single plain text message
+	Send(ctx context.Context, message *MailMessage) error
+}
+
+// MailMessage is a plain text email. The sender address is configured on the MailSender.
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
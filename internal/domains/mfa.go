@@ -0,0 +1,111 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=mfa.go -destination=../../test/unit/mocks/mock_mfa.go -package=mocks
+
+// MFARepository defines the contract for second factor data access
+type MFARepository interface {
+	// GetFactor retrieves the TOTP factor of a user, pending or confirmed
+	GetFactor(ctx context.Context, userID string) (*MFAFactor, error)
+
+	// SaveFactor stores a pending factor, replacing any earlier enrollment
+	SaveFactor(ctx context.Context, userID, secret string) error
+
+	// ConfirmFactor activates a pending factor; it reports false if there was none
+	ConfirmFactor(ctx context.Context, userID string) (bool, error)
+
+	// AdvanceStep records the last accepted TOTP step; it reports false if the
+	// step is not newer than the recorded one, i.e. the code was replayed
+	AdvanceStep(ctx context.Context, userID string, step int64) (bool, error)
+
+	// DeleteFactor removes the factor and the recovery codes of a user
+	DeleteFactor(ctx context.Context, userID string) error
+
+	// ReplaceRecoveryCodes discards the recovery codes of a user and stores new ones
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+
+	// UseRecoveryCode consumes a recovery code; it reports false if there was no unused match
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+
+	// CountRecoveryCodes returns the number of unused recovery codes of a user
+	CountRecoveryCodes(ctx context.Context, userID string) (int64, error)
+
+	// CreateChallenge stores a pending second factor login
+	CreateChallenge(ctx context.Context, challenge *MFAChallenge) error
+
+	// GetChallengeByHash retrieves a challenge by the hash of its opaque token
+	GetChallengeByHash(ctx context.Context, tokenHash string) (*MFAChallenge, error)
+
+	// IncrementChallengeAttempts records a second factor attempt; it reports
+	// false if the challenge already had limit attempts
+	IncrementChallengeAttempts(ctx context.Context, id string, limit int) (bool, error)
+
+	// MarkChallengeUsed completes a challenge; it reports false if it was already used
+	MarkChallengeUsed(ctx context.Context, id string) (bool, error)
+}
+
+// MFAUsecase defines the contract for managing TOTP second factors
+type MFAUsecase interface {
+	// GetStatus reports whether a user has MFA enabled
+	GetStatus(ctx context.Context, userID string) (*MFAStatus, error)
+
+	// Enroll starts enrollment with a new secret; it has to be confirmed with a code
+	Enroll(ctx context.Context, userID string) (*MFAEnrollment, error)
+
+	// ConfirmEnrollment activates MFA with a code from the authenticator app and
+	// returns the recovery codes, which are only ever shown this once
+	ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error)
+
+	// RegenerateRecoveryCodes replaces the recovery codes of a user
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+
+	// Disable turns MFA off for a user who proves both factors
+	Disable(ctx context.Context, userID string, input *DisableMFAInput) error
+
+	// Reset turns MFA off for another user, e.g. after a lost device
+	Reset(ctx context.Context, userID string) error
+}
+
+// MFAFactor represents a user's TOTP secret
+type MFAFactor struct {
+	UserID       string
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+// MFAChallenge represents a login that passed the password check and waits
+// for the second factor. Only the hash of the opaque token is stored.
+type MFAChallenge struct {
+	ID        string
+	UserID    string
+	TenantID  string
+	TokenHash string
+	Attempts  int
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// MFAEnrollment is the secret handed to the user's authenticator app
+type MFAEnrollment struct {
+	Secret string
+	URI    string // otpauth:// URI, usually rendered as a QR code
+}
+
+// MFAStatus describes the second factor of a user
+type MFAStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int64
+}
+
+// DisableMFAInput is the input for turning MFA off
+type DisableMFAInput struct {
+	Password string
+	Code     string // TOTP or recovery code
+}
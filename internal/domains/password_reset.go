@@ -0,0 +1,63 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=password_reset.go -destination=../../test/unit/mocks/mock_password_reset.go -package=mocks
+
+// PasswordResetTokenRepository defines the contract for password reset token data access
+type PasswordResetTokenRepository interface {
+	// Create stores a new reset token
+	Create(ctx context.Context, token *PasswordResetToken) error
+
+	// GetByHash retrieves a reset token by the hash of its opaque value
+	GetByHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+
+	// GetLatestForUser retrieves the most recently issued token of a user
+	GetLatestForUser(ctx context.Context, userID string) (*PasswordResetToken, error)
+
+	// MarkUsed consumes a token; it reports false if the token was already used
+	MarkUsed(ctx context.Context, id string) (bool, error)
+
+	// InvalidateForUser consumes every outstanding token of a user
+	InvalidateForUser(ctx context.Context, userID string) error
+}
+
+// PasswordUsecase defines the contract for changing and resetting passwords
+type PasswordUsecase interface {
+	// ChangePassword replaces the password of a user who knows the current one
+	ChangePassword(ctx context.Context, userID string, input *ChangePasswordInput) error
+
+	// ForgotPassword mails a reset token to a registered address. It does not
+	// reveal whether the address is registered.
+	ForgotPassword(ctx context.Context, email string) error
+
+	// ResetPassword consumes a reset token and sets a new password
+	ResetPassword(ctx context.Context, input *ResetPasswordInput) error
+}
+
+// PasswordResetToken represents a persisted reset token. Only the hash of the
+// opaque token mailed to the user is stored.
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TenantID  string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// ChangePasswordInput is the input for changing a password
+type ChangePasswordInput struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
+// ResetPasswordInput is the input for resetting a forgotten password
+type ResetPasswordInput struct {
+	Token       string
+	NewPassword string
+}
@@ -0,0 +1,119 @@
+package domains
+
+import (
+	"context"
+	"slices"
+)
+
+//go:generate mockgen -source=rbac.go -destination=../../test/unit/mocks/mock_role_repository.go -package=mocks
+
+// Roles seeded by the RBAC migration
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Permissions seeded by the RBAC migrations. The users:* permissions apply to
+// every account, the self:* permissions only to the caller's own account.
+const (
+	PermissionUsersRead        = "users:read"
+	PermissionUsersWrite       = "users:write"
+	PermissionUsersDelete      = "users:delete"
+	PermissionUsersImpersonate = "users:impersonate"
+	PermissionUsersPurge       = "users:purge"
+	PermissionSelfRead         = "self:read"
+	PermissionSelfWrite        = "self:write"
+)
+
+// RoleRepository defines the contract for role and permission data access
+type RoleRepository interface {
+	// GetUserRoles returns the names of the roles assigned to a user, directly
+	// or through their groups
+	GetUserRoles(ctx context.Context, userID string) ([]string, error)
+
+	// GetUserPermissions returns the permissions granted by all of a user's
+	// roles, including those of their groups
+	GetUserPermissions(ctx context.Context, userID string) ([]string, error)
+
+	// AssignRole grants a role to a user; assigning a held role is a no-op
+	AssignRole(ctx context.Context, userID, role string) error
+
+	// GetRolePermissions returns the permissions a role grants; unknown roles
+	// grant none
+	GetRolePermissions(ctx context.Context, role string) ([]string, error)
+}
+
+// PermissionResolver looks up the permissions users hold now, as opposed to
+// the snapshot carried by their token or session
+type PermissionResolver interface {
+	// Permissions returns the current permissions of a user
+	Permissions(ctx context.Context, userID string) ([]string, error)
+
+	// Invalidate makes the next lookup for each of the users see changes to
+	// their roles and groups
+	Invalidate(userIDs ...string)
+}
+
+// Principal is the authenticated caller a usecase acts on behalf of
+type Principal struct {
+	UserID      string
+	TenantID    string
+	Email       string
+	Roles       []string
+	Permissions []string
+	APIKeyID    string // set when the caller authenticated with an API key
+	ActorID     string // set when an admin impersonates the user
+	ActorEmail  string
+}
+
+// Impersonated reports whether an admin is acting as the principal
+func (p *Principal) Impersonated() bool {
+	return p.ActorID != ""
+}
+
+// HasPermission reports whether the principal holds any of the permissions
+func (p *Principal) HasPermission(permissions ...string) bool {
+	for _, permission := range permissions {
+		if slices.Contains(p.Permissions, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// principalKey is the context key for the authenticated principal
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal, if any. Contexts
+// without a principal come from trusted internal callers, not from requests.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// permissionResolverKey is the context key for the permission resolver
+type permissionResolverKey struct{}
+
+// WithPermissionResolver returns a context whose authorization checks look up
+// the current permissions of the principal through resolver
+func WithPermissionResolver(ctx context.Context, resolver PermissionResolver) context.Context {
+	return context.WithValue(ctx, permissionResolverKey{}, resolver)
+}
+
+// CurrentPermissions returns the permissions to authorize the principal with.
+// When the context carries a resolver, users are checked against the
+// permissions they hold now, so role and group changes apply to tokens and
+// sessions issued before them. API keys keep the scopes they were narrowed to.
+func CurrentPermissions(ctx context.Context, principal *Principal) ([]string, error) {
+	resolver, ok := ctx.Value(permissionResolverKey{}).(PermissionResolver)
+	if !ok || resolver == nil || principal.APIKeyID != "" {
+		return principal.Permissions, nil
+	}
+
+	return resolver.Permissions(ctx, principal.UserID)
+}
@@ -0,0 +1,40 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=refresh_token.go -destination=../../test/unit/mocks/mock_refresh_token_repository.go -package=mocks
+
+// RefreshTokenRepository defines the contract for refresh token data access
+type RefreshTokenRepository interface {
+	// Create stores a new refresh token
+	Create(ctx context.Context, token *RefreshToken) error
+
+	// GetByHash retrieves a refresh token by the hash of its opaque value
+	GetByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+
+	// MarkUsed flags a token as rotated; it reports false if the token was already used or revoked
+	MarkUsed(ctx context.Context, id string) (bool, error)
+
+	// RevokeFamily revokes every token in a rotation family
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	// RevokeAllForUser revokes every refresh token owned by a user
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+// RefreshToken represents a persisted refresh token. Only the hash of the
+// opaque token handed to the client is stored.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	TenantID  string
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,59 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=session.go -destination=../../test/unit/mocks/mock_session.go -package=mocks
+
+// SessionStore defines the contract for server-side session storage
+type SessionStore interface {
+	// Create stores a new session
+	Create(ctx context.Context, session *Session) error
+
+	// Get retrieves a session by its ID, the hash of the cookie value
+	Get(ctx context.Context, id string) (*Session, error)
+
+	// Update stores the roles, permissions, expiry and last activity of a session
+	Update(ctx context.Context, session *Session) error
+
+	// Delete removes a session
+	Delete(ctx context.Context, id string) error
+}
+
+// SessionUsecase defines the contract for cookie session business logic
+type SessionUsecase interface {
+	// Start creates a session for a user who has just logged in
+	Start(ctx context.Context, user *User) (*IssuedSession, error)
+
+	// Resume looks up the session for a cookie value and extends it while in use.
+	// renewed reports whether the expiry moved and the cookie has to be sent again.
+	Resume(ctx context.Context, token string) (session *Session, renewed bool, err error)
+
+	// End destroys a session
+	End(ctx context.Context, id string) error
+}
+
+// Session represents a persisted server-side session. The cookie holds a
+// random token; only its hash is stored, as the session ID.
+type Session struct {
+	ID                string
+	UserID            string
+	TenantID          string
+	Email             string
+	Roles             []string
+	Permissions       []string
+	CSRFToken         string
+	ExpiresAt         time.Time // idle expiry, pushed back while the session is in use
+	AbsoluteExpiresAt time.Time // the session ends here regardless of activity
+	LastSeenAt        time.Time
+	CreatedAt         time.Time
+}
+
+// IssuedSession is a newly started session together with its cookie value,
+// which is only available at this point
+type IssuedSession struct {
+	Session *Session
+	Token   string
+}
@@ -0,0 +1,84 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+//go:generate mockgen -source=tenant.go -destination=../../test/unit/mocks/mock_tenant.go -package=mocks
+
+// DefaultTenantID identifies the tenant seeded by the tenants migration.
+// Requests that do not name a tenant, and users that predate multi-tenancy,
+// belong to it.
+const DefaultTenantID = "00000000-0000-0000-0000-000000000000"
+
+// Permissions seeded by the tenants migration for the platform_admin role
+const (
+	PermissionTenantsRead  = "tenants:read"
+	PermissionTenantsWrite = "tenants:write"
+)
+
+// ErrNoTenant is returned by tenant-scoped repositories for contexts that do not carry a tenant
+var ErrNoTenant = errors.New("no tenant in context")
+
+// TenantRepository defines the contract for tenant data access
+type TenantRepository interface {
+	// Create stores a new tenant
+	Create(ctx context.Context, tenant *Tenant) error
+
+	// GetByID retrieves a tenant by its ID
+	GetByID(ctx context.Context, id string) (*Tenant, error)
+
+	// GetBySlug retrieves a tenant by its slug
+	GetBySlug(ctx context.Context, slug string) (*Tenant, error)
+
+	// List retrieves a paginated list of tenants ordered by slug
+	List(ctx context.Context, limit, offset int32) ([]*Tenant, error)
+}
+
+// TenantUsecase defines the contract for tenant business logic
+type TenantUsecase interface {
+	// CreateTenant creates a new tenant
+	CreateTenant(ctx context.Context, input *CreateTenantInput) (*Tenant, error)
+
+	// GetTenant retrieves a tenant by ID
+	GetTenant(ctx context.Context, id string) (*Tenant, error)
+
+	// ListTenants retrieves a paginated tenant list
+	ListTenants(ctx context.Context, limit, offset int32) ([]*Tenant, error)
+
+	// ResolveTenant returns the active tenant a request names by slug
+	ResolveTenant(ctx context.Context, slug string) (*Tenant, error)
+}
+
+// Tenant represents an organization whose users are isolated from those of
+// every other tenant
+type Tenant struct {
+	ID        string
+	Slug      string
+	Name      string
+	IsActive  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CreateTenantInput is the input for creating a tenant
+type CreateTenantInput struct {
+	Slug string
+	Name string
+}
+
+// tenantKey is the context key for the tenant a request is scoped to
+type tenantKey struct{}
+
+// WithTenant returns a context scoped to a tenant
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the ID of the tenant a context is scoped to, if any
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
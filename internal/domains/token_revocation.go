@@ -0,0 +1,21 @@
+package domains
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=token_revocation.go -destination=../../test/unit/mocks/mock_token_revocation_store.go -package=mocks
+
+// TokenRevocationStore defines the contract for revoking issued access tokens
+type TokenRevocationStore interface {
+	// RevokeToken denylists a single access token until it expires
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// RevokeUserTokens rejects every access token issued to a user up to now
+	RevokeUserTokens(ctx context.Context, userID string) error
+
+	// IsRevoked reports whether an access token has been revoked, either by ID
+	// or because it was issued before its owner's tokens were revoked
+	IsRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error)
+}
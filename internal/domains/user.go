@@ -2,33 +2,84 @@ package domains
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
 //go:generate mockgen -source=user.go -destination=../../test/unit/mocks/mock_user_repository.go -package=mocks
 
-// UserRepository defines the contract for user data access
+// UserRepository defines the contract for user data access. Every method is
+// scoped to the tenant of the context (see WithTenant) and fails with
+// ErrNoTenant when the context carries none, so users of other tenants can
+// neither be read nor changed.
 type UserRepository interface {
 	// GetByID retrieves a user by their ID
 	GetByID(ctx context.Context, id string) (*User, error)
 
-	// GetByEmail retrieves a user by their email address
+	// GetByEmail retrieves a user by their email address, which is
+	// normalized first
 	GetByEmail(ctx context.Context, email string) (*User, error)
 
-	// Create creates a new user in the database
+	// Create creates a new user in the tenant of the context. The email is
+	// normalized, and taken addresses fail with a DUPLICATE_ENTRY error.
 	Create(ctx context.Context, user *User) error
 
-	// Update updates an existing user
+	// Update updates an existing user, with the same email handling as Create.
+	// It only applies while the stored user is still at user.Version and
+	// fails with a PRECONDITION_FAILED error otherwise; on success
+	// user.Version is advanced to the stored version.
 	Update(ctx context.Context, user *User) error
 
-	// Delete soft deletes a user
-	Delete(ctx context.Context, id string) error
+	// UpdatePasswordHash replaces the stored hash of the user's password,
+	// such as when rehashing it with a stronger algorithm. Unlike Update it
+	// leaves the version alone, as the user is unchanged for clients.
+	UpdatePasswordHash(ctx context.Context, id, passwordHash string) error
 
-	// List retrieves a paginated list of users
-	List(ctx context.Context, limit, offset int32) ([]*User, error)
+	// MarkEmailVerified records when the user proved control of their email
+	// address, unless it is already verified. Like UpdatePasswordHash it
+	// leaves the version alone.
+	MarkEmailVerified(ctx context.Context, id string, verifiedAt time.Time) error
 
-	// Count returns the total number of active users
-	Count(ctx context.Context) (int64, error)
+	// Delete soft deletes a user that is still at the given version, and
+	// fails with a PRECONDITION_FAILED error otherwise
+	Delete(ctx context.Context, id string, version int32) error
+
+	// List retrieves a paginated list of users matching the filter; a nil
+	// filter lists every user, newest first
+	List(ctx context.Context, filter *UserListFilter, limit, offset int32) ([]*User, error)
+
+	// ListByCursor retrieves up to limit users matching the filter that follow
+	// the cursor in creation order, or precede it for a backward cursor. The
+	// users are returned in list order; a nil cursor starts at the first user.
+	// The filter may only sort by creation time.
+	ListByCursor(ctx context.Context, filter *UserListFilter, cursor *UserCursor, limit int32) ([]*User, error)
+
+	// Count returns the number of users matching the filter; a nil filter
+	// counts every user. Sorting does not affect the count.
+	Count(ctx context.Context, filter *UserListFilter) (int64, error)
+
+	// GetDeletedByID retrieves a soft-deleted user by their ID
+	GetDeletedByID(ctx context.Context, id string) (*User, error)
+
+	// ListDeleted retrieves a paginated list of soft-deleted users, most
+	// recently deleted first
+	ListDeleted(ctx context.Context, limit, offset int32) ([]*User, error)
+
+	// CountDeleted returns the number of soft-deleted users
+	CountDeleted(ctx context.Context) (int64, error)
+
+	// Restore undoes the soft deletion of a user; it reports false when the
+	// user is not deleted, and fails with a DUPLICATE_ENTRY error when a live
+	// user has the email address
+	Restore(ctx context.Context, id string) (bool, error)
+
+	// HardDelete permanently removes a soft-deleted user and everything that
+	// belongs to them; it reports false when the user is not deleted
+	HardDelete(ctx context.Context, id string) (bool, error)
+
+	// PurgeDeleted permanently removes the users soft-deleted before the
+	// cutoff and returns how many were removed
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 // UserUsecase defines the contract for user business logic
@@ -45,24 +96,108 @@ type UserUsecase interface {
 	// UpdateUser updates user information
 	UpdateUser(ctx context.Context, id string, input *UpdateUserInput) (*User, error)
 
-	// DeleteUser deletes a user account
-	DeleteUser(ctx context.Context, id string) error
+	// PatchUser applies a JSON Merge Patch or JSON Patch to the editable
+	// fields of a user
+	PatchUser(ctx context.Context, id string, input *PatchUserInput) (*User, error)
+
+	// DeleteUser deletes a user account. A non-nil version makes the deletion
+	// fail with a PRECONDITION_FAILED error unless the user is still at it.
+	DeleteUser(ctx context.Context, id string, version *int32) error
+
+	// ListUsers retrieves a filtered, sorted and paginated user list
+	ListUsers(ctx context.Context, filter *UserListFilter, limit, offset int32) ([]*User, error)
+
+	// ListUsersByCursor retrieves a filtered page of users by keyset
+	// pagination; an empty cursor starts at the first page
+	ListUsersByCursor(ctx context.Context, filter *UserListFilter, cursor string, limit int32) (*UserPage, error)
+
+	// CountUsers returns the number of users matching the filter, across all
+	// pages of ListUsers and ListUsersByCursor
+	CountUsers(ctx context.Context, filter *UserListFilter) (int64, error)
+
+	// ListDeletedUsers retrieves a paginated list of soft-deleted users
+	ListDeletedUsers(ctx context.Context, limit, offset int32) ([]*User, error)
+
+	// CountDeletedUsers returns the number of soft-deleted users
+	CountDeletedUsers(ctx context.Context) (int64, error)
+
+	// RestoreUser undoes the deletion of a user account
+	RestoreUser(ctx context.Context, id string) (*User, error)
 
-	// ListUsers retrieves paginated user list
-	ListUsers(ctx context.Context, limit, offset int32) ([]*User, error)
+	// HardDeleteUser permanently removes a deleted user account
+	HardDeleteUser(ctx context.Context, id string) error
+}
+
+// UserRetentionUsecase defines the contract for removing deleted users for good
+type UserRetentionUsecase interface {
+	// PurgeDeletedUsers permanently removes the users of every tenant that
+	// were deleted longer ago than the retention period, and returns how
+	// many were removed
+	PurgeDeletedUsers(ctx context.Context) (int64, error)
 }
 
 // User represents a user entity in the domain
 type User struct {
-	ID           string
-	Email        string
-	PasswordHash string
-	FirstName    *string
-	LastName     *string
-	IsActive     bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	DeletedAt    *time.Time
+	ID               string
+	TenantID         string
+	Email            string
+	PasswordHash     string
+	FirstName        *string
+	LastName         *string
+	IsActive         bool
+	EmailVerifiedAt  *time.Time
+	IsServiceAccount bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        *time.Time
+	Version          int32 // incremented by every write
+}
+
+// NormalizeEmail returns the form emails are stored and looked up in:
+// trimmed and lowercased, so that addresses differing only in case belong
+// to the same user
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Fields a user list can be sorted by
+const (
+	UserSortCreatedAt = "created_at"
+	UserSortUpdatedAt = "updated_at"
+	UserSortEmail     = "email"
+	UserSortFirstName = "first_name"
+	UserSortLastName  = "last_name"
+)
+
+// UserSortFields lists the fields accepted by UserListFilter.SortBy
+var UserSortFields = []string{UserSortCreatedAt, UserSortUpdatedAt, UserSortEmail, UserSortFirstName, UserSortLastName}
+
+// UserListFilter narrows and orders a user list. Zero fields do not filter,
+// and an empty SortBy lists the newest users first.
+type UserListFilter struct {
+	IsActive      *bool
+	CreatedAfter  *time.Time // inclusive
+	CreatedBefore *time.Time // exclusive
+	EmailDomain   string     // matches the part of the email after the @
+	Search        string     // matches part of the email, first or last name
+	SortBy        string     // one of UserSortFields
+	SortDesc      bool
+}
+
+// UserCursor marks a position in a user list ordered by creation time. A
+// page continues after the position, or ends before it when Backward is set.
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        string
+	Backward  bool
+}
+
+// UserPage is one page of a keyset paginated user list. The cursors are
+// opaque and empty when there is no page in that direction.
+type UserPage struct {
+	Users      []*User
+	NextCursor string
+	PrevCursor string
 }
 
 // RegisterUserInput is the input for user registration
@@ -71,6 +206,10 @@ type RegisterUserInput struct {
 	Password  string
 	FirstName *string
 	LastName  *string
+
+	// EmailVerified is set by callers that already proved control of the
+	// address, such as invitations, and skips the verification email
+	EmailVerified bool
 }
 
 // UpdateUserInput is the input for updating a user
@@ -79,4 +218,21 @@ type UpdateUserInput struct {
 	FirstName *string
 	LastName  *string
 	IsActive  *bool
+
+	// Version, when set, makes the update fail with a PRECONDITION_FAILED
+	// error unless the user is still at this version
+	Version *int32
+}
+
+// PatchUserInput is the input for patching a user. The patch applies to the
+// JSON document {"email", "first_name", "last_name", "is_active"} of the
+// user; unlike UpdateUserInput, it can clear the names by setting them to
+// null or removing them.
+type PatchUserInput struct {
+	ContentType string // application/merge-patch+json or application/json-patch+json
+	Patch       []byte
+
+	// Version, when set, makes the patch fail with a PRECONDITION_FAILED
+	// error unless the user is still at this version
+	Version *int32
 }
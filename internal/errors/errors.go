@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // ErrorCode represents an error code for API responses
@@ -18,6 +19,11 @@ const (
 	ErrCodeValidation      ErrorCode = "VALIDATION_ERROR"
 	ErrCodeDatabaseError   ErrorCode = "DATABASE_ERROR"
 	ErrCodeDuplicateEntry  ErrorCode = "DUPLICATE_ENTRY"
+	ErrCodeTooManyAttempts ErrorCode = "TOO_MANY_ATTEMPTS"
+
+	ErrCodePreconditionFailed   ErrorCode = "PRECONDITION_FAILED"
+	ErrCodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
+	ErrCodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
 )
 
 // APIError represents an error that can be returned via API
@@ -26,6 +32,9 @@ type APIError struct {
 	Message    string    `json:"message"`
 	StatusCode int       `json:"status_code"`
 	Err        error     `json:"-"`
+
+	// RetryAfter, when set, tells the client how long to wait before retrying
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error implements error interface
@@ -96,6 +105,28 @@ func NewInternalError(message string, err error) *APIError {
 	return NewAPIError(ErrCodeInternalError, message, http.StatusInternalServerError, err)
 }
 
+// NewTooManyAttemptsError creates a TOO_MANY_ATTEMPTS error
+func NewTooManyAttemptsError(message string, retryAfter time.Duration) *APIError {
+	apiErr := NewAPIError(ErrCodeTooManyAttempts, message, http.StatusTooManyRequests, nil)
+	apiErr.RetryAfter = retryAfter
+	return apiErr
+}
+
+// NewPreconditionFailedError creates a PRECONDITION_FAILED error
+func NewPreconditionFailedError(message string) *APIError {
+	return NewAPIError(ErrCodePreconditionFailed, message, http.StatusPreconditionFailed, nil)
+}
+
+// NewPreconditionRequiredError creates a PRECONDITION_REQUIRED error
+func NewPreconditionRequiredError(message string) *APIError {
+	return NewAPIError(ErrCodePreconditionRequired, message, http.StatusPreconditionRequired, nil)
+}
+
+// NewUnsupportedMediaTypeError creates an UNSUPPORTED_MEDIA_TYPE error
+func NewUnsupportedMediaTypeError(message string) *APIError {
+	return NewAPIError(ErrCodeUnsupportedMediaType, message, http.StatusUnsupportedMediaType, nil)
+}
+
 // IsAPIError checks if an error is an APIError
 func IsAPIError(err error) bool {
 	_, ok := err.(*APIError)
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// APIKeyHandler handles API key and service account HTTP requests
+type APIKeyHandler struct {
+	usecase domains.APIKeyUsecase
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(usecase domains.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateServiceAccountRequest is the request body for service account creation
+type CreateServiceAccountRequest struct {
+	Email string `json:"email" binding:"required,email" example:"nightly-export@example.com"`
+	Name  string `json:"name,omitempty" example:"Nightly export"`
+}
+
+// CreateAPIKeyRequest is the request body for API key creation
+type CreateAPIKeyRequest struct {
+	UserID    string     `json:"user_id,omitempty" example:"3f0c6b1e-9f1a-4c53-8d1e-2b7a0f6c9e11"`
+	Name      string     `json:"name" binding:"required" example:"Nightly export"`
+	Scopes    []string   `json:"scopes" binding:"required" example:"users:read"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2027-01-01T00:00:00Z"`
+}
+
+// UpdateAPIKeyRequest is the request body for API key update
+type UpdateAPIKeyRequest struct {
+	Name      *string    `json:"name,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyResponse is the response body for API key data; it never holds the secret
+type APIKeyResponse struct {
+	ID         string   `json:"id"`
+	UserID     string   `json:"user_id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix" example:"ak_1f2e3d4c5b6a7988"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  *string  `json:"expires_at,omitempty"`
+	LastUsedAt *string  `json:"last_used_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse is the response body for a newly created API key
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"ak_1f2e3d4c5b6a7988.hV1m2b3X9kq0s7Zp4Yw6rT8uE5oN1aLcJdGfIiKj3Qe"`
+}
+
+// CreateServiceAccount creates a service account
+// @Summary Create a service account
+// @Description Create a non-interactive account that cannot log in and acts only through its API keys
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param request body CreateServiceAccountRequest true "Service account request"
+// @Success 201 {object} response.Response[UserResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /service-accounts [post]
+func (h *APIKeyHandler) CreateServiceAccount(c *fiber.Ctx) error {
+	var req CreateServiceAccountRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	user, err := h.usecase.CreateServiceAccount(c.UserContext(), &domains.CreateServiceAccountInput{
+		Email: req.Email,
+		Name:  req.Name,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendCreated(c, userToResponse(user))
+}
+
+// CreateAPIKey issues a new API key
+// @Summary Create an API key
+// @Description Issue an API key for the caller, or for another user or service account given by user_id. Scopes are limited to permissions the caller holds. The key is only returned in this response.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param request body CreateAPIKeyRequest true "API key request"
+// @Success 201 {object} response.Response[CreatedAPIKeyResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req CreateAPIKeyRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.SendError(c, errors.NewUnauthorizedError("authentication required"))
+	}
+
+	if req.UserID != "" {
+		userID = req.UserID
+	}
+
+	issued, err := h.usecase.CreateAPIKey(c.UserContext(), &domains.CreateAPIKeyInput{
+		UserID:    userID,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendCreated(c, CreatedAPIKeyResponse{
+		APIKeyResponse: apiKeyToResponse(issued.APIKey),
+		Key:            issued.Key,
+	})
+}
+
+// ListAPIKeys lists the API keys of a user
+// @Summary List API keys
+// @Description List the API keys of the caller, or of the user given by user_id
+// @Tags API Keys
+// @Produce json
+// @Param user_id query string false "Owner of the keys (default: the caller)"
+// @Success 200 {object} response.Response[[]APIKeyResponse]
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.SendError(c, errors.NewUnauthorizedError("authentication required"))
+	}
+
+	userID = c.Query("user_id", userID)
+
+	keys, err := h.usecase.ListAPIKeys(c.UserContext(), userID)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	responses := make([]APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = apiKeyToResponse(key)
+	}
+
+	return response.SendOK(c, responses)
+}
+
+// GetAPIKey retrieves an API key by ID
+// @Summary Get API key by ID
+// @Description Retrieve an API key's metadata by its ID
+// @Tags API Keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} response.Response[APIKeyResponse]
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /api-keys/{id} [get]
+func (h *APIKeyHandler) GetAPIKey(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	key, err := h.usecase.GetAPIKey(c.UserContext(), id)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, apiKeyToResponse(key))
+}
+
+// UpdateAPIKey updates an API key
+// @Summary Update an API key
+// @Description Change the name, scopes or expiry of an API key
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param id path string true "API key ID"
+// @Param request body UpdateAPIKeyRequest true "API key update request"
+// @Success 200 {object} response.Response[APIKeyResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /api-keys/{id} [put]
+func (h *APIKeyHandler) UpdateAPIKey(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req UpdateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	key, err := h.usecase.UpdateAPIKey(c.UserContext(), id, &domains.UpdateAPIKeyInput{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, apiKeyToResponse(key))
+}
+
+// DeleteAPIKey revokes an API key
+// @Summary Delete an API key
+// @Description Revoke an API key; requests using it are rejected immediately
+// @Tags API Keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 204
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) DeleteAPIKey(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.usecase.DeleteAPIKey(c.UserContext(), id); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// Helper function to convert a domain API key to response
+func apiKeyToResponse(key *domains.APIKey) APIKeyResponse {
+	resp := APIKeyResponse{
+		ID:     key.ID,
+		UserID: key.UserID,
+		Name:   key.Name,
+		Prefix: key.Prefix,
+		Scopes: key.Scopes,
+	}
+
+	if !key.CreatedAt.IsZero() {
+		resp.CreatedAt = key.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	if key.ExpiresAt != nil {
+		expiresAt := key.ExpiresAt.Format("2006-01-02T15:04:05Z")
+		resp.ExpiresAt = &expiresAt
+	}
+
+	if key.LastUsedAt != nil {
+		lastUsedAt := key.LastUsedAt.Format("2006-01-02T15:04:05Z")
+		resp.LastUsedAt = &lastUsedAt
+	}
+
+	return resp
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// AuthHandler handles authentication-related HTTP requests
+type AuthHandler struct {
+	usecase domains.AuthUsecase
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(usecase domains.AuthUsecase) *AuthHandler {
+	return &AuthHandler{
+		usecase: usecase,
+	}
+}
+
+// LoginRequest is the request body for login
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email" example:"user@example.com"`
+	Password string `json:"password" binding:"required" example:"password123"`
+}
+
+// VerifyMFARequest is the request body for the second step of a login
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+// RefreshTokenRequest is the request body for token refresh
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest is the request body for logout
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// TokenResponse is the response body for issued tokens
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"3600"`
+	ExpiresAt    string `json:"expires_at"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// MFAChallengeResponse is the response body for a login that needs a second factor
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required" example:"true"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// Login authenticates a user and issues an access and refresh token pair
+// @Summary Log in
+// @Description Verify email and password and issue a JWT access token with a refresh token. Users with MFA enabled get an MFA token instead, to be exchanged at /auth/mfa/verify.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body LoginRequest true "Login request"
+// @Success 200 {object} response.Response[TokenResponse]
+// @Success 202 {object} response.Response[MFAChallengeResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	var req LoginRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	input := &domains.LoginInput{
+		Email:     req.Email,
+		Password:  req.Password,
+		IPAddress: c.IP(),
+	}
+
+	token, err := h.usecase.Login(c.UserContext(), input)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	if token.MFAToken != "" {
+		return response.SendSuccess(c, fiber.StatusAccepted, MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    token.MFAToken,
+			ExpiresAt:   token.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return response.SendOK(c, tokenToResponse(token))
+}
+
+// VerifyMFA completes a login with the second factor
+// @Summary Complete MFA login
+// @Description Exchange the MFA token from /auth/login and a TOTP or recovery code for an access and refresh token pair. The MFA token is void after five wrong codes.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyMFARequest true "MFA verification request"
+// @Success 200 {object} response.Response[TokenResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *fiber.Ctx) error {
+	var req VerifyMFARequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	input := &domains.MFALoginInput{
+		MFAToken:  req.MFAToken,
+		Code:      req.Code,
+		IPAddress: c.IP(),
+	}
+
+	token, err := h.usecase.VerifyMFA(c.UserContext(), input)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, tokenToResponse(token))
+}
+
+// Refresh rotates a refresh token
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access and refresh token pair. Replaying a rotated refresh token revokes its whole family.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshTokenRequest true "Refresh request"
+// @Success 200 {object} response.Response[TokenResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req RefreshTokenRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	token, err := h.usecase.Refresh(c.UserContext(), req.RefreshToken)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, tokenToResponse(token))
+}
+
+// Logout revokes the current session
+// @Summary Log out
+// @Description Revoke the refresh token family the given refresh token belongs to, and the bearer access token if one is sent
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body LogoutRequest false "Logout request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	var req LogoutRequest
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return response.SendError(c, errors.NewValidationError("invalid request body", err))
+		}
+	}
+
+	input := &domains.LogoutInput{
+		RefreshToken: req.RefreshToken,
+	}
+
+	// Populated by OptionalAuthMiddleware when a valid bearer token is sent
+	if tokenID, ok := c.Locals("token_id").(string); ok {
+		input.AccessTokenID = tokenID
+	}
+
+	if expiresAt, ok := c.Locals("token_expires_at").(time.Time); ok {
+		input.AccessTokenExpiresAt = expiresAt
+	}
+
+	if err := h.usecase.Logout(c.UserContext(), input); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// Helper function to convert domain token to response
+func tokenToResponse(token *domains.AuthToken) TokenResponse {
+	return TokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		ExpiresIn:    int64(time.Until(token.ExpiresAt).Seconds()),
+		ExpiresAt:    token.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+		RefreshToken: token.RefreshToken,
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// EmailVerificationHandler handles email verification HTTP requests
+type EmailVerificationHandler struct {
+	usecase domains.EmailVerificationUsecase
+}
+
+// NewEmailVerificationHandler creates a new email verification handler
+func NewEmailVerificationHandler(usecase domains.EmailVerificationUsecase) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		usecase: usecase,
+	}
+}
+
+// VerifyEmailRequest is the request body for email verification
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ResendVerificationRequest is the request body for resending a verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
+}
+
+// VerifyEmail marks a user's email address as verified
+// @Summary Verify email address
+// @Description Consume the single-use token from a verification email and mark the address as verified
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyEmailRequest true "Verification request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Router /auth/verify-email [post]
+func (h *EmailVerificationHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req VerifyEmailRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	if err := h.usecase.VerifyEmail(c.UserContext(), req.Token); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// ResendVerification sends a new verification email
+// @Summary Resend verification email
+// @Description Send a new verification link to an unverified address. The response is the same whether or not the address is registered, and repeated requests within the resend interval are ignored.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body ResendVerificationRequest true "Resend request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/resend-verification [post]
+func (h *EmailVerificationHandler) ResendVerification(c *fiber.Ctx) error {
+	var req ResendVerificationRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	if err := h.usecase.ResendVerification(c.UserContext(), req.Email); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
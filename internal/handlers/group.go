@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// GroupHandler handles group HTTP requests
+type GroupHandler struct {
+	usecase domains.GroupUsecase
+}
+
+// NewGroupHandler creates a new group handler
+func NewGroupHandler(usecase domains.GroupUsecase) *GroupHandler {
+	return &GroupHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateGroupRequest is the request body for group creation
+type CreateGroupRequest struct {
+	Name        string  `json:"name" binding:"required" example:"support"`
+	Description *string `json:"description,omitempty" example:"First line support staff"`
+}
+
+// UpdateGroupRequest is the request body for group update
+type UpdateGroupRequest struct {
+	Name        *string `json:"name,omitempty" example:"support"`
+	Description *string `json:"description,omitempty" example:"First line support staff"`
+}
+
+// GroupResponse is the response body for group data
+type GroupResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Roles       []string `json:"roles"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// CreateGroup creates a new group
+// @Summary Create a group
+// @Description Create a group of users in the tenant. Roles and members are added separately. Requires the groups:write permission.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param request body CreateGroupRequest true "Group creation request"
+// @Success 201 {object} response.Response[GroupResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups [post]
+func (h *GroupHandler) CreateGroup(c *fiber.Ctx) error {
+	var req CreateGroupRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	group, err := h.usecase.CreateGroup(c.UserContext(), &domains.CreateGroupInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendCreated(c, groupToResponse(group))
+}
+
+// ListGroups lists groups with pagination
+// @Summary List groups
+// @Description Retrieve the groups of the tenant ordered by name. Requires the groups:read permission.
+// @Tags Groups
+// @Produce json
+// @Param limit query int false "Number of groups to return (default 10, max 100)" default(10)
+// @Param offset query int false "Number of groups to skip" default(0)
+// @Success 200 {object} response.Response[[]GroupResponse]
+// @Failure 403 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups [get]
+func (h *GroupHandler) ListGroups(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 10)
+	offset := c.QueryInt("offset", 0)
+
+	groups, err := h.usecase.ListGroups(c.UserContext(), int32(limit), int32(offset))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	responses := make([]GroupResponse, len(groups))
+	for i, group := range groups {
+		responses[i] = groupToResponse(group)
+	}
+
+	return response.SendOK(c, responses)
+}
+
+// GetGroup retrieves a group by ID
+// @Summary Get group by ID
+// @Description Retrieve a group and the roles it grants. Requires the groups:read permission.
+// @Tags Groups
+// @Produce json
+// @Param id path string true "Group ID"
+// @Success 200 {object} response.Response[GroupResponse]
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups/{id} [get]
+func (h *GroupHandler) GetGroup(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	group, err := h.usecase.GetGroup(c.UserContext(), id)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, groupToResponse(group))
+}
+
+// UpdateGroup updates a group
+// @Summary Update a group
+// @Description Rename a group or change its description. Requires the groups:write permission.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param request body UpdateGroupRequest true "Group update request"
+// @Success 200 {object} response.Response[GroupResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups/{id} [put]
+func (h *GroupHandler) UpdateGroup(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req UpdateGroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	group, err := h.usecase.UpdateGroup(c.UserContext(), id, &domains.UpdateGroupInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, groupToResponse(group))
+}
+
+// DeleteGroup deletes a group
+// @Summary Delete a group
+// @Description Delete a group; its members lose the roles it granted. Requires the groups:write permission.
+// @Tags Groups
+// @Param id path string true "Group ID"
+// @Success 204
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups/{id} [delete]
+func (h *GroupHandler) DeleteGroup(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.usecase.DeleteGroup(c.UserContext(), id); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// ListMembers lists the members of a group with pagination
+// @Summary List group members
+// @Description Retrieve the members of a group ordered by email. Requires the groups:read permission.
+// @Tags Groups
+// @Produce json
+// @Param id path string true "Group ID"
+// @Param limit query int false "Number of members to return (default 10, max 100)" default(10)
+// @Param offset query int false "Number of members to skip" default(0)
+// @Success 200 {object} response.Response[[]UserResponse]
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups/{id}/members [get]
+func (h *GroupHandler) ListMembers(c *fiber.Ctx) error {
+	id := c.Params("id")
+	limit := c.QueryInt("limit", 10)
+	offset := c.QueryInt("offset", 0)
+
+	members, err := h.usecase.ListMembers(c.UserContext(), id, int32(limit), int32(offset))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	responses := make([]UserResponse, len(members))
+	for i, member := range members {
+		responses[i] = userToResponse(member)
+	}
+
+	return response.SendOK(c, responses)
+}
+
+// AddMember adds a user to a group
+// @Summary Add a group member
+// @Description Add a user to a group, granting them its roles. You can only add members to groups whose roles grant nothing you do not hold. Requires the groups:write permission.
+// @Tags Groups
+// @Param id path string true "Group ID"
+// @Param userId path string true "User ID"
+// @Success 204
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups/{id}/members/{userId} [put]
+func (h *GroupHandler) AddMember(c *fiber.Ctx) error {
+	if err := h.usecase.AddMember(c.UserContext(), c.Params("id"), c.Params("userId")); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// RemoveMember removes a user from a group
+// @Summary Remove a group member
+// @Description Remove a user from a group; they lose the roles it granted. Requires the groups:write permission.
+// @Tags Groups
+// @Param id path string true "Group ID"
+// @Param userId path string true "User ID"
+// @Success 204
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups/{id}/members/{userId} [delete]
+func (h *GroupHandler) RemoveMember(c *fiber.Ctx) error {
+	if err := h.usecase.RemoveMember(c.UserContext(), c.Params("id"), c.Params("userId")); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// AddRole grants a role to the members of a group
+// @Summary Add a group role
+// @Description Grant a role to every member of a group. You can only grant roles whose permissions you hold. Requires the groups:write permission.
+// @Tags Groups
+// @Param id path string true "Group ID"
+// @Param role path string true "Role name"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups/{id}/roles/{role} [put]
+func (h *GroupHandler) AddRole(c *fiber.Ctx) error {
+	if err := h.usecase.AddRole(c.UserContext(), c.Params("id"), c.Params("role")); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// RemoveRole withdraws a role from a group
+// @Summary Remove a group role
+// @Description Withdraw a role from a group; members keep it only if they hold it otherwise. Requires the groups:write permission.
+// @Tags Groups
+// @Param id path string true "Group ID"
+// @Param role path string true "Role name"
+// @Success 204
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /groups/{id}/roles/{role} [delete]
+func (h *GroupHandler) RemoveRole(c *fiber.Ctx) error {
+	if err := h.usecase.RemoveRole(c.UserContext(), c.Params("id"), c.Params("role")); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// groupToResponse converts a domain group to its response body
+func groupToResponse(group *domains.Group) GroupResponse {
+	resp := GroupResponse{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		Roles:       group.Roles,
+	}
+
+	if resp.Roles == nil {
+		resp.Roles = []string{}
+	}
+
+	if !group.CreatedAt.IsZero() {
+		resp.CreatedAt = group.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	if !group.UpdatedAt.IsZero() {
+		resp.UpdatedAt = group.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return resp
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// ImpersonationHandler handles admin impersonation HTTP requests
+type ImpersonationHandler struct {
+	usecase domains.ImpersonationUsecase
+}
+
+// NewImpersonationHandler creates a new impersonation handler
+func NewImpersonationHandler(usecase domains.ImpersonationUsecase) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		usecase: usecase,
+	}
+}
+
+// ImpersonateRequest is the request body for impersonating a user
+type ImpersonateRequest struct {
+	Reason string `json:"reason" binding:"required" example:"Support ticket #1234"`
+}
+
+// Impersonate issues a token to act as a user
+// @Summary Impersonate a user
+// @Description Issue a short-lived access token for the user that also names the calling admin in its act claim. No refresh token is issued. Requests made with the token are logged with the admin's identity, and password changes, account deletion, MFA changes and API key creation are refused.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body ImpersonateRequest true "Impersonation request"
+// @Success 200 {object} response.Response[TokenResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /users/{id}/impersonate [post]
+func (h *ImpersonationHandler) Impersonate(c *fiber.Ctx) error {
+	var req ImpersonateRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	token, err := h.usecase.Impersonate(c.UserContext(), &domains.ImpersonateInput{
+		UserID: c.Params("id"),
+		Reason: req.Reason,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, tokenToResponse(token))
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// InvitationHandler handles invitation HTTP requests
+type InvitationHandler struct {
+	usecase domains.InvitationUsecase
+}
+
+// NewInvitationHandler creates a new invitation handler
+func NewInvitationHandler(usecase domains.InvitationUsecase) *InvitationHandler {
+	return &InvitationHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateInvitationRequest is the request body for inviting someone
+type CreateInvitationRequest struct {
+	Email string `json:"email" binding:"required,email" example:"new.colleague@example.com"`
+	Role  string `json:"role" binding:"required" example:"user"`
+}
+
+// AcceptInvitationRequest is the request body for accepting an invitation.
+// The password and names are only used when the invitee has no account yet.
+type AcceptInvitationRequest struct {
+	Token     string  `json:"token" binding:"required"`
+	Password  string  `json:"password,omitempty" example:"password123"`
+	FirstName *string `json:"first_name,omitempty" example:"John"`
+	LastName  *string `json:"last_name,omitempty" example:"Doe"`
+}
+
+// DeclineInvitationRequest is the request body for declining an invitation
+type DeclineInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// InvitationResponse is the response body for invitation data
+type InvitationResponse struct {
+	ID        string  `json:"id"`
+	Email     string  `json:"email"`
+	Role      string  `json:"role"`
+	Status    string  `json:"status"`
+	InvitedBy *string `json:"invited_by,omitempty"`
+	Expired   bool    `json:"expired"`
+	ExpiresAt string  `json:"expires_at"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// CreateInvitation invites someone into the tenant
+// @Summary Invite someone
+// @Description Mail an invitation to join the tenant with a role. You can only invite with roles whose permissions you hold. Requires the users:write permission.
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param request body CreateInvitationRequest true "Invitation request"
+// @Success 201 {object} response.Response[InvitationResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /invitations [post]
+func (h *InvitationHandler) CreateInvitation(c *fiber.Ctx) error {
+	var req CreateInvitationRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	invitation, err := h.usecase.CreateInvitation(c.UserContext(), &domains.CreateInvitationInput{
+		Email: req.Email,
+		Role:  req.Role,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendCreated(c, invitationToResponse(invitation))
+}
+
+// ListInvitations lists pending invitations with pagination
+// @Summary List pending invitations
+// @Description Retrieve the pending invitations of the tenant, newest first, including expired ones that can still be resent. Requires the users:read permission.
+// @Tags Invitations
+// @Produce json
+// @Param limit query int false "Number of invitations to return (default 10, max 100)" default(10)
+// @Param offset query int false "Number of invitations to skip" default(0)
+// @Success 200 {object} response.Response[[]InvitationResponse]
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /invitations [get]
+func (h *InvitationHandler) ListInvitations(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 10)
+	offset := c.QueryInt("offset", 0)
+
+	invitations, err := h.usecase.ListPendingInvitations(c.UserContext(), int32(limit), int32(offset))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	responses := make([]InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = invitationToResponse(invitation)
+	}
+
+	return response.SendOK(c, responses)
+}
+
+// ResendInvitation mails a pending invitation again
+// @Summary Resend an invitation
+// @Description Mail a pending invitation again with a new link and a fresh expiry; the previous link stops working. Requires the users:write permission.
+// @Tags Invitations
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} response.Response[InvitationResponse]
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /invitations/{id}/resend [post]
+func (h *InvitationHandler) ResendInvitation(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	invitation, err := h.usecase.ResendInvitation(c.UserContext(), id)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, invitationToResponse(invitation))
+}
+
+// RevokeInvitation withdraws a pending invitation
+// @Summary Revoke an invitation
+// @Description Withdraw a pending invitation; its link stops working. Requires the users:write permission.
+// @Tags Invitations
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 204
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /invitations/{id} [delete]
+func (h *InvitationHandler) RevokeInvitation(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.usecase.RevokeInvitation(c.UserContext(), id); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// AcceptInvitation accepts an invitation with the token from the invitation email
+// @Summary Accept an invitation
+// @Description Grant the invited role to the account with the invited email address. Invitees without an account must choose a password and are registered with a verified address. The request must name the tenant from the link.
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param request body AcceptInvitationRequest true "Accept invitation request"
+// @Success 200 {object} response.Response[UserResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *fiber.Ctx) error {
+	var req AcceptInvitationRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	user, err := h.usecase.AcceptInvitation(c.UserContext(), &domains.AcceptInvitationInput{
+		Token:     req.Token,
+		Password:  req.Password,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, userToResponse(user))
+}
+
+// DeclineInvitation declines an invitation with the token from the invitation email
+// @Summary Decline an invitation
+// @Description Turn a pending invitation down; its link stops working. The request must name the tenant from the link.
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param request body DeclineInvitationRequest true "Decline invitation request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /invitations/decline [post]
+func (h *InvitationHandler) DeclineInvitation(c *fiber.Ctx) error {
+	var req DeclineInvitationRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	if err := h.usecase.DeclineInvitation(c.UserContext(), req.Token); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// invitationToResponse converts a domain invitation to its response body
+func invitationToResponse(invitation *domains.Invitation) InvitationResponse {
+	resp := InvitationResponse{
+		ID:        invitation.ID,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		Status:    invitation.Status,
+		InvitedBy: invitation.InvitedBy,
+		Expired:   invitation.Status == domains.InvitationPending && invitation.Expired(),
+		ExpiresAt: invitation.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if !invitation.CreatedAt.IsZero() {
+		resp.CreatedAt = invitation.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return resp
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// JWKSHandler publishes the public keys used to verify access tokens
+type JWKSHandler struct {
+	keys *jwtkeys.KeySet
+}
+
+// NewJWKSHandler creates a new JWKS handler
+func NewJWKSHandler(keys *jwtkeys.KeySet) *JWKSHandler {
+	return &JWKSHandler{
+		keys: keys,
+	}
+}
+
+// GetJWKS returns the public keys for verifying access tokens, including keys
+// scheduled to activate and keys still in their rotation grace period. The
+// set is empty when tokens are signed with a shared secret. It is served
+// outside /api, like /health, so it is not part of the Swagger document.
+func (h *JWKSHandler) GetJWKS(c *fiber.Ctx) error {
+	set, err := h.keys.JWKS()
+	if err != nil {
+		return response.SendUnknownError(c, err)
+	}
+
+	// Verifiers may cache the set; rotation publishes new keys ahead of activation
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+	// Served as a bare RFC 7517 document, not wrapped in the response envelope
+	return c.JSON(set)
+}
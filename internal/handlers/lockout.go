@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// LockoutHandler handles login lockout administration HTTP requests
+type LockoutHandler struct {
+	usecase domains.LockoutUsecase
+}
+
+// NewLockoutHandler creates a new lockout handler
+func NewLockoutHandler(usecase domains.LockoutUsecase) *LockoutHandler {
+	return &LockoutHandler{
+		usecase: usecase,
+	}
+}
+
+// Unlock lifts the login lockout of a user
+// @Summary Unlock a user
+// @Description Clear the failed login counters of a user, lifting any lockout or delay from every IP address
+// @Tags Users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /users/{id}/unlock [post]
+func (h *LockoutHandler) Unlock(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.usecase.Unlock(c.UserContext(), id); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
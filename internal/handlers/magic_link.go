@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// magicLinkCookiePath scopes the binding cookie to the magic link endpoints
+const magicLinkCookiePath = "/api/auth/magic-link"
+
+// MagicLinkCookie describes the HttpOnly cookie that binds a magic link to
+// the browser that requested it
+type MagicLinkCookie struct {
+	Name   string
+	Secure bool
+	TTL    time.Duration // should match the token lifetime
+}
+
+// MagicLinkHandler handles passwordless login HTTP requests
+type MagicLinkHandler struct {
+	usecase domains.MagicLinkUsecase
+	cookie  MagicLinkCookie
+}
+
+// NewMagicLinkHandler creates a new magic link handler
+func NewMagicLinkHandler(usecase domains.MagicLinkUsecase, cookie MagicLinkCookie) *MagicLinkHandler {
+	return &MagicLinkHandler{
+		usecase: usecase,
+		cookie:  cookie,
+	}
+}
+
+// MagicLinkRequest is the request body for requesting a login link
+type MagicLinkRequest struct {
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
+}
+
+// MagicLinkLoginRequest is the request body for logging in with a login link
+type MagicLinkLoginRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// RequestLink sends a login link by email
+// @Summary Request magic link
+// @Description Send a single-use login link and bind it to the calling client with an HttpOnly cookie. The response is the same whether or not the address is registered, and repeated requests within the request interval are ignored.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body MagicLinkRequest true "Magic link request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/magic-link [post]
+func (h *MagicLinkHandler) RequestLink(c *fiber.Ctx) error {
+	var req MagicLinkRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	binding, err := h.usecase.RequestLink(c.UserContext(), req.Email)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	c.Cookie(h.bindingCookie(binding, time.Now().Add(h.cookie.TTL)))
+
+	return response.SendNoContent(c)
+}
+
+// Login exchanges a login link for tokens
+// @Summary Log in with magic link
+// @Description Consume the single-use token from a magic link email and issue a JWT access token with a refresh token. The request must come from the client that requested the link. Users with MFA enabled get an MFA token instead, to be exchanged at /auth/mfa/verify.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body MagicLinkLoginRequest true "Magic link login request"
+// @Success 200 {object} response.Response[TokenResponse]
+// @Success 202 {object} response.Response[MFAChallengeResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/magic-link/verify [post]
+func (h *MagicLinkHandler) Login(c *fiber.Ctx) error {
+	var req MagicLinkLoginRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	token, err := h.usecase.Login(c.UserContext(), &domains.MagicLinkLoginInput{
+		Token:   req.Token,
+		Binding: c.Cookies(h.cookie.Name),
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	// The binding is spent along with the token
+	c.Cookie(h.bindingCookie("", time.Unix(0, 0)))
+
+	if token.MFAToken != "" {
+		return response.SendSuccess(c, fiber.StatusAccepted, MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    token.MFAToken,
+			ExpiresAt:   token.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return response.SendOK(c, tokenToResponse(token))
+}
+
+// bindingCookie builds the binding cookie with the configured flags
+func (h *MagicLinkHandler) bindingCookie(value string, expiresAt time.Time) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     h.cookie.Name,
+		Value:    value,
+		Path:     magicLinkCookiePath,
+		Expires:  expiresAt,
+		Secure:   h.cookie.Secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	}
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// MFAHandler handles MFA enrollment HTTP requests
+type MFAHandler struct {
+	usecase domains.MFAUsecase
+}
+
+// NewMFAHandler creates a new MFA handler
+func NewMFAHandler(usecase domains.MFAUsecase) *MFAHandler {
+	return &MFAHandler{
+		usecase: usecase,
+	}
+}
+
+// MFACodeRequest is the request body for endpoints that take a second factor code
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// DisableMFARequest is the request body for turning MFA off
+type DisableMFARequest struct {
+	Password string `json:"password" binding:"required" example:"password123"`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+// MFAStatusResponse is the response body for the MFA status
+type MFAStatusResponse struct {
+	Enabled                bool  `json:"enabled"`
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
+
+// MFAEnrollmentResponse is the response body for a started enrollment
+type MFAEnrollmentResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	OTPAuthURI string `json:"otpauth_uri" example:"otpauth://totp/Example:user@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"`
+}
+
+// RecoveryCodesResponse is the response body for newly issued recovery codes
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// GetStatus returns the MFA status of the authenticated user
+// @Summary Get MFA status
+// @Description Report whether MFA is enabled for the caller and how many recovery codes are left
+// @Tags MFA
+// @Produce json
+// @Success 200 {object} response.Response[MFAStatusResponse]
+// @Failure 401 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /auth/mfa [get]
+func (h *MFAHandler) GetStatus(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.SendError(c, errors.NewUnauthorizedError("authentication required"))
+	}
+
+	status, err := h.usecase.GetStatus(c.UserContext(), userID)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, MFAStatusResponse{
+		Enabled:                status.Enabled,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+	})
+}
+
+// Enroll starts TOTP enrollment for the authenticated user
+// @Summary Start MFA enrollment
+// @Description Generate a new TOTP secret and its otpauth URI for an authenticator app. MFA is not active until the enrollment is confirmed with a code.
+// @Tags MFA
+// @Produce json
+// @Success 200 {object} response.Response[MFAEnrollmentResponse]
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /auth/mfa/enroll [post]
+func (h *MFAHandler) Enroll(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.SendError(c, errors.NewUnauthorizedError("authentication required"))
+	}
+
+	enrollment, err := h.usecase.Enroll(c.UserContext(), userID)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, MFAEnrollmentResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	})
+}
+
+// ConfirmEnrollment activates MFA for the authenticated user
+// @Summary Confirm MFA enrollment
+// @Description Activate MFA with a code from the authenticator app. The response holds the recovery codes, which are not shown again.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param request body MFACodeRequest true "Confirmation request"
+// @Success 200 {object} response.Response[RecoveryCodesResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /auth/mfa/confirm [post]
+func (h *MFAHandler) ConfirmEnrollment(c *fiber.Ctx) error {
+	var req MFACodeRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.SendError(c, errors.NewUnauthorizedError("authentication required"))
+	}
+
+	codes, err := h.usecase.ConfirmEnrollment(c.UserContext(), userID, req.Code)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the authenticated user
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes after checking a TOTP or recovery code. Earlier recovery codes stop working.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param request body MFACodeRequest true "Regeneration request"
+// @Success 200 {object} response.Response[RecoveryCodesResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /auth/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	var req MFACodeRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.SendError(c, errors.NewUnauthorizedError("authentication required"))
+	}
+
+	codes, err := h.usecase.RegenerateRecoveryCodes(c.UserContext(), userID, req.Code)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable turns MFA off for the authenticated user
+// @Summary Disable MFA
+// @Description Turn MFA off after checking the password and a TOTP or recovery code
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param request body DisableMFARequest true "Disable request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /auth/mfa/disable [post]
+func (h *MFAHandler) Disable(c *fiber.Ctx) error {
+	var req DisableMFARequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.SendError(c, errors.NewUnauthorizedError("authentication required"))
+	}
+
+	input := &domains.DisableMFAInput{
+		Password: req.Password,
+		Code:     req.Code,
+	}
+
+	if err := h.usecase.Disable(c.UserContext(), userID, input); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// Reset turns MFA off for another user
+// @Summary Reset a user's MFA
+// @Description Turn MFA off for a user who lost their authenticator, so they can sign in with the password alone and enroll again
+// @Tags Users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /users/{id}/mfa [delete]
+func (h *MFAHandler) Reset(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.usecase.Reset(c.UserContext(), id); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
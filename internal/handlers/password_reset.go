@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// PasswordHandler handles password change and reset HTTP requests
+type PasswordHandler struct {
+	usecase domains.PasswordUsecase
+}
+
+// NewPasswordHandler creates a new password handler
+func NewPasswordHandler(usecase domains.PasswordUsecase) *PasswordHandler {
+	return &PasswordHandler{
+		usecase: usecase,
+	}
+}
+
+// ChangePasswordRequest is the request body for changing a password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"password123"`
+	NewPassword     string `json:"new_password" binding:"required" example:"new-password456"`
+}
+
+// ForgotPasswordRequest is the request body for requesting a password reset email
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
+}
+
+// ResetPasswordRequest is the request body for resetting a password
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required" example:"new-password456"`
+}
+
+// ChangePassword changes the password of the authenticated user
+// @Summary Change password
+// @Description Replace the caller's password after checking the current one. Every session of the user, including the current one, is revoked.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body ChangePasswordRequest true "Change password request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /auth/change-password [post]
+func (h *PasswordHandler) ChangePassword(c *fiber.Ctx) error {
+	var req ChangePasswordRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	userID, ok := c.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return response.SendError(c, errors.NewUnauthorizedError("authentication required"))
+	}
+
+	input := &domains.ChangePasswordInput{
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}
+
+	if err := h.usecase.ChangePassword(c.UserContext(), userID, input); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// ForgotPassword sends a password reset email
+// @Summary Request password reset
+// @Description Send a single-use password reset link. The response is the same whether or not the address is registered, and repeated requests within the request interval are ignored.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Forgot password request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/forgot-password [post]
+func (h *PasswordHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req ForgotPasswordRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	if err := h.usecase.ForgotPassword(c.UserContext(), req.Email); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// ResetPassword sets a new password with a reset token
+// @Summary Reset password
+// @Description Consume the single-use token from a password reset email and set a new password. Every session of the user is revoked.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Reset password request"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/reset-password [post]
+func (h *PasswordHandler) ResetPassword(c *fiber.Ctx) error {
+	var req ResetPasswordRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	input := &domains.ResetPasswordInput{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	}
+
+	if err := h.usecase.ResetPassword(c.UserContext(), input); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
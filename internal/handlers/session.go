@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// SessionHandler handles cookie session HTTP requests
+type SessionHandler struct {
+	auth     domains.AuthUsecase
+	sessions domains.SessionUsecase
+	cookie   *middleware.SessionCookie
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(auth domains.AuthUsecase, sessions domains.SessionUsecase, cookie *middleware.SessionCookie) *SessionHandler {
+	return &SessionHandler{
+		auth:     auth,
+		sessions: sessions,
+		cookie:   cookie,
+	}
+}
+
+// SessionResponse is the response body for a started session. The session
+// itself travels in an HttpOnly cookie.
+type SessionResponse struct {
+	UserID    string `json:"user_id"`
+	ExpiresAt string `json:"expires_at"`
+	CSRFToken string `json:"csrf_token" example:"Zb3k9X2mQ7pV1sL0aT6yR8wE4uN5cJdHfGiIoKjPqWe"`
+}
+
+// Login authenticates a user and starts a cookie session
+// @Summary Log in with a session
+// @Description Verify email and password and start a server-side session held in an HttpOnly cookie. Send the returned CSRF token, also available in the csrf_token cookie, in the X-CSRF-Token header of every state-changing request. Users with MFA enabled get an MFA token instead, to be exchanged at /auth/session/mfa/verify.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body LoginRequest true "Login request"
+// @Success 200 {object} response.Response[SessionResponse]
+// @Success 202 {object} response.Response[MFAChallengeResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/session [post]
+func (h *SessionHandler) Login(c *fiber.Ctx) error {
+	var req LoginRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	token, err := h.auth.Login(c.UserContext(), &domains.LoginInput{
+		Email:        req.Email,
+		Password:     req.Password,
+		IPAddress:    c.IP(),
+		StartSession: true,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	if token.MFAToken != "" {
+		return response.SendSuccess(c, fiber.StatusAccepted, MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    token.MFAToken,
+			ExpiresAt:   token.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return h.sendSession(c, token.Session)
+}
+
+// VerifyMFA completes a session login with the second factor
+// @Summary Complete MFA session login
+// @Description Exchange the MFA token from /auth/session and a TOTP or recovery code for a cookie session
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyMFARequest true "MFA verification request"
+// @Success 200 {object} response.Response[SessionResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Router /auth/session/mfa/verify [post]
+func (h *SessionHandler) VerifyMFA(c *fiber.Ctx) error {
+	var req VerifyMFARequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	token, err := h.auth.VerifyMFA(c.UserContext(), &domains.MFALoginInput{
+		MFAToken:     req.MFAToken,
+		Code:         req.Code,
+		IPAddress:    c.IP(),
+		StartSession: true,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return h.sendSession(c, token.Session)
+}
+
+// Logout ends the current session
+// @Summary Log out of a session
+// @Description Destroy the current server-side session and clear its cookies. Requires the X-CSRF-Token header.
+// @Tags Auth
+// @Produce json
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /auth/session [delete]
+func (h *SessionHandler) Logout(c *fiber.Ctx) error {
+	sessionID, ok := c.Locals("session_id").(string)
+	if !ok || sessionID == "" {
+		return response.SendError(c, errors.NewBadRequestError("request is not authenticated with a session"))
+	}
+
+	if err := h.sessions.End(c.UserContext(), sessionID); err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	h.cookie.Clear(c)
+
+	return response.SendNoContent(c)
+}
+
+// sendSession sets the session cookies and returns the CSRF token
+func (h *SessionHandler) sendSession(c *fiber.Ctx, issued *domains.IssuedSession) error {
+	h.cookie.Set(c, issued.Token, issued.Session.CSRFToken, issued.Session.ExpiresAt)
+
+	return response.SendOK(c, SessionResponse{
+		UserID:    issued.Session.UserID,
+		ExpiresAt: issued.Session.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+		CSRFToken: issued.Session.CSRFToken,
+	})
+}
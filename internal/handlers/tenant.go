@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/response"
+)
+
+// TenantHandler handles tenant HTTP requests
+type TenantHandler struct {
+	usecase domains.TenantUsecase
+}
+
+// NewTenantHandler creates a new tenant handler
+func NewTenantHandler(usecase domains.TenantUsecase) *TenantHandler {
+	return &TenantHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateTenantRequest is the request body for tenant creation
+type CreateTenantRequest struct {
+	Slug string `json:"slug" binding:"required" example:"acme"`
+	Name string `json:"name" binding:"required" example:"Acme Corporation"`
+}
+
+// TenantResponse is the response body for tenant data
+type TenantResponse struct {
+	ID        string `json:"id"`
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	IsActive  bool   `json:"is_active"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// CreateTenant creates a new tenant
+// @Summary Create a tenant
+// @Description Create an organization whose users are isolated from those of every other tenant. Requires the tenants:write permission.
+// @Tags Tenants
+// @Accept json
+// @Produce json
+// @Param request body CreateTenantRequest true "Tenant request"
+// @Success 201 {object} response.Response[TenantResponse]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /tenants [post]
+func (h *TenantHandler) CreateTenant(c *fiber.Ctx) error {
+	var req CreateTenantRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return response.SendError(c, errors.NewValidationError("invalid request body", err))
+	}
+
+	tenant, err := h.usecase.CreateTenant(c.UserContext(), &domains.CreateTenantInput{
+		Slug: req.Slug,
+		Name: req.Name,
+	})
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendCreated(c, tenantToResponse(tenant))
+}
+
+// GetTenant retrieves a tenant by ID
+// @Summary Get tenant by ID
+// @Description Retrieve a tenant by its ID. Requires the tenants:read permission.
+// @Tags Tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} response.Response[TenantResponse]
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /tenants/{id} [get]
+func (h *TenantHandler) GetTenant(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	tenant, err := h.usecase.GetTenant(c.UserContext(), id)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendOK(c, tenantToResponse(tenant))
+}
+
+// ListTenants lists tenants with pagination
+// @Summary List tenants
+// @Description Retrieve a paginated list of tenants ordered by slug. Requires the tenants:read permission.
+// @Tags Tenants
+// @Produce json
+// @Param limit query int false "Number of tenants to return (default 10, max 100)" default(10)
+// @Param offset query int false "Number of tenants to skip" default(0)
+// @Success 200 {object} response.Response[[]TenantResponse]
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /tenants [get]
+func (h *TenantHandler) ListTenants(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 10)
+	offset := c.QueryInt("offset", 0)
+
+	tenants, err := h.usecase.ListTenants(c.UserContext(), int32(limit), int32(offset))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	responses := make([]TenantResponse, len(tenants))
+	for i, tenant := range tenants {
+		responses[i] = tenantToResponse(tenant)
+	}
+
+	return response.SendOK(c, responses)
+}
+
+// tenantToResponse converts a domain tenant to its response body
+func tenantToResponse(tenant *domains.Tenant) TenantResponse {
+	resp := TenantResponse{
+		ID:       tenant.ID,
+		Slug:     tenant.Slug,
+		Name:     tenant.Name,
+		IsActive: tenant.IsActive,
+	}
+
+	if !tenant.CreatedAt.IsZero() {
+		resp.CreatedAt = tenant.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	if !tenant.UpdatedAt.IsZero() {
+		resp.UpdatedAt = tenant.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return resp
+}
@@ -1,21 +1,34 @@
 package handlers
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/zercle/template-go-fiber/internal/domains"
 	"github.com/zercle/template-go-fiber/internal/errors"
 	"github.com/zercle/template-go-fiber/pkg/response"
 )
 
+// UserHandlerConfig contains the user handler configuration
+type UserHandlerConfig struct {
+	// RequireIfMatch rejects updates and deletions of users that do not send
+	// an If-Match header, instead of applying them unconditionally
+	RequireIfMatch bool
+}
+
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
 	usecase domains.UserUsecase
+	config  UserHandlerConfig
 }
 
 // NewUserHandler creates a new user handler
-func NewUserHandler(usecase domains.UserUsecase) *UserHandler {
+func NewUserHandler(usecase domains.UserUsecase, config UserHandlerConfig) *UserHandler {
 	return &UserHandler{
 		usecase: usecase,
+		config:  config,
 	}
 }
 
@@ -37,13 +50,16 @@ type UpdateUserRequest struct {
 
 // UserResponse is the response body for user data
 type UserResponse struct {
-	ID        string  `json:"id"`
-	Email     string  `json:"email"`
-	FirstName *string `json:"first_name,omitempty"`
-	LastName  *string `json:"last_name,omitempty"`
-	IsActive  bool    `json:"is_active"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	ID               string  `json:"id"`
+	Email            string  `json:"email"`
+	FirstName        *string `json:"first_name,omitempty"`
+	LastName         *string `json:"last_name,omitempty"`
+	IsActive         bool    `json:"is_active"`
+	EmailVerified    bool    `json:"email_verified"`
+	IsServiceAccount bool    `json:"is_service_account,omitempty"`
+	CreatedAt        string  `json:"created_at"`
+	UpdatedAt        string  `json:"updated_at"`
+	DeletedAt        string  `json:"deleted_at,omitempty"`
 }
 
 // RegisterUser registers a new user
@@ -71,7 +87,7 @@ func (h *UserHandler) RegisterUser(c *fiber.Ctx) error {
 		LastName:  req.LastName,
 	}
 
-	user, err := h.usecase.RegisterUser(c.Context(), input)
+	user, err := h.usecase.RegisterUser(c.UserContext(), input)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			return response.SendError(c, apiErr)
@@ -90,12 +106,14 @@ func (h *UserHandler) RegisterUser(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 200 {object} response.Response[UserResponse]
+// @Header 200 {string} ETag "Version of the user, for If-Match"
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Router /users/{id} [get]
 func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 	id := c.Params("id")
 
-	user, err := h.usecase.GetUserByID(c.Context(), id)
+	user, err := h.usecase.GetUserByID(c.UserContext(), id)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			return response.SendError(c, apiErr)
@@ -103,6 +121,7 @@ func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 		return response.SendUnknownError(c, err)
 	}
 
+	c.Set(fiber.HeaderETag, userETag(user))
 	return response.SendOK(c, userToResponse(user))
 }
 
@@ -114,6 +133,7 @@ func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 // @Produce json
 // @Param email query string true "User email"
 // @Success 200 {object} response.Response[UserResponse]
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Router /users/email [get]
 func (h *UserHandler) GetUserByEmail(c *fiber.Ctx) error {
@@ -123,7 +143,7 @@ func (h *UserHandler) GetUserByEmail(c *fiber.Ctx) error {
 		return response.SendError(c, errors.NewValidationError("email query parameter is required", nil))
 	}
 
-	user, err := h.usecase.GetUserByEmail(c.Context(), email)
+	user, err := h.usecase.GetUserByEmail(c.UserContext(), email)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			return response.SendError(c, apiErr)
@@ -136,20 +156,30 @@ func (h *UserHandler) GetUserByEmail(c *fiber.Ctx) error {
 
 // UpdateUser updates a user
 // @Summary Update user information
-// @Description Update an existing user's information
+// @Description Update an existing user's information. With If-Match, the update only applies while the user is still at that ETag.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
+// @Param If-Match header string false "ETag of the user as last read"
 // @Param request body UpdateUserRequest true "User update request"
 // @Success 200 {object} response.Response[UserResponse]
+// @Header 200 {string} ETag "Version of the updated user"
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Security Bearer
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	id := c.Params("id")
 
+	version, apiErr := h.versionFromIfMatch(c)
+	if apiErr != nil {
+		return response.SendError(c, apiErr)
+	}
+
 	var req UpdateUserRequest
 	if err := c.BodyParser(&req); err != nil {
 		return response.SendError(c, errors.NewValidationError("invalid request body", err))
@@ -160,9 +190,60 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		IsActive:  req.IsActive,
+		Version:   version,
+	}
+
+	user, err := h.usecase.UpdateUser(c.UserContext(), id, input)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	c.Set(fiber.HeaderETag, userETag(user))
+	return response.SendOK(c, userToResponse(user))
+}
+
+// PatchUser applies a partial update to a user
+// @Summary Patch user information
+// @Description Apply a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) to the email, first_name, last_name and is_active of a user. Unlike PUT, a patch can clear the names by setting them to null. With If-Match, the patch only applies while the user is still at that ETag.
+// @Tags Users
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param If-Match header string false "ETag of the user as last read"
+// @Param request body object true "Merge patch object or array of JSON Patch operations"
+// @Success 200 {object} response.Response[UserResponse]
+// @Header 200 {string} ETag "Version of the patched user"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 415 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /users/{id} [patch]
+func (h *UserHandler) PatchUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	version, apiErr := h.versionFromIfMatch(c)
+	if apiErr != nil {
+		return response.SendError(c, apiErr)
 	}
 
-	user, err := h.usecase.UpdateUser(c.Context(), id, input)
+	// The media type decides the patch format; parameters such as a charset
+	// do not matter
+	contentType, _, _ := strings.Cut(c.Get(fiber.HeaderContentType), ";")
+
+	input := &domains.PatchUserInput{
+		ContentType: strings.ToLower(strings.TrimSpace(contentType)),
+		Patch:       c.Body(),
+		Version:     version,
+	}
+
+	user, err := h.usecase.PatchUser(c.UserContext(), id, input)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			return response.SendError(c, apiErr)
@@ -170,24 +251,35 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		return response.SendUnknownError(c, err)
 	}
 
+	c.Set(fiber.HeaderETag, userETag(user))
 	return response.SendOK(c, userToResponse(user))
 }
 
 // DeleteUser deletes a user
 // @Summary Delete a user
-// @Description Delete an existing user account
+// @Description Delete an existing user account. With If-Match, the deletion only applies while the user is still at that ETag.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
+// @Param If-Match header string false "ETag of the user as last read"
 // @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Security Bearer
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	id := c.Params("id")
 
-	err := h.usecase.DeleteUser(c.Context(), id)
+	version, apiErr := h.versionFromIfMatch(c)
+	if apiErr != nil {
+		return response.SendError(c, apiErr)
+	}
+
+	err := h.usecase.DeleteUser(c.UserContext(), id, version)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			return response.SendError(c, apiErr)
@@ -198,21 +290,152 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	return response.SendNoContent(c)
 }
 
-// ListUsers lists all users with pagination
-// @Summary List users
-// @Description Retrieve a paginated list of users
+// ListDeletedUsers lists deleted users
+// @Summary List deleted users
+// @Description Retrieve a paginated list of deleted users that have not been purged yet, most recently deleted first. Requires the users:delete permission.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param limit query int false "Number of users to return (default 10, max 100)" default(10)
 // @Param offset query int false "Number of users to skip" default(0)
-// @Success 200 {object} response.Response[[]UserResponse]
+// @Success 200 {object} response.Response[response.Page[UserResponse]]
+// @Failure 403 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /users/deleted [get]
+func (h *UserHandler) ListDeletedUsers(c *fiber.Ctx) error {
+	limit, offset := listBounds(c.QueryInt("limit", 10), c.QueryInt("offset", 0))
+
+	users, err := h.usecase.ListDeletedUsers(c.UserContext(), int32(limit), int32(offset))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	total, err := h.usecase.CountDeletedUsers(c.UserContext())
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	responses := make([]UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = userToResponse(user)
+	}
+
+	return response.SendPage(c, response.NewOffsetPage(c, responses, total, limit, offset))
+}
+
+// RestoreUser restores a deleted user
+// @Summary Restore a deleted user
+// @Description Undo the deletion of a user account. Fails with 409 when the email address has been registered again since. Requires the users:delete permission.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Response[UserResponse]
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	user, err := h.usecase.RestoreUser(c.UserContext(), id)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	c.Set(fiber.HeaderETag, userETag(user))
+	return response.SendOK(c, userToResponse(user))
+}
+
+// PurgeUser permanently removes a deleted user
+// @Summary Purge a deleted user
+// @Description Permanently remove a deleted user account and everything that belongs to it. Live users must be deleted first. Requires the users:purge permission.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /users/{id}/purge [delete]
+func (h *UserHandler) PurgeUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	err := h.usecase.HardDeleteUser(c.UserContext(), id)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	return response.SendNoContent(c)
+}
+
+// ListUsers lists users with filtering, sorting and pagination
+// @Summary List users
+// @Description Retrieve a paginated list of users, optionally filtered and sorted, with the total number of matching users. Requires the users:read permission.
+// @Description With pagination=cursor, or a cursor, pages are read by keyset and sorted by created_at only. The page links are also sent in a Link header.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of users to return (default 10, max 100)" default(10)
+// @Param offset query int false "Number of users to skip (offset pagination only)" default(0)
+// @Param pagination query string false "Pagination mode" Enums(offset, cursor) default(offset)
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page; implies cursor pagination"
+// @Param is_active query bool false "Only active or only inactive users"
+// @Param created_after query string false "Only users created at or after this RFC 3339 time or date"
+// @Param created_before query string false "Only users created before this RFC 3339 time or date"
+// @Param email_domain query string false "Only users whose email is at this domain" example(example.com)
+// @Param search query string false "Text to find in the email, first or last name"
+// @Param sort query string false "Field to sort by; prefix with - for descending order" Enums(created_at, -created_at, updated_at, -updated_at, email, -email, first_name, -first_name, last_name, -last_name) default(-created_at)
+// @Success 200 {object} response.Response[response.Page[UserResponse]]
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Router /users [get]
 func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	limit := c.QueryInt("limit", 10)
 	offset := c.QueryInt("offset", 0)
 
-	users, err := h.usecase.ListUsers(c.Context(), int32(limit), int32(offset))
+	filter, apiErr := userListFilterFromQuery(c)
+	if apiErr != nil {
+		return response.SendError(c, apiErr)
+	}
+
+	cursor := c.Query("cursor")
+	switch c.Query("pagination") {
+	case "", "offset":
+		if cursor != "" {
+			return h.listUsersByCursor(c, filter, cursor, limit)
+		}
+	case "cursor":
+		return h.listUsersByCursor(c, filter, cursor, limit)
+	default:
+		return response.SendError(c, errors.NewValidationError("pagination must be offset or cursor", nil))
+	}
+
+	limit, offset = listBounds(limit, offset)
+
+	users, err := h.usecase.ListUsers(c.UserContext(), filter, int32(limit), int32(offset))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	total, err := h.usecase.CountUsers(c.UserContext(), filter)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			return response.SendError(c, apiErr)
@@ -226,7 +449,143 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 		responses[i] = resp
 	}
 
-	return response.SendOK(c, responses)
+	return response.SendPage(c, response.NewOffsetPage(c, responses, total, limit, offset))
+}
+
+// listUsersByCursor responds with a keyset paginated page of users
+func (h *UserHandler) listUsersByCursor(c *fiber.Ctx, filter *domains.UserListFilter, cursor string, limit int) error {
+	if c.Query("offset") != "" {
+		return response.SendError(c, errors.NewValidationError("offset cannot be combined with cursor pagination", nil))
+	}
+
+	limit, _ = listBounds(limit, 0)
+
+	page, err := h.usecase.ListUsersByCursor(c.UserContext(), filter, cursor, int32(limit))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	total, err := h.usecase.CountUsers(c.UserContext(), filter)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			return response.SendError(c, apiErr)
+		}
+		return response.SendUnknownError(c, err)
+	}
+
+	users := make([]UserResponse, len(page.Users))
+	for i, user := range page.Users {
+		users[i] = userToResponse(user)
+	}
+
+	return response.SendPage(c, response.NewCursorPage(c, users, total, limit, page.NextCursor, page.PrevCursor))
+}
+
+// listBounds applies the page size and offset bounds of the list usecases,
+// so that the envelope describes the page that is actually returned
+func listBounds(limit, offset int) (int, int) {
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
+
+// userListFilterFromQuery reads the filter and sort of a user list from the
+// query string
+func userListFilterFromQuery(c *fiber.Ctx) (*domains.UserListFilter, *errors.APIError) {
+	filter := &domains.UserListFilter{
+		EmailDomain: c.Query("email_domain"),
+		Search:      c.Query("search"),
+	}
+
+	if value := c.Query("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.NewValidationError("is_active must be true or false", err)
+		}
+		filter.IsActive = &isActive
+	}
+
+	var apiErr *errors.APIError
+	if filter.CreatedAfter, apiErr = timeFromQuery(c, "created_after"); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if filter.CreatedBefore, apiErr = timeFromQuery(c, "created_before"); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if sort := c.Query("sort"); sort != "" {
+		filter.SortBy = strings.TrimPrefix(sort, "-")
+		filter.SortDesc = strings.HasPrefix(sort, "-")
+	}
+
+	return filter, nil
+}
+
+// timeFromQuery reads an optional RFC 3339 time or date from the query string
+func timeFromQuery(c *fiber.Ctx, name string) (*time.Time, *errors.APIError) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, value); err != nil {
+			return nil, errors.NewValidationError(name+" must be an RFC 3339 time or a date such as 2026-01-31", err)
+		}
+	}
+
+	return &t, nil
+}
+
+// userETag returns the strong entity tag of a user, which changes with
+// every write
+func userETag(user *domains.User) string {
+	return strconv.Quote(strconv.FormatInt(int64(user.Version), 10))
+}
+
+// versionFromIfMatch returns the user version an If-Match header expects.
+// A missing header or "*" expects none, unless the header is required. Only
+// a single strong tag is understood; weak tags never match a write.
+func (h *UserHandler) versionFromIfMatch(c *fiber.Ctx) (*int32, *errors.APIError) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		if h.config.RequireIfMatch {
+			return nil, errors.NewPreconditionRequiredError("If-Match header is required")
+		}
+		return nil, nil
+	}
+
+	if header == "*" {
+		return nil, nil
+	}
+
+	if strings.Contains(header, ",") {
+		return nil, errors.NewBadRequestError("If-Match must name a single entity tag")
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return nil, errors.NewPreconditionFailedError("user has been modified since it was read")
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 32)
+	if err != nil {
+		return nil, errors.NewPreconditionFailedError("user has been modified since it was read")
+	}
+
+	v := int32(version)
+	return &v, nil
 }
 
 // Helper function to convert domain user to response
@@ -241,13 +600,21 @@ func userToResponse(user *domains.User) UserResponse {
 		updatedAt = user.UpdatedAt.Format("2006-01-02T15:04:05Z")
 	}
 
+	deletedAt := ""
+	if user.DeletedAt != nil {
+		deletedAt = user.DeletedAt.Format("2006-01-02T15:04:05Z")
+	}
+
 	return UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		IsActive:  user.IsActive,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:               user.ID,
+		Email:            user.Email,
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		IsActive:         user.IsActive,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		IsServiceAccount: user.IsServiceAccount,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+		DeletedAt:        deletedAt,
 	}
 }
@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// CaptureSender keeps every message in memory instead of delivering it, so
+// tests can read the links a flow mails out
+type CaptureSender struct {
+	mu       sync.Mutex
+	messages []domains.MailMessage
+}
+
+// NewCaptureSender creates a new capture sender
+func NewCaptureSender() *CaptureSender {
+	return &CaptureSender{}
+}
+
+// Send records a copy of the message
+func (s *CaptureSender) Send(ctx context.Context, message *domains.MailMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, *message)
+	return nil
+}
+
+// Messages returns the captured messages, oldest first
+func (s *CaptureSender) Messages() []domains.MailMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]domains.MailMessage(nil), s.messages...)
+}
+
+// Last returns the most recently captured message, or nil if none was sent
+func (s *CaptureSender) Last() *domains.MailMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.messages) == 0 {
+		return nil
+	}
+	message := s.messages[len(s.messages)-1]
+	return &message
+}
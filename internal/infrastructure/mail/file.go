@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// FileSender writes every message to its own .eml file in a directory
+// instead of delivering it, for local development
+type FileSender struct {
+	dir  string
+	from string
+}
+
+// NewFileSender creates a new file sender; the directory is created on first use
+func NewFileSender(dir, from string) *FileSender {
+	return &FileSender{
+		dir:  dir,
+		from: from,
+	}
+}
+
+// Send writes a single plain text message to the directory
+func (s *FileSender) Send(ctx context.Context, message *domains.MailMessage) error {
+	now := time.Now()
+
+	data, err := format(s.from, message, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to name mail file: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000Z"), hex.EncodeToString(suffix))
+	if err := os.WriteFile(filepath.Join(s.dir, name), data, 0o640); err != nil {
+		return fmt.Errorf("failed to write mail file: %w", err)
+	}
+
+	return nil
+}
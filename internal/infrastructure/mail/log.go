@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// LogSender logs every message instead of delivering it, for development
+// and tests. Message bodies, and any tokens in them, end up in the log.
+type LogSender struct {
+	logger *slog.Logger
+	from   string
+}
+
+// NewLogSender creates a new log sender
+func NewLogSender(logger *slog.Logger, from string) *LogSender {
+	return &LogSender{
+		logger: logger,
+		from:   from,
+	}
+}
+
+// Send logs a single plain text message
+func (s *LogSender) Send(ctx context.Context, message *domains.MailMessage) error {
+	s.logger.InfoContext(ctx, "mail sent to log sink",
+		slog.String("from", s.from),
+		slog.String("to", message.To),
+		slog.String("subject", message.Subject),
+		slog.String("body", message.Body),
+	)
+	return nil
+}
@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// SMTPOptions configures an SMTPSender
+type SMTPOptions struct {
+	Host     string
+	Port     int
+	Username string // authentication is skipped when empty
+	Password string
+	From     string
+}
+
+// SMTPSender delivers mail through an SMTP relay. STARTTLS is used whenever
+// the server offers it.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender creates a new SMTP sender
+func NewSMTPSender(opts SMTPOptions) *SMTPSender {
+	var auth smtp.Auth
+	if opts.Username != "" {
+		auth = smtp.PlainAuth("", opts.Username, opts.Password, opts.Host)
+	}
+
+	return &SMTPSender{
+		addr: net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)),
+		auth: auth,
+		from: opts.From,
+	}
+}
+
+// Send delivers a single plain text message
+func (s *SMTPSender) Send(ctx context.Context, message *domains.MailMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := format(s.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{message.To}, data); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
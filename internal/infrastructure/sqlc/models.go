@@ -6,12 +6,285 @@ package db
 
 import (
 	"database/sql"
+	"time"
 )
 
+// API keys for machine-to-machine clients
+type ApiKey struct {
+	// UUID
+	ID string `json:"id"`
+	// User or service account the key acts as
+	UserID string `json:"user_id"`
+	// Label chosen by the owner
+	Name string `json:"name"`
+	// Public part of the key, shown in listings and used for lookup
+	Prefix string `json:"prefix"`
+	// SHA-256 hash of the secret part of the key
+	SecretHash string `json:"secret_hash"`
+	// Space separated permissions granted to the key
+	Scopes string `json:"scopes"`
+	// Expiration timestamp; NULL for keys that do not expire
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	// When the key last authenticated a request
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Single-use email verification tokens
+type EmailVerificationToken struct {
+	// UUID
+	ID string `json:"id"`
+	// User whose email address the token verifies
+	UserID string `json:"user_id"`
+	// Tenant of the user the token belongs to
+	TenantID string `json:"tenant_id"`
+	// SHA-256 hash of the opaque verification token
+	TokenHash string `json:"token_hash"`
+	// Expiration timestamp
+	ExpiresAt time.Time `json:"expires_at"`
+	// When the token was consumed or superseded
+	UsedAt sql.NullTime `json:"used_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Users belonging to each group
+type GroupMember struct {
+	// Group the user belongs to
+	GroupID string `json:"group_id"`
+	// Member
+	UserID string `json:"user_id"`
+	// Membership timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Roles granted to the members of each group
+type GroupRole struct {
+	// Group holding the role
+	GroupID string `json:"group_id"`
+	// Role granted to every member
+	RoleName string `json:"role_name"`
+	// Assignment timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Invitations to join a tenant with a role
+type Invitation struct {
+	// UUID
+	ID string `json:"id"`
+	// Tenant the invitee joins
+	TenantID string `json:"tenant_id"`
+	// Invited email address
+	Email string `json:"email"`
+	// Role granted on acceptance
+	RoleName string `json:"role_name"`
+	// SHA-256 hash of the opaque invitation token
+	TokenHash string `json:"token_hash"`
+	// User who sent the invitation
+	InvitedBy sql.NullString `json:"invited_by"`
+	// pending, accepted, declined or revoked
+	Status string `json:"status"`
+	// Expiration timestamp
+	ExpiresAt time.Time `json:"expires_at"`
+	// When the invitation was accepted, declined or revoked
+	RespondedAt sql.NullTime `json:"responded_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+	// Last update timestamp
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+// Failed login counters for brute-force protection
+type LoginAttempt struct {
+	// Normalized email the attempts were made against, qualified by tenant
+	Account string `json:"account"`
+	// Client IP; empty for the account-wide counter
+	IpAddress string `json:"ip_address"`
+	// Failed attempts in the current failure window
+	Failures int32 `json:"failures"`
+	// When the last failed attempt was made
+	LastFailedAt time.Time `json:"last_failed_at"`
+	// End of the current lockout; NULL when not locked
+	LockedUntil sql.NullTime `json:"locked_until"`
+}
+
+// Pending second factor logins
+type MagicLinkToken struct {
+	// UUID
+	ID string `json:"id"`
+	// User the link signs in
+	UserID string `json:"user_id"`
+	// Tenant of the user the token belongs to
+	TenantID string `json:"tenant_id"`
+	// SHA-256 hash of the opaque login token
+	TokenHash string `json:"token_hash"`
+	// SHA-256 hash of the value given to the client that asked for the link
+	BindingHash string `json:"binding_hash"`
+	// Expiration timestamp
+	ExpiresAt time.Time `json:"expires_at"`
+	// When the token was consumed or superseded
+	UsedAt sql.NullTime `json:"used_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type MfaChallenge struct {
+	// UUID
+	ID string `json:"id"`
+	// User who passed the first factor
+	UserID string `json:"user_id"`
+	// Tenant of the user the challenge belongs to
+	TenantID string `json:"tenant_id"`
+	// SHA-256 hash of the opaque challenge token
+	TokenHash string `json:"token_hash"`
+	// Failed second factor attempts
+	Attempts int32 `json:"attempts"`
+	// Expiration timestamp
+	ExpiresAt time.Time `json:"expires_at"`
+	// When the challenge was completed
+	UsedAt sql.NullTime `json:"used_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Single-use MFA recovery codes
+type MfaRecoveryCode struct {
+	// UUID
+	ID string `json:"id"`
+	// User the recovery code belongs to
+	UserID string `json:"user_id"`
+	// SHA-256 hash of the normalized recovery code
+	CodeHash string `json:"code_hash"`
+	// When the code was consumed
+	UsedAt sql.NullTime `json:"used_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Single-use password reset tokens
+type PasswordResetToken struct {
+	// UUID
+	ID string `json:"id"`
+	// User whose password the token resets
+	UserID string `json:"user_id"`
+	// Tenant of the user the token belongs to
+	TenantID string `json:"tenant_id"`
+	// SHA-256 hash of the opaque reset token
+	TokenHash string `json:"token_hash"`
+	// Expiration timestamp
+	ExpiresAt time.Time `json:"expires_at"`
+	// When the token was consumed or superseded
+	UsedAt sql.NullTime `json:"used_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Permissions checked by the API
+type Permission struct {
+	// Permission name, resource:action
+	Name string `json:"name"`
+	// Human readable description
+	Description sql.NullString `json:"description"`
+}
+
+// Opaque refresh tokens with rotation families
+type RefreshToken struct {
+	// UUID
+	ID string `json:"id"`
+	// Owner of the refresh token
+	UserID string `json:"user_id"`
+	// Tenant of the user the token belongs to
+	TenantID string `json:"tenant_id"`
+	// Rotation chain started by a single login
+	FamilyID string `json:"family_id"`
+	// SHA-256 hash of the opaque refresh token
+	TokenHash string `json:"token_hash"`
+	// Expiration timestamp
+	ExpiresAt time.Time `json:"expires_at"`
+	// When the token was rotated for a new one
+	UsedAt sql.NullTime `json:"used_at"`
+	// When the token family was revoked
+	RevokedAt sql.NullTime `json:"revoked_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Denylist of individually revoked access tokens
+type RevokedToken struct {
+	// JWT ID of the revoked access token
+	Jti string `json:"jti"`
+	// Expiry of the revoked token; the row can be purged afterwards
+	ExpiresAt time.Time `json:"expires_at"`
+	// Revocation timestamp
+	RevokedAt sql.NullTime `json:"revoked_at"`
+}
+
+// Roles that group permissions
+type Role struct {
+	// Role name
+	Name string `json:"name"`
+	// Human readable description
+	Description sql.NullString `json:"description"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Permissions granted by each role
+type RolePermission struct {
+	// Role granting the permission
+	RoleName string `json:"role_name"`
+	// Granted permission
+	PermissionName string `json:"permission_name"`
+}
+
 // Users table for authentication and basic user info
+type Session struct {
+	// SHA-256 hash of the session cookie value
+	ID string `json:"id"`
+	// User the session belongs to
+	UserID string `json:"user_id"`
+	// Tenant of the user the session belongs to
+	TenantID string `json:"tenant_id"`
+	// Email of the user when the session was started
+	Email string `json:"email"`
+	// Space separated roles, reloaded when the session is extended
+	Roles string `json:"roles"`
+	// Space separated permissions, reloaded when the session is extended
+	Permissions string `json:"permissions"`
+	// Token state-changing requests must echo in the X-CSRF-Token header
+	CsrfToken string `json:"csrf_token"`
+	// Idle expiry, pushed back while the session is in use
+	ExpiresAt time.Time `json:"expires_at"`
+	// Hard end of the session regardless of activity
+	AbsoluteExpiresAt time.Time `json:"absolute_expires_at"`
+	// When the session was last extended
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Organizations whose users are isolated from each other
+type Tenant struct {
+	// UUID
+	ID string `json:"id"`
+	// DNS label naming the tenant in subdomains and the tenant header
+	Slug string `json:"slug"`
+	// Display name
+	Name string `json:"name"`
+	// Whether the tenant can be used
+	IsActive bool `json:"is_active"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+	// Last update timestamp
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
 type User struct {
 	// UUID v7
 	ID string `json:"id"`
+	// Tenant the user belongs to
+	TenantID string `json:"tenant_id"`
 	// User email address
 	Email string `json:"email"`
 	// Bcrypt hashed password
@@ -22,10 +295,82 @@ type User struct {
 	LastName sql.NullString `json:"last_name"`
 	// Whether the user is active
 	IsActive sql.NullBool `json:"is_active"`
+	// When the email address was verified
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	// Whether the user is a non-interactive service account
+	IsServiceAccount bool `json:"is_service_account"`
 	// Creation timestamp
 	CreatedAt sql.NullTime `json:"created_at"`
 	// Last update timestamp
 	UpdatedAt sql.NullTime `json:"updated_at"`
 	// Soft delete timestamp
 	DeletedAt sql.NullTime `json:"deleted_at"`
+	// Email of live users, NULL once deleted; unique per tenant
+	LiveEmail sql.NullString `json:"live_email"`
+	// Incremented by every write, for optimistic concurrency control
+	Version int32 `json:"version"`
+}
+
+// Groups of users that share roles
+type UserGroup struct {
+	// UUID
+	ID string `json:"id"`
+	// Tenant the group belongs to
+	TenantID string `json:"tenant_id"`
+	// Group name, unique per tenant
+	Name string `json:"name"`
+	// Human readable description
+	Description sql.NullString `json:"description"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+	// Last update timestamp
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+// External OIDC identities linked to local users
+type UserIdentity struct {
+	// UUID
+	ID string `json:"id"`
+	// Local user the external identity is linked to
+	UserID string `json:"user_id"`
+	// OIDC issuer URL
+	Issuer string `json:"issuer"`
+	// Subject identifier assigned by the issuer
+	Subject string `json:"subject"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// TOTP second factors
+type UserMfa struct {
+	// User the second factor belongs to
+	UserID string `json:"user_id"`
+	// Base32 encoded TOTP secret
+	Secret string `json:"secret"`
+	// When enrollment was confirmed; NULL while pending
+	ConfirmedAt sql.NullTime `json:"confirmed_at"`
+	// Last accepted TOTP time step, to reject replayed codes
+	LastUsedStep int64 `json:"last_used_step"`
+	// Creation timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+	// Last update timestamp
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+// Roles assigned to users
+type UserRole struct {
+	// User holding the role
+	UserID string `json:"user_id"`
+	// Assigned role
+	RoleName string `json:"role_name"`
+	// Assignment timestamp
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+// Per-user cut-off for previously issued access tokens
+type UserTokenRevocation struct {
+	// User whose tokens were revoked
+	UserID string `json:"user_id"`
+	// Access tokens issued at or before this time are rejected
+	RevokedBefore time.Time `json:"revoked_before"`
 }
@@ -6,17 +6,114 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
-	CountUsers(ctx context.Context) (int64, error)
+	AddGroupMember(ctx context.Context, arg AddGroupMemberParams) error
+	AddGroupRole(ctx context.Context, arg AddGroupRoleParams) error
+	AdvanceUserMFAStep(ctx context.Context, arg AdvanceUserMFAStepParams) (int64, error)
+	AssignUserRole(ctx context.Context, arg AssignUserRoleParams) error
+	ConfirmUserMFA(ctx context.Context, userID string) (int64, error)
+	CountDeletedUsers(ctx context.Context, tenantID string) (int64, error)
+	CountRevokedToken(ctx context.Context, jti string) (int64, error)
+	CountUnusedMFARecoveryCodes(ctx context.Context, userID string) (int64, error)
+	CountUsers(ctx context.Context, arg CountUsersParams) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) error
+	CreateGroup(ctx context.Context, arg CreateGroupParams) error
+	CreateInvitation(ctx context.Context, arg CreateInvitationParams) error
+	CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) error
+	CreateMFARecoveryCode(ctx context.Context, arg CreateMFARecoveryCodeParams) error
+	CreateMagicLinkToken(ctx context.Context, arg CreateMagicLinkTokenParams) error
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
+	CreateSession(ctx context.Context, arg CreateSessionParams) error
+	CreateTenant(ctx context.Context, arg CreateTenantParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) error
-	DeleteUser(ctx context.Context, id string) error
-	GetUserByEmail(ctx context.Context, email string) (User, error)
-	GetUserByID(ctx context.Context, id string) (User, error)
-	HardDeleteUser(ctx context.Context, id string) error
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error
+	DeleteAPIKey(ctx context.Context, id string) error
+	DeleteExpiredRefreshTokens(ctx context.Context, expiresAt time.Time) error
+	DeleteExpiredRevokedTokens(ctx context.Context, expiresAt time.Time) error
+	DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) error
+	DeleteGroup(ctx context.Context, arg DeleteGroupParams) error
+	DeleteLoginAttempt(ctx context.Context, arg DeleteLoginAttemptParams) error
+	DeleteLoginAttemptsByAccount(ctx context.Context, account string) error
+	DeleteMFARecoveryCodes(ctx context.Context, userID string) error
+	DeleteSession(ctx context.Context, id string) error
+	DeleteStaleLoginAttempts(ctx context.Context, lastFailedAt time.Time) error
+	DeleteUser(ctx context.Context, arg DeleteUserParams) (int64, error)
+	DeleteUserMFA(ctx context.Context, userID string) error
+	GetAPIKeyByID(ctx context.Context, id string) (ApiKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
+	GetDeletedUserByID(ctx context.Context, arg GetDeletedUserByIDParams) (User, error)
+	GetEmailVerificationTokenByHash(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
+	GetGroupByID(ctx context.Context, arg GetGroupByIDParams) (UserGroup, error)
+	GetGroupByName(ctx context.Context, arg GetGroupByNameParams) (UserGroup, error)
+	GetGroupRoles(ctx context.Context, groupID string) ([]string, error)
+	GetInvitationByHash(ctx context.Context, arg GetInvitationByHashParams) (Invitation, error)
+	GetInvitationByID(ctx context.Context, arg GetInvitationByIDParams) (Invitation, error)
+	GetLatestEmailVerificationToken(ctx context.Context, userID string) (EmailVerificationToken, error)
+	GetLatestMagicLinkToken(ctx context.Context, userID string) (MagicLinkToken, error)
+	GetLatestPasswordResetToken(ctx context.Context, userID string) (PasswordResetToken, error)
+	GetLoginAttempt(ctx context.Context, arg GetLoginAttemptParams) (LoginAttempt, error)
+	GetMFAChallengeByHash(ctx context.Context, tokenHash string) (MfaChallenge, error)
+	GetMagicLinkTokenByHash(ctx context.Context, tokenHash string) (MagicLinkToken, error)
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	GetPendingInvitationByEmail(ctx context.Context, arg GetPendingInvitationByEmailParams) (Invitation, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
+	GetRolePermissions(ctx context.Context, roleName string) ([]string, error)
+	GetSession(ctx context.Context, id string) (Session, error)
+	GetTenantByID(ctx context.Context, id string) (Tenant, error)
+	GetTenantBySlug(ctx context.Context, slug string) (Tenant, error)
+	GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error)
+	GetUserByID(ctx context.Context, arg GetUserByIDParams) (User, error)
+	GetUserIdentityByIssuerSubject(ctx context.Context, arg GetUserIdentityByIssuerSubjectParams) (UserIdentity, error)
+	GetUserMFA(ctx context.Context, userID string) (UserMfa, error)
+	GetUserPermissions(ctx context.Context, userID string) ([]string, error)
+	GetUserRoles(ctx context.Context, userID string) ([]string, error)
+	GetUserTokenRevocation(ctx context.Context, userID string) (UserTokenRevocation, error)
+	HardDeleteUser(ctx context.Context, arg HardDeleteUserParams) (int64, error)
+	IncrementMFAChallengeAttempts(ctx context.Context, arg IncrementMFAChallengeAttemptsParams) (int64, error)
+	InvalidateUserEmailVerificationTokens(ctx context.Context, userID string) error
+	InvalidateUserMagicLinkTokens(ctx context.Context, userID string) error
+	InvalidateUserPasswordResetTokens(ctx context.Context, userID string) error
+	ListAPIKeysByUser(ctx context.Context, userID string) ([]ApiKey, error)
+	ListDeletedUsers(ctx context.Context, arg ListDeletedUsersParams) ([]User, error)
+	ListGroupMemberIDs(ctx context.Context, arg ListGroupMemberIDsParams) ([]string, error)
+	ListGroupMembers(ctx context.Context, arg ListGroupMembersParams) ([]User, error)
+	ListGroups(ctx context.Context, arg ListGroupsParams) ([]UserGroup, error)
+	ListPendingInvitations(ctx context.Context, arg ListPendingInvitationsParams) ([]Invitation, error)
+	ListTenants(ctx context.Context, arg ListTenantsParams) ([]Tenant, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
-	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	ListUsersAfterCursor(ctx context.Context, arg ListUsersAfterCursorParams) ([]User, error)
+	ListUsersBeforeCursor(ctx context.Context, arg ListUsersBeforeCursorParams) ([]User, error)
+	LockLoginAttempt(ctx context.Context, arg LockLoginAttemptParams) error
+	MarkEmailVerificationTokenUsed(ctx context.Context, id string) (int64, error)
+	MarkMFAChallengeUsed(ctx context.Context, id string) (int64, error)
+	MarkMagicLinkTokenUsed(ctx context.Context, id string) (int64, error)
+	MarkPasswordResetTokenUsed(ctx context.Context, id string) (int64, error)
+	MarkRefreshTokenUsed(ctx context.Context, id string) (int64, error)
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) error
+	PurgeDeletedUsers(ctx context.Context, arg PurgeDeletedUsersParams) (int64, error)
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) error
+	RemoveGroupMember(ctx context.Context, arg RemoveGroupMemberParams) (int64, error)
+	RemoveGroupRole(ctx context.Context, arg RemoveGroupRoleParams) (int64, error)
+	RenewInvitation(ctx context.Context, arg RenewInvitationParams) (int64, error)
+	RespondToInvitation(ctx context.Context, arg RespondToInvitationParams) (int64, error)
+	RestoreUser(ctx context.Context, arg RestoreUserParams) (int64, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	RevokeUserRefreshTokens(ctx context.Context, userID string) error
+	RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) error
+	TouchAPIKeyLastUsed(ctx context.Context, arg TouchAPIKeyLastUsedParams) error
+	UpdateAPIKey(ctx context.Context, arg UpdateAPIKeyParams) error
+	UpdateGroup(ctx context.Context, arg UpdateGroupParams) error
+	UpdateSession(ctx context.Context, arg UpdateSessionParams) error
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (int64, error)
+	UpdateUserPasswordHash(ctx context.Context, arg UpdateUserPasswordHashParams) error
+	UpsertUserMFA(ctx context.Context, arg UpsertUserMFAParams) error
+	UseMFARecoveryCode(ctx context.Context, arg UseMFARecoveryCodeParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
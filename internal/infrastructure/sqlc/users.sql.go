@@ -10,127 +10,348 @@ import (
 	"database/sql"
 )
 
+const countDeletedUsers = `-- name: CountDeletedUsers :one
+SELECT COUNT(*) as count
+FROM users
+WHERE tenant_id = ? AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) CountDeletedUsers(ctx context.Context, tenantID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countDeletedUsers, tenantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) as count
 FROM users
-WHERE deleted_at IS NULL
+WHERE tenant_id = ? AND deleted_at IS NULL
+  AND (? IS NULL OR is_active = ?)
+  AND (? IS NULL OR created_at >= ?)
+  AND (? IS NULL OR created_at < ?)
+  AND (? IS NULL OR email LIKE ?)
+  AND (? IS NULL OR email LIKE ? OR first_name LIKE ? OR last_name LIKE ?)
 `
 
-func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countUsers)
+type CountUsersParams struct {
+	TenantID      string         `json:"tenant_id"`
+	IsActive      sql.NullBool   `json:"is_active"`
+	CreatedAfter  sql.NullTime   `json:"created_after"`
+	CreatedBefore sql.NullTime   `json:"created_before"`
+	EmailPattern  sql.NullString `json:"email_pattern"`
+	SearchPattern sql.NullString `json:"search_pattern"`
+}
+
+// Counts the users ListUsers pages through, with the same filters
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers,
+		arg.TenantID,
+		arg.IsActive,
+		arg.IsActive,
+		arg.CreatedAfter,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CreatedBefore,
+		arg.EmailPattern,
+		arg.EmailPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
 const createUser = `-- name: CreateUser :exec
-INSERT INTO users (id, email, password_hash, first_name, last_name, is_active, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+INSERT INTO users (id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
 `
 
 type CreateUserParams struct {
-	ID           string         `json:"id"`
-	Email        string         `json:"email"`
-	PasswordHash string         `json:"password_hash"`
-	FirstName    sql.NullString `json:"first_name"`
-	LastName     sql.NullString `json:"last_name"`
-	IsActive     sql.NullBool   `json:"is_active"`
+	ID               string         `json:"id"`
+	TenantID         string         `json:"tenant_id"`
+	Email            string         `json:"email"`
+	PasswordHash     string         `json:"password_hash"`
+	FirstName        sql.NullString `json:"first_name"`
+	LastName         sql.NullString `json:"last_name"`
+	IsActive         sql.NullBool   `json:"is_active"`
+	EmailVerifiedAt  sql.NullTime   `json:"email_verified_at"`
+	IsServiceAccount bool           `json:"is_service_account"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 	_, err := q.db.ExecContext(ctx, createUser,
 		arg.ID,
+		arg.TenantID,
 		arg.Email,
 		arg.PasswordHash,
 		arg.FirstName,
 		arg.LastName,
 		arg.IsActive,
+		arg.EmailVerifiedAt,
+		arg.IsServiceAccount,
 	)
 	return err
 }
 
-const deleteUser = `-- name: DeleteUser :exec
+const deleteUser = `-- name: DeleteUser :execrows
 UPDATE users
-SET deleted_at = NOW(), updated_at = NOW()
-WHERE id = ? AND deleted_at IS NULL
+SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+WHERE tenant_id = ? AND id = ? AND version = ? AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteUser, id)
-	return err
+type DeleteUserParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       string `json:"id"`
+	Version  int32  `json:"version"`
+}
+
+// Only applies while the user is still at the version it was read at
+func (q *Queries) DeleteUser(ctx context.Context, arg DeleteUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUser, arg.TenantID, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getDeletedUserByID = `-- name: GetDeletedUserByID :one
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
+FROM users
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NOT NULL
+`
+
+type GetDeletedUserByIDParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       string `json:"id"`
+}
+
+func (q *Queries) GetDeletedUserByID(ctx context.Context, arg GetDeletedUserByIDParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedUserByID, arg.TenantID, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.FirstName,
+		&i.LastName,
+		&i.IsActive,
+		&i.EmailVerifiedAt,
+		&i.IsServiceAccount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.LiveEmail,
+		&i.Version,
+	)
+	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
 FROM users
-WHERE email = ? AND deleted_at IS NULL
+WHERE tenant_id = ? AND email = ? AND deleted_at IS NULL
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+type GetUserByEmailParams struct {
+	TenantID string `json:"tenant_id"`
+	Email    string `json:"email"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, arg.TenantID, arg.Email)
 	var i User
 	err := row.Scan(
 		&i.ID,
+		&i.TenantID,
 		&i.Email,
 		&i.PasswordHash,
 		&i.FirstName,
 		&i.LastName,
 		&i.IsActive,
+		&i.EmailVerifiedAt,
+		&i.IsServiceAccount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.LiveEmail,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
 FROM users
-WHERE id = ? AND deleted_at IS NULL
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NULL
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByID, id)
+type GetUserByIDParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       string `json:"id"`
+}
+
+func (q *Queries) GetUserByID(ctx context.Context, arg GetUserByIDParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, arg.TenantID, arg.ID)
 	var i User
 	err := row.Scan(
 		&i.ID,
+		&i.TenantID,
 		&i.Email,
 		&i.PasswordHash,
 		&i.FirstName,
 		&i.LastName,
 		&i.IsActive,
+		&i.EmailVerifiedAt,
+		&i.IsServiceAccount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.LiveEmail,
+		&i.Version,
 	)
 	return i, err
 }
 
-const hardDeleteUser = `-- name: HardDeleteUser :exec
+const hardDeleteUser = `-- name: HardDeleteUser :execrows
 DELETE FROM users
-WHERE id = ?
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) HardDeleteUser(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, hardDeleteUser, id)
-	return err
+type HardDeleteUserParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       string `json:"id"`
+}
+
+// Only removes users that are soft-deleted
+func (q *Queries) HardDeleteUser(ctx context.Context, arg HardDeleteUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, hardDeleteUser, arg.TenantID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listDeletedUsers = `-- name: ListDeletedUsers :many
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
+FROM users
+WHERE tenant_id = ? AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC
+LIMIT ? OFFSET ?
+`
+
+type ListDeletedUsersParams struct {
+	TenantID string `json:"tenant_id"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
+}
+
+func (q *Queries) ListDeletedUsers(ctx context.Context, arg ListDeletedUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedUsers, arg.TenantID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FirstName,
+			&i.LastName,
+			&i.IsActive,
+			&i.EmailVerifiedAt,
+			&i.IsServiceAccount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.LiveEmail,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
 FROM users
-WHERE deleted_at IS NULL
-ORDER BY created_at DESC
+WHERE tenant_id = ? AND deleted_at IS NULL
+  AND (? IS NULL OR is_active = ?)
+  AND (? IS NULL OR created_at >= ?)
+  AND (? IS NULL OR created_at < ?)
+  AND (? IS NULL OR email LIKE ?)
+  AND (? IS NULL OR email LIKE ? OR first_name LIKE ? OR last_name LIKE ?)
+ORDER BY
+  CASE WHEN ? = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN ? = 'updated_at_asc' THEN updated_at END ASC,
+  CASE WHEN ? = 'updated_at_desc' THEN updated_at END DESC,
+  CASE WHEN ? = 'email_asc' THEN email END ASC,
+  CASE WHEN ? = 'email_desc' THEN email END DESC,
+  CASE WHEN ? = 'first_name_asc' THEN first_name END ASC,
+  CASE WHEN ? = 'first_name_desc' THEN first_name END DESC,
+  CASE WHEN ? = 'last_name_asc' THEN last_name END ASC,
+  CASE WHEN ? = 'last_name_desc' THEN last_name END DESC,
+  created_at DESC, id DESC
 LIMIT ? OFFSET ?
 `
 
 type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	TenantID      string         `json:"tenant_id"`
+	IsActive      sql.NullBool   `json:"is_active"`
+	CreatedAfter  sql.NullTime   `json:"created_after"`
+	CreatedBefore sql.NullTime   `json:"created_before"`
+	EmailPattern  sql.NullString `json:"email_pattern"`
+	SearchPattern sql.NullString `json:"search_pattern"`
+	SortKey       interface{}    `json:"sort_key"`
+	Limit         int32          `json:"limit"`
+	Offset        int32          `json:"offset"`
 }
 
+// Filters that are NULL do not apply. sort_key selects one of the whitelisted
+// orders, so the ORDER BY never contains caller input.
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listUsers,
+		arg.TenantID,
+		arg.IsActive,
+		arg.IsActive,
+		arg.CreatedAfter,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CreatedBefore,
+		arg.EmailPattern,
+		arg.EmailPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SortKey,
+		arg.SortKey,
+		arg.SortKey,
+		arg.SortKey,
+		arg.SortKey,
+		arg.SortKey,
+		arg.SortKey,
+		arg.SortKey,
+		arg.SortKey,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -140,14 +361,19 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 		var i User
 		if err := rows.Scan(
 			&i.ID,
+			&i.TenantID,
 			&i.Email,
 			&i.PasswordHash,
 			&i.FirstName,
 			&i.LastName,
 			&i.IsActive,
+			&i.EmailVerifiedAt,
+			&i.IsServiceAccount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.LiveEmail,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -162,29 +388,288 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
-const updateUser = `-- name: UpdateUser :exec
+const listUsersAfterCursor = `-- name: ListUsersAfterCursor :many
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
+FROM users
+WHERE tenant_id = ? AND deleted_at IS NULL
+  AND (? IS NULL OR is_active = ?)
+  AND (? IS NULL OR created_at >= ?)
+  AND (? IS NULL OR created_at < ?)
+  AND (? IS NULL OR email LIKE ?)
+  AND (? IS NULL OR email LIKE ? OR first_name LIKE ? OR last_name LIKE ?)
+  AND (? IS NULL OR created_at > ? OR (created_at = ? AND id > ?))
+ORDER BY created_at ASC, id ASC
+LIMIT ?
+`
+
+type ListUsersAfterCursorParams struct {
+	TenantID        string         `json:"tenant_id"`
+	IsActive        sql.NullBool   `json:"is_active"`
+	CreatedAfter    sql.NullTime   `json:"created_after"`
+	CreatedBefore   sql.NullTime   `json:"created_before"`
+	EmailPattern    sql.NullString `json:"email_pattern"`
+	SearchPattern   sql.NullString `json:"search_pattern"`
+	CursorCreatedAt sql.NullTime   `json:"cursor_created_at"`
+	CursorID        string         `json:"cursor_id"`
+	Limit           int32          `json:"limit"`
+}
+
+// Keyset page of the users that follow (cursor_created_at, cursor_id) in
+// ascending creation order; a NULL cursor starts at the oldest user.
+func (q *Queries) ListUsersAfterCursor(ctx context.Context, arg ListUsersAfterCursorParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersAfterCursor,
+		arg.TenantID,
+		arg.IsActive,
+		arg.IsActive,
+		arg.CreatedAfter,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CreatedBefore,
+		arg.EmailPattern,
+		arg.EmailPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FirstName,
+			&i.LastName,
+			&i.IsActive,
+			&i.EmailVerifiedAt,
+			&i.IsServiceAccount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.LiveEmail,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersBeforeCursor = `-- name: ListUsersBeforeCursor :many
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
+FROM users
+WHERE tenant_id = ? AND deleted_at IS NULL
+  AND (? IS NULL OR is_active = ?)
+  AND (? IS NULL OR created_at >= ?)
+  AND (? IS NULL OR created_at < ?)
+  AND (? IS NULL OR email LIKE ?)
+  AND (? IS NULL OR email LIKE ? OR first_name LIKE ? OR last_name LIKE ?)
+  AND (? IS NULL OR created_at < ? OR (created_at = ? AND id < ?))
+ORDER BY created_at DESC, id DESC
+LIMIT ?
+`
+
+type ListUsersBeforeCursorParams struct {
+	TenantID        string         `json:"tenant_id"`
+	IsActive        sql.NullBool   `json:"is_active"`
+	CreatedAfter    sql.NullTime   `json:"created_after"`
+	CreatedBefore   sql.NullTime   `json:"created_before"`
+	EmailPattern    sql.NullString `json:"email_pattern"`
+	SearchPattern   sql.NullString `json:"search_pattern"`
+	CursorCreatedAt sql.NullTime   `json:"cursor_created_at"`
+	CursorID        string         `json:"cursor_id"`
+	Limit           int32          `json:"limit"`
+}
+
+// Keyset page of the users that precede (cursor_created_at, cursor_id) in
+// descending creation order; a NULL cursor starts at the newest user.
+func (q *Queries) ListUsersBeforeCursor(ctx context.Context, arg ListUsersBeforeCursorParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersBeforeCursor,
+		arg.TenantID,
+		arg.IsActive,
+		arg.IsActive,
+		arg.CreatedAfter,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CreatedBefore,
+		arg.EmailPattern,
+		arg.EmailPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.SearchPattern,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.FirstName,
+			&i.LastName,
+			&i.IsActive,
+			&i.EmailVerifiedAt,
+			&i.IsServiceAccount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.LiveEmail,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET email_verified_at = ?
+WHERE tenant_id = ? AND id = ? AND email_verified_at IS NULL AND deleted_at IS NULL
+`
+
+type MarkUserEmailVerifiedParams struct {
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	TenantID        string       `json:"tenant_id"`
+	ID              string       `json:"id"`
+}
+
+// Records proof of control of the address, which does not change the user
+// as clients edit it, so the version stays as it is
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) error {
+	_, err := q.db.ExecContext(ctx, markUserEmailVerified, arg.EmailVerifiedAt, arg.TenantID, arg.ID)
+	return err
+}
+
+const purgeDeletedUsers = `-- name: PurgeDeletedUsers :execrows
+DELETE FROM users
+WHERE tenant_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?
+LIMIT ?
+`
+
+type PurgeDeletedUsersParams struct {
+	TenantID  string       `json:"tenant_id"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	Limit     int32        `json:"limit"`
+}
+
+// Removes at most limit users per call, so that large purges do not hold
+// locks for long
+func (q *Queries) PurgeDeletedUsers(ctx context.Context, arg PurgeDeletedUsersParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedUsers, arg.TenantID, arg.DeletedAt, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreUser = `-- name: RestoreUser :execrows
 UPDATE users
-SET email = ?, password_hash = ?, first_name = ?, last_name = ?, is_active = ?, updated_at = NOW()
-WHERE id = ? AND deleted_at IS NULL
+SET deleted_at = NULL, version = version + 1, updated_at = NOW()
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NOT NULL
+`
+
+type RestoreUserParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       string `json:"id"`
+}
+
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreUser, arg.TenantID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUser = `-- name: UpdateUser :execrows
+UPDATE users
+SET email = ?, password_hash = ?, first_name = ?, last_name = ?, is_active = ?, email_verified_at = ?, version = version + 1, updated_at = NOW()
+WHERE tenant_id = ? AND id = ? AND version = ? AND deleted_at IS NULL
 `
 
 type UpdateUserParams struct {
-	Email        string         `json:"email"`
-	PasswordHash string         `json:"password_hash"`
-	FirstName    sql.NullString `json:"first_name"`
-	LastName     sql.NullString `json:"last_name"`
-	IsActive     sql.NullBool   `json:"is_active"`
-	ID           string         `json:"id"`
+	Email           string         `json:"email"`
+	PasswordHash    string         `json:"password_hash"`
+	FirstName       sql.NullString `json:"first_name"`
+	LastName        sql.NullString `json:"last_name"`
+	IsActive        sql.NullBool   `json:"is_active"`
+	EmailVerifiedAt sql.NullTime   `json:"email_verified_at"`
+	TenantID        string         `json:"tenant_id"`
+	ID              string         `json:"id"`
+	Version         int32          `json:"version"`
 }
 
-func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
-	_, err := q.db.ExecContext(ctx, updateUser,
+// Only applies while the user is still at the version it was read at
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUser,
 		arg.Email,
 		arg.PasswordHash,
 		arg.FirstName,
 		arg.LastName,
 		arg.IsActive,
+		arg.EmailVerifiedAt,
+		arg.TenantID,
 		arg.ID,
+		arg.Version,
 	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUserPasswordHash = `-- name: UpdateUserPasswordHash :exec
+UPDATE users
+SET password_hash = ?
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NULL
+`
+
+type UpdateUserPasswordHashParams struct {
+	PasswordHash string `json:"password_hash"`
+	TenantID     string `json:"tenant_id"`
+	ID           string `json:"id"`
+}
+
+// Upgrades the stored hash of a user's unchanged password, so the version
+// stays as it is
+func (q *Queries) UpdateUserPasswordHash(ctx context.Context, arg UpdateUserPasswordHashParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPasswordHash, arg.PasswordHash, arg.TenantID, arg.ID)
 	return err
 }
@@ -1,24 +1,223 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/zercle/template-go-fiber/internal/config"
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
 	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/pkg/oidc"
 )
 
-// JWTClaims represents JWT token claims
+// JWTClaims represents JWT token claims. The token ID is carried in the
+// registered jti claim and is used for revocation. Roles and permissions are
+// a snapshot taken when the token was issued. The tid claim names the tenant
+// of the user. Impersonation tokens name the admin acting as the user in the
+// act claim (RFC 8693).
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID      string      `json:"user_id"`
+	TenantID    string      `json:"tid,omitempty"`
+	Email       string      `json:"email"`
+	Roles       []string    `json:"roles,omitempty"`
+	Permissions []string    `json:"permissions,omitempty"`
+	Actor       *ActorClaim `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// AuthMiddleware validates JWT tokens
-func AuthMiddleware(cfg *config.Config) fiber.Handler {
+// ActorClaim identifies the party acting on behalf of the token subject
+type ActorClaim struct {
+	Subject string `json:"sub"`
+	Email   string `json:"email,omitempty"`
+}
+
+// JWTIssuer mints tokens with the current key of a key set, which
+// AuthMiddleware accepts when given the same key set
+type JWTIssuer struct {
+	keys       *jwtkeys.KeySet
+	expiration time.Duration
+}
+
+// NewJWTIssuer creates a new JWT issuer
+func NewJWTIssuer(keys *jwtkeys.KeySet, expiration time.Duration) *JWTIssuer {
+	return &JWTIssuer{
+		keys:       keys,
+		expiration: expiration,
+	}
+}
+
+// Issue signs a new access token for the given subject
+func (i *JWTIssuer) Issue(input *domains.IssueTokenInput) (*domains.AuthToken, error) {
+	expiration := i.expiration
+	if input.TTL > 0 {
+		expiration = input.TTL
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(expiration)
+
+	claims := &JWTClaims{
+		UserID:      input.UserID,
+		TenantID:    input.TenantID,
+		Email:       input.Email,
+		Roles:       input.Roles,
+		Permissions: input.Permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   input.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	if input.ActorID != "" {
+		claims.Actor = &ActorClaim{Subject: input.ActorID, Email: input.ActorEmail}
+	}
+
+	key, err := i.keys.SigningKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select signing key: %w", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+
+	signed, err := token.SignedString(key.SigningKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return &domains.AuthToken{
+		AccessToken: signed,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// OIDCClaimMapping names the claims of an external token that identify the user
+type OIDCClaimMapping struct {
+	SubjectClaim string
+	EmailClaim   string
+}
+
+// OIDCAuthenticator accepts tokens from an external OIDC issuer and maps
+// them onto a local user
+type OIDCAuthenticator struct {
+	verifier   *oidc.Verifier
+	identities domains.IdentityUsecase
+	roles      domains.RoleRepository
+	mapping    OIDCClaimMapping
+}
+
+// NewOIDCAuthenticator creates an authenticator for an external issuer.
+// With a nil identity usecase the subject claim is used as the local user ID
+// verbatim; otherwise it is resolved to the linked local user. Roles and
+// permissions are loaded for the mapped user, as external tokens do not carry ours.
+func NewOIDCAuthenticator(verifier *oidc.Verifier, identities domains.IdentityUsecase, roles domains.RoleRepository, mapping OIDCClaimMapping) *OIDCAuthenticator {
+	if mapping.SubjectClaim == "" {
+		mapping.SubjectClaim = "sub"
+	}
+	if mapping.EmailClaim == "" {
+		mapping.EmailClaim = "email"
+	}
+
+	return &OIDCAuthenticator{
+		verifier:   verifier,
+		identities: identities,
+		roles:      roles,
+		mapping:    mapping,
+	}
+}
+
+// authenticate verifies an external token and maps it onto local claims
+func (a *OIDCAuthenticator) authenticate(ctx context.Context, tokenString string) (*JWTClaims, *errors.APIError) {
+	external, err := a.verifier.Verify(ctx, tokenString)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid or expired token")
+	}
+
+	subject := stringClaim(external, a.mapping.SubjectClaim)
+	if subject == "" {
+		return nil, errors.NewUnauthorizedError("token has no subject")
+	}
+
+	// External users are mapped within the tenant the request named
+	tenantID, _ := domains.TenantFromContext(ctx)
+
+	claims := &JWTClaims{
+		UserID:   subject,
+		TenantID: tenantID,
+		Email:    stringClaim(external, a.mapping.EmailClaim),
+	}
+	claims.ID = stringClaim(external, "jti")
+	claims.IssuedAt, _ = external.GetIssuedAt()
+	claims.ExpiresAt, _ = external.GetExpirationTime()
+
+	if a.identities != nil {
+		emailVerified, _ := external["email_verified"].(bool)
+
+		user, err := a.identities.ResolveExternalUser(ctx, &domains.ExternalIdentityInput{
+			Issuer:        a.verifier.Issuer(),
+			Subject:       subject,
+			Email:         claims.Email,
+			EmailVerified: emailVerified,
+			FirstName:     optionalClaim(external, "given_name"),
+			LastName:      optionalClaim(external, "family_name"),
+		})
+		if err != nil {
+			return nil, errors.AsAPIError(err)
+		}
+
+		claims.UserID = user.ID
+		claims.Email = user.Email
+	}
+
+	if a.roles != nil {
+		if claims.Roles, err = a.roles.GetUserRoles(ctx, claims.UserID); err != nil {
+			return nil, errors.NewDatabaseError("failed to fetch roles", err)
+		}
+
+		if claims.Permissions, err = a.roles.GetUserPermissions(ctx, claims.UserID); err != nil {
+			return nil, errors.NewDatabaseError("failed to fetch permissions", err)
+		}
+	}
+
+	return claims, nil
+}
+
+// AuthMiddleware validates JWT tokens and rejects revoked ones. When an
+// OIDC authenticator is given, tokens whose iss names its issuer are
+// validated against that issuer instead of the local keys. When an API key
+// usecase is given, keys sent in X-API-Key or as "Authorization: ApiKey"
+// are accepted as well.
+func AuthMiddleware(keys *jwtkeys.KeySet, revocations domains.TokenRevocationStore, external *OIDCAuthenticator, apiKeys domains.APIKeyUsecase) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if apiKey := apiKeyFromRequest(c); apiKey != "" {
+			if apiKeys == nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(errors.NewUnauthorizedError("API keys are not accepted"))
+			}
+
+			principal, err := apiKeys.Authenticate(c.UserContext(), apiKey)
+			if err != nil {
+				apiErr := errors.AsAPIError(err)
+				return c.Status(apiErr.StatusCode).JSON(apiErr)
+			}
+
+			if apiErr := storePrincipal(c, principal); apiErr != nil {
+				return c.Status(apiErr.StatusCode).JSON(apiErr)
+			}
+
+			return c.Next()
+		}
+
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(errors.NewUnauthorizedError("missing authorization header"))
@@ -32,35 +231,48 @@ func AuthMiddleware(cfg *config.Config) fiber.Handler {
 
 		tokenString := parts[1]
 
-		// Parse and validate token
-		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			// Verify signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.NewUnauthorizedError("invalid signing method")
-			}
-			return []byte(cfg.JWT.Secret), nil
-		})
+		// Parse and validate token against the key named by its kid
+		claims, apiErr := authenticate(c, keys, external, tokenString)
+		if apiErr != nil {
+			return c.Status(apiErr.StatusCode).JSON(apiErr)
+		}
 
+		revoked, err := isRevoked(c, revocations, claims)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(errors.NewUnauthorizedError("invalid or expired token"))
+			return c.Status(fiber.StatusInternalServerError).JSON(errors.NewInternalError("failed to check token revocation", err))
 		}
 
-		claims, ok := token.Claims.(*JWTClaims)
-		if !ok || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(errors.NewUnauthorizedError("invalid token claims"))
+		if revoked {
+			return c.Status(fiber.StatusUnauthorized).JSON(errors.NewUnauthorizedError("token has been revoked"))
 		}
 
 		// Store claims in context
-		c.Locals("user_id", claims.UserID)
-		c.Locals("email", claims.Email)
+		if apiErr := storeClaims(c, claims); apiErr != nil {
+			return c.Status(apiErr.StatusCode).JSON(apiErr)
+		}
 
 		return c.Next()
 	}
 }
 
-// OptionalAuthMiddleware validates JWT tokens but doesn't fail if missing
-func OptionalAuthMiddleware(cfg *config.Config) fiber.Handler {
+// OptionalAuthMiddleware validates JWT tokens and API keys but doesn't fail if missing
+func OptionalAuthMiddleware(keys *jwtkeys.KeySet, revocations domains.TokenRevocationStore, external *OIDCAuthenticator, apiKeys domains.APIKeyUsecase) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if apiKey := apiKeyFromRequest(c); apiKey != "" {
+			if apiKeys == nil {
+				return c.Next() // Continue without authentication
+			}
+
+			principal, err := apiKeys.Authenticate(c.UserContext(), apiKey)
+			if err != nil {
+				return c.Next() // Continue without authentication
+			}
+
+			_ = storePrincipal(c, principal) // Continue without authentication on failure
+
+			return c.Next()
+		}
+
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			return c.Next() // Continue without authentication
@@ -75,26 +287,154 @@ func OptionalAuthMiddleware(cfg *config.Config) fiber.Handler {
 		tokenString := parts[1]
 
 		// Parse and validate token
-		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.NewUnauthorizedError("invalid signing method")
-			}
-			return []byte(cfg.JWT.Secret), nil
-		})
-
-		if err != nil {
+		claims, apiErr := authenticate(c, keys, external, tokenString)
+		if apiErr != nil {
 			return c.Next() // Continue without authentication
 		}
 
-		claims, ok := token.Claims.(*JWTClaims)
-		if !ok || !token.Valid {
+		if revoked, err := isRevoked(c, revocations, claims); err != nil || revoked {
 			return c.Next() // Continue without authentication
 		}
 
 		// Store claims in context
-		c.Locals("user_id", claims.UserID)
-		c.Locals("email", claims.Email)
+		_ = storeClaims(c, claims) // Continue without authentication on failure
 
 		return c.Next()
 	}
 }
+
+// apiKeyFromRequest returns the API key sent in X-API-Key or in an
+// "Authorization: ApiKey <key>" header, or an empty string
+func apiKeyFromRequest(c *fiber.Ctx) string {
+	if key := c.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	scheme, key, ok := strings.Cut(c.Get("Authorization"), " ")
+	if ok && scheme == "ApiKey" {
+		return key
+	}
+
+	return ""
+}
+
+// authenticate validates a bearer token with the external issuer it names,
+// or with the local keys
+func authenticate(c *fiber.Ctx, keys *jwtkeys.KeySet, external *OIDCAuthenticator, tokenString string) (*JWTClaims, *errors.APIError) {
+	if external != nil && unverifiedIssuer(tokenString) == external.verifier.Issuer() {
+		return external.authenticate(c.UserContext(), tokenString)
+	}
+
+	token, err := parseToken(keys, tokenString)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("invalid or expired token")
+	}
+
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid {
+		return nil, errors.NewUnauthorizedError("invalid token claims")
+	}
+
+	return claims, nil
+}
+
+// unverifiedIssuer peeks at the iss claim to route the token to its verifier.
+// The claim is only trusted after the chosen verifier checked the signature.
+func unverifiedIssuer(tokenString string) string {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, claims); err != nil {
+		return ""
+	}
+	issuer, _ := claims.GetIssuer()
+	return issuer
+}
+
+// stringClaim returns a string claim, or an empty string
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// optionalClaim returns a non-empty string claim, or nil
+func optionalClaim(claims jwt.MapClaims, name string) *string {
+	if value := stringClaim(claims, name); value != "" {
+		return &value
+	}
+	return nil
+}
+
+// parseToken verifies the token signature with the key set and restricts
+// the accepted algorithms to those of the configured keys
+func parseToken(keys *jwtkeys.KeySet, tokenString string) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, &JWTClaims{}, keys.Keyfunc, jwt.WithValidMethods(keys.Algorithms()))
+}
+
+// isRevoked consults the revocation store, if one is configured
+func isRevoked(c *fiber.Ctx, revocations domains.TokenRevocationStore, claims *JWTClaims) (bool, error) {
+	if revocations == nil {
+		return false, nil
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	return revocations.IsRevoked(c.Context(), claims.ID, claims.UserID, issuedAt)
+}
+
+// storeClaims exposes validated claims to downstream handlers via Locals,
+// and to usecases as the principal of the user context
+func storeClaims(c *fiber.Ctx, claims *JWTClaims) *errors.APIError {
+	principal := &domains.Principal{
+		UserID:      claims.UserID,
+		TenantID:    claims.TenantID,
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+	}
+
+	if claims.Actor != nil {
+		principal.ActorID = claims.Actor.Subject
+		principal.ActorEmail = claims.Actor.Email
+	}
+
+	if apiErr := storePrincipal(c, principal); apiErr != nil {
+		return apiErr
+	}
+
+	c.Locals("token_id", claims.ID)
+
+	if claims.ExpiresAt != nil {
+		c.Locals("token_expires_at", claims.ExpiresAt.Time)
+	}
+
+	return nil
+}
+
+// storePrincipal exposes an authenticated principal to downstream handlers
+// via Locals, and to usecases through the user context. Principals of another
+// tenant than the one the request named are rejected.
+func storePrincipal(c *fiber.Ctx, principal *domains.Principal) *errors.APIError {
+	if apiErr := bindTenant(c, principal); apiErr != nil {
+		return apiErr
+	}
+
+	c.Locals("user_id", principal.UserID)
+	c.Locals("email", principal.Email)
+	c.Locals("roles", principal.Roles)
+	c.Locals("permissions", principal.Permissions)
+
+	if principal.APIKeyID != "" {
+		c.Locals("api_key_id", principal.APIKeyID)
+	}
+
+	if principal.Impersonated() {
+		c.Locals("actor_id", principal.ActorID)
+		c.Locals("actor_email", principal.ActorEmail)
+	}
+
+	c.SetUserContext(domains.WithPrincipal(c.UserContext(), principal))
+
+	return nil
+}
@@ -17,6 +17,7 @@ func CORSMiddleware(cfg *config.Config) fiber.Handler {
 			c.Set("Access-Control-Allow-Origin", origin)
 			c.Set("Access-Control-Allow-Methods", strings.Join(cfg.CORS.AllowedMethods, ", "))
 			c.Set("Access-Control-Allow-Headers", strings.Join(cfg.CORS.AllowedHeaders, ", "))
+			c.Set("Access-Control-Expose-Headers", strings.Join(cfg.CORS.ExposedHeaders, ", "))
 
 			if cfg.CORS.AllowedCredentials {
 				c.Set("Access-Control-Allow-Credentials", "true")
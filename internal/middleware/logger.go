@@ -22,7 +22,7 @@ func LoggerMiddleware(log *slog.Logger) fiber.Handler {
 
 		// Log request details
 		duration := time.Since(start)
-		log.Info("HTTP Request",
+		attrs := []any{
 			slog.String("request_id", requestID),
 			slog.String("method", c.Method()),
 			slog.String("path", c.Path()),
@@ -30,7 +30,26 @@ func LoggerMiddleware(log *slog.Logger) fiber.Handler {
 			slog.Duration("duration_ms", duration),
 			slog.String("ip", c.IP()),
 			slog.String("user_agent", c.Get("User-Agent")),
-		)
+		}
+
+		if tenantID, _ := c.Locals("tenant_id").(string); tenantID != "" {
+			attrs = append(attrs, slog.String("tenant_id", tenantID))
+		}
+
+		// Requests made while impersonating are attributed to the admin as well
+		level, message := slog.LevelInfo, "HTTP Request"
+		if actorID, _ := c.Locals("actor_id").(string); actorID != "" {
+			userID, _ := c.Locals("user_id").(string)
+			actorEmail, _ := c.Locals("actor_email").(string)
+			attrs = append(attrs,
+				slog.String("user_id", userID),
+				slog.String("actor_id", actorID),
+				slog.String("actor_email", actorEmail),
+			)
+			level, message = slog.LevelWarn, "Impersonated HTTP Request"
+		}
+
+		log.Log(c.UserContext(), level, message, attrs...)
 
 		return err
 	}
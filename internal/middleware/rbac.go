@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// ResolvePermissions makes the authorization checks of a request, both
+// RequirePermission and those in the usecases, look up the caller's current
+// permissions through resolver instead of trusting the snapshot in their
+// token or session.
+func ResolvePermissions(resolver domains.PermissionResolver) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.SetUserContext(domains.WithPermissionResolver(c.UserContext(), resolver))
+		return c.Next()
+	}
+}
+
+// RequirePermission allows the request when the authenticated caller holds
+// any of the given permissions. It must run after AuthMiddleware.
+func RequirePermission(permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Locals("user_id") == nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(errors.NewUnauthorizedError("authentication required"))
+		}
+
+		granted, _ := c.Locals("permissions").([]string)
+		if principal, ok := domains.PrincipalFromContext(c.UserContext()); ok {
+			current, err := domains.CurrentPermissions(c.UserContext(), principal)
+			if err != nil {
+				apiErr := errors.NewDatabaseError("failed to fetch permissions", err)
+				return c.Status(apiErr.StatusCode).JSON(apiErr)
+			}
+			granted = current
+		}
+
+		for _, permission := range permissions {
+			if slices.Contains(granted, permission) {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(errors.NewForbiddenError("insufficient permissions"))
+	}
+}
+
+// DenyImpersonation rejects the request when an admin is impersonating the
+// caller, for operations only the account owner may perform. It must run
+// after AuthMiddleware.
+func DenyImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if actorID, _ := c.Locals("actor_id").(string); actorID != "" {
+			return c.Status(fiber.StatusForbidden).JSON(errors.NewForbiddenError("not allowed while impersonating a user"))
+		}
+
+		return c.Next()
+	}
+}
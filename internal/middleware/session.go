@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// CSRFHeader is the header state-changing requests in session mode must
+// echo the session's CSRF token in
+const CSRFHeader = "X-CSRF-Token"
+
+// SessionCookie describes the cookies that carry a session. The session
+// cookie is HttpOnly; the CSRF cookie is readable by scripts so that they can
+// echo it in CSRFHeader.
+type SessionCookie struct {
+	Name     string
+	CSRFName string
+	Domain   string
+	Secure   bool
+	SameSite string // Strict, Lax, None
+}
+
+// Set sends the session and CSRF cookies, both expiring with the session
+func (sc *SessionCookie) Set(c *fiber.Ctx, token, csrfToken string, expiresAt time.Time) {
+	c.Cookie(sc.cookie(sc.Name, token, expiresAt, true))
+	c.Cookie(sc.cookie(sc.CSRFName, csrfToken, expiresAt, false))
+}
+
+// Clear tells the browser to drop the session and CSRF cookies
+func (sc *SessionCookie) Clear(c *fiber.Ctx) {
+	expired := time.Unix(0, 0)
+	c.Cookie(sc.cookie(sc.Name, "", expired, true))
+	c.Cookie(sc.cookie(sc.CSRFName, "", expired, false))
+}
+
+// cookie builds a cookie with the configured scope and flags
+func (sc *SessionCookie) cookie(name, value string, expiresAt time.Time, httpOnly bool) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   sc.Domain,
+		Expires:  expiresAt,
+		Secure:   sc.Secure,
+		HTTPOnly: httpOnly,
+		SameSite: sc.SameSite,
+	}
+}
+
+// SessionMiddleware authenticates requests with a session cookie and extends
+// the session while it is in use. State-changing requests must carry the
+// session's CSRF token in CSRFHeader. Requests that send a bearer token or
+// an API key instead are handed to fallback, normally AuthMiddleware; with a
+// nil fallback they are rejected.
+func SessionMiddleware(sessions domains.SessionUsecase, cookie *SessionCookie, fallback fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := c.Cookies(cookie.Name)
+		if token == "" || c.Get("Authorization") != "" || c.Get("X-API-Key") != "" {
+			if fallback != nil {
+				return fallback(c)
+			}
+			return c.Status(fiber.StatusUnauthorized).JSON(errors.NewUnauthorizedError("missing session cookie"))
+		}
+
+		session, renewed, err := sessions.Resume(c.UserContext(), token)
+		if err != nil {
+			apiErr := errors.AsAPIError(err)
+			if apiErr.StatusCode == fiber.StatusUnauthorized {
+				cookie.Clear(c)
+			}
+			return c.Status(apiErr.StatusCode).JSON(apiErr)
+		}
+
+		if !isSafeMethod(c.Method()) && subtle.ConstantTimeCompare([]byte(c.Get(CSRFHeader)), []byte(session.CSRFToken)) != 1 {
+			return c.Status(fiber.StatusForbidden).JSON(errors.NewForbiddenError("missing or invalid CSRF token"))
+		}
+
+		if renewed {
+			cookie.Set(c, token, session.CSRFToken, session.ExpiresAt)
+		}
+
+		if apiErr := storePrincipal(c, &domains.Principal{
+			UserID:      session.UserID,
+			TenantID:    session.TenantID,
+			Email:       session.Email,
+			Roles:       session.Roles,
+			Permissions: session.Permissions,
+		}); apiErr != nil {
+			return c.Status(apiErr.StatusCode).JSON(apiErr)
+		}
+
+		c.Locals("session_id", session.ID)
+
+		return c.Next()
+	}
+}
+
+// isSafeMethod reports whether a method is read-only and needs no CSRF token
+func isSafeMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// Sources a request's tenant can be resolved from, exposed as the
+// "tenant_source" local
+const (
+	TenantSourceHeader    = "header"
+	TenantSourceSubdomain = "subdomain"
+	TenantSourceToken     = "token"
+	TenantSourceDefault   = "default"
+)
+
+// TenantResolution describes how requests name their tenant
+type TenantResolution struct {
+	// Header carries the tenant slug, e.g. X-Tenant; empty disables it
+	Header string
+
+	// BaseDomain makes requests to <slug>.<BaseDomain> belong to that
+	// tenant; empty disables subdomains
+	BaseDomain string
+}
+
+// TenantMiddleware scopes the request to the tenant named by the tenant
+// header or, failing that, the subdomain. Requests that name neither belong
+// to the default tenant until AuthMiddleware adopts the tenant of their
+// credentials. Unknown and inactive tenants are rejected with 404.
+func TenantMiddleware(tenants domains.TenantUsecase, resolution TenantResolution) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tenantID, source := domains.DefaultTenantID, TenantSourceDefault
+
+		slug, named := resolution.slug(c)
+		if named != "" {
+			tenant, err := tenants.ResolveTenant(c.UserContext(), slug)
+			if err != nil {
+				apiErr := errors.AsAPIError(err)
+				return c.Status(apiErr.StatusCode).JSON(apiErr)
+			}
+			tenantID, source = tenant.ID, named
+		}
+
+		setTenant(c, tenantID, source)
+
+		return c.Next()
+	}
+}
+
+// slug returns the tenant slug a request names and where it was found
+func (r TenantResolution) slug(c *fiber.Ctx) (string, string) {
+	if r.Header != "" {
+		if slug := strings.TrimSpace(c.Get(r.Header)); slug != "" {
+			return slug, TenantSourceHeader
+		}
+	}
+
+	if r.BaseDomain != "" {
+		host := c.Hostname()
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		label, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(r.BaseDomain))
+		if ok && label != "" && !strings.Contains(label, ".") {
+			return label, TenantSourceSubdomain
+		}
+	}
+
+	return "", ""
+}
+
+// bindTenant checks that an authenticated principal belongs to the tenant
+// the request named. Requests that named none adopt the principal's tenant;
+// principals without one, such as tokens issued before multi-tenancy, belong
+// to the default tenant.
+func bindTenant(c *fiber.Ctx, principal *domains.Principal) *errors.APIError {
+	if principal.TenantID == "" {
+		principal.TenantID = domains.DefaultTenantID
+	}
+
+	current, ok := domains.TenantFromContext(c.UserContext())
+	if ok && current == principal.TenantID {
+		return nil
+	}
+
+	if source, _ := c.Locals("tenant_source").(string); ok && source != TenantSourceDefault {
+		return errors.NewUnauthorizedError("credentials belong to another tenant")
+	}
+
+	setTenant(c, principal.TenantID, TenantSourceToken)
+
+	return nil
+}
+
+// setTenant exposes the tenant to downstream handlers via Locals, and scopes
+// the user context, and with it every user query, to it
+func setTenant(c *fiber.Ctx, tenantID, source string) {
+	c.Locals("tenant_id", tenantID)
+	c.Locals("tenant_source", source)
+	c.SetUserContext(domains.WithTenant(c.UserContext(), tenantID))
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// APIKeyRepository implements the domains.APIKeyRepository interface using sqlc
+type APIKeyRepository struct {
+	queries *db.Queries
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(queries *db.Queries) *APIKeyRepository {
+	return &APIKeyRepository{
+		queries: queries,
+	}
+}
+
+// Create stores a new API key
+func (r *APIKeyRepository) Create(ctx context.Context, key *domains.APIKey) error {
+	return r.queries.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		ID:         key.ID,
+		UserID:     key.UserID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		SecretHash: key.SecretHash,
+		Scopes:     strings.Join(key.Scopes, " "),
+		ExpiresAt:  r.timeToNullTime(key.ExpiresAt),
+	})
+}
+
+// GetByID retrieves an API key by its ID
+func (r *APIKeyRepository) GetByID(ctx context.Context, id string) (*domains.APIKey, error) {
+	dbKey, err := r.queries.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Key not found
+		}
+		return nil, err
+	}
+
+	return r.dbAPIKeyToDomain(dbKey), nil
+}
+
+// GetByPrefix retrieves an API key by the public prefix of its value
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domains.APIKey, error) {
+	dbKey, err := r.queries.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Key not found
+		}
+		return nil, err
+	}
+
+	return r.dbAPIKeyToDomain(dbKey), nil
+}
+
+// ListByUser retrieves the API keys owned by a user, newest first
+func (r *APIKeyRepository) ListByUser(ctx context.Context, userID string) ([]*domains.APIKey, error) {
+	dbKeys, err := r.queries.ListAPIKeysByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*domains.APIKey, len(dbKeys))
+	for i, dbKey := range dbKeys {
+		keys[i] = r.dbAPIKeyToDomain(dbKey)
+	}
+
+	return keys, nil
+}
+
+// Update stores the name, scopes and expiry of an API key
+func (r *APIKeyRepository) Update(ctx context.Context, key *domains.APIKey) error {
+	return r.queries.UpdateAPIKey(ctx, db.UpdateAPIKeyParams{
+		ID:        key.ID,
+		Name:      key.Name,
+		Scopes:    strings.Join(key.Scopes, " "),
+		ExpiresAt: r.timeToNullTime(key.ExpiresAt),
+	})
+}
+
+// Delete removes an API key
+func (r *APIKeyRepository) Delete(ctx context.Context, id string) error {
+	return r.queries.DeleteAPIKey(ctx, id)
+}
+
+// TouchLastUsed records a use of the key unless one was recorded after staleBefore
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string, usedAt, staleBefore time.Time) error {
+	return r.queries.TouchAPIKeyLastUsed(ctx, db.TouchAPIKeyLastUsedParams{
+		ID:          id,
+		UsedAt:      sql.NullTime{Time: usedAt, Valid: true},
+		StaleBefore: sql.NullTime{Time: staleBefore, Valid: true},
+	})
+}
+
+// Helper functions
+
+func (r *APIKeyRepository) dbAPIKeyToDomain(dbKey db.ApiKey) *domains.APIKey {
+	return &domains.APIKey{
+		ID:         dbKey.ID,
+		UserID:     dbKey.UserID,
+		Name:       dbKey.Name,
+		Prefix:     dbKey.Prefix,
+		SecretHash: dbKey.SecretHash,
+		Scopes:     strings.Fields(dbKey.Scopes),
+		ExpiresAt:  r.nullTimeToPointer(dbKey.ExpiresAt),
+		LastUsedAt: r.nullTimeToPointer(dbKey.LastUsedAt),
+		CreatedAt:  dbKey.CreatedAt.Time,
+	}
+}
+
+func (r *APIKeyRepository) timeToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func (r *APIKeyRepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// EmailVerificationTokenRepository implements the domains.EmailVerificationTokenRepository interface using sqlc
+type EmailVerificationTokenRepository struct {
+	queries *db.Queries
+}
+
+// NewEmailVerificationTokenRepository creates a new email verification token repository
+func NewEmailVerificationTokenRepository(queries *db.Queries) *EmailVerificationTokenRepository {
+	return &EmailVerificationTokenRepository{
+		queries: queries,
+	}
+}
+
+// Create stores a new verification token
+func (r *EmailVerificationTokenRepository) Create(ctx context.Context, token *domains.EmailVerificationToken) error {
+	return r.queries.CreateEmailVerificationToken(ctx, db.CreateEmailVerificationTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TenantID:  token.TenantID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+}
+
+// GetByHash retrieves a verification token by the hash of its opaque value
+func (r *EmailVerificationTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.EmailVerificationToken, error) {
+	dbToken, err := r.queries.GetEmailVerificationTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Token not found
+		}
+		return nil, err
+	}
+
+	return r.dbTokenToDomain(dbToken), nil
+}
+
+// GetLatestForUser retrieves the most recently issued token of a user
+func (r *EmailVerificationTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.EmailVerificationToken, error) {
+	dbToken, err := r.queries.GetLatestEmailVerificationToken(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No token issued yet
+		}
+		return nil, err
+	}
+
+	return r.dbTokenToDomain(dbToken), nil
+}
+
+// MarkUsed consumes a token; it reports false if the token was already used
+func (r *EmailVerificationTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	rows, err := r.queries.MarkEmailVerificationTokenUsed(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// InvalidateForUser consumes every outstanding token of a user
+func (r *EmailVerificationTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	return r.queries.InvalidateUserEmailVerificationTokens(ctx, userID)
+}
+
+// Helper functions
+
+func (r *EmailVerificationTokenRepository) dbTokenToDomain(dbToken db.EmailVerificationToken) *domains.EmailVerificationToken {
+	return &domains.EmailVerificationToken{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		TenantID:  dbToken.TenantID,
+		TokenHash: dbToken.TokenHash,
+		ExpiresAt: dbToken.ExpiresAt,
+		UsedAt:    r.nullTimeToPointer(dbToken.UsedAt),
+		CreatedAt: dbToken.CreatedAt.Time,
+	}
+}
+
+func (r *EmailVerificationTokenRepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
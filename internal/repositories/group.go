@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// GroupRepository implements the domains.GroupRepository interface using sqlc
+type GroupRepository struct {
+	queries *db.Queries
+	users   *UserRepository // converts members
+}
+
+// NewGroupRepository creates a new group repository
+func NewGroupRepository(queries *db.Queries) *GroupRepository {
+	return &GroupRepository{
+		queries: queries,
+		users:   NewUserRepository(queries),
+	}
+}
+
+// Create stores a new group in the tenant of the context
+func (r *GroupRepository) Create(ctx context.Context, group *domains.Group) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := r.queries.CreateGroup(ctx, db.CreateGroupParams{
+		ID:          group.ID,
+		TenantID:    tenantID,
+		Name:        group.Name,
+		Description: r.stringToNullString(group.Description),
+	}); err != nil {
+		return err
+	}
+
+	group.TenantID = tenantID
+	return nil
+}
+
+// GetByID retrieves a group and its roles by ID
+func (r *GroupRepository) GetByID(ctx context.Context, id string) (*domains.Group, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbGroup, err := r.queries.GetGroupByID(ctx, db.GetGroupByIDParams{
+		TenantID: tenantID,
+		ID:       id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Group not found
+		}
+		return nil, err
+	}
+
+	return r.withRoles(ctx, dbGroup)
+}
+
+// GetByName retrieves a group and its roles by name
+func (r *GroupRepository) GetByName(ctx context.Context, name string) (*domains.Group, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbGroup, err := r.queries.GetGroupByName(ctx, db.GetGroupByNameParams{
+		TenantID: tenantID,
+		Name:     name,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Group not found
+		}
+		return nil, err
+	}
+
+	return r.withRoles(ctx, dbGroup)
+}
+
+// List retrieves a paginated list of groups and their roles ordered by name
+func (r *GroupRepository) List(ctx context.Context, limit, offset int32) ([]*domains.Group, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbGroups, err := r.queries.ListGroups(ctx, db.ListGroupsParams{
+		TenantID: tenantID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*domains.Group, len(dbGroups))
+	for i, dbGroup := range dbGroups {
+		if groups[i], err = r.withRoles(ctx, dbGroup); err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
+
+// Update updates the name and description of a group
+func (r *GroupRepository) Update(ctx context.Context, group *domains.Group) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.UpdateGroup(ctx, db.UpdateGroupParams{
+		Name:        group.Name,
+		Description: r.stringToNullString(group.Description),
+		TenantID:    tenantID,
+		ID:          group.ID,
+	})
+}
+
+// Delete deletes a group along with its memberships and roles
+func (r *GroupRepository) Delete(ctx context.Context, id string) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.DeleteGroup(ctx, db.DeleteGroupParams{
+		TenantID: tenantID,
+		ID:       id,
+	})
+}
+
+// AddMember adds a user to a group; adding a member is a no-op. Callers
+// must have looked both up in the tenant of the context.
+func (r *GroupRepository) AddMember(ctx context.Context, groupID, userID string) error {
+	if _, err := tenantFromContext(ctx); err != nil {
+		return err
+	}
+
+	return r.queries.AddGroupMember(ctx, db.AddGroupMemberParams{
+		GroupID: groupID,
+		UserID:  userID,
+	})
+}
+
+// RemoveMember removes a user from a group; it reports false if the user was
+// not a member
+func (r *GroupRepository) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := r.queries.RemoveGroupMember(ctx, db.RemoveGroupMemberParams{
+		TenantID: tenantID,
+		GroupID:  groupID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// ListMembers retrieves a paginated list of the members of a group ordered
+// by email
+func (r *GroupRepository) ListMembers(ctx context.Context, groupID string, limit, offset int32) ([]*domains.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbUsers, err := r.queries.ListGroupMembers(ctx, db.ListGroupMembersParams{
+		TenantID: tenantID,
+		GroupID:  groupID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domains.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i] = r.users.dbUserToDomain(dbUser)
+	}
+
+	return users, nil
+}
+
+// ListMemberIDs returns the IDs of every member of a group
+func (r *GroupRepository) ListMemberIDs(ctx context.Context, groupID string) ([]string, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListGroupMemberIDs(ctx, db.ListGroupMemberIDsParams{
+		TenantID: tenantID,
+		GroupID:  groupID,
+	})
+}
+
+// AddRole grants a role to the members of a group; adding a held role is a
+// no-op. Callers must have looked the group up in the tenant of the context.
+func (r *GroupRepository) AddRole(ctx context.Context, groupID, role string) error {
+	if _, err := tenantFromContext(ctx); err != nil {
+		return err
+	}
+
+	return r.queries.AddGroupRole(ctx, db.AddGroupRoleParams{
+		GroupID:  groupID,
+		RoleName: role,
+	})
+}
+
+// RemoveRole withdraws a role from a group; it reports false if the group
+// did not hold it
+func (r *GroupRepository) RemoveRole(ctx context.Context, groupID, role string) (bool, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := r.queries.RemoveGroupRole(ctx, db.RemoveGroupRoleParams{
+		TenantID: tenantID,
+		GroupID:  groupID,
+		RoleName: role,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// Helper functions
+
+// withRoles converts a group and loads the roles it grants
+func (r *GroupRepository) withRoles(ctx context.Context, dbGroup db.UserGroup) (*domains.Group, error) {
+	roles, err := r.queries.GetGroupRoles(ctx, dbGroup.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domains.Group{
+		ID:          dbGroup.ID,
+		TenantID:    dbGroup.TenantID,
+		Name:        dbGroup.Name,
+		Description: r.nullStringToPointer(dbGroup.Description),
+		Roles:       roles,
+		CreatedAt:   dbGroup.CreatedAt.Time,
+		UpdatedAt:   dbGroup.UpdatedAt.Time,
+	}, nil
+}
+
+func (r *GroupRepository) stringToNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func (r *GroupRepository) nullStringToPointer(ns sql.NullString) *string {
+	if !ns.Valid {
+		return nil
+	}
+	return &ns.String
+}
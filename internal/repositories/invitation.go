@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// InvitationRepository implements the domains.InvitationRepository interface using sqlc
+type InvitationRepository struct {
+	queries *db.Queries
+}
+
+// NewInvitationRepository creates a new invitation repository
+func NewInvitationRepository(queries *db.Queries) *InvitationRepository {
+	return &InvitationRepository{
+		queries: queries,
+	}
+}
+
+// Create stores a new pending invitation in the tenant of the context
+func (r *InvitationRepository) Create(ctx context.Context, invitation *domains.Invitation) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := r.queries.CreateInvitation(ctx, db.CreateInvitationParams{
+		ID:        invitation.ID,
+		TenantID:  tenantID,
+		Email:     invitation.Email,
+		RoleName:  invitation.Role,
+		TokenHash: invitation.TokenHash,
+		InvitedBy: r.stringToNullString(invitation.InvitedBy),
+		ExpiresAt: invitation.ExpiresAt,
+	}); err != nil {
+		return err
+	}
+
+	invitation.TenantID = tenantID
+	invitation.Status = domains.InvitationPending
+	return nil
+}
+
+// GetByID retrieves an invitation by its ID
+func (r *InvitationRepository) GetByID(ctx context.Context, id string) (*domains.Invitation, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInvitation, err := r.queries.GetInvitationByID(ctx, db.GetInvitationByIDParams{
+		TenantID: tenantID,
+		ID:       id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Invitation not found
+		}
+		return nil, err
+	}
+
+	return r.dbInvitationToDomain(dbInvitation), nil
+}
+
+// GetByHash retrieves an invitation by the hash of its opaque token
+func (r *InvitationRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.Invitation, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInvitation, err := r.queries.GetInvitationByHash(ctx, db.GetInvitationByHashParams{
+		TenantID:  tenantID,
+		TokenHash: tokenHash,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Invitation not found
+		}
+		return nil, err
+	}
+
+	return r.dbInvitationToDomain(dbInvitation), nil
+}
+
+// GetPendingByEmail retrieves the pending invitation of an email address
+func (r *InvitationRepository) GetPendingByEmail(ctx context.Context, email string) (*domains.Invitation, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInvitation, err := r.queries.GetPendingInvitationByEmail(ctx, db.GetPendingInvitationByEmailParams{
+		TenantID: tenantID,
+		Email:    email,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No pending invitation
+		}
+		return nil, err
+	}
+
+	return r.dbInvitationToDomain(dbInvitation), nil
+}
+
+// ListPending retrieves a paginated list of pending invitations, newest first
+func (r *InvitationRepository) ListPending(ctx context.Context, limit, offset int32) ([]*domains.Invitation, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInvitations, err := r.queries.ListPendingInvitations(ctx, db.ListPendingInvitationsParams{
+		TenantID: tenantID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*domains.Invitation, len(dbInvitations))
+	for i, dbInvitation := range dbInvitations {
+		invitations[i] = r.dbInvitationToDomain(dbInvitation)
+	}
+
+	return invitations, nil
+}
+
+// Renew replaces the token and expiry of a pending invitation; it reports
+// false if the invitation is no longer pending
+func (r *InvitationRepository) Renew(ctx context.Context, id, tokenHash string, expiresAt time.Time) (bool, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := r.queries.RenewInvitation(ctx, db.RenewInvitationParams{
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		TenantID:  tenantID,
+		ID:        id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// Respond moves a pending invitation to a final status; it reports false if
+// the invitation is no longer pending
+func (r *InvitationRepository) Respond(ctx context.Context, id, status string) (bool, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := r.queries.RespondToInvitation(ctx, db.RespondToInvitationParams{
+		Status:   status,
+		TenantID: tenantID,
+		ID:       id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// Helper functions
+
+func (r *InvitationRepository) dbInvitationToDomain(dbInvitation db.Invitation) *domains.Invitation {
+	return &domains.Invitation{
+		ID:          dbInvitation.ID,
+		TenantID:    dbInvitation.TenantID,
+		Email:       dbInvitation.Email,
+		Role:        dbInvitation.RoleName,
+		TokenHash:   dbInvitation.TokenHash,
+		InvitedBy:   r.nullStringToPointer(dbInvitation.InvitedBy),
+		Status:      dbInvitation.Status,
+		ExpiresAt:   dbInvitation.ExpiresAt,
+		RespondedAt: r.nullTimeToPointer(dbInvitation.RespondedAt),
+		CreatedAt:   dbInvitation.CreatedAt.Time,
+		UpdatedAt:   dbInvitation.UpdatedAt.Time,
+	}
+}
+
+func (r *InvitationRepository) stringToNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func (r *InvitationRepository) nullStringToPointer(ns sql.NullString) *string {
+	if !ns.Valid {
+		return nil
+	}
+	return &ns.String
+}
+
+func (r *InvitationRepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// LoginAttemptRepository implements the domains.LoginAttemptStore interface using sqlc
+type LoginAttemptRepository struct {
+	queries *db.Queries
+}
+
+// NewLoginAttemptRepository creates a new SQL backed login attempt store
+func NewLoginAttemptRepository(queries *db.Queries) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		queries: queries,
+	}
+}
+
+// Get retrieves a counter; it returns nil if there were no recent failures
+func (r *LoginAttemptRepository) Get(ctx context.Context, account, ip string) (*domains.LoginAttempt, error) {
+	dbAttempt, err := r.queries.GetLoginAttempt(ctx, db.GetLoginAttemptParams{
+		Account:   account,
+		IpAddress: ip,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No recent failures
+		}
+		return nil, err
+	}
+
+	return &domains.LoginAttempt{
+		Account:      dbAttempt.Account,
+		IPAddress:    dbAttempt.IpAddress,
+		Failures:     int(dbAttempt.Failures),
+		LastFailedAt: dbAttempt.LastFailedAt,
+		LockedUntil:  r.nullTimeToPointer(dbAttempt.LockedUntil),
+	}, nil
+}
+
+// RecordFailure counts a failed attempt and returns the updated counter
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, account, ip string, windowStart time.Time) (*domains.LoginAttempt, error) {
+	// Counters that are neither recent nor locked are no longer needed
+	if err := r.queries.DeleteStaleLoginAttempts(ctx, windowStart); err != nil {
+		return nil, err
+	}
+
+	err := r.queries.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+		Account:     account,
+		IpAddress:   ip,
+		FailedAt:    time.Now(),
+		WindowStart: windowStart,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Get(ctx, account, ip)
+}
+
+// Lock rejects further attempts on a counter until the given time
+func (r *LoginAttemptRepository) Lock(ctx context.Context, account, ip string, until time.Time) error {
+	return r.queries.LockLoginAttempt(ctx, db.LockLoginAttemptParams{
+		LockedUntil: sql.NullTime{Time: until, Valid: true},
+		Account:     account,
+		IpAddress:   ip,
+	})
+}
+
+// Reset clears a counter
+func (r *LoginAttemptRepository) Reset(ctx context.Context, account, ip string) error {
+	return r.queries.DeleteLoginAttempt(ctx, db.DeleteLoginAttemptParams{
+		Account:   account,
+		IpAddress: ip,
+	})
+}
+
+// ResetAccount clears every counter of an account
+func (r *LoginAttemptRepository) ResetAccount(ctx context.Context, account string) error {
+	return r.queries.DeleteLoginAttemptsByAccount(ctx, account)
+}
+
+// Helper functions
+
+func (r *LoginAttemptRepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
+
+// MemoryLoginAttemptStore implements the domains.LoginAttemptStore interface in process memory.
+// It is suitable for single instance deployments and tests.
+type MemoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[loginAttemptKey]*domains.LoginAttempt
+}
+
+type loginAttemptKey struct {
+	account string
+	ip      string
+}
+
+// NewMemoryLoginAttemptStore creates a new in-memory login attempt store
+func NewMemoryLoginAttemptStore() *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{
+		attempts: make(map[loginAttemptKey]*domains.LoginAttempt),
+	}
+}
+
+// Get retrieves a counter; it returns nil if there were no recent failures
+func (s *MemoryLoginAttemptStore) Get(ctx context.Context, account, ip string) (*domains.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[loginAttemptKey{account, ip}]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *attempt
+	return &copied, nil
+}
+
+// RecordFailure counts a failed attempt and returns the updated counter
+func (s *MemoryLoginAttemptStore) RecordFailure(ctx context.Context, account, ip string, windowStart time.Time) (*domains.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, attempt := range s.attempts {
+		if attempt.LastFailedAt.Before(windowStart) && (attempt.LockedUntil == nil || attempt.LockedUntil.Before(now)) {
+			delete(s.attempts, key)
+		}
+	}
+
+	key := loginAttemptKey{account, ip}
+	attempt, ok := s.attempts[key]
+	if !ok {
+		attempt = &domains.LoginAttempt{Account: account, IPAddress: ip}
+		s.attempts[key] = attempt
+	}
+
+	if attempt.LastFailedAt.Before(windowStart) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailedAt = now
+
+	copied := *attempt
+	return &copied, nil
+}
+
+// Lock rejects further attempts on a counter until the given time
+func (s *MemoryLoginAttemptStore) Lock(ctx context.Context, account, ip string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempt, ok := s.attempts[loginAttemptKey{account, ip}]; ok {
+		attempt.LockedUntil = &until
+	}
+	return nil
+}
+
+// Reset clears a counter
+func (s *MemoryLoginAttemptStore) Reset(ctx context.Context, account, ip string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, loginAttemptKey{account, ip})
+	return nil
+}
+
+// ResetAccount clears every counter of an account
+func (s *MemoryLoginAttemptStore) ResetAccount(ctx context.Context, account string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.attempts {
+		if key.account == account {
+			delete(s.attempts, key)
+		}
+	}
+	return nil
+}
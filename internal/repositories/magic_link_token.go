@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// MagicLinkTokenRepository implements the domains.MagicLinkTokenRepository interface using sqlc
+type MagicLinkTokenRepository struct {
+	queries *db.Queries
+}
+
+// NewMagicLinkTokenRepository creates a new magic link token repository
+func NewMagicLinkTokenRepository(queries *db.Queries) *MagicLinkTokenRepository {
+	return &MagicLinkTokenRepository{
+		queries: queries,
+	}
+}
+
+// Create stores a new login token
+func (r *MagicLinkTokenRepository) Create(ctx context.Context, token *domains.MagicLinkToken) error {
+	return r.queries.CreateMagicLinkToken(ctx, db.CreateMagicLinkTokenParams{
+		ID:          token.ID,
+		UserID:      token.UserID,
+		TenantID:    token.TenantID,
+		TokenHash:   token.TokenHash,
+		BindingHash: token.BindingHash,
+		ExpiresAt:   token.ExpiresAt,
+	})
+}
+
+// GetByHash retrieves a login token by the hash of its opaque value
+func (r *MagicLinkTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.MagicLinkToken, error) {
+	dbToken, err := r.queries.GetMagicLinkTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Token not found
+		}
+		return nil, err
+	}
+
+	return r.dbTokenToDomain(dbToken), nil
+}
+
+// GetLatestForUser retrieves the most recently issued token of a user
+func (r *MagicLinkTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.MagicLinkToken, error) {
+	dbToken, err := r.queries.GetLatestMagicLinkToken(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No token issued yet
+		}
+		return nil, err
+	}
+
+	return r.dbTokenToDomain(dbToken), nil
+}
+
+// MarkUsed consumes a token; it reports false if the token was already used
+func (r *MagicLinkTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	rows, err := r.queries.MarkMagicLinkTokenUsed(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// InvalidateForUser consumes every outstanding token of a user
+func (r *MagicLinkTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	return r.queries.InvalidateUserMagicLinkTokens(ctx, userID)
+}
+
+// Helper functions
+
+func (r *MagicLinkTokenRepository) dbTokenToDomain(dbToken db.MagicLinkToken) *domains.MagicLinkToken {
+	return &domains.MagicLinkToken{
+		ID:          dbToken.ID,
+		UserID:      dbToken.UserID,
+		TenantID:    dbToken.TenantID,
+		TokenHash:   dbToken.TokenHash,
+		BindingHash: dbToken.BindingHash,
+		ExpiresAt:   dbToken.ExpiresAt,
+		UsedAt:      r.nullTimeToPointer(dbToken.UsedAt),
+		CreatedAt:   dbToken.CreatedAt.Time,
+	}
+}
+
+func (r *MagicLinkTokenRepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
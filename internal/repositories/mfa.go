@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// MFARepository implements the domains.MFARepository interface using sqlc
+type MFARepository struct {
+	queries *db.Queries
+}
+
+// NewMFARepository creates a new MFA repository
+func NewMFARepository(queries *db.Queries) *MFARepository {
+	return &MFARepository{
+		queries: queries,
+	}
+}
+
+// GetFactor retrieves the TOTP factor of a user, pending or confirmed
+func (r *MFARepository) GetFactor(ctx context.Context, userID string) (*domains.MFAFactor, error) {
+	dbFactor, err := r.queries.GetUserMFA(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Not enrolled
+		}
+		return nil, err
+	}
+
+	return &domains.MFAFactor{
+		UserID:       dbFactor.UserID,
+		Secret:       dbFactor.Secret,
+		ConfirmedAt:  r.nullTimeToPointer(dbFactor.ConfirmedAt),
+		LastUsedStep: dbFactor.LastUsedStep,
+		CreatedAt:    dbFactor.CreatedAt.Time,
+	}, nil
+}
+
+// SaveFactor stores a pending factor, replacing any earlier enrollment
+func (r *MFARepository) SaveFactor(ctx context.Context, userID, secret string) error {
+	return r.queries.UpsertUserMFA(ctx, db.UpsertUserMFAParams{
+		UserID: userID,
+		Secret: secret,
+	})
+}
+
+// ConfirmFactor activates a pending factor; it reports false if there was none
+func (r *MFARepository) ConfirmFactor(ctx context.Context, userID string) (bool, error) {
+	rows, err := r.queries.ConfirmUserMFA(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// AdvanceStep records the last accepted TOTP step; it reports false if the
+// step is not newer than the recorded one
+func (r *MFARepository) AdvanceStep(ctx context.Context, userID string, step int64) (bool, error) {
+	rows, err := r.queries.AdvanceUserMFAStep(ctx, db.AdvanceUserMFAStepParams{
+		Step:   step,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// DeleteFactor removes the factor and the recovery codes of a user
+func (r *MFARepository) DeleteFactor(ctx context.Context, userID string) error {
+	if err := r.queries.DeleteUserMFA(ctx, userID); err != nil {
+		return err
+	}
+	return r.queries.DeleteMFARecoveryCodes(ctx, userID)
+}
+
+// ReplaceRecoveryCodes discards the recovery codes of a user and stores new ones
+func (r *MFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	if err := r.queries.DeleteMFARecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		err := r.queries.CreateMFARecoveryCode(ctx, db.CreateMFARecoveryCodeParams{
+			ID:       uuid.New().String(),
+			UserID:   userID,
+			CodeHash: codeHash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UseRecoveryCode consumes a recovery code; it reports false if there was no unused match
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	rows, err := r.queries.UseMFARecoveryCode(ctx, db.UseMFARecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// CountRecoveryCodes returns the number of unused recovery codes of a user
+func (r *MFARepository) CountRecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	return r.queries.CountUnusedMFARecoveryCodes(ctx, userID)
+}
+
+// CreateChallenge stores a pending second factor login
+func (r *MFARepository) CreateChallenge(ctx context.Context, challenge *domains.MFAChallenge) error {
+	return r.queries.CreateMFAChallenge(ctx, db.CreateMFAChallengeParams{
+		ID:        challenge.ID,
+		UserID:    challenge.UserID,
+		TenantID:  challenge.TenantID,
+		TokenHash: challenge.TokenHash,
+		ExpiresAt: challenge.ExpiresAt,
+	})
+}
+
+// GetChallengeByHash retrieves a challenge by the hash of its opaque token
+func (r *MFARepository) GetChallengeByHash(ctx context.Context, tokenHash string) (*domains.MFAChallenge, error) {
+	dbChallenge, err := r.queries.GetMFAChallengeByHash(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Challenge not found
+		}
+		return nil, err
+	}
+
+	return &domains.MFAChallenge{
+		ID:        dbChallenge.ID,
+		UserID:    dbChallenge.UserID,
+		TenantID:  dbChallenge.TenantID,
+		TokenHash: dbChallenge.TokenHash,
+		Attempts:  int(dbChallenge.Attempts),
+		ExpiresAt: dbChallenge.ExpiresAt,
+		UsedAt:    r.nullTimeToPointer(dbChallenge.UsedAt),
+		CreatedAt: dbChallenge.CreatedAt.Time,
+	}, nil
+}
+
+// IncrementChallengeAttempts records a second factor attempt; it reports
+// false if the challenge already had limit attempts
+func (r *MFARepository) IncrementChallengeAttempts(ctx context.Context, id string, limit int) (bool, error) {
+	rows, err := r.queries.IncrementMFAChallengeAttempts(ctx, db.IncrementMFAChallengeAttemptsParams{
+		ID:       id,
+		Attempts: int32(limit),
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// MarkChallengeUsed completes a challenge; it reports false if it was already used
+func (r *MFARepository) MarkChallengeUsed(ctx context.Context, id string) (bool, error) {
+	rows, err := r.queries.MarkMFAChallengeUsed(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// Helper functions
+
+func (r *MFARepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
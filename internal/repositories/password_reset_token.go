@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// PasswordResetTokenRepository implements the domains.PasswordResetTokenRepository interface using sqlc
+type PasswordResetTokenRepository struct {
+	queries *db.Queries
+}
+
+// NewPasswordResetTokenRepository creates a new password reset token repository
+func NewPasswordResetTokenRepository(queries *db.Queries) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{
+		queries: queries,
+	}
+}
+
+// Create stores a new reset token
+func (r *PasswordResetTokenRepository) Create(ctx context.Context, token *domains.PasswordResetToken) error {
+	return r.queries.CreatePasswordResetToken(ctx, db.CreatePasswordResetTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TenantID:  token.TenantID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+}
+
+// GetByHash retrieves a reset token by the hash of its opaque value
+func (r *PasswordResetTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.PasswordResetToken, error) {
+	dbToken, err := r.queries.GetPasswordResetTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Token not found
+		}
+		return nil, err
+	}
+
+	return r.dbTokenToDomain(dbToken), nil
+}
+
+// GetLatestForUser retrieves the most recently issued token of a user
+func (r *PasswordResetTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.PasswordResetToken, error) {
+	dbToken, err := r.queries.GetLatestPasswordResetToken(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No token issued yet
+		}
+		return nil, err
+	}
+
+	return r.dbTokenToDomain(dbToken), nil
+}
+
+// MarkUsed consumes a token; it reports false if the token was already used
+func (r *PasswordResetTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	rows, err := r.queries.MarkPasswordResetTokenUsed(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// InvalidateForUser consumes every outstanding token of a user
+func (r *PasswordResetTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	return r.queries.InvalidateUserPasswordResetTokens(ctx, userID)
+}
+
+// Helper functions
+
+func (r *PasswordResetTokenRepository) dbTokenToDomain(dbToken db.PasswordResetToken) *domains.PasswordResetToken {
+	return &domains.PasswordResetToken{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		TenantID:  dbToken.TenantID,
+		TokenHash: dbToken.TokenHash,
+		ExpiresAt: dbToken.ExpiresAt,
+		UsedAt:    r.nullTimeToPointer(dbToken.UsedAt),
+		CreatedAt: dbToken.CreatedAt.Time,
+	}
+}
+
+func (r *PasswordResetTokenRepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// RefreshTokenRepository implements the domains.RefreshTokenRepository interface using sqlc
+type RefreshTokenRepository struct {
+	queries *db.Queries
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(queries *db.Queries) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		queries: queries,
+	}
+}
+
+// Create stores a new refresh token
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *domains.RefreshToken) error {
+	return r.queries.CreateRefreshToken(ctx, db.CreateRefreshTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TenantID:  token.TenantID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+}
+
+// GetByHash retrieves a refresh token by the hash of its opaque value
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.RefreshToken, error) {
+	dbToken, err := r.queries.GetRefreshTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Token not found
+		}
+		return nil, err
+	}
+
+	return r.dbRefreshTokenToDomain(dbToken), nil
+}
+
+// MarkUsed flags a token as rotated; it reports false if the token was already used or revoked
+func (r *RefreshTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	rows, err := r.queries.MarkRefreshTokenUsed(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// RevokeFamily revokes every token in a rotation family
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.queries.RevokeRefreshTokenFamily(ctx, familyID)
+}
+
+// RevokeAllForUser revokes every refresh token owned by a user
+func (r *RefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	return r.queries.RevokeUserRefreshTokens(ctx, userID)
+}
+
+// Helper functions
+
+func (r *RefreshTokenRepository) dbRefreshTokenToDomain(dbToken db.RefreshToken) *domains.RefreshToken {
+	return &domains.RefreshToken{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		TenantID:  dbToken.TenantID,
+		FamilyID:  dbToken.FamilyID,
+		TokenHash: dbToken.TokenHash,
+		ExpiresAt: dbToken.ExpiresAt,
+		UsedAt:    r.nullTimeToPointer(dbToken.UsedAt),
+		RevokedAt: r.nullTimeToPointer(dbToken.RevokedAt),
+		CreatedAt: dbToken.CreatedAt.Time,
+	}
+}
+
+func (r *RefreshTokenRepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
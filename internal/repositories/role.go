@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// RoleRepository implements the domains.RoleRepository interface using sqlc
+type RoleRepository struct {
+	queries *db.Queries
+}
+
+// NewRoleRepository creates a new role repository
+func NewRoleRepository(queries *db.Queries) *RoleRepository {
+	return &RoleRepository{
+		queries: queries,
+	}
+}
+
+// GetUserRoles returns the names of the roles assigned to a user
+func (r *RoleRepository) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	return r.queries.GetUserRoles(ctx, userID)
+}
+
+// GetUserPermissions returns the permissions granted by all of a user's roles
+func (r *RoleRepository) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
+	return r.queries.GetUserPermissions(ctx, userID)
+}
+
+// AssignRole grants a role to a user; assigning a held role is a no-op
+func (r *RoleRepository) AssignRole(ctx context.Context, userID, role string) error {
+	return r.queries.AssignUserRole(ctx, db.AssignUserRoleParams{
+		UserID:   userID,
+		RoleName: role,
+	})
+}
+
+// GetRolePermissions returns the permissions a role grants; unknown roles
+// grant none
+func (r *RoleRepository) GetRolePermissions(ctx context.Context, role string) ([]string, error) {
+	return r.queries.GetRolePermissions(ctx, role)
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// SessionRepository implements the domains.SessionStore interface using sqlc
+type SessionRepository struct {
+	queries *db.Queries
+}
+
+// NewSessionRepository creates a new SQL backed session store
+func NewSessionRepository(queries *db.Queries) *SessionRepository {
+	return &SessionRepository{
+		queries: queries,
+	}
+}
+
+// Create stores a new session
+func (r *SessionRepository) Create(ctx context.Context, session *domains.Session) error {
+	// Sessions that ran out on their own are no longer needed
+	if err := r.queries.DeleteExpiredSessions(ctx, time.Now()); err != nil {
+		return err
+	}
+
+	return r.queries.CreateSession(ctx, db.CreateSessionParams{
+		ID:                session.ID,
+		UserID:            session.UserID,
+		TenantID:          session.TenantID,
+		Email:             session.Email,
+		Roles:             strings.Join(session.Roles, " "),
+		Permissions:       strings.Join(session.Permissions, " "),
+		CsrfToken:         session.CSRFToken,
+		ExpiresAt:         session.ExpiresAt,
+		AbsoluteExpiresAt: session.AbsoluteExpiresAt,
+		LastSeenAt:        session.LastSeenAt,
+		CreatedAt:         sql.NullTime{Time: session.CreatedAt, Valid: !session.CreatedAt.IsZero()},
+	})
+}
+
+// Get retrieves a session by its ID
+func (r *SessionRepository) Get(ctx context.Context, id string) (*domains.Session, error) {
+	dbSession, err := r.queries.GetSession(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Session not found
+		}
+		return nil, err
+	}
+
+	session := &domains.Session{
+		ID:                dbSession.ID,
+		UserID:            dbSession.UserID,
+		TenantID:          dbSession.TenantID,
+		Email:             dbSession.Email,
+		Roles:             strings.Fields(dbSession.Roles),
+		Permissions:       strings.Fields(dbSession.Permissions),
+		CSRFToken:         dbSession.CsrfToken,
+		ExpiresAt:         dbSession.ExpiresAt,
+		AbsoluteExpiresAt: dbSession.AbsoluteExpiresAt,
+		LastSeenAt:        dbSession.LastSeenAt,
+	}
+
+	if dbSession.CreatedAt.Valid {
+		session.CreatedAt = dbSession.CreatedAt.Time
+	}
+
+	return session, nil
+}
+
+// Update stores the roles, permissions, expiry and last activity of a session
+func (r *SessionRepository) Update(ctx context.Context, session *domains.Session) error {
+	return r.queries.UpdateSession(ctx, db.UpdateSessionParams{
+		Roles:       strings.Join(session.Roles, " "),
+		Permissions: strings.Join(session.Permissions, " "),
+		ExpiresAt:   session.ExpiresAt,
+		LastSeenAt:  session.LastSeenAt,
+		ID:          session.ID,
+	})
+}
+
+// Delete removes a session
+func (r *SessionRepository) Delete(ctx context.Context, id string) error {
+	return r.queries.DeleteSession(ctx, id)
+}
+
+// MemorySessionStore implements the domains.SessionStore interface in process memory.
+// It is suitable for single instance deployments and tests.
+type MemorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]domains.Session
+}
+
+// NewMemorySessionStore creates a new in-memory session store
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions: make(map[string]domains.Session),
+	}
+}
+
+// Create stores a new session
+func (s *MemorySessionStore) Create(ctx context.Context, session *domains.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, stored := range s.sessions {
+		if now.After(stored.ExpiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+
+	s.sessions[session.ID] = *session
+	return nil
+}
+
+// Get retrieves a session by its ID
+func (s *MemorySessionStore) Get(ctx context.Context, id string) (*domains.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+
+	// Hand out a copy so callers cannot modify the stored session
+	return &stored, nil
+}
+
+// Update stores the roles, permissions, expiry and last activity of a session
+func (s *MemorySessionStore) Update(ctx context.Context, session *domains.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.sessions[session.ID]
+	if !ok {
+		return nil
+	}
+
+	stored.Roles = session.Roles
+	stored.Permissions = session.Permissions
+	stored.ExpiresAt = session.ExpiresAt
+	stored.LastSeenAt = session.LastSeenAt
+	s.sessions[session.ID] = stored
+	return nil
+}
+
+// Delete removes a session
+func (s *MemorySessionStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, id)
+	return nil
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// TenantRepository implements the domains.TenantRepository interface using sqlc
+type TenantRepository struct {
+	queries *db.Queries
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(queries *db.Queries) *TenantRepository {
+	return &TenantRepository{
+		queries: queries,
+	}
+}
+
+// Create stores a new tenant
+func (r *TenantRepository) Create(ctx context.Context, tenant *domains.Tenant) error {
+	return r.queries.CreateTenant(ctx, db.CreateTenantParams{
+		ID:       tenant.ID,
+		Slug:     tenant.Slug,
+		Name:     tenant.Name,
+		IsActive: tenant.IsActive,
+	})
+}
+
+// GetByID retrieves a tenant by its ID
+func (r *TenantRepository) GetByID(ctx context.Context, id string) (*domains.Tenant, error) {
+	dbTenant, err := r.queries.GetTenantByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Tenant not found
+		}
+		return nil, err
+	}
+
+	return r.dbTenantToDomain(dbTenant), nil
+}
+
+// GetBySlug retrieves a tenant by its slug
+func (r *TenantRepository) GetBySlug(ctx context.Context, slug string) (*domains.Tenant, error) {
+	dbTenant, err := r.queries.GetTenantBySlug(ctx, slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Tenant not found
+		}
+		return nil, err
+	}
+
+	return r.dbTenantToDomain(dbTenant), nil
+}
+
+// List retrieves a paginated list of tenants ordered by slug
+func (r *TenantRepository) List(ctx context.Context, limit, offset int32) ([]*domains.Tenant, error) {
+	dbTenants, err := r.queries.ListTenants(ctx, db.ListTenantsParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := make([]*domains.Tenant, len(dbTenants))
+	for i, dbTenant := range dbTenants {
+		tenants[i] = r.dbTenantToDomain(dbTenant)
+	}
+
+	return tenants, nil
+}
+
+func (r *TenantRepository) dbTenantToDomain(dbTenant db.Tenant) *domains.Tenant {
+	return &domains.Tenant{
+		ID:        dbTenant.ID,
+		Slug:      dbTenant.Slug,
+		Name:      dbTenant.Name,
+		IsActive:  dbTenant.IsActive,
+		CreatedAt: dbTenant.CreatedAt.Time,
+		UpdatedAt: dbTenant.UpdatedAt.Time,
+	}
+}
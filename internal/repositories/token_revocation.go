@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// TokenRevocationRepository implements the domains.TokenRevocationStore interface using sqlc
+type TokenRevocationRepository struct {
+	queries *db.Queries
+}
+
+// NewTokenRevocationRepository creates a new SQL backed token revocation store
+func NewTokenRevocationRepository(queries *db.Queries) *TokenRevocationRepository {
+	return &TokenRevocationRepository{
+		queries: queries,
+	}
+}
+
+// RevokeToken denylists a single access token until it expires
+func (r *TokenRevocationRepository) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	// Entries for tokens that expired on their own are no longer needed
+	if err := r.queries.DeleteExpiredRevokedTokens(ctx, time.Now()); err != nil {
+		return err
+	}
+
+	return r.queries.RevokeToken(ctx, db.RevokeTokenParams{
+		Jti:       tokenID,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// RevokeUserTokens rejects every access token issued to a user up to now
+func (r *TokenRevocationRepository) RevokeUserTokens(ctx context.Context, userID string) error {
+	return r.queries.RevokeUserTokens(ctx, db.RevokeUserTokensParams{
+		UserID:        userID,
+		RevokedBefore: time.Now().Truncate(time.Second),
+	})
+}
+
+// IsRevoked reports whether an access token has been revoked
+func (r *TokenRevocationRepository) IsRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error) {
+	if tokenID != "" {
+		count, err := r.queries.CountRevokedToken(ctx, tokenID)
+		if err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	revocation, err := r.queries.GetUserTokenRevocation(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil // Never revoked
+		}
+		return false, err
+	}
+
+	return issuedBeforeCutoff(issuedAt, revocation.RevokedBefore), nil
+}
+
+// MemoryTokenRevocationStore implements the domains.TokenRevocationStore interface in process memory.
+// It is suitable for single instance deployments and tests.
+type MemoryTokenRevocationStore struct {
+	mu      sync.RWMutex
+	tokens  map[string]time.Time
+	cutoffs map[string]time.Time
+}
+
+// NewMemoryTokenRevocationStore creates a new in-memory token revocation store
+func NewMemoryTokenRevocationStore() *MemoryTokenRevocationStore {
+	return &MemoryTokenRevocationStore{
+		tokens:  make(map[string]time.Time),
+		cutoffs: make(map[string]time.Time),
+	}
+}
+
+// RevokeToken denylists a single access token until it expires
+func (s *MemoryTokenRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, exp := range s.tokens {
+		if now.After(exp) {
+			delete(s.tokens, id)
+		}
+	}
+
+	s.tokens[tokenID] = expiresAt
+	return nil
+}
+
+// RevokeUserTokens rejects every access token issued to a user up to now
+func (s *MemoryTokenRevocationStore) RevokeUserTokens(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cutoffs[userID] = time.Now().Truncate(time.Second)
+	return nil
+}
+
+// IsRevoked reports whether an access token has been revoked
+func (s *MemoryTokenRevocationStore) IsRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.tokens[tokenID]; ok && tokenID != "" {
+		return true, nil
+	}
+
+	cutoff, ok := s.cutoffs[userID]
+	if !ok {
+		return false, nil
+	}
+
+	return issuedBeforeCutoff(issuedAt, cutoff), nil
+}
+
+// issuedBeforeCutoff compares at the one second resolution of the JWT iat claim,
+// so a token issued in the same second as the revocation is rejected as well
+func issuedBeforeCutoff(issuedAt, cutoff time.Time) bool {
+	return !issuedAt.Truncate(time.Second).After(cutoff)
+}
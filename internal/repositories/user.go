@@ -3,13 +3,23 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
 	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
 )
 
-// UserRepository implements the domains.UserRepository interface using sqlc
+// mysqlDuplicateEntry is the MySQL and MariaDB error number of unique key
+// violations
+const mysqlDuplicateEntry = 1062
+
+// UserRepository implements the domains.UserRepository interface using sqlc.
+// Every query is scoped to the tenant of the context.
 type UserRepository struct {
 	queries *db.Queries
 }
@@ -23,7 +33,15 @@ func NewUserRepository(queries *db.Queries) *UserRepository {
 
 // GetByID retrieves a user by their ID
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*domains.User, error) {
-	dbUser, err := r.queries.GetUserByID(ctx, id)
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbUser, err := r.queries.GetUserByID(ctx, db.GetUserByIDParams{
+		TenantID: tenantID,
+		ID:       id,
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // User not found
@@ -36,7 +54,15 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*domains.User,
 
 // GetByEmail retrieves a user by their email address
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domains.User, error) {
-	dbUser, err := r.queries.GetUserByEmail(ctx, email)
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbUser, err := r.queries.GetUserByEmail(ctx, db.GetUserByEmailParams{
+		TenantID: tenantID,
+		Email:    domains.NormalizeEmail(email),
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // User not found
@@ -47,40 +73,141 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domains
 	return r.dbUserToDomain(dbUser), nil
 }
 
-// Create creates a new user in the database
+// Create creates a new user in the tenant of the context. The unique key on
+// the emails of live users decides races between registrations.
 func (r *UserRepository) Create(ctx context.Context, user *domains.User) error {
-	return r.queries.CreateUser(ctx, db.CreateUserParams{
-		ID:           user.ID,
-		Email:        user.Email,
-		PasswordHash: user.PasswordHash,
-		FirstName:    r.stringToNullString(user.FirstName),
-		LastName:     r.stringToNullString(user.LastName),
-		IsActive:     sql.NullBool{Bool: user.IsActive, Valid: true},
-	})
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	user.Email = domains.NormalizeEmail(user.Email)
+	if err := r.queries.CreateUser(ctx, db.CreateUserParams{
+		ID:               user.ID,
+		TenantID:         tenantID,
+		Email:            user.Email,
+		PasswordHash:     user.PasswordHash,
+		FirstName:        r.stringToNullString(user.FirstName),
+		LastName:         r.stringToNullString(user.LastName),
+		IsActive:         sql.NullBool{Bool: user.IsActive, Valid: true},
+		EmailVerifiedAt:  r.timeToNullTime(user.EmailVerifiedAt),
+		IsServiceAccount: user.IsServiceAccount,
+	}); err != nil {
+		return duplicateEmail(err)
+	}
+
+	user.TenantID = tenantID
+	return nil
 }
 
-// Update updates an existing user
+// Update updates an existing user that is still at user.Version
 func (r *UserRepository) Update(ctx context.Context, user *domains.User) error {
-	return r.queries.UpdateUser(ctx, db.UpdateUserParams{
-		ID:           user.ID,
-		Email:        user.Email,
-		PasswordHash: user.PasswordHash,
-		FirstName:    r.stringToNullString(user.FirstName),
-		LastName:     r.stringToNullString(user.LastName),
-		IsActive:     sql.NullBool{Bool: user.IsActive, Valid: true},
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	user.Email = domains.NormalizeEmail(user.Email)
+	rows, err := r.queries.UpdateUser(ctx, db.UpdateUserParams{
+		ID:              user.ID,
+		Email:           user.Email,
+		PasswordHash:    user.PasswordHash,
+		FirstName:       r.stringToNullString(user.FirstName),
+		LastName:        r.stringToNullString(user.LastName),
+		IsActive:        sql.NullBool{Bool: user.IsActive, Valid: true},
+		EmailVerifiedAt: r.timeToNullTime(user.EmailVerifiedAt),
+		TenantID:        tenantID,
+		Version:         user.Version,
+	})
+	if err != nil {
+		return duplicateEmail(err)
+	}
+	if rows == 0 {
+		return staleUser()
+	}
+
+	user.Version++
+	return nil
+}
+
+// UpdatePasswordHash replaces the stored password hash without advancing the
+// version
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.UpdateUserPasswordHash(ctx, db.UpdateUserPasswordHashParams{
+		PasswordHash: passwordHash,
+		TenantID:     tenantID,
+		ID:           id,
+	})
+}
+
+// MarkEmailVerified records the verification of an unverified email address
+// without advancing the version
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id string, verifiedAt time.Time) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.MarkUserEmailVerified(ctx, db.MarkUserEmailVerifiedParams{
+		EmailVerifiedAt: sql.NullTime{Time: verifiedAt, Valid: true},
+		TenantID:        tenantID,
+		ID:              id,
 	})
 }
 
-// Delete soft deletes a user
-func (r *UserRepository) Delete(ctx context.Context, id string) error {
-	return r.queries.DeleteUser(ctx, id)
+// Delete soft deletes a user that is still at the given version
+func (r *UserRepository) Delete(ctx context.Context, id string, version int32) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.queries.DeleteUser(ctx, db.DeleteUserParams{
+		TenantID: tenantID,
+		ID:       id,
+		Version:  version,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return staleUser()
+	}
+
+	return nil
 }
 
-// List retrieves a paginated list of users
-func (r *UserRepository) List(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+// List retrieves a paginated list of users matching the filter
+func (r *UserRepository) List(ctx context.Context, filter *domains.UserListFilter, limit, offset int32) ([]*domains.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter == nil {
+		filter = &domains.UserListFilter{}
+	}
+
+	sortKey, err := userSortKey(filter)
+	if err != nil {
+		return nil, err
+	}
+
 	dbUsers, err := r.queries.ListUsers(ctx, db.ListUsersParams{
-		Limit:  limit,
-		Offset: offset,
+		TenantID:      tenantID,
+		IsActive:      boolToNullBool(filter.IsActive),
+		CreatedAfter:  r.timeToNullTime(filter.CreatedAfter),
+		CreatedBefore: r.timeToNullTime(filter.CreatedBefore),
+		EmailPattern:  likePattern("%@", filter.EmailDomain, ""),
+		SearchPattern: likePattern("%", filter.Search, "%"),
+		SortKey:       sortKey,
+		Limit:         limit,
+		Offset:        offset,
 	})
 	if err != nil {
 		return nil, err
@@ -94,24 +221,301 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int32) ([]*doma
 	return users, nil
 }
 
-// Count returns the total number of active users
-func (r *UserRepository) Count(ctx context.Context) (int64, error) {
-	return r.queries.CountUsers(ctx)
+// ListByCursor retrieves up to limit users matching the filter on the far
+// side of the cursor, in list order
+func (r *UserRepository) ListByCursor(ctx context.Context, filter *domains.UserListFilter, cursor *domains.UserCursor, limit int32) ([]*domains.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter == nil {
+		filter = &domains.UserListFilter{}
+	}
+
+	if filter.SortBy != "" && filter.SortBy != domains.UserSortCreatedAt {
+		return nil, fmt.Errorf("cursor pagination cannot sort by %q", filter.SortBy)
+	}
+
+	// Lists without a sort are newest first
+	desc := filter.SortBy == "" || filter.SortDesc
+
+	var cursorCreatedAt sql.NullTime
+	var cursorID string
+	backward := false
+	if cursor != nil {
+		cursorCreatedAt = sql.NullTime{Time: cursor.CreatedAt, Valid: true}
+		cursorID = cursor.ID
+		backward = cursor.Backward
+	}
+
+	// Walking a descending list forward, or an ascending list backward,
+	// means descending through the index
+	var dbUsers []db.User
+	if desc != backward {
+		dbUsers, err = r.queries.ListUsersBeforeCursor(ctx, db.ListUsersBeforeCursorParams{
+			TenantID:        tenantID,
+			IsActive:        boolToNullBool(filter.IsActive),
+			CreatedAfter:    r.timeToNullTime(filter.CreatedAfter),
+			CreatedBefore:   r.timeToNullTime(filter.CreatedBefore),
+			EmailPattern:    likePattern("%@", filter.EmailDomain, ""),
+			SearchPattern:   likePattern("%", filter.Search, "%"),
+			CursorCreatedAt: cursorCreatedAt,
+			CursorID:        cursorID,
+			Limit:           limit,
+		})
+	} else {
+		dbUsers, err = r.queries.ListUsersAfterCursor(ctx, db.ListUsersAfterCursorParams{
+			TenantID:        tenantID,
+			IsActive:        boolToNullBool(filter.IsActive),
+			CreatedAfter:    r.timeToNullTime(filter.CreatedAfter),
+			CreatedBefore:   r.timeToNullTime(filter.CreatedBefore),
+			EmailPattern:    likePattern("%@", filter.EmailDomain, ""),
+			SearchPattern:   likePattern("%", filter.Search, "%"),
+			CursorCreatedAt: cursorCreatedAt,
+			CursorID:        cursorID,
+			Limit:           limit,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domains.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i] = r.dbUserToDomain(dbUser)
+	}
+
+	// A backward page is read away from the cursor, so it is reversed into
+	// list order
+	if backward {
+		slices.Reverse(users)
+	}
+
+	return users, nil
+}
+
+// Count returns the number of users matching the filter
+func (r *UserRepository) Count(ctx context.Context, filter *domains.UserListFilter) (int64, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if filter == nil {
+		filter = &domains.UserListFilter{}
+	}
+
+	return r.queries.CountUsers(ctx, db.CountUsersParams{
+		TenantID:      tenantID,
+		IsActive:      boolToNullBool(filter.IsActive),
+		CreatedAfter:  r.timeToNullTime(filter.CreatedAfter),
+		CreatedBefore: r.timeToNullTime(filter.CreatedBefore),
+		EmailPattern:  likePattern("%@", filter.EmailDomain, ""),
+		SearchPattern: likePattern("%", filter.Search, "%"),
+	})
+}
+
+// GetDeletedByID retrieves a soft-deleted user by their ID
+func (r *UserRepository) GetDeletedByID(ctx context.Context, id string) (*domains.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbUser, err := r.queries.GetDeletedUserByID(ctx, db.GetDeletedUserByIDParams{
+		TenantID: tenantID,
+		ID:       id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // User not found
+		}
+		return nil, err
+	}
+
+	return r.dbUserToDomain(dbUser), nil
+}
+
+// ListDeleted retrieves a paginated list of soft-deleted users
+func (r *UserRepository) ListDeleted(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbUsers, err := r.queries.ListDeletedUsers(ctx, db.ListDeletedUsersParams{
+		TenantID: tenantID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*domains.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i] = r.dbUserToDomain(dbUser)
+	}
+
+	return users, nil
+}
+
+// CountDeleted returns the number of soft-deleted users
+func (r *UserRepository) CountDeleted(ctx context.Context) (int64, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.queries.CountDeletedUsers(ctx, tenantID)
+}
+
+// Restore undoes the soft deletion of a user
+func (r *UserRepository) Restore(ctx context.Context, id string) (bool, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := r.queries.RestoreUser(ctx, db.RestoreUserParams{
+		TenantID: tenantID,
+		ID:       id,
+	})
+	if err != nil {
+		return false, duplicateEmail(err)
+	}
+
+	return rows > 0, nil
+}
+
+// HardDelete permanently removes a soft-deleted user; rows referencing the
+// user are removed by the foreign key cascades
+func (r *UserRepository) HardDelete(ctx context.Context, id string) (bool, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := r.queries.HardDeleteUser(ctx, db.HardDeleteUserParams{
+		TenantID: tenantID,
+		ID:       id,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// purgeBatchSize bounds the rows removed by a single purge statement
+const purgeBatchSize = 500
+
+// PurgeDeleted permanently removes the users soft-deleted before the cutoff,
+// in batches of purgeBatchSize
+func (r *UserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for {
+		rows, err := r.queries.PurgeDeletedUsers(ctx, db.PurgeDeletedUsersParams{
+			TenantID:  tenantID,
+			DeletedAt: sql.NullTime{Time: deletedBefore, Valid: true},
+			Limit:     purgeBatchSize,
+		})
+		if err != nil {
+			return purged, err
+		}
+
+		purged += rows
+		if rows < purgeBatchSize {
+			return purged, nil
+		}
+	}
 }
 
 // Helper functions
 
+// tenantFromContext returns the tenant queries are scoped to. A missing
+// tenant is an error rather than a reason to query across tenants.
+func tenantFromContext(ctx context.Context) (string, error) {
+	tenantID, ok := domains.TenantFromContext(ctx)
+	if !ok {
+		return "", domains.ErrNoTenant
+	}
+	return tenantID, nil
+}
+
+// likeEscaper escapes the LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// duplicateEmail reports violations of the unique key on the emails of live
+// users as DUPLICATE_ENTRY, and passes on any other error
+func duplicateEmail(err error) error {
+	if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == mysqlDuplicateEntry {
+		return errors.NewDuplicateEntryError("email is already registered to another user")
+	}
+	return err
+}
+
+// staleUser is returned when a versioned write matched no user, because the
+// user was changed or deleted since it was read
+func staleUser() error {
+	return errors.NewPreconditionFailedError("user has been modified since it was read")
+}
+
+// likePattern matches value literally between prefix and suffix; an empty
+// value does not filter
+func likePattern(prefix, value, suffix string) sql.NullString {
+	if value == "" {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: prefix + likeEscaper.Replace(value) + suffix, Valid: true}
+}
+
+// userSortKey maps the sort of a filter to one of the orders ListUsers
+// knows. Only whitelisted fields are accepted, and the key is bound as a
+// parameter rather than spliced into the query.
+func userSortKey(filter *domains.UserListFilter) (string, error) {
+	if filter.SortBy == "" {
+		return "created_at_desc", nil
+	}
+
+	if !slices.Contains(domains.UserSortFields, filter.SortBy) {
+		return "", fmt.Errorf("unsupported sort field %q", filter.SortBy)
+	}
+
+	if filter.SortDesc {
+		return filter.SortBy + "_desc", nil
+	}
+	return filter.SortBy + "_asc", nil
+}
+
+func boolToNullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{Valid: false}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
 func (r *UserRepository) dbUserToDomain(dbUser db.User) *domains.User {
 	return &domains.User{
-		ID:           dbUser.ID,
-		Email:        dbUser.Email,
-		PasswordHash: dbUser.PasswordHash,
-		FirstName:    r.nullStringToPointer(dbUser.FirstName),
-		LastName:     r.nullStringToPointer(dbUser.LastName),
-		IsActive:     dbUser.IsActive.Bool,
-		CreatedAt:    dbUser.CreatedAt.Time,
-		UpdatedAt:    dbUser.UpdatedAt.Time,
-		DeletedAt:    r.nullTimeToPointer(dbUser.DeletedAt),
+		ID:               dbUser.ID,
+		TenantID:         dbUser.TenantID,
+		Email:            dbUser.Email,
+		PasswordHash:     dbUser.PasswordHash,
+		FirstName:        r.nullStringToPointer(dbUser.FirstName),
+		LastName:         r.nullStringToPointer(dbUser.LastName),
+		IsActive:         dbUser.IsActive.Bool,
+		EmailVerifiedAt:  r.nullTimeToPointer(dbUser.EmailVerifiedAt),
+		IsServiceAccount: dbUser.IsServiceAccount,
+		CreatedAt:        dbUser.CreatedAt.Time,
+		UpdatedAt:        dbUser.UpdatedAt.Time,
+		DeletedAt:        r.nullTimeToPointer(dbUser.DeletedAt),
+		Version:          dbUser.Version,
 	}
 }
 
@@ -135,3 +539,10 @@ func (r *UserRepository) nullTimeToPointer(nt sql.NullTime) *time.Time {
 	}
 	return &nt.Time
 }
+
+func (r *UserRepository) timeToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
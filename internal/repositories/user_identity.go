@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+)
+
+// UserIdentityRepository implements the domains.UserIdentityRepository interface using sqlc
+type UserIdentityRepository struct {
+	queries *db.Queries
+}
+
+// NewUserIdentityRepository creates a new user identity repository
+func NewUserIdentityRepository(queries *db.Queries) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		queries: queries,
+	}
+}
+
+// GetByIssuerSubject retrieves the identity an issuer asserts for a subject
+func (r *UserIdentityRepository) GetByIssuerSubject(ctx context.Context, issuer, subject string) (*domains.UserIdentity, error) {
+	dbIdentity, err := r.queries.GetUserIdentityByIssuerSubject(ctx, db.GetUserIdentityByIssuerSubjectParams{
+		Issuer:  issuer,
+		Subject: subject,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Identity not linked
+		}
+		return nil, err
+	}
+
+	return &domains.UserIdentity{
+		ID:        dbIdentity.ID,
+		UserID:    dbIdentity.UserID,
+		Issuer:    dbIdentity.Issuer,
+		Subject:   dbIdentity.Subject,
+		CreatedAt: dbIdentity.CreatedAt.Time,
+	}, nil
+}
+
+// Create links an external identity to a local user
+func (r *UserIdentityRepository) Create(ctx context.Context, identity *domains.UserIdentity) error {
+	return r.queries.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		ID:      identity.ID,
+		UserID:  identity.UserID,
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+	})
+}
@@ -0,0 +1,413 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+const (
+	// apiKeyPrefix starts the public part of every key, so leaked keys are easy to recognise
+	apiKeyPrefix = "ak_"
+
+	// apiKeyPrefixBytes is the number of random bytes in the public part of a key
+	apiKeyPrefixBytes = 8
+)
+
+// APIKeyConfig holds the tunables for APIKeyUsecase
+type APIKeyConfig struct {
+	LastUsedInterval time.Duration // minimum time between two last-used updates of a key
+}
+
+// APIKeyUsecase implements the API key business logic. A key is presented
+// as prefix.secret: the prefix locates the key, the secret proves possession.
+type APIKeyUsecase struct {
+	repo   domains.UserRepository
+	keys   domains.APIKeyRepository
+	roles  domains.RoleRepository
+	config APIKeyConfig
+}
+
+// NewAPIKeyUsecase creates a new API key usecase
+func NewAPIKeyUsecase(
+	repo domains.UserRepository,
+	keys domains.APIKeyRepository,
+	roles domains.RoleRepository,
+	config APIKeyConfig,
+) domains.APIKeyUsecase {
+	return &APIKeyUsecase{
+		repo:   repo,
+		keys:   keys,
+		roles:  roles,
+		config: config,
+	}
+}
+
+// CreateServiceAccount creates an account that cannot log in and only acts
+// through its API keys. It gets no roles; its keys carry their permissions.
+func (u *APIKeyUsecase) CreateServiceAccount(ctx context.Context, input *domains.CreateServiceAccountInput) (*domains.User, error) {
+	if err := authorize(ctx, domains.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return nil, err
+	}
+
+	if input == nil {
+		return nil, errors.NewValidationError("service account input is required", nil)
+	}
+
+	if input.Email == "" {
+		return nil, errors.NewValidationError("email is required", nil)
+	}
+
+	existingUser, err := u.repo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to check email", err)
+	}
+
+	if existingUser != nil {
+		return nil, errors.NewDuplicateEntryError(fmt.Sprintf("email %s is already registered", input.Email))
+	}
+
+	user := &domains.User{
+		ID:               uuid.New().String(),
+		Email:            input.Email,
+		IsActive:         true,
+		IsServiceAccount: true,
+	}
+
+	if input.Name != "" {
+		user.FirstName = &input.Name
+	}
+
+	if err := u.repo.Create(ctx, user); err != nil {
+		return nil, repositoryError("failed to create service account", err)
+	}
+
+	return user, nil
+}
+
+// CreateAPIKey issues a new key. Callers can only grant scopes they hold
+// themselves, and a key cannot be used to mint further keys.
+func (u *APIKeyUsecase) CreateAPIKey(ctx context.Context, input *domains.CreateAPIKeyInput) (*domains.IssuedAPIKey, error) {
+	if input == nil {
+		return nil, errors.NewValidationError("API key input is required", nil)
+	}
+
+	if err := authorizeUser(ctx, input.UserID, domains.PermissionUsersWrite, domains.PermissionSelfWrite); err != nil {
+		return nil, err
+	}
+
+	if err := rejectAPIKeyPrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	if input.Name == "" {
+		return nil, errors.NewValidationError("name is required", nil)
+	}
+
+	scopes, err := validateScopes(ctx, input.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, errors.NewValidationError("expiry must be in the future", nil)
+	}
+
+	owner, err := u.repo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if owner == nil || owner.DeletedAt != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", input.UserID))
+	}
+
+	if !owner.IsActive {
+		return nil, errors.NewBadRequestError("user account is inactive")
+	}
+
+	prefix, err := generateAPIKeyPrefix()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate API key", err)
+	}
+
+	secret, err := generateToken()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate API key", err)
+	}
+
+	key := &domains.APIKey{
+		ID:         uuid.New().String(),
+		UserID:     owner.ID,
+		Name:       input.Name,
+		Prefix:     prefix,
+		SecretHash: hashToken(secret),
+		Scopes:     scopes,
+		ExpiresAt:  input.ExpiresAt,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := u.keys.Create(ctx, key); err != nil {
+		return nil, errors.NewDatabaseError("failed to store API key", err)
+	}
+
+	return &domains.IssuedAPIKey{
+		APIKey: key,
+		Key:    prefix + "." + secret,
+	}, nil
+}
+
+// ListAPIKeys retrieves the keys owned by a user
+func (u *APIKeyUsecase) ListAPIKeys(ctx context.Context, userID string) ([]*domains.APIKey, error) {
+	if err := authorizeUser(ctx, userID, domains.PermissionUsersRead, domains.PermissionSelfRead); err != nil {
+		return nil, err
+	}
+
+	// Only users of the caller's tenant are visible
+	owner, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if owner == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", userID))
+	}
+
+	keys, err := u.keys.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to list API keys", err)
+	}
+
+	return keys, nil
+}
+
+// GetAPIKey retrieves a key by ID
+func (u *APIKeyUsecase) GetAPIKey(ctx context.Context, id string) (*domains.APIKey, error) {
+	key, err := u.getKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeUser(ctx, key.UserID, domains.PermissionUsersRead, domains.PermissionSelfRead); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// UpdateAPIKey changes the name, scopes or expiry of a key
+func (u *APIKeyUsecase) UpdateAPIKey(ctx context.Context, id string, input *domains.UpdateAPIKeyInput) (*domains.APIKey, error) {
+	if input == nil {
+		return nil, errors.NewValidationError("update input is required", nil)
+	}
+
+	key, err := u.getKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeUser(ctx, key.UserID, domains.PermissionUsersWrite, domains.PermissionSelfWrite); err != nil {
+		return nil, err
+	}
+
+	if err := rejectAPIKeyPrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if *input.Name == "" {
+			return nil, errors.NewValidationError("name must not be empty", nil)
+		}
+		key.Name = *input.Name
+	}
+
+	if input.Scopes != nil {
+		if key.Scopes, err = validateScopes(ctx, input.Scopes); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(time.Now()) {
+			return nil, errors.NewValidationError("expiry must be in the future", nil)
+		}
+		key.ExpiresAt = input.ExpiresAt
+	}
+
+	if err := u.keys.Update(ctx, key); err != nil {
+		return nil, errors.NewDatabaseError("failed to update API key", err)
+	}
+
+	return key, nil
+}
+
+// DeleteAPIKey revokes a key; it stops authenticating immediately
+func (u *APIKeyUsecase) DeleteAPIKey(ctx context.Context, id string) error {
+	key, err := u.getKey(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeUser(ctx, key.UserID, domains.PermissionUsersWrite, domains.PermissionSelfWrite); err != nil {
+		return err
+	}
+
+	if err := u.keys.Delete(ctx, id); err != nil {
+		return errors.NewDatabaseError("failed to delete API key", err)
+	}
+
+	return nil
+}
+
+// Authenticate resolves a key presented by a client to the principal it acts
+// as. A key never grants more than its scopes, and for regular users no more
+// than the owner currently holds; service accounts hold exactly their scopes.
+func (u *APIKeyUsecase) Authenticate(ctx context.Context, value string) (*domains.Principal, error) {
+	prefix, secret, ok := strings.Cut(value, ".")
+	if !ok || !strings.HasPrefix(prefix, apiKeyPrefix) || secret == "" {
+		return nil, errors.NewUnauthorizedError("invalid API key")
+	}
+
+	key, err := u.keys.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch API key", err)
+	}
+
+	if key == nil || subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(key.SecretHash)) != 1 {
+		return nil, errors.NewUnauthorizedError("invalid API key")
+	}
+
+	now := time.Now()
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return nil, errors.NewUnauthorizedError("API key has expired")
+	}
+
+	owner, err := u.repo.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if owner == nil || owner.DeletedAt != nil || !owner.IsActive {
+		return nil, errors.NewUnauthorizedError("API key owner is inactive")
+	}
+
+	roles, err := u.roles.GetUserRoles(ctx, owner.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch roles", err)
+	}
+
+	permissions := key.Scopes
+	if !owner.IsServiceAccount {
+		held, err := u.roles.GetUserPermissions(ctx, owner.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("failed to fetch permissions", err)
+		}
+
+		permissions = make([]string, 0, len(key.Scopes))
+		for _, scope := range key.Scopes {
+			if slices.Contains(held, scope) {
+				permissions = append(permissions, scope)
+			}
+		}
+	}
+
+	// Writes are throttled, and a failed write must not fail the request
+	_ = u.keys.TouchLastUsed(ctx, key.ID, now, now.Add(-u.config.LastUsedInterval))
+
+	return &domains.Principal{
+		UserID:      owner.ID,
+		TenantID:    owner.TenantID,
+		Email:       owner.Email,
+		Roles:       roles,
+		Permissions: permissions,
+		APIKeyID:    key.ID,
+	}, nil
+}
+
+// getKey retrieves a key, mapping a missing one, or one owned by a user of
+// another tenant, to a not found error
+func (u *APIKeyUsecase) getKey(ctx context.Context, id string) (*domains.APIKey, error) {
+	key, err := u.keys.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch API key", err)
+	}
+
+	if key == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("API key with id %s not found", id))
+	}
+
+	owner, err := u.repo.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch API key owner", err)
+	}
+
+	if owner == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("API key with id %s not found", id))
+	}
+
+	return key, nil
+}
+
+// rejectAPIKeyPrincipal refuses callers that authenticated with an API key,
+// so a leaked key cannot be used to create or extend keys
+func rejectAPIKeyPrincipal(ctx context.Context) error {
+	if principal, ok := domains.PrincipalFromContext(ctx); ok && principal.APIKeyID != "" {
+		return errors.NewForbiddenError("API keys cannot manage API keys")
+	}
+	return nil
+}
+
+// validateScopes deduplicates the requested scopes and checks that the caller
+// holds every one of them
+func validateScopes(ctx context.Context, requested []string) ([]string, error) {
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		if scope == "" || strings.ContainsAny(scope, " \t\r\n") {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid scope %q", scope), nil)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if len(scopes) == 0 {
+		return nil, errors.NewValidationError("at least one scope is required", nil)
+	}
+
+	principal, ok, err := currentPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		for _, scope := range scopes {
+			if !principal.HasPermission(scope) {
+				return nil, errors.NewForbiddenError(fmt.Sprintf("cannot grant scope %s", scope))
+			}
+		}
+	}
+
+	return scopes, nil
+}
+
+// generateAPIKeyPrefix returns a random public key part
+func generateAPIKeyPrefix() (string, error) {
+	b := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,487 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/password"
+)
+
+// AuthConfig holds the tunables for AuthUsecase
+type AuthConfig struct {
+	RefreshTokenTTL time.Duration
+
+	// RequireVerifiedEmail blocks login until the user verified their email address
+	RequireVerifiedEmail bool
+
+	// MFAChallengeTTL is how long a user has to enter the second factor after the password
+	MFAChallengeTTL time.Duration
+}
+
+// maxMFAAttempts is the number of wrong codes after which an MFA challenge is void
+const maxMFAAttempts = 5
+
+// AuthUsecase implements the authentication business logic
+type AuthUsecase struct {
+	repo          domains.UserRepository
+	refreshTokens domains.RefreshTokenRepository
+	revocations   domains.TokenRevocationStore
+	roles         domains.RoleRepository
+	mfa           domains.MFARepository
+	lockout       domains.LockoutUsecase
+	hasher        password.Hasher
+	issuer        domains.TokenIssuer
+	sessions      domains.SessionUsecase
+	config        AuthConfig
+
+	dummyHashOnce sync.Once
+	dummyHash     string
+}
+
+// NewAuthUsecase creates a new auth usecase
+func NewAuthUsecase(
+	repo domains.UserRepository,
+	refreshTokens domains.RefreshTokenRepository,
+	revocations domains.TokenRevocationStore,
+	roles domains.RoleRepository,
+	mfa domains.MFARepository,
+	lockout domains.LockoutUsecase,
+	hasher password.Hasher,
+	issuer domains.TokenIssuer,
+	sessions domains.SessionUsecase,
+	config AuthConfig,
+) domains.AuthUsecase {
+	return &AuthUsecase{
+		repo:          repo,
+		refreshTokens: refreshTokens,
+		revocations:   revocations,
+		roles:         roles,
+		mfa:           mfa,
+		lockout:       lockout,
+		hasher:        hasher,
+		issuer:        issuer,
+		sessions:      sessions,
+		config:        config,
+	}
+}
+
+// Login verifies user credentials and issues an access and refresh token pair,
+// or starts a session when asked to. Users with MFA enabled get an MFA
+// challenge token instead.
+func (u *AuthUsecase) Login(ctx context.Context, input *domains.LoginInput) (*domains.AuthToken, error) {
+	// Validate input
+	if input == nil {
+		return nil, errors.NewValidationError("login input is required", nil)
+	}
+
+	if input.StartSession && u.sessions == nil {
+		return nil, errors.NewBadRequestError("session login is not enabled")
+	}
+
+	if input.Email == "" {
+		return nil, errors.NewValidationError("email is required", nil)
+	}
+
+	if input.Password == "" {
+		return nil, errors.NewValidationError("password is required", nil)
+	}
+
+	// Locked accounts are refused before the password is looked at, so a
+	// lockout cannot be used to keep guessing
+	if err := u.lockout.Check(ctx, input.Email, input.IPAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := u.repo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	// Unknown and soft-deleted users, and service accounts, which have no
+	// password, get the same answer as a wrong password
+	if user == nil || user.DeletedAt != nil || user.IsServiceAccount {
+		u.burnVerification(input.Password)
+		return nil, u.loginFailed(ctx, input.Email, input.IPAddress, "invalid email or password")
+	}
+
+	match, err := u.hasher.Verify(input.Password, user.PasswordHash)
+	if stderrors.Is(err, password.ErrUnsupportedHash) {
+		// A stored hash in an unknown format can never match; the user can
+		// recover through a password reset
+		return nil, u.loginFailed(ctx, input.Email, input.IPAddress, "invalid email or password")
+	}
+	if err != nil {
+		return nil, errors.NewInternalError("failed to verify password", err)
+	}
+
+	if !match {
+		return nil, u.loginFailed(ctx, input.Email, input.IPAddress, "invalid email or password")
+	}
+
+	if !user.IsActive {
+		return nil, errors.NewForbiddenError("user account is inactive")
+	}
+
+	if u.config.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+		return nil, errors.NewForbiddenError("email address is not verified")
+	}
+
+	u.rehashIfNeeded(ctx, user, input.Password)
+
+	factor, err := u.mfa.GetFactor(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch MFA factor", err)
+	}
+
+	if factor != nil && factor.ConfirmedAt != nil {
+		return u.startMFAChallenge(ctx, user)
+	}
+
+	if err := u.lockout.RecordSuccess(ctx, input.Email, input.IPAddress); err != nil {
+		return nil, err
+	}
+
+	return u.completeLogin(ctx, user, input.StartSession)
+}
+
+// VerifyMFA completes a login with the second factor and issues the token pair,
+// or starts a session when asked to
+func (u *AuthUsecase) VerifyMFA(ctx context.Context, input *domains.MFALoginInput) (*domains.AuthToken, error) {
+	if input == nil || input.MFAToken == "" {
+		return nil, errors.NewValidationError("MFA token is required", nil)
+	}
+
+	if input.StartSession && u.sessions == nil {
+		return nil, errors.NewBadRequestError("session login is not enabled")
+	}
+
+	if input.Code == "" {
+		return nil, errors.NewValidationError("code is required", nil)
+	}
+
+	challenge, err := u.mfa.GetChallengeByHash(ctx, hashToken(input.MFAToken))
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch MFA challenge", err)
+	}
+
+	if challenge == nil || challenge.UsedAt != nil || challenge.Attempts >= maxMFAAttempts || time.Now().After(challenge.ExpiresAt) {
+		return nil, errors.NewUnauthorizedError("invalid or expired MFA token")
+	}
+
+	// The challenge is completed in the tenant of the password step
+	ctx = domains.WithTenant(ctx, challenge.TenantID)
+
+	user, err := u.repo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil || !user.IsActive {
+		return nil, errors.NewUnauthorizedError("invalid or expired MFA token")
+	}
+
+	if err := u.lockout.Check(ctx, user.Email, input.IPAddress); err != nil {
+		return nil, err
+	}
+
+	factor, err := u.mfa.GetFactor(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch MFA factor", err)
+	}
+
+	// MFA was disabled or reset after the password step
+	if factor == nil || factor.ConfirmedAt == nil {
+		return nil, errors.NewUnauthorizedError("invalid or expired MFA token")
+	}
+
+	// The attempt is counted before the code is checked, so that parallel
+	// requests cannot make more guesses than the limit between them
+	counted, err := u.mfa.IncrementChallengeAttempts(ctx, challenge.ID, maxMFAAttempts)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to record MFA attempt", err)
+	}
+
+	if !counted {
+		return nil, errors.NewUnauthorizedError("invalid or expired MFA token")
+	}
+
+	valid, err := verifySecondFactor(ctx, u.mfa, factor, input.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		return nil, u.loginFailed(ctx, user.Email, input.IPAddress, "invalid MFA code")
+	}
+
+	completed, err := u.mfa.MarkChallengeUsed(ctx, challenge.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to complete MFA challenge", err)
+	}
+
+	if !completed {
+		return nil, errors.NewUnauthorizedError("invalid or expired MFA token")
+	}
+
+	if err := u.lockout.RecordSuccess(ctx, user.Email, input.IPAddress); err != nil {
+		return nil, err
+	}
+
+	return u.completeLogin(ctx, user, input.StartSession)
+}
+
+// LoginWithoutPassword issues the token pair for a user who proved their
+// identity by other means, such as a magic link. Users with MFA enabled get
+// an MFA challenge token instead.
+func (u *AuthUsecase) LoginWithoutPassword(ctx context.Context, user *domains.User) (*domains.AuthToken, error) {
+	if user == nil || user.DeletedAt != nil || user.IsServiceAccount {
+		return nil, errors.NewUnauthorizedError("user cannot log in")
+	}
+
+	if !user.IsActive {
+		return nil, errors.NewForbiddenError("user account is inactive")
+	}
+
+	if u.config.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+		return nil, errors.NewForbiddenError("email address is not verified")
+	}
+
+	factor, err := u.mfa.GetFactor(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch MFA factor", err)
+	}
+
+	if factor != nil && factor.ConfirmedAt != nil {
+		return u.startMFAChallenge(ctx, user)
+	}
+
+	return u.completeLogin(ctx, user, false)
+}
+
+// Refresh rotates a refresh token and issues a new token pair
+func (u *AuthUsecase) Refresh(ctx context.Context, refreshToken string) (*domains.AuthToken, error) {
+	if refreshToken == "" {
+		return nil, errors.NewValidationError("refresh token is required", nil)
+	}
+
+	stored, err := u.refreshTokens.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch refresh token", err)
+	}
+
+	if stored == nil || stored.RevokedAt != nil {
+		return nil, errors.NewUnauthorizedError("invalid refresh token")
+	}
+
+	// A token that was already rotated is being replayed: assume it was stolen
+	// and cut off the whole chain, including the legitimate holder.
+	if stored.UsedAt != nil {
+		return nil, u.revokeFamilyOnReuse(ctx, stored.FamilyID)
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, errors.NewUnauthorizedError("refresh token has expired")
+	}
+
+	// Refresh requests need not name a tenant; the user is looked up in the
+	// tenant the token was issued in
+	ctx = domains.WithTenant(ctx, stored.TenantID)
+
+	user, err := u.repo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil || !user.IsActive {
+		if err := u.refreshTokens.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, errors.NewDatabaseError("failed to revoke refresh tokens", err)
+		}
+		return nil, errors.NewUnauthorizedError("invalid refresh token")
+	}
+
+	// Losing the race against a concurrent rotation is also a replay
+	rotated, err := u.refreshTokens.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to rotate refresh token", err)
+	}
+
+	if !rotated {
+		return nil, u.revokeFamilyOnReuse(ctx, stored.FamilyID)
+	}
+
+	return u.issueTokens(ctx, user, stored.FamilyID)
+}
+
+// Logout revokes the current refresh token family and access token
+func (u *AuthUsecase) Logout(ctx context.Context, input *domains.LogoutInput) error {
+	if input == nil || (input.RefreshToken == "" && input.AccessTokenID == "") {
+		return errors.NewValidationError("refresh token or access token is required", nil)
+	}
+
+	if input.AccessTokenID != "" {
+		if err := u.revocations.RevokeToken(ctx, input.AccessTokenID, input.AccessTokenExpiresAt); err != nil {
+			return errors.NewDatabaseError("failed to revoke access token", err)
+		}
+	}
+
+	if input.RefreshToken == "" {
+		return nil
+	}
+
+	stored, err := u.refreshTokens.GetByHash(ctx, hashToken(input.RefreshToken))
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch refresh token", err)
+	}
+
+	// Logging out with an unknown token is a no-op
+	if stored == nil {
+		return nil
+	}
+
+	if err := u.refreshTokens.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return errors.NewDatabaseError("failed to revoke refresh tokens", err)
+	}
+
+	return nil
+}
+
+// completeLogin finishes a successful login with a new token pair or a new session
+func (u *AuthUsecase) completeLogin(ctx context.Context, user *domains.User, startSession bool) (*domains.AuthToken, error) {
+	if !startSession {
+		// Every login starts a new refresh token family
+		return u.issueTokens(ctx, user, uuid.New().String())
+	}
+
+	session, err := u.sessions.Start(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domains.AuthToken{
+		ExpiresAt: session.Session.ExpiresAt,
+		Session:   session,
+	}, nil
+}
+
+// issueTokens signs an access token and stores a new refresh token in the given family.
+// Roles are reloaded on every refresh so that changes apply within one token lifetime.
+func (u *AuthUsecase) issueTokens(ctx context.Context, user *domains.User, familyID string) (*domains.AuthToken, error) {
+	roles, err := u.roles.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch roles", err)
+	}
+
+	permissions, err := u.roles.GetUserPermissions(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch permissions", err)
+	}
+
+	token, err := u.issuer.Issue(&domains.IssueTokenInput{
+		UserID:      user.ID,
+		TenantID:    user.TenantID,
+		Email:       user.Email,
+		Roles:       roles,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to issue token", err)
+	}
+
+	refreshToken, err := generateToken()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate refresh token", err)
+	}
+
+	err = u.refreshTokens.Create(ctx, &domains.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TenantID:  user.TenantID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(u.config.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to store refresh token", err)
+	}
+
+	token.RefreshToken = refreshToken
+
+	return token, nil
+}
+
+// startMFAChallenge records that the password step succeeded and returns the
+// token that has to accompany the second factor
+func (u *AuthUsecase) startMFAChallenge(ctx context.Context, user *domains.User) (*domains.AuthToken, error) {
+	token, err := generateToken()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate MFA token", err)
+	}
+
+	expiresAt := time.Now().Add(u.config.MFAChallengeTTL)
+	err = u.mfa.CreateChallenge(ctx, &domains.MFAChallenge{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TenantID:  user.TenantID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to store MFA challenge", err)
+	}
+
+	return &domains.AuthToken{
+		MFAToken:  token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// loginFailed counts a failed login towards the lockout policy and returns
+// the error for the caller
+func (u *AuthUsecase) loginFailed(ctx context.Context, email, ip, message string) error {
+	if err := u.lockout.RecordFailure(ctx, email, ip); err != nil {
+		return err
+	}
+	return errors.NewUnauthorizedError(message)
+}
+
+// revokeFamilyOnReuse revokes a token family after a replayed refresh token was detected
+func (u *AuthUsecase) revokeFamilyOnReuse(ctx context.Context, familyID string) error {
+	if err := u.refreshTokens.RevokeFamily(ctx, familyID); err != nil {
+		return errors.NewDatabaseError("failed to revoke refresh tokens", err)
+	}
+	return errors.NewUnauthorizedError("refresh token reuse detected")
+}
+
+// rehashIfNeeded upgrades the stored hash when the configured algorithm or cost changed.
+// Failures are ignored: the user already proved their password and can be upgraded next time.
+func (u *AuthUsecase) rehashIfNeeded(ctx context.Context, user *domains.User, plain string) {
+	if !u.hasher.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	passwordHash, err := u.hasher.Hash(plain)
+	if err != nil {
+		return
+	}
+
+	if err := u.repo.UpdatePasswordHash(ctx, user.ID, passwordHash); err == nil {
+		user.PasswordHash = passwordHash
+	}
+}
+
+// burnVerification runs a verification against a throwaway hash so that
+// unknown emails take as long to reject as wrong passwords
+func (u *AuthUsecase) burnVerification(plain string) {
+	u.dummyHashOnce.Do(func() {
+		u.dummyHash, _ = u.hasher.Hash("dummy-password-for-timing")
+	})
+
+	if u.dummyHash != "" {
+		_, _ = u.hasher.Verify(plain, u.dummyHash)
+	}
+}
@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// EmailVerificationConfig holds the tunables for EmailVerificationUsecase
+type EmailVerificationConfig struct {
+	TokenTTL       time.Duration
+	ResendInterval time.Duration // minimum time between two emails to the same user
+	URL            string        // page the token is appended to as the token query parameter
+}
+
+// EmailVerificationUsecase implements the email verification business logic
+type EmailVerificationUsecase struct {
+	repo   domains.UserRepository
+	tokens domains.EmailVerificationTokenRepository
+	sender domains.MailSender
+	logger *slog.Logger
+	config EmailVerificationConfig
+}
+
+// NewEmailVerificationUsecase creates a new email verification usecase
+func NewEmailVerificationUsecase(
+	repo domains.UserRepository,
+	tokens domains.EmailVerificationTokenRepository,
+	sender domains.MailSender,
+	logger *slog.Logger,
+	config EmailVerificationConfig,
+) domains.EmailVerificationUsecase {
+	return &EmailVerificationUsecase{
+		repo:   repo,
+		tokens: tokens,
+		sender: sender,
+		logger: logger,
+		config: config,
+	}
+}
+
+// SendVerification issues a new verification token and mails it to the user,
+// invalidating any earlier ones
+func (u *EmailVerificationUsecase) SendVerification(ctx context.Context, user *domains.User) error {
+	// Only the most recent link works, so a link sent to a previous address is dead
+	if err := u.tokens.InvalidateForUser(ctx, user.ID); err != nil {
+		return errors.NewDatabaseError("failed to invalidate verification tokens", err)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return errors.NewInternalError("failed to generate verification token", err)
+	}
+
+	err = u.tokens.Create(ctx, &domains.EmailVerificationToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TenantID:  user.TenantID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(u.config.TokenTTL),
+	})
+	if err != nil {
+		return errors.NewDatabaseError("failed to store verification token", err)
+	}
+
+	link, err := url.Parse(u.config.URL)
+	if err != nil {
+		return errors.NewInternalError("invalid verification URL", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	err = u.sender.Send(ctx, &domains.MailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s. If you did not create an account, you can ignore this email.\n",
+			link.String(), u.config.TokenTTL),
+	})
+	if err != nil {
+		return errors.NewInternalError("failed to send verification email", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail consumes a verification token and marks the address as verified
+func (u *EmailVerificationUsecase) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return errors.NewValidationError("verification token is required", nil)
+	}
+
+	stored, err := u.tokens.GetByHash(ctx, hashToken(token))
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch verification token", err)
+	}
+
+	if stored == nil || stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return errors.NewBadRequestError("invalid or expired verification token")
+	}
+
+	// The user belongs to the tenant the token was issued in, whichever one
+	// the request names
+	ctx = domains.WithTenant(ctx, stored.TenantID)
+
+	user, err := u.repo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil {
+		return errors.NewBadRequestError("invalid or expired verification token")
+	}
+
+	consumed, err := u.tokens.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to consume verification token", err)
+	}
+
+	if !consumed {
+		return errors.NewBadRequestError("invalid or expired verification token")
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	if err := u.repo.MarkEmailVerified(ctx, user.ID, time.Now()); err != nil {
+		return errors.NewDatabaseError("failed to update user", err)
+	}
+
+	return nil
+}
+
+// ResendVerification mails a new token to an unverified address. Unknown and
+// already verified addresses, and requests within the resend interval, are
+// silently ignored so the response does not reveal whether an account exists.
+func (u *EmailVerificationUsecase) ResendVerification(ctx context.Context, email string) error {
+	if email == "" {
+		return errors.NewValidationError("email is required", nil)
+	}
+
+	user, err := u.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil || user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	latest, err := u.tokens.GetLatestForUser(ctx, user.ID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch verification token", err)
+	}
+
+	if latest != nil && time.Since(latest.CreatedAt) < u.config.ResendInterval {
+		return nil
+	}
+
+	// Failing the request would tell registered addresses apart from unknown
+	// ones
+	if err := u.SendVerification(ctx, user); err != nil {
+		u.logger.ErrorContext(ctx, "verification email not sent",
+			slog.String("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,350 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// maxGroupNameLength matches the user_groups.name column
+const maxGroupNameLength = 100
+
+// GroupUsecase implements the group business logic
+type GroupUsecase struct {
+	groups      domains.GroupRepository
+	users       domains.UserRepository
+	roles       domains.RoleRepository
+	permissions domains.PermissionResolver
+}
+
+// NewGroupUsecase creates a new group usecase. Changes to memberships and
+// group roles are reported to permissions, so that they apply to the
+// affected users right away.
+func NewGroupUsecase(groups domains.GroupRepository, users domains.UserRepository, roles domains.RoleRepository, permissions domains.PermissionResolver) domains.GroupUsecase {
+	return &GroupUsecase{
+		groups:      groups,
+		users:       users,
+		roles:       roles,
+		permissions: permissions,
+	}
+}
+
+// CreateGroup creates a new group without members or roles
+func (u *GroupUsecase) CreateGroup(ctx context.Context, input *domains.CreateGroupInput) (*domains.Group, error) {
+	if err := authorize(ctx, domains.PermissionGroupsWrite); err != nil {
+		return nil, err
+	}
+
+	if input == nil {
+		return nil, errors.NewValidationError("group input is required", nil)
+	}
+
+	name, err := u.validateName(ctx, input.Name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	group := &domains.Group{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: input.Description,
+		Roles:       []string{},
+	}
+
+	if err := u.groups.Create(ctx, group); err != nil {
+		return nil, errors.NewDatabaseError("failed to create group", err)
+	}
+
+	return group, nil
+}
+
+// GetGroup retrieves a group by ID
+func (u *GroupUsecase) GetGroup(ctx context.Context, id string) (*domains.Group, error) {
+	if err := authorize(ctx, domains.PermissionGroupsRead); err != nil {
+		return nil, err
+	}
+
+	return u.getGroup(ctx, id)
+}
+
+// ListGroups retrieves a paginated group list ordered by name
+func (u *GroupUsecase) ListGroups(ctx context.Context, limit, offset int32) ([]*domains.Group, error) {
+	if err := authorize(ctx, domains.PermissionGroupsRead); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	groups, err := u.groups.List(ctx, limit, offset)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch groups", err)
+	}
+
+	return groups, nil
+}
+
+// UpdateGroup updates the name or description of a group
+func (u *GroupUsecase) UpdateGroup(ctx context.Context, id string, input *domains.UpdateGroupInput) (*domains.Group, error) {
+	if err := authorize(ctx, domains.PermissionGroupsWrite); err != nil {
+		return nil, err
+	}
+
+	if input == nil {
+		return nil, errors.NewValidationError("group input is required", nil)
+	}
+
+	group, err := u.getGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if group.Name, err = u.validateName(ctx, *input.Name, group.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Description != nil {
+		group.Description = input.Description
+	}
+
+	if err := u.groups.Update(ctx, group); err != nil {
+		return nil, errors.NewDatabaseError("failed to update group", err)
+	}
+
+	return group, nil
+}
+
+// DeleteGroup deletes a group; its members lose the roles it granted
+func (u *GroupUsecase) DeleteGroup(ctx context.Context, id string) error {
+	if err := authorize(ctx, domains.PermissionGroupsWrite); err != nil {
+		return err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return err
+	}
+
+	group, err := u.getGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	members, err := u.groups.ListMemberIDs(ctx, group.ID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch group members", err)
+	}
+
+	if err := u.groups.Delete(ctx, group.ID); err != nil {
+		return errors.NewDatabaseError("failed to delete group", err)
+	}
+
+	u.permissions.Invalidate(members...)
+	return nil
+}
+
+// ListMembers retrieves a paginated list of the members of a group ordered
+// by email
+func (u *GroupUsecase) ListMembers(ctx context.Context, groupID string, limit, offset int32) ([]*domains.User, error) {
+	if err := authorize(ctx, domains.PermissionGroupsRead); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.getGroup(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	members, err := u.groups.ListMembers(ctx, groupID, limit, offset)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch group members", err)
+	}
+
+	return members, nil
+}
+
+// AddMember adds a user to a group. Like invitations, callers can only add
+// members to groups whose roles grant nothing they do not hold themselves.
+func (u *GroupUsecase) AddMember(ctx context.Context, groupID, userID string) error {
+	if err := authorize(ctx, domains.PermissionGroupsWrite); err != nil {
+		return err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return err
+	}
+
+	group, err := u.getGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.users.GetByID(ctx, userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", userID))
+	}
+
+	for _, role := range group.Roles {
+		if err := authorizeRoleGrant(ctx, u.roles, role); err != nil {
+			return err
+		}
+	}
+
+	if err := u.groups.AddMember(ctx, group.ID, user.ID); err != nil {
+		return errors.NewDatabaseError("failed to add group member", err)
+	}
+
+	u.permissions.Invalidate(user.ID)
+	return nil
+}
+
+// RemoveMember removes a user from a group
+func (u *GroupUsecase) RemoveMember(ctx context.Context, groupID, userID string) error {
+	if err := authorize(ctx, domains.PermissionGroupsWrite); err != nil {
+		return err
+	}
+
+	group, err := u.getGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := u.groups.RemoveMember(ctx, group.ID, userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to remove group member", err)
+	}
+
+	if !removed {
+		return errors.NewNotFoundError(fmt.Sprintf("user with id %s is not a member of the group", userID))
+	}
+
+	u.permissions.Invalidate(userID)
+	return nil
+}
+
+// AddRole grants a role to every member of a group. Callers can only grant
+// roles whose permissions they hold themselves.
+func (u *GroupUsecase) AddRole(ctx context.Context, groupID, role string) error {
+	if err := authorize(ctx, domains.PermissionGroupsWrite); err != nil {
+		return err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return err
+	}
+
+	role = strings.TrimSpace(role)
+	if role == "" {
+		return errors.NewValidationError("role is required", nil)
+	}
+
+	group, err := u.getGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeRoleGrant(ctx, u.roles, role); err != nil {
+		return err
+	}
+
+	members, err := u.groups.ListMemberIDs(ctx, group.ID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch group members", err)
+	}
+
+	if err := u.groups.AddRole(ctx, group.ID, role); err != nil {
+		return errors.NewDatabaseError("failed to add group role", err)
+	}
+
+	u.permissions.Invalidate(members...)
+	return nil
+}
+
+// RemoveRole withdraws a role from a group
+func (u *GroupUsecase) RemoveRole(ctx context.Context, groupID, role string) error {
+	if err := authorize(ctx, domains.PermissionGroupsWrite); err != nil {
+		return err
+	}
+
+	group, err := u.getGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	members, err := u.groups.ListMemberIDs(ctx, group.ID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch group members", err)
+	}
+
+	removed, err := u.groups.RemoveRole(ctx, group.ID, role)
+	if err != nil {
+		return errors.NewDatabaseError("failed to remove group role", err)
+	}
+
+	if !removed {
+		return errors.NewNotFoundError(fmt.Sprintf("group does not hold the %s role", role))
+	}
+
+	u.permissions.Invalidate(members...)
+	return nil
+}
+
+// getGroup retrieves a group of the tenant or fails with not found
+func (u *GroupUsecase) getGroup(ctx context.Context, id string) (*domains.Group, error) {
+	group, err := u.groups.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch group", err)
+	}
+
+	if group == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("group with id %s not found", id))
+	}
+
+	return group, nil
+}
+
+// validateName checks a group name and that no other group of the tenant
+// uses it; selfID is the group being renamed, if any
+func (u *GroupUsecase) validateName(ctx context.Context, name, selfID string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.NewValidationError("name is required", nil)
+	}
+
+	if utf8.RuneCountInString(name) > maxGroupNameLength {
+		return "", errors.NewValidationError(fmt.Sprintf("name must be at most %d characters", maxGroupNameLength), nil)
+	}
+
+	existing, err := u.groups.GetByName(ctx, name)
+	if err != nil {
+		return "", errors.NewDatabaseError("failed to check group name", err)
+	}
+
+	if existing != nil && existing.ID != selfID {
+		return "", errors.NewDuplicateEntryError(fmt.Sprintf("group %s already exists", name))
+	}
+
+	return name, nil
+}
@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/password"
+)
+
+// IdentityConfig holds the tunables for IdentityUsecase
+type IdentityConfig struct {
+	// Provision creates or links a local user the first time an external identity is seen
+	Provision bool
+}
+
+// IdentityUsecase implements the mapping of external identities onto local users
+type IdentityUsecase struct {
+	repo       domains.UserRepository
+	identities domains.UserIdentityRepository
+	roles      domains.RoleRepository
+	hasher     password.Hasher
+	config     IdentityConfig
+}
+
+// NewIdentityUsecase creates a new identity usecase
+func NewIdentityUsecase(
+	repo domains.UserRepository,
+	identities domains.UserIdentityRepository,
+	roles domains.RoleRepository,
+	hasher password.Hasher,
+	config IdentityConfig,
+) domains.IdentityUsecase {
+	return &IdentityUsecase{
+		repo:       repo,
+		identities: identities,
+		roles:      roles,
+		hasher:     hasher,
+		config:     config,
+	}
+}
+
+// ResolveExternalUser returns the local user linked to an external identity,
+// provisioning one on first sight when enabled
+func (u *IdentityUsecase) ResolveExternalUser(ctx context.Context, input *domains.ExternalIdentityInput) (*domains.User, error) {
+	if input == nil || input.Issuer == "" || input.Subject == "" {
+		return nil, errors.NewUnauthorizedError("token does not identify a subject")
+	}
+
+	identity, err := u.identities.GetByIssuerSubject(ctx, input.Issuer, input.Subject)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch identity", err)
+	}
+
+	if identity != nil {
+		user, err := u.repo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("failed to fetch user", err)
+		}
+
+		if user == nil {
+			return nil, errors.NewUnauthorizedError("linked user no longer exists")
+		}
+
+		return u.checkActive(user)
+	}
+
+	if !u.config.Provision {
+		return nil, errors.NewUnauthorizedError("no local account is linked to this identity")
+	}
+
+	return u.provision(ctx, input)
+}
+
+// provision links the identity to the user with the same email, or creates one
+func (u *IdentityUsecase) provision(ctx context.Context, input *domains.ExternalIdentityInput) (*domains.User, error) {
+	if input.Email == "" {
+		return nil, errors.NewUnauthorizedError("token has no email to provision an account with")
+	}
+
+	user, err := u.repo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to check email", err)
+	}
+
+	if user != nil {
+		// Only an issuer that vouches for the address may take over an existing account
+		if !input.EmailVerified {
+			return nil, errors.NewConflictError("an account with this email already exists")
+		}
+
+		if _, err := u.checkActive(user); err != nil {
+			return nil, err
+		}
+	} else {
+		user, err = u.createUser(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.identities.Create(ctx, &domains.UserIdentity{
+		ID:      uuid.New().String(),
+		UserID:  user.ID,
+		Issuer:  input.Issuer,
+		Subject: input.Subject,
+	})
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to link identity", err)
+	}
+
+	return user, nil
+}
+
+// createUser creates a local user that can only sign in through the issuer
+func (u *IdentityUsecase) createUser(ctx context.Context, input *domains.ExternalIdentityInput) (*domains.User, error) {
+	// A random password nobody knows keeps password login closed for this account
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.NewInternalError("failed to generate password", err)
+	}
+
+	passwordHash, err := u.hasher.Hash(base64.RawURLEncoding.EncodeToString(secret))
+	if err != nil {
+		return nil, errors.NewInternalError("failed to hash password", err)
+	}
+
+	user := &domains.User{
+		ID:           uuid.New().String(),
+		Email:        input.Email,
+		PasswordHash: passwordHash,
+		FirstName:    input.FirstName,
+		LastName:     input.LastName,
+		IsActive:     true,
+	}
+
+	// The issuer vouches for the address, there is nothing left for us to verify
+	if input.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := u.repo.Create(ctx, user); err != nil {
+		return nil, repositoryError("failed to create user", err)
+	}
+
+	if err := u.roles.AssignRole(ctx, user.ID, domains.RoleUser); err != nil {
+		return nil, errors.NewDatabaseError("failed to assign default role", err)
+	}
+
+	return user, nil
+}
+
+// checkActive rejects users that may not sign in
+func (u *IdentityUsecase) checkActive(user *domains.User) (*domains.User, error) {
+	if user.DeletedAt != nil {
+		return nil, errors.NewUnauthorizedError("linked user no longer exists")
+	}
+
+	if !user.IsActive {
+		return nil, errors.NewForbiddenError("user account is inactive")
+	}
+
+	return user, nil
+}
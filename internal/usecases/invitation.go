@@ -0,0 +1,358 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// InvitationConfig holds the tunables for InvitationUsecase
+type InvitationConfig struct {
+	TokenTTL time.Duration
+	URL      string // page the token and tenant slug are appended to as query parameters
+}
+
+// InvitationUsecase implements inviting people into a tenant
+type InvitationUsecase struct {
+	invitations domains.InvitationRepository
+	repo        domains.UserRepository
+	users       domains.UserUsecase
+	roles       domains.RoleRepository
+	tenants     domains.TenantRepository
+	sender      domains.MailSender
+	config      InvitationConfig
+}
+
+// NewInvitationUsecase creates a new invitation usecase. New accounts are
+// registered through users, so they get the same defaults as self-registered ones.
+func NewInvitationUsecase(
+	invitations domains.InvitationRepository,
+	repo domains.UserRepository,
+	users domains.UserUsecase,
+	roles domains.RoleRepository,
+	tenants domains.TenantRepository,
+	sender domains.MailSender,
+	config InvitationConfig,
+) domains.InvitationUsecase {
+	return &InvitationUsecase{
+		invitations: invitations,
+		repo:        repo,
+		users:       users,
+		roles:       roles,
+		tenants:     tenants,
+		sender:      sender,
+		config:      config,
+	}
+}
+
+// CreateInvitation mails an invitation to join the tenant with a role. Callers
+// can only invite with roles whose permissions they hold themselves, and an
+// address can have one pending invitation at a time.
+func (u *InvitationUsecase) CreateInvitation(ctx context.Context, input *domains.CreateInvitationInput) (*domains.Invitation, error) {
+	if err := authorize(ctx, domains.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return nil, err
+	}
+
+	if input == nil {
+		return nil, errors.NewValidationError("invitation input is required", nil)
+	}
+
+	email := domains.NormalizeEmail(input.Email)
+	if email == "" {
+		return nil, errors.NewValidationError("email is required", nil)
+	}
+
+	role := strings.TrimSpace(input.Role)
+	if role == "" {
+		return nil, errors.NewValidationError("role is required", nil)
+	}
+
+	if err := authorizeRoleGrant(ctx, u.roles, role); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.invitations.GetPendingByEmail(ctx, email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to check invitations", err)
+	}
+
+	if existing != nil {
+		return nil, errors.NewDuplicateEntryError(fmt.Sprintf("%s already has a pending invitation", email))
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate invitation token", err)
+	}
+
+	invitation := &domains.Invitation{
+		ID:        uuid.New().String(),
+		Email:     email,
+		Role:      role,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(u.config.TokenTTL),
+	}
+
+	if principal, ok := domains.PrincipalFromContext(ctx); ok {
+		invitation.InvitedBy = &principal.UserID
+	}
+
+	if err := u.invitations.Create(ctx, invitation); err != nil {
+		return nil, errors.NewDatabaseError("failed to create invitation", err)
+	}
+
+	if err := u.send(ctx, invitation, token); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// ListPendingInvitations retrieves a paginated list of pending invitations,
+// including expired ones that can still be resent
+func (u *InvitationUsecase) ListPendingInvitations(ctx context.Context, limit, offset int32) ([]*domains.Invitation, error) {
+	if err := authorize(ctx, domains.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	invitations, err := u.invitations.ListPending(ctx, limit, offset)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch invitations", err)
+	}
+
+	return invitations, nil
+}
+
+// ResendInvitation mails a pending invitation again. The new token replaces
+// the old one, which stops working, and the expiry starts over.
+func (u *InvitationUsecase) ResendInvitation(ctx context.Context, id string) (*domains.Invitation, error) {
+	if err := authorize(ctx, domains.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return nil, err
+	}
+
+	invitation, err := u.getPending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate invitation token", err)
+	}
+
+	invitation.TokenHash = hashToken(token)
+	invitation.ExpiresAt = time.Now().Add(u.config.TokenTTL)
+
+	renewed, err := u.invitations.Renew(ctx, invitation.ID, invitation.TokenHash, invitation.ExpiresAt)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to renew invitation", err)
+	}
+
+	if !renewed {
+		return nil, errors.NewConflictError("invitation is no longer pending")
+	}
+
+	if err := u.send(ctx, invitation, token); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// RevokeInvitation withdraws a pending invitation; its link stops working
+func (u *InvitationUsecase) RevokeInvitation(ctx context.Context, id string) error {
+	if err := authorize(ctx, domains.PermissionUsersWrite); err != nil {
+		return err
+	}
+
+	invitation, err := u.getPending(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := u.invitations.Respond(ctx, invitation.ID, domains.InvitationRevoked)
+	if err != nil {
+		return errors.NewDatabaseError("failed to revoke invitation", err)
+	}
+
+	if !revoked {
+		return errors.NewConflictError("invitation is no longer pending")
+	}
+
+	return nil
+}
+
+// AcceptInvitation grants the invited role to the account with the invited
+// email address. Invitees without an account are registered with the given
+// password; following the mailed link verifies their address.
+func (u *InvitationUsecase) AcceptInvitation(ctx context.Context, input *domains.AcceptInvitationInput) (*domains.User, error) {
+	if input == nil {
+		return nil, errors.NewValidationError("accept invitation input is required", nil)
+	}
+
+	invitation, err := u.getByToken(ctx, input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.repo.GetByEmail(ctx, invitation.Email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user != nil && user.IsServiceAccount {
+		return nil, errors.NewBadRequestError("invalid or expired invitation")
+	}
+
+	// Checked before the invitation is consumed, so a rejected password can be retried
+	if user == nil {
+		if err := validatePassword(input.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	accepted, err := u.invitations.Respond(ctx, invitation.ID, domains.InvitationAccepted)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to accept invitation", err)
+	}
+
+	if !accepted {
+		return nil, errors.NewBadRequestError("invalid or expired invitation")
+	}
+
+	if user == nil {
+		user, err = u.users.RegisterUser(ctx, &domains.RegisterUserInput{
+			Email:         invitation.Email,
+			Password:      input.Password,
+			FirstName:     input.FirstName,
+			LastName:      input.LastName,
+			EmailVerified: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		if err := u.repo.MarkEmailVerified(ctx, user.ID, now); err != nil {
+			return nil, errors.NewDatabaseError("failed to update user", err)
+		}
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := u.roles.AssignRole(ctx, user.ID, invitation.Role); err != nil {
+		return nil, errors.NewDatabaseError("failed to assign invited role", err)
+	}
+
+	return user, nil
+}
+
+// DeclineInvitation turns a pending invitation down
+func (u *InvitationUsecase) DeclineInvitation(ctx context.Context, token string) error {
+	invitation, err := u.getByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	declined, err := u.invitations.Respond(ctx, invitation.ID, domains.InvitationDeclined)
+	if err != nil {
+		return errors.NewDatabaseError("failed to decline invitation", err)
+	}
+
+	if !declined {
+		return errors.NewBadRequestError("invalid or expired invitation")
+	}
+
+	return nil
+}
+
+// getPending retrieves an invitation that can still be resent or revoked
+func (u *InvitationUsecase) getPending(ctx context.Context, id string) (*domains.Invitation, error) {
+	invitation, err := u.invitations.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch invitation", err)
+	}
+
+	if invitation == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("invitation with id %s not found", id))
+	}
+
+	if invitation.Status != domains.InvitationPending {
+		return nil, errors.NewConflictError("invitation is no longer pending")
+	}
+
+	return invitation, nil
+}
+
+// getByToken retrieves the pending, unexpired invitation a mailed token belongs to
+func (u *InvitationUsecase) getByToken(ctx context.Context, token string) (*domains.Invitation, error) {
+	if token == "" {
+		return nil, errors.NewValidationError("invitation token is required", nil)
+	}
+
+	invitation, err := u.invitations.GetByHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch invitation", err)
+	}
+
+	if invitation == nil || invitation.Status != domains.InvitationPending || invitation.Expired() {
+		return nil, errors.NewBadRequestError("invalid or expired invitation")
+	}
+
+	return invitation, nil
+}
+
+// send mails the invitation link. The link names the tenant, since the
+// invitee's client must send it along when accepting or declining.
+func (u *InvitationUsecase) send(ctx context.Context, invitation *domains.Invitation, token string) error {
+	tenant, err := u.tenants.GetByID(ctx, invitation.TenantID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch tenant", err)
+	}
+
+	if tenant == nil {
+		return errors.NewInternalError("invitation tenant not found", nil)
+	}
+
+	link, err := url.Parse(u.config.URL)
+	if err != nil {
+		return errors.NewInternalError("invalid invitation URL", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	query.Set("tenant", tenant.Slug)
+	link.RawQuery = query.Encode()
+
+	err = u.sender.Send(ctx, &domains.MailMessage{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to join %s", tenant.Name),
+		Body: fmt.Sprintf("You have been invited to join %s as %s. Accept or decline the invitation by opening the link below:\n\n%s\n\nThe invitation expires in %s. If you were not expecting it, you can ignore this email.\n",
+			tenant.Name, invitation.Role, link.String(), u.config.TokenTTL),
+	})
+	if err != nil {
+		return errors.NewInternalError("failed to send invitation email", err)
+	}
+
+	return nil
+}
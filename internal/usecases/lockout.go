@@ -0,0 +1,233 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// LockoutPolicy holds the tunables for LockoutUsecase. A zero failure limit
+// disables that counter; a zero DelayAfter disables progressive delays.
+type LockoutPolicy struct {
+	FailureWindow        time.Duration // failures older than this are forgotten
+	MaxAccountFailures   int           // failures from any IP before the account is locked
+	MaxIPAccountFailures int           // failures from one IP before that IP is locked out of the account
+	Duration             time.Duration // how long a lockout lasts
+	DelayAfter           int           // failures before progressive delays start
+	BaseDelay            time.Duration // first delay, doubled with every further failure
+	MaxDelay             time.Duration
+}
+
+// LockoutUsecase implements brute-force protection for logins. Failures are
+// counted per account and per account and client IP, so credential stuffing
+// spread over many addresses still locks the account, while a single noisy
+// address is shut out well before the real owner is affected.
+type LockoutUsecase struct {
+	repo     domains.UserRepository
+	attempts domains.LoginAttemptStore
+	logger   *slog.Logger
+	policy   LockoutPolicy
+}
+
+// NewLockoutUsecase creates a new lockout usecase
+func NewLockoutUsecase(
+	repo domains.UserRepository,
+	attempts domains.LoginAttemptStore,
+	logger *slog.Logger,
+	policy LockoutPolicy,
+) domains.LockoutUsecase {
+	return &LockoutUsecase{
+		repo:     repo,
+		attempts: attempts,
+		logger:   logger,
+		policy:   policy,
+	}
+}
+
+// lockoutCounter is one of the failure counters kept for an attempt
+type lockoutCounter struct {
+	ip          string // empty for the account-wide counter
+	maxFailures int
+	event       string
+}
+
+// Check rejects an attempt while a counter is locked or delayed
+func (u *LockoutUsecase) Check(ctx context.Context, email, ip string) error {
+	account := normalizeAccount(ctx, email)
+	now := time.Now()
+
+	var wait time.Duration
+	for _, counter := range u.counters(ip) {
+		attempt, err := u.attempts.Get(ctx, account, counter.ip)
+		if err != nil {
+			return errors.NewDatabaseError("failed to fetch login attempts", err)
+		}
+
+		if attempt == nil {
+			continue
+		}
+
+		if w := u.retryAfter(attempt, now); w > wait {
+			wait = w
+		}
+	}
+
+	if wait > 0 {
+		return errors.NewTooManyAttemptsError("too many failed login attempts, try again later", wait)
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed attempt and locks a counter that reached its limit
+func (u *LockoutUsecase) RecordFailure(ctx context.Context, email, ip string) error {
+	account := normalizeAccount(ctx, email)
+	now := time.Now()
+
+	for _, counter := range u.counters(ip) {
+		attempt, err := u.attempts.RecordFailure(ctx, account, counter.ip, now.Add(-u.policy.FailureWindow))
+		if err != nil {
+			return errors.NewDatabaseError("failed to record login attempt", err)
+		}
+
+		if counter.maxFailures == 0 || attempt.Failures < counter.maxFailures {
+			continue
+		}
+
+		until := now.Add(u.policy.Duration)
+		if err := u.attempts.Lock(ctx, account, counter.ip, until); err != nil {
+			return errors.NewDatabaseError("failed to lock account", err)
+		}
+
+		u.logger.WarnContext(ctx, "login locked out",
+			slog.String("event", counter.event),
+			slog.String("account", account),
+			slog.String("ip", ip),
+			slog.Int("failures", attempt.Failures),
+			slog.Time("locked_until", until),
+		)
+	}
+
+	return nil
+}
+
+// RecordSuccess clears the account-wide counter and the counter of this IP.
+// Counters of other addresses are kept, so an attacker is not let off by the
+// owner signing in.
+func (u *LockoutUsecase) RecordSuccess(ctx context.Context, email, ip string) error {
+	account := normalizeAccount(ctx, email)
+
+	for _, counter := range u.counters(ip) {
+		if err := u.attempts.Reset(ctx, account, counter.ip); err != nil {
+			return errors.NewDatabaseError("failed to reset login attempts", err)
+		}
+	}
+
+	return nil
+}
+
+// Unlock lifts every lockout of a user
+func (u *LockoutUsecase) Unlock(ctx context.Context, userID string) error {
+	if err := authorize(ctx, domains.PermissionUsersWrite); err != nil {
+		return err
+	}
+
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil {
+		return errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", userID))
+	}
+
+	account := normalizeAccount(ctx, user.Email)
+	if err := u.attempts.ResetAccount(ctx, account); err != nil {
+		return errors.NewDatabaseError("failed to reset login attempts", err)
+	}
+
+	attrs := []any{
+		slog.String("event", "account_unlocked"),
+		slog.String("account", account),
+		slog.String("user_id", userID),
+	}
+	if principal, ok := domains.PrincipalFromContext(ctx); ok {
+		attrs = append(attrs, slog.String("actor_id", principal.UserID))
+	}
+	u.logger.InfoContext(ctx, "login lockout lifted", attrs...)
+
+	return nil
+}
+
+// counters lists the enabled counters for an attempt from ip
+func (u *LockoutUsecase) counters(ip string) []lockoutCounter {
+	counters := make([]lockoutCounter, 0, 2)
+
+	if u.policy.MaxAccountFailures > 0 || u.policy.DelayAfter > 0 {
+		counters = append(counters, lockoutCounter{
+			maxFailures: u.policy.MaxAccountFailures,
+			event:       "account_locked",
+		})
+	}
+
+	if ip != "" && (u.policy.MaxIPAccountFailures > 0 || u.policy.DelayAfter > 0) {
+		counters = append(counters, lockoutCounter{
+			ip:          ip,
+			maxFailures: u.policy.MaxIPAccountFailures,
+			event:       "ip_account_locked",
+		})
+	}
+
+	return counters
+}
+
+// retryAfter returns how long a counter still blocks attempts
+func (u *LockoutUsecase) retryAfter(attempt *domains.LoginAttempt, now time.Time) time.Duration {
+	if attempt.LockedUntil != nil && now.Before(*attempt.LockedUntil) {
+		return attempt.LockedUntil.Sub(now)
+	}
+
+	if now.Sub(attempt.LastFailedAt) >= u.policy.FailureWindow {
+		return 0 // Failures have been forgotten
+	}
+
+	if until := attempt.LastFailedAt.Add(u.delay(attempt.Failures)); now.Before(until) {
+		return until.Sub(now)
+	}
+
+	return 0
+}
+
+// delay returns the wait imposed after the given number of failures, doubling
+// from BaseDelay for every failure past DelayAfter up to MaxDelay
+func (u *LockoutUsecase) delay(failures int) time.Duration {
+	if u.policy.DelayAfter == 0 || failures < u.policy.DelayAfter {
+		return 0
+	}
+
+	delay := u.policy.BaseDelay
+	for i := u.policy.DelayAfter; i < failures && delay < u.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if u.policy.MaxDelay > 0 && delay > u.policy.MaxDelay {
+		delay = u.policy.MaxDelay
+	}
+
+	return delay
+}
+
+// normalizeAccount makes the counters of an email address case insensitive.
+// Outside the default tenant the address is qualified by the tenant, so the
+// same address in another tenant has counters of its own.
+func normalizeAccount(ctx context.Context, email string) string {
+	account := domains.NormalizeEmail(email)
+	if tenantID, ok := domains.TenantFromContext(ctx); ok && tenantID != domains.DefaultTenantID {
+		return tenantID + ":" + account
+	}
+	return account
+}
@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// MagicLinkConfig holds the tunables for MagicLinkUsecase
+type MagicLinkConfig struct {
+	TokenTTL        time.Duration
+	RequestInterval time.Duration // minimum time between two login emails to the same user
+	URL             string        // page the token is appended to as the token query parameter
+}
+
+// MagicLinkUsecase implements passwordless login by email. A link only works
+// for the client that asked for it: that client receives a random binding
+// which has to accompany the mailed token.
+type MagicLinkUsecase struct {
+	repo   domains.UserRepository
+	tokens domains.MagicLinkTokenRepository
+	auth   domains.AuthUsecase
+	sender domains.MailSender
+	logger *slog.Logger
+	config MagicLinkConfig
+}
+
+// NewMagicLinkUsecase creates a new magic link usecase
+func NewMagicLinkUsecase(
+	repo domains.UserRepository,
+	tokens domains.MagicLinkTokenRepository,
+	auth domains.AuthUsecase,
+	sender domains.MailSender,
+	logger *slog.Logger,
+	config MagicLinkConfig,
+) domains.MagicLinkUsecase {
+	return &MagicLinkUsecase{
+		repo:   repo,
+		tokens: tokens,
+		auth:   auth,
+		sender: sender,
+		logger: logger,
+		config: config,
+	}
+}
+
+// RequestLink mails a login link to a registered address. A binding is
+// returned for every request, so unknown and deleted addresses, service
+// accounts, inactive users and requests within the request interval cannot
+// be told apart from a mailed link.
+func (u *MagicLinkUsecase) RequestLink(ctx context.Context, email string) (string, error) {
+	if email == "" {
+		return "", errors.NewValidationError("email is required", nil)
+	}
+
+	binding, err := generateToken()
+	if err != nil {
+		return "", errors.NewInternalError("failed to generate magic link binding", err)
+	}
+
+	user, err := u.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return "", errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil || user.IsServiceAccount || !user.IsActive {
+		return binding, nil
+	}
+
+	latest, err := u.tokens.GetLatestForUser(ctx, user.ID)
+	if err != nil {
+		return "", errors.NewDatabaseError("failed to fetch magic link token", err)
+	}
+
+	if latest != nil && time.Since(latest.CreatedAt) < u.config.RequestInterval {
+		return binding, nil
+	}
+
+	// Only the most recent link works
+	if err := u.tokens.InvalidateForUser(ctx, user.ID); err != nil {
+		return "", errors.NewDatabaseError("failed to invalidate magic link tokens", err)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return "", errors.NewInternalError("failed to generate magic link token", err)
+	}
+
+	err = u.tokens.Create(ctx, &domains.MagicLinkToken{
+		ID:          uuid.New().String(),
+		UserID:      user.ID,
+		TenantID:    user.TenantID,
+		TokenHash:   hashToken(token),
+		BindingHash: hashToken(binding),
+		ExpiresAt:   time.Now().Add(u.config.TokenTTL),
+	})
+	if err != nil {
+		return "", errors.NewDatabaseError("failed to store magic link token", err)
+	}
+
+	link, err := url.Parse(u.config.URL)
+	if err != nil {
+		return "", errors.NewInternalError("invalid magic link URL", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	err = u.sender.Send(ctx, &domains.MailMessage{
+		To:      user.Email,
+		Subject: "Your sign-in link",
+		Body: fmt.Sprintf("Sign in by opening the link below in the browser you requested it from:\n\n%s\n\nThe link expires in %s and works once. If you did not ask to sign in, you can ignore this email.\n",
+			link.String(), u.config.TokenTTL),
+	})
+	if err != nil {
+		// Failing the request would tell registered addresses apart from
+		// unknown ones
+		u.logger.ErrorContext(ctx, "magic link email not sent",
+			slog.String("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return binding, nil
+}
+
+// Login consumes a login token presented together with its binding and
+// issues the token pair, or an MFA challenge for users with MFA enabled
+func (u *MagicLinkUsecase) Login(ctx context.Context, input *domains.MagicLinkLoginInput) (*domains.AuthToken, error) {
+	if input == nil || input.Token == "" {
+		return nil, errors.NewValidationError("magic link token is required", nil)
+	}
+
+	stored, err := u.tokens.GetByHash(ctx, hashToken(input.Token))
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch magic link token", err)
+	}
+
+	if stored == nil || stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return nil, errors.NewUnauthorizedError("invalid or expired magic link")
+	}
+
+	// A link forwarded to, or intercepted by, someone else is useless without the binding
+	if subtle.ConstantTimeCompare([]byte(hashToken(input.Binding)), []byte(stored.BindingHash)) != 1 {
+		return nil, errors.NewUnauthorizedError("magic link was requested from another client")
+	}
+
+	// The login completes in the tenant the link was issued in, so that the
+	// token pair belongs to it as well
+	ctx = domains.WithTenant(ctx, stored.TenantID)
+
+	user, err := u.repo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil {
+		return nil, errors.NewUnauthorizedError("invalid or expired magic link")
+	}
+
+	consumed, err := u.tokens.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to consume magic link token", err)
+	}
+
+	if !consumed {
+		return nil, errors.NewUnauthorizedError("invalid or expired magic link")
+	}
+
+	// Following the mailed link proves control of the address
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		if err := u.repo.MarkEmailVerified(ctx, user.ID, now); err != nil {
+			return nil, errors.NewDatabaseError("failed to update user", err)
+		}
+		user.EmailVerifiedAt = &now
+	}
+
+	return u.auth.LoginWithoutPassword(ctx, user)
+}
@@ -0,0 +1,342 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/password"
+	"github.com/zercle/template-go-fiber/pkg/totp"
+)
+
+const (
+	// totpSkew is the number of time steps a code may be off, to tolerate clock drift
+	totpSkew = 1
+
+	// recoveryCodeCount is the number of recovery codes issued at a time
+	recoveryCodeCount = 10
+
+	// recoveryCodeLength is the number of characters in a recovery code, without the dash
+	recoveryCodeLength = 10
+)
+
+// MFAConfig holds the tunables for MFAUsecase
+type MFAConfig struct {
+	Issuer string // name shown in authenticator apps
+}
+
+// MFAUsecase implements the TOTP enrollment business logic
+type MFAUsecase struct {
+	repo   domains.UserRepository
+	mfa    domains.MFARepository
+	hasher password.Hasher
+	config MFAConfig
+}
+
+// NewMFAUsecase creates a new MFA usecase
+func NewMFAUsecase(
+	repo domains.UserRepository,
+	mfa domains.MFARepository,
+	hasher password.Hasher,
+	config MFAConfig,
+) domains.MFAUsecase {
+	return &MFAUsecase{
+		repo:   repo,
+		mfa:    mfa,
+		hasher: hasher,
+		config: config,
+	}
+}
+
+// GetStatus reports whether a user has MFA enabled
+func (u *MFAUsecase) GetStatus(ctx context.Context, userID string) (*domains.MFAStatus, error) {
+	factor, err := u.mfa.GetFactor(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch MFA factor", err)
+	}
+
+	if factor == nil || factor.ConfirmedAt == nil {
+		return &domains.MFAStatus{}, nil
+	}
+
+	remaining, err := u.mfa.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to count recovery codes", err)
+	}
+
+	return &domains.MFAStatus{
+		Enabled:                true,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// Enroll starts enrollment with a new secret; it has to be confirmed with a code
+func (u *MFAUsecase) Enroll(ctx context.Context, userID string) (*domains.MFAEnrollment, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	factor, err := u.mfa.GetFactor(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch MFA factor", err)
+	}
+
+	if factor != nil && factor.ConfirmedAt != nil {
+		return nil, errors.NewConflictError("MFA is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate MFA secret", err)
+	}
+
+	if err := u.mfa.SaveFactor(ctx, userID, secret); err != nil {
+		return nil, errors.NewDatabaseError("failed to store MFA factor", err)
+	}
+
+	return &domains.MFAEnrollment{
+		Secret: secret,
+		URI:    totp.URI(u.config.Issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment activates MFA with a code from the authenticator app and
+// returns the recovery codes
+func (u *MFAUsecase) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	if code == "" {
+		return nil, errors.NewValidationError("code is required", nil)
+	}
+
+	factor, err := u.mfa.GetFactor(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch MFA factor", err)
+	}
+
+	if factor == nil {
+		return nil, errors.NewBadRequestError("MFA enrollment has not been started")
+	}
+
+	if factor.ConfirmedAt != nil {
+		return nil, errors.NewConflictError("MFA is already enabled")
+	}
+
+	// Recovery codes do not exist yet; only the app can prove the secret was imported
+	valid, err := verifyTOTP(ctx, u.mfa, factor, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		return nil, errors.NewBadRequestError("invalid MFA code")
+	}
+
+	confirmed, err := u.mfa.ConfirmFactor(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to confirm MFA factor", err)
+	}
+
+	if !confirmed {
+		return nil, errors.NewConflictError("MFA is already enabled")
+	}
+
+	return u.issueRecoveryCodes(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user
+func (u *MFAUsecase) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if code == "" {
+		return nil, errors.NewValidationError("code is required", nil)
+	}
+
+	factor, err := u.getConfirmedFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := verifySecondFactor(ctx, u.mfa, factor, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		return nil, errors.NewBadRequestError("invalid MFA code")
+	}
+
+	return u.issueRecoveryCodes(ctx, userID)
+}
+
+// Disable turns MFA off for a user who proves both factors
+func (u *MFAUsecase) Disable(ctx context.Context, userID string, input *domains.DisableMFAInput) error {
+	if input == nil {
+		return errors.NewValidationError("disable MFA input is required", nil)
+	}
+
+	if input.Password == "" {
+		return errors.NewValidationError("password is required", nil)
+	}
+
+	if input.Code == "" {
+		return errors.NewValidationError("code is required", nil)
+	}
+
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Hashes in an unknown format, and the empty hash of service accounts,
+	// never match
+	match, err := u.hasher.Verify(input.Password, user.PasswordHash)
+	if err != nil && !stderrors.Is(err, password.ErrUnsupportedHash) {
+		return errors.NewInternalError("failed to verify password", err)
+	}
+
+	if !match {
+		return errors.NewBadRequestError("password is incorrect")
+	}
+
+	factor, err := u.getConfirmedFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	valid, err := verifySecondFactor(ctx, u.mfa, factor, input.Code)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return errors.NewBadRequestError("invalid MFA code")
+	}
+
+	if err := u.mfa.DeleteFactor(ctx, userID); err != nil {
+		return errors.NewDatabaseError("failed to delete MFA factor", err)
+	}
+
+	return nil
+}
+
+// Reset turns MFA off for another user, e.g. after a lost device
+func (u *MFAUsecase) Reset(ctx context.Context, userID string) error {
+	if err := authorize(ctx, domains.PermissionUsersWrite); err != nil {
+		return err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return err
+	}
+
+	if _, err := u.getUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := u.mfa.DeleteFactor(ctx, userID); err != nil {
+		return errors.NewDatabaseError("failed to delete MFA factor", err)
+	}
+
+	return nil
+}
+
+// getUser fetches a user that has not been deleted
+func (u *MFAUsecase) getUser(ctx context.Context, userID string) (*domains.User, error) {
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", userID))
+	}
+
+	return user, nil
+}
+
+// getConfirmedFactor fetches the factor of a user that has MFA enabled
+func (u *MFAUsecase) getConfirmedFactor(ctx context.Context, userID string) (*domains.MFAFactor, error) {
+	factor, err := u.mfa.GetFactor(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch MFA factor", err)
+	}
+
+	if factor == nil || factor.ConfirmedAt == nil {
+		return nil, errors.NewBadRequestError("MFA is not enabled")
+	}
+
+	return factor, nil
+}
+
+// issueRecoveryCodes replaces the recovery codes of a user and returns the new
+// ones in plain text; only their hashes are stored
+func (u *MFAUsecase) issueRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, errors.NewInternalError("failed to generate recovery code", err)
+		}
+		codes[i] = code
+		hashes[i] = hashToken(normalizeRecoveryCode(code))
+	}
+
+	if err := u.mfa.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, errors.NewDatabaseError("failed to store recovery codes", err)
+	}
+
+	return codes, nil
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code
+func verifySecondFactor(ctx context.Context, mfa domains.MFARepository, factor *domains.MFAFactor, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+
+	if len(code) == totp.Digits {
+		return verifyTOTP(ctx, mfa, factor, code)
+	}
+
+	used, err := mfa.UseRecoveryCode(ctx, factor.UserID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return false, errors.NewDatabaseError("failed to consume recovery code", err)
+	}
+
+	return used, nil
+}
+
+// verifyTOTP checks a TOTP code and burns its time step so it cannot be replayed
+func verifyTOTP(ctx context.Context, mfa domains.MFARepository, factor *domains.MFAFactor, code string) (bool, error) {
+	step, ok := totp.Validate(factor.Secret, strings.TrimSpace(code), time.Now(), totpSkew)
+	if !ok || step <= factor.LastUsedStep {
+		return false, nil
+	}
+
+	advanced, err := mfa.AdvanceStep(ctx, factor.UserID, step)
+	if err != nil {
+		return false, errors.NewDatabaseError("failed to record MFA code", err)
+	}
+
+	return advanced, nil
+}
+
+// generateRecoveryCode returns a random code formatted as xxxxx-xxxxx
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))[:recoveryCodeLength]
+	return code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:], nil
+}
+
+// normalizeRecoveryCode makes recovery codes case and separator insensitive
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
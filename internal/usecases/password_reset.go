@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/password"
+)
+
+// PasswordResetConfig holds the tunables for PasswordUsecase
+type PasswordResetConfig struct {
+	TokenTTL        time.Duration
+	RequestInterval time.Duration // minimum time between two reset emails to the same user
+	URL             string        // page the token is appended to as the token query parameter
+}
+
+// PasswordUsecase implements the password change and reset business logic
+type PasswordUsecase struct {
+	repo          domains.UserRepository
+	tokens        domains.PasswordResetTokenRepository
+	hasher        password.Hasher
+	revocations   domains.TokenRevocationStore
+	refreshTokens domains.RefreshTokenRepository
+	sender        domains.MailSender
+	logger        *slog.Logger
+	config        PasswordResetConfig
+}
+
+// NewPasswordUsecase creates a new password usecase
+func NewPasswordUsecase(
+	repo domains.UserRepository,
+	tokens domains.PasswordResetTokenRepository,
+	hasher password.Hasher,
+	revocations domains.TokenRevocationStore,
+	refreshTokens domains.RefreshTokenRepository,
+	sender domains.MailSender,
+	logger *slog.Logger,
+	config PasswordResetConfig,
+) domains.PasswordUsecase {
+	return &PasswordUsecase{
+		repo:          repo,
+		tokens:        tokens,
+		hasher:        hasher,
+		revocations:   revocations,
+		refreshTokens: refreshTokens,
+		sender:        sender,
+		logger:        logger,
+		config:        config,
+	}
+}
+
+// ChangePassword replaces the password of a user who knows the current one.
+// Admins impersonating the user cannot change it.
+func (u *PasswordUsecase) ChangePassword(ctx context.Context, userID string, input *domains.ChangePasswordInput) error {
+	if err := rejectImpersonation(ctx); err != nil {
+		return err
+	}
+
+	if input == nil {
+		return errors.NewValidationError("change password input is required", nil)
+	}
+
+	if input.CurrentPassword == "" {
+		return errors.NewValidationError("current password is required", nil)
+	}
+
+	if err := validatePassword(input.NewPassword); err != nil {
+		return err
+	}
+
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil {
+		return errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", userID))
+	}
+
+	// Hashes in an unknown format, and the empty hash of service accounts,
+	// never match
+	match, err := u.hasher.Verify(input.CurrentPassword, user.PasswordHash)
+	if err != nil && !stderrors.Is(err, password.ErrUnsupportedHash) {
+		return errors.NewInternalError("failed to verify password", err)
+	}
+
+	if !match {
+		return errors.NewBadRequestError("current password is incorrect")
+	}
+
+	return u.setPassword(ctx, user, input.NewPassword)
+}
+
+// ForgotPassword mails a reset token to a registered address. Unknown and
+// deleted addresses, service accounts, and requests within the request
+// interval are silently ignored so the response does not reveal whether an
+// account exists.
+func (u *PasswordUsecase) ForgotPassword(ctx context.Context, email string) error {
+	if email == "" {
+		return errors.NewValidationError("email is required", nil)
+	}
+
+	user, err := u.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil || user.IsServiceAccount {
+		return nil
+	}
+
+	latest, err := u.tokens.GetLatestForUser(ctx, user.ID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch reset token", err)
+	}
+
+	if latest != nil && time.Since(latest.CreatedAt) < u.config.RequestInterval {
+		return nil
+	}
+
+	// Only the most recent link works
+	if err := u.tokens.InvalidateForUser(ctx, user.ID); err != nil {
+		return errors.NewDatabaseError("failed to invalidate reset tokens", err)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return errors.NewInternalError("failed to generate reset token", err)
+	}
+
+	err = u.tokens.Create(ctx, &domains.PasswordResetToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TenantID:  user.TenantID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(u.config.TokenTTL),
+	})
+	if err != nil {
+		return errors.NewDatabaseError("failed to store reset token", err)
+	}
+
+	link, err := url.Parse(u.config.URL)
+	if err != nil {
+		return errors.NewInternalError("invalid password reset URL", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	err = u.sender.Send(ctx, &domains.MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Choose a new password by opening the link below:\n\n%s\n\nThe link expires in %s. If you did not ask to reset your password, you can ignore this email.\n",
+			link.String(), u.config.TokenTTL),
+	})
+	if err != nil {
+		// Failing the request would tell registered addresses apart from
+		// unknown ones
+		u.logger.ErrorContext(ctx, "password reset email not sent",
+			slog.String("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return nil
+}
+
+// ResetPassword consumes a reset token and sets a new password
+func (u *PasswordUsecase) ResetPassword(ctx context.Context, input *domains.ResetPasswordInput) error {
+	if input == nil {
+		return errors.NewValidationError("reset password input is required", nil)
+	}
+
+	if input.Token == "" {
+		return errors.NewValidationError("reset token is required", nil)
+	}
+
+	if err := validatePassword(input.NewPassword); err != nil {
+		return err
+	}
+
+	stored, err := u.tokens.GetByHash(ctx, hashToken(input.Token))
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch reset token", err)
+	}
+
+	if stored == nil || stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return errors.NewBadRequestError("invalid or expired reset token")
+	}
+
+	// The reset page need not name the tenant, so the user is looked up in
+	// the one the token was issued in
+	ctx = domains.WithTenant(ctx, stored.TenantID)
+
+	user, err := u.repo.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil || user.DeletedAt != nil {
+		return errors.NewBadRequestError("invalid or expired reset token")
+	}
+
+	consumed, err := u.tokens.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return errors.NewDatabaseError("failed to consume reset token", err)
+	}
+
+	if !consumed {
+		return errors.NewBadRequestError("invalid or expired reset token")
+	}
+
+	// Following the mailed link proves control of the address
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	return u.setPassword(ctx, user, input.NewPassword)
+}
+
+// setPassword stores a new password hash and signs the user out everywhere,
+// since the old password may be what an attacker used to get in
+func (u *PasswordUsecase) setPassword(ctx context.Context, user *domains.User, plain string) error {
+	passwordHash, err := u.hasher.Hash(plain)
+	if err != nil {
+		return errors.NewInternalError("failed to hash password", err)
+	}
+
+	user.PasswordHash = passwordHash
+	if err := u.repo.Update(ctx, user); err != nil {
+		return repositoryError("failed to update user", err)
+	}
+
+	// Reset links issued before the change must not outlive it
+	if err := u.tokens.InvalidateForUser(ctx, user.ID); err != nil {
+		return errors.NewDatabaseError("failed to invalidate reset tokens", err)
+	}
+
+	return revokeSessions(ctx, u.revocations, u.refreshTokens, user.ID)
+}
+
+// validatePassword checks a new password against the password policy
+func validatePassword(plain string) error {
+	if plain == "" {
+		return errors.NewValidationError("password is required", nil)
+	}
+
+	if len(plain) > password.MaxLength {
+		return errors.NewValidationError(fmt.Sprintf("password must be at most %d bytes", password.MaxLength), nil)
+	}
+
+	return nil
+}
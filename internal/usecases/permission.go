@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// PermissionCacheConfig holds the tunables for PermissionCache
+type PermissionCacheConfig struct {
+	TTL time.Duration // how long a lookup is reused; zero disables caching
+}
+
+// PermissionCache implements domains.PermissionResolver on top of the role
+// repository. Lookups are kept for the configured TTL, which bounds how long
+// role changes made elsewhere, such as by another instance, take to apply.
+type PermissionCache struct {
+	roles  domains.RoleRepository
+	config PermissionCacheConfig
+
+	mu         sync.Mutex
+	entries    map[string]cachedPermissions
+	generation uint64 // bumped by Invalidate, so lookups racing it are not stored
+	nextSweep  time.Time
+}
+
+// cachedPermissions is the result of one lookup
+type cachedPermissions struct {
+	permissions []string
+	expiresAt   time.Time
+}
+
+// NewPermissionCache creates a new permission cache
+func NewPermissionCache(roles domains.RoleRepository, config PermissionCacheConfig) *PermissionCache {
+	return &PermissionCache{
+		roles:   roles,
+		config:  config,
+		entries: make(map[string]cachedPermissions),
+	}
+}
+
+// Permissions returns the permissions a user holds through their own roles
+// and those of their groups
+func (c *PermissionCache) Permissions(ctx context.Context, userID string) ([]string, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[userID]
+	generation := c.generation
+	c.mu.Unlock()
+
+	if ok && now.Before(entry.expiresAt) {
+		return entry.permissions, nil
+	}
+
+	permissions, err := c.roles.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.config.TTL > 0 {
+		c.mu.Lock()
+		if generation == c.generation {
+			c.entries[userID] = cachedPermissions{permissions: permissions, expiresAt: now.Add(c.config.TTL)}
+			c.sweep(now)
+		}
+		c.mu.Unlock()
+	}
+
+	return permissions, nil
+}
+
+// Invalidate forgets the permissions of the users
+func (c *PermissionCache) Invalidate(userIDs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, userID := range userIDs {
+		delete(c.entries, userID)
+	}
+	c.generation++
+}
+
+// sweep drops expired entries at most once per TTL, so that users who stop
+// making requests do not stay in memory. The caller must hold mu.
+func (c *PermissionCache) sweep(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+
+	for userID, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, userID)
+		}
+	}
+
+	c.nextSweep = now.Add(c.config.TTL)
+}
@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// authorizeUser checks that the caller may act on the target user, either
+// through a permission covering every account or a self permission on their
+// own. Calls without a principal come from trusted internal code.
+func authorizeUser(ctx context.Context, targetID, anyUser, self string) error {
+	principal, ok, err := currentPrincipal(ctx)
+	if !ok || err != nil {
+		return err
+	}
+
+	if principal.HasPermission(anyUser) {
+		return nil
+	}
+
+	if principal.UserID == targetID && principal.HasPermission(self) {
+		return nil
+	}
+
+	return errors.NewForbiddenError("you are not allowed to access this user")
+}
+
+// authorize checks that the caller holds any of the permissions
+func authorize(ctx context.Context, permissions ...string) error {
+	principal, ok, err := currentPrincipal(ctx)
+	if !ok || err != nil {
+		return err
+	}
+
+	if principal.HasPermission(permissions...) {
+		return nil
+	}
+
+	return errors.NewForbiddenError("insufficient permissions")
+}
+
+// authorizeRoleGrant checks that a role exists and that the caller holds
+// every permission it grants, so that granting it cannot escalate privileges
+func authorizeRoleGrant(ctx context.Context, roles domains.RoleRepository, role string) error {
+	permissions, err := roles.GetRolePermissions(ctx, role)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch role permissions", err)
+	}
+
+	if len(permissions) == 0 {
+		return errors.NewValidationError(fmt.Sprintf("unknown role %s", role), nil)
+	}
+
+	principal, ok, err := currentPrincipal(ctx)
+	if !ok || err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		if !principal.HasPermission(permission) {
+			return errors.NewForbiddenError(fmt.Sprintf("you cannot grant the %s role", role))
+		}
+	}
+
+	return nil
+}
+
+// currentPrincipal returns the caller with the permissions they hold now,
+// which may differ from those their credentials were issued with. It reports
+// false for trusted internal calls without a principal.
+func currentPrincipal(ctx context.Context) (*domains.Principal, bool, error) {
+	principal, ok := domains.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, false, nil
+	}
+
+	permissions, err := domains.CurrentPermissions(ctx, principal)
+	if err != nil {
+		return nil, true, errors.NewDatabaseError("failed to fetch permissions", err)
+	}
+
+	current := *principal
+	current.Permissions = permissions
+	return &current, true, nil
+}
+
+// rejectImpersonation refuses operations an admin must not perform while
+// acting as another user
+func rejectImpersonation(ctx context.Context) error {
+	if principal, ok := domains.PrincipalFromContext(ctx); ok && principal.Impersonated() {
+		return errors.NewForbiddenError("not allowed while impersonating a user")
+	}
+	return nil
+}
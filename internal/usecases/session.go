@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// SessionConfig holds the tunables for SessionUsecase
+type SessionConfig struct {
+	// IdleTimeout ends a session that has not been used for this long
+	IdleTimeout time.Duration
+
+	// AbsoluteTimeout ends a session this long after login, however active it is
+	AbsoluteTimeout time.Duration
+}
+
+// SessionUsecase implements the cookie session business logic
+type SessionUsecase struct {
+	store       domains.SessionStore
+	roles       domains.RoleRepository
+	revocations domains.TokenRevocationStore
+	config      SessionConfig
+}
+
+// NewSessionUsecase creates a new session usecase. Sessions honour user-wide
+// token revocations, so deactivating a user or changing a password ends them too.
+func NewSessionUsecase(
+	store domains.SessionStore,
+	roles domains.RoleRepository,
+	revocations domains.TokenRevocationStore,
+	config SessionConfig,
+) domains.SessionUsecase {
+	return &SessionUsecase{
+		store:       store,
+		roles:       roles,
+		revocations: revocations,
+		config:      config,
+	}
+}
+
+// Start creates a session for a user who has just logged in
+func (u *SessionUsecase) Start(ctx context.Context, user *domains.User) (*domains.IssuedSession, error) {
+	roles, permissions, err := u.loadAccess(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate session token", err)
+	}
+
+	csrfToken, err := generateToken()
+	if err != nil {
+		return nil, errors.NewInternalError("failed to generate CSRF token", err)
+	}
+
+	now := time.Now()
+	absoluteExpiresAt := now.Add(u.config.AbsoluteTimeout)
+
+	session := &domains.Session{
+		ID:                hashToken(token),
+		UserID:            user.ID,
+		TenantID:          user.TenantID,
+		Email:             user.Email,
+		Roles:             roles,
+		Permissions:       permissions,
+		CSRFToken:         csrfToken,
+		ExpiresAt:         u.idleExpiry(now, absoluteExpiresAt),
+		AbsoluteExpiresAt: absoluteExpiresAt,
+		LastSeenAt:        now,
+		CreatedAt:         now,
+	}
+
+	if err := u.store.Create(ctx, session); err != nil {
+		return nil, errors.NewDatabaseError("failed to store session", err)
+	}
+
+	return &domains.IssuedSession{
+		Session: session,
+		Token:   token,
+	}, nil
+}
+
+// Resume looks up the session for a cookie value and extends it while in use.
+// The expiry only moves once less than half of the idle timeout is left, so
+// busy sessions are not written on every request.
+func (u *SessionUsecase) Resume(ctx context.Context, token string) (*domains.Session, bool, error) {
+	if token == "" {
+		return nil, false, errors.NewUnauthorizedError("invalid session")
+	}
+
+	session, err := u.store.Get(ctx, hashToken(token))
+	if err != nil {
+		return nil, false, errors.NewDatabaseError("failed to fetch session", err)
+	}
+
+	if session == nil {
+		return nil, false, errors.NewUnauthorizedError("invalid session")
+	}
+
+	now := time.Now()
+	if !now.Before(session.ExpiresAt) {
+		return nil, false, u.endWith(ctx, session.ID, "session has expired")
+	}
+
+	if u.revocations != nil {
+		revoked, err := u.revocations.IsRevoked(ctx, "", session.UserID, session.CreatedAt)
+		if err != nil {
+			return nil, false, errors.NewDatabaseError("failed to check session revocation", err)
+		}
+		if revoked {
+			return nil, false, u.endWith(ctx, session.ID, "session has been revoked")
+		}
+	}
+
+	expiresAt := u.idleExpiry(now, session.AbsoluteExpiresAt)
+	if session.ExpiresAt.Sub(now) >= u.config.IdleTimeout/2 || !expiresAt.After(session.ExpiresAt) {
+		return session, false, nil
+	}
+
+	// Roles are reloaded on every extension so that changes apply within half an idle timeout
+	if session.Roles, session.Permissions, err = u.loadAccess(ctx, session.UserID); err != nil {
+		return nil, false, err
+	}
+
+	session.ExpiresAt = expiresAt
+	session.LastSeenAt = now
+
+	if err := u.store.Update(ctx, session); err != nil {
+		return nil, false, errors.NewDatabaseError("failed to extend session", err)
+	}
+
+	return session, true, nil
+}
+
+// End destroys a session
+func (u *SessionUsecase) End(ctx context.Context, id string) error {
+	if err := u.store.Delete(ctx, id); err != nil {
+		return errors.NewDatabaseError("failed to delete session", err)
+	}
+	return nil
+}
+
+// endWith removes a session that can no longer be used and returns the error for the caller
+func (u *SessionUsecase) endWith(ctx context.Context, id, message string) error {
+	if err := u.End(ctx, id); err != nil {
+		return err
+	}
+	return errors.NewUnauthorizedError(message)
+}
+
+// idleExpiry returns the expiry for a session used at now, capped by its absolute expiry
+func (u *SessionUsecase) idleExpiry(now, absoluteExpiresAt time.Time) time.Time {
+	expiresAt := now.Add(u.config.IdleTimeout)
+	if expiresAt.After(absoluteExpiresAt) {
+		return absoluteExpiresAt
+	}
+	return expiresAt
+}
+
+// loadAccess fetches the roles and permissions a session carries
+func (u *SessionUsecase) loadAccess(ctx context.Context, userID string) ([]string, []string, error) {
+	roles, err := u.roles.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("failed to fetch roles", err)
+	}
+
+	permissions, err := u.roles.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("failed to fetch permissions", err)
+	}
+
+	return roles, permissions, nil
+}
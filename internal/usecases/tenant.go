@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// tenantSlugPattern restricts slugs to DNS labels, so that every tenant can
+// be addressed by subdomain
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// TenantUsecase implements the tenant business logic
+type TenantUsecase struct {
+	repo domains.TenantRepository
+}
+
+// NewTenantUsecase creates a new tenant usecase
+func NewTenantUsecase(repo domains.TenantRepository) domains.TenantUsecase {
+	return &TenantUsecase{
+		repo: repo,
+	}
+}
+
+// CreateTenant creates a new tenant
+func (u *TenantUsecase) CreateTenant(ctx context.Context, input *domains.CreateTenantInput) (*domains.Tenant, error) {
+	if err := authorize(ctx, domains.PermissionTenantsWrite); err != nil {
+		return nil, err
+	}
+
+	if input == nil {
+		return nil, errors.NewValidationError("tenant input is required", nil)
+	}
+
+	slug := strings.ToLower(strings.TrimSpace(input.Slug))
+	if !tenantSlugPattern.MatchString(slug) {
+		return nil, errors.NewValidationError("slug must be a DNS label of lowercase letters, digits and hyphens", nil)
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", nil)
+	}
+
+	existing, err := u.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to check slug", err)
+	}
+
+	if existing != nil {
+		return nil, errors.NewDuplicateEntryError(fmt.Sprintf("tenant %s already exists", slug))
+	}
+
+	tenant := &domains.Tenant{
+		ID:       uuid.New().String(),
+		Slug:     slug,
+		Name:     name,
+		IsActive: true,
+	}
+
+	if err := u.repo.Create(ctx, tenant); err != nil {
+		return nil, errors.NewDatabaseError("failed to create tenant", err)
+	}
+
+	return tenant, nil
+}
+
+// GetTenant retrieves a tenant by ID
+func (u *TenantUsecase) GetTenant(ctx context.Context, id string) (*domains.Tenant, error) {
+	if err := authorize(ctx, domains.PermissionTenantsRead); err != nil {
+		return nil, err
+	}
+
+	tenant, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch tenant", err)
+	}
+
+	if tenant == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("tenant with id %s not found", id))
+	}
+
+	return tenant, nil
+}
+
+// ListTenants retrieves a paginated tenant list
+func (u *TenantUsecase) ListTenants(ctx context.Context, limit, offset int32) ([]*domains.Tenant, error) {
+	if err := authorize(ctx, domains.PermissionTenantsRead); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	tenants, err := u.repo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch tenants", err)
+	}
+
+	return tenants, nil
+}
+
+// ResolveTenant returns the active tenant a request names by slug. Unknown
+// and inactive tenants are indistinguishable to the caller.
+func (u *TenantUsecase) ResolveTenant(ctx context.Context, slug string) (*domains.Tenant, error) {
+	slug = strings.ToLower(slug)
+	if !tenantSlugPattern.MatchString(slug) {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("tenant %s not found", slug))
+	}
+
+	tenant, err := u.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch tenant", err)
+	}
+
+	if tenant == nil || !tenant.IsActive {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("tenant %s not found", slug))
+	}
+
+	return tenant, nil
+}
@@ -1,30 +1,82 @@
 package usecases
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/zercle/template-go-fiber/internal/domains"
 	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/pkg/cursor"
+	"github.com/zercle/template-go-fiber/pkg/jsonpatch"
+	"github.com/zercle/template-go-fiber/pkg/password"
 )
 
 //go:generate mockgen -source=user.go -destination=../../test/unit/mocks/mock_user_usecase.go -package=mocks
 
+// maxUserSearchLength bounds the free-text search of the user list
+const maxUserSearchLength = 100
+
+// UserConfig contains user management configuration
+type UserConfig struct {
+	CursorSecret []byte // signs the cursors of keyset paginated user lists
+}
+
 // UserUsecase implements the user business logic
 type UserUsecase struct {
-	repo domains.UserRepository
+	repo          domains.UserRepository
+	hasher        password.Hasher
+	revocations   domains.TokenRevocationStore
+	refreshTokens domains.RefreshTokenRepository
+	roles         domains.RoleRepository
+	verification  domains.EmailVerificationUsecase
+	cursors       *cursor.Signer
+}
+
+// userCursor is the signed position behind the opaque cursors of user
+// lists. It records the sort it was issued for, so that it cannot be
+// replayed against the opposite order.
+type userCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+	Backward  bool      `json:"b,omitempty"`
+	Desc      bool      `json:"d,omitempty"`
 }
 
 // NewUserUsecase creates a new user usecase
-func NewUserUsecase(repo domains.UserRepository) domains.UserUsecase {
+func NewUserUsecase(
+	repo domains.UserRepository,
+	hasher password.Hasher,
+	revocations domains.TokenRevocationStore,
+	refreshTokens domains.RefreshTokenRepository,
+	roles domains.RoleRepository,
+	verification domains.EmailVerificationUsecase,
+	config UserConfig,
+) domains.UserUsecase {
 	return &UserUsecase{
-		repo: repo,
+		repo:          repo,
+		hasher:        hasher,
+		revocations:   revocations,
+		refreshTokens: refreshTokens,
+		roles:         roles,
+		verification:  verification,
+		cursors:       cursor.NewSigner(config.CursorSecret),
 	}
 }
 
 // GetUserByID retrieves a user by ID
 func (u *UserUsecase) GetUserByID(ctx context.Context, id string) (*domains.User, error) {
+	if err := authorizeUser(ctx, id, domains.PermissionUsersRead, domains.PermissionSelfRead); err != nil {
+		return nil, err
+	}
+
 	user, err := u.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, errors.NewDatabaseError("failed to fetch user", err)
@@ -39,6 +91,14 @@ func (u *UserUsecase) GetUserByID(ctx context.Context, id string) (*domains.User
 
 // GetUserByEmail retrieves a user by email
 func (u *UserUsecase) GetUserByEmail(ctx context.Context, email string) (*domains.User, error) {
+	// Checked before the lookup so that regular users cannot probe for emails
+	email = domains.NormalizeEmail(email)
+	if principal, ok := domains.PrincipalFromContext(ctx); ok && domains.NormalizeEmail(principal.Email) != email {
+		if err := authorize(ctx, domains.PermissionUsersRead); err != nil {
+			return nil, err
+		}
+	}
+
 	user, err := u.repo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, errors.NewDatabaseError("failed to fetch user", err)
@@ -58,36 +118,58 @@ func (u *UserUsecase) RegisterUser(ctx context.Context, input *domains.RegisterU
 		return nil, errors.NewValidationError("registration input is required", nil)
 	}
 
-	if input.Email == "" {
+	email := domains.NormalizeEmail(input.Email)
+	if email == "" {
 		return nil, errors.NewValidationError("email is required", nil)
 	}
 
-	if input.Password == "" {
-		return nil, errors.NewValidationError("password is required", nil)
+	if err := validatePassword(input.Password); err != nil {
+		return nil, err
 	}
 
-	// Check if email already exists
-	existingUser, err := u.repo.GetByEmail(ctx, input.Email)
+	// Check if email already exists; the unique key catches concurrent
+	// registrations that pass this check
+	existingUser, err := u.repo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, errors.NewDatabaseError("failed to check email", err)
 	}
 
 	if existingUser != nil {
-		return nil, errors.NewDuplicateEntryError(fmt.Sprintf("email %s is already registered", input.Email))
+		return nil, errors.NewDuplicateEntryError(fmt.Sprintf("email %s is already registered", email))
+	}
+
+	passwordHash, err := u.hasher.Hash(input.Password)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to hash password", err)
 	}
 
 	// Create new user
 	user := &domains.User{
 		ID:           uuid.New().String(),
-		Email:        input.Email,
-		PasswordHash: hashPassword(input.Password), // In real app, use proper password hashing
+		Email:        email,
+		PasswordHash: passwordHash,
 		FirstName:    input.FirstName,
 		LastName:     input.LastName,
 		IsActive:     true,
 	}
 
+	if input.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
 	if err := u.repo.Create(ctx, user); err != nil {
-		return nil, errors.NewDatabaseError("failed to create user", err)
+		return nil, repositoryError("failed to create user", err)
+	}
+
+	if err := u.roles.AssignRole(ctx, user.ID, domains.RoleUser); err != nil {
+		return nil, errors.NewDatabaseError("failed to assign default role", err)
+	}
+
+	// The account exists either way; a failed delivery can be retried through
+	// the resend endpoint
+	if user.EmailVerifiedAt == nil {
+		_ = u.verification.SendVerification(ctx, user)
 	}
 
 	return user, nil
@@ -100,6 +182,17 @@ func (u *UserUsecase) UpdateUser(ctx context.Context, id string, input *domains.
 		return nil, errors.NewValidationError("update input is required", nil)
 	}
 
+	if err := authorizeUser(ctx, id, domains.PermissionUsersWrite, domains.PermissionSelfWrite); err != nil {
+		return nil, err
+	}
+
+	// Account status is an administrative decision, even on your own account
+	if input.IsActive != nil {
+		if err := authorize(ctx, domains.PermissionUsersWrite); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get existing user
 	user, err := u.repo.GetByID(ctx, id)
 	if err != nil {
@@ -110,42 +203,183 @@ func (u *UserUsecase) UpdateUser(ctx context.Context, id string, input *domains.
 		return nil, errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", id))
 	}
 
-	// Update fields
+	if err := checkUserVersion(user, input.Version); err != nil {
+		return nil, err
+	}
+
+	// Fields the input leaves out keep their value
+	email := user.Email
 	if input.Email != nil {
+		email = *input.Email
+	}
+
+	firstName := user.FirstName
+	if input.FirstName != nil {
+		firstName = input.FirstName
+	}
+
+	lastName := user.LastName
+	if input.LastName != nil {
+		lastName = input.LastName
+	}
+
+	isActive := user.IsActive
+	if input.IsActive != nil {
+		isActive = *input.IsActive
+	}
+
+	return u.saveUser(ctx, user, email, firstName, lastName, isActive)
+}
+
+// userPatchDocument is the JSON document user patches apply to: the fields
+// of a user that can be edited, with null for names that are not set
+type userPatchDocument struct {
+	Email     *string `json:"email"`
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	IsActive  *bool   `json:"is_active"`
+}
+
+// PatchUser applies a JSON Merge Patch or JSON Patch to the editable fields
+// of a user. The patched document is validated as a whole before anything
+// is written.
+func (u *UserUsecase) PatchUser(ctx context.Context, id string, input *domains.PatchUserInput) (*domains.User, error) {
+	if input == nil {
+		return nil, errors.NewValidationError("patch input is required", nil)
+	}
+
+	var apply func(doc, patch []byte) ([]byte, error)
+	switch input.ContentType {
+	case jsonpatch.MediaTypeMergePatch:
+		apply = jsonpatch.MergePatch
+	case jsonpatch.MediaTypeJSONPatch:
+		apply = jsonpatch.Apply
+	default:
+		return nil, errors.NewUnsupportedMediaTypeError(fmt.Sprintf("patches must be %s or %s", jsonpatch.MediaTypeMergePatch, jsonpatch.MediaTypeJSONPatch))
+	}
+
+	if err := authorizeUser(ctx, id, domains.PermissionUsersWrite, domains.PermissionSelfWrite); err != nil {
+		return nil, err
+	}
+
+	user, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", id))
+	}
+
+	if err := checkUserVersion(user, input.Version); err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(userPatchDocument{
+		Email:     &user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		IsActive:  &user.IsActive,
+	})
+	if err != nil {
+		return nil, errors.NewInternalError("failed to encode user", err)
+	}
+
+	patched, err := apply(doc, input.Patch)
+	if err != nil {
+		if stderrors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, errors.NewConflictError(err.Error())
+		}
+		return nil, errors.NewValidationError("invalid patch", err)
+	}
+
+	// Only the editable fields may be patched, and email and is_active
+	// cannot be removed
+	var fields userPatchDocument
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, errors.NewValidationError("invalid patched user", err)
+	}
+
+	if fields.Email == nil {
+		return nil, errors.NewValidationError("email is required", nil)
+	}
+
+	if fields.IsActive == nil {
+		return nil, errors.NewValidationError("is_active is required", nil)
+	}
+
+	return u.saveUser(ctx, user, *fields.Email, fields.FirstName, fields.LastName, *fields.IsActive)
+}
+
+// saveUser writes new values of the editable fields over a user that was
+// just read, with the checks and side effects every edit is subject to.
+// Nil names are cleared.
+func (u *UserUsecase) saveUser(ctx context.Context, user *domains.User, email string, firstName, lastName *string, isActive bool) (*domains.User, error) {
+	// Account status is an administrative decision, even on your own account
+	if isActive != user.IsActive {
+		if err := authorize(ctx, domains.PermissionUsersWrite); err != nil {
+			return nil, err
+		}
+	}
+
+	emailChanged := false
+	if email = domains.NormalizeEmail(email); email != user.Email {
+		if email == "" {
+			return nil, errors.NewValidationError("email is required", nil)
+		}
+
 		// Check if new email is already taken
-		existingUser, err := u.repo.GetByEmail(ctx, *input.Email)
+		existingUser, err := u.repo.GetByEmail(ctx, email)
 		if err != nil {
 			return nil, errors.NewDatabaseError("failed to check email", err)
 		}
 
 		if existingUser != nil && existingUser.ID != user.ID {
-			return nil, errors.NewDuplicateEntryError(fmt.Sprintf("email %s is already in use", *input.Email))
+			return nil, errors.NewDuplicateEntryError(fmt.Sprintf("email %s is already in use", email))
 		}
 
-		user.Email = *input.Email
+		user.Email = email
+		user.EmailVerifiedAt = nil
+		emailChanged = true
 	}
 
-	if input.FirstName != nil {
-		user.FirstName = input.FirstName
-	}
+	user.FirstName = firstName
+	user.LastName = lastName
 
-	if input.LastName != nil {
-		user.LastName = input.LastName
+	wasActive := user.IsActive
+	user.IsActive = isActive
+
+	if err := u.repo.Update(ctx, user); err != nil {
+		return nil, repositoryError("failed to update user", err)
 	}
 
-	if input.IsActive != nil {
-		user.IsActive = *input.IsActive
+	// Deactivation must cut off sessions that are already established
+	if wasActive && !user.IsActive {
+		if err := revokeSessions(ctx, u.revocations, u.refreshTokens, user.ID); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := u.repo.Update(ctx, user); err != nil {
-		return nil, errors.NewDatabaseError("failed to update user", err)
+	// A new address has to be verified again
+	if emailChanged {
+		_ = u.verification.SendVerification(ctx, user)
 	}
 
 	return user, nil
 }
 
-// DeleteUser deletes a user account
-func (u *UserUsecase) DeleteUser(ctx context.Context, id string) error {
+// DeleteUser deletes a user account; not while impersonating a user
+func (u *UserUsecase) DeleteUser(ctx context.Context, id string, version *int32) error {
+	if err := authorize(ctx, domains.PermissionUsersDelete); err != nil {
+		return err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return err
+	}
+
 	// Check if user exists
 	user, err := u.repo.GetByID(ctx, id)
 	if err != nil {
@@ -156,15 +390,30 @@ func (u *UserUsecase) DeleteUser(ctx context.Context, id string) error {
 		return errors.NewNotFoundError(fmt.Sprintf("user with id %s not found", id))
 	}
 
-	if err := u.repo.Delete(ctx, id); err != nil {
-		return errors.NewDatabaseError("failed to delete user", err)
+	if err := checkUserVersion(user, version); err != nil {
+		return err
 	}
 
-	return nil
+	// The write is conditional as well, so a change made after the read
+	// above still fails the deletion
+	if err := u.repo.Delete(ctx, id, user.Version); err != nil {
+		return repositoryError("failed to delete user", err)
+	}
+
+	return revokeSessions(ctx, u.revocations, u.refreshTokens, id)
 }
 
-// ListUsers retrieves paginated user list
-func (u *UserUsecase) ListUsers(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+// ListUsers retrieves a filtered, sorted and paginated user list
+func (u *UserUsecase) ListUsers(ctx context.Context, filter *domains.UserListFilter, limit, offset int32) ([]*domains.User, error) {
+	if err := authorize(ctx, domains.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	filter, err := normalizeUserListFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
 	if limit <= 0 || limit > 100 {
 		limit = 10
 	}
@@ -173,16 +422,287 @@ func (u *UserUsecase) ListUsers(ctx context.Context, limit, offset int32) ([]*do
 		offset = 0
 	}
 
-	users, err := u.repo.List(ctx, limit, offset)
+	users, err := u.repo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch users", err)
+	}
+
+	return users, nil
+}
+
+// ListUsersByCursor retrieves a filtered page of users by keyset
+// pagination, which stays stable while users are added or removed
+func (u *UserUsecase) ListUsersByCursor(ctx context.Context, filter *domains.UserListFilter, encodedCursor string, limit int32) (*domains.UserPage, error) {
+	if err := authorize(ctx, domains.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	filter, err := normalizeUserListFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.SortBy != "" && filter.SortBy != domains.UserSortCreatedAt {
+		return nil, errors.NewValidationError("cursor pagination can only sort by created_at", nil)
+	}
+	desc := filter.SortBy == "" || filter.SortDesc
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	var position *domains.UserCursor
+	if encodedCursor != "" {
+		var decoded userCursor
+		if err := u.cursors.Decode(encodedCursor, &decoded); err != nil {
+			return nil, errors.NewValidationError("invalid cursor", err)
+		}
+
+		if decoded.Desc != desc {
+			return nil, errors.NewValidationError("cursor does not match the sort order", nil)
+		}
+
+		position = &domains.UserCursor{CreatedAt: decoded.CreatedAt, ID: decoded.ID, Backward: decoded.Backward}
+	}
+
+	// One extra user tells whether there is a further page
+	users, err := u.repo.ListByCursor(ctx, filter, position, limit+1)
 	if err != nil {
 		return nil, errors.NewDatabaseError("failed to fetch users", err)
 	}
 
+	backward := position != nil && position.Backward
+	hasMore := len(users) > int(limit)
+	if hasMore {
+		// The extra user is the one furthest from the cursor
+		if backward {
+			users = users[1:]
+		} else {
+			users = users[:limit]
+		}
+	}
+
+	page := &domains.UserPage{Users: users}
+	if len(users) == 0 {
+		return page, nil
+	}
+
+	// Coming from a cursor means there is a page back in that direction
+	hasNext := hasMore
+	hasPrev := position != nil
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if hasNext {
+		last := users[len(users)-1]
+		if page.NextCursor, err = u.cursors.Encode(userCursor{CreatedAt: last.CreatedAt, ID: last.ID, Desc: desc}); err != nil {
+			return nil, errors.NewInternalError("failed to encode cursor", err)
+		}
+	}
+
+	if hasPrev {
+		first := users[0]
+		if page.PrevCursor, err = u.cursors.Encode(userCursor{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true, Desc: desc}); err != nil {
+			return nil, errors.NewInternalError("failed to encode cursor", err)
+		}
+	}
+
+	return page, nil
+}
+
+// CountUsers returns the number of users matching the filter
+func (u *UserUsecase) CountUsers(ctx context.Context, filter *domains.UserListFilter) (int64, error) {
+	if err := authorize(ctx, domains.PermissionUsersRead); err != nil {
+		return 0, err
+	}
+
+	filter, err := normalizeUserListFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := u.repo.Count(ctx, filter)
+	if err != nil {
+		return 0, errors.NewDatabaseError("failed to count users", err)
+	}
+
+	return count, nil
+}
+
+// ListDeletedUsers retrieves a paginated list of deleted users, most
+// recently deleted first
+func (u *UserUsecase) ListDeletedUsers(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+	if err := authorize(ctx, domains.PermissionUsersDelete); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, err := u.repo.ListDeleted(ctx, limit, offset)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch deleted users", err)
+	}
+
 	return users, nil
 }
 
-// Helper function to hash password (placeholder - use proper hashing in production)
-func hashPassword(password string) string {
-	// In production, use bcrypt or argon2
-	return fmt.Sprintf("hashed_%s", password)
+// CountDeletedUsers returns the number of deleted users
+func (u *UserUsecase) CountDeletedUsers(ctx context.Context) (int64, error) {
+	if err := authorize(ctx, domains.PermissionUsersDelete); err != nil {
+		return 0, err
+	}
+
+	count, err := u.repo.CountDeleted(ctx)
+	if err != nil {
+		return 0, errors.NewDatabaseError("failed to count deleted users", err)
+	}
+
+	return count, nil
+}
+
+// RestoreUser undoes the deletion of a user account. It fails when the
+// email address has been registered again since the user was deleted.
+func (u *UserUsecase) RestoreUser(ctx context.Context, id string) (*domains.User, error) {
+	if err := authorize(ctx, domains.PermissionUsersDelete); err != nil {
+		return nil, err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := u.repo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("deleted user with id %s not found", id))
+	}
+
+	existingUser, err := u.repo.GetByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to check email", err)
+	}
+
+	if existingUser != nil && existingUser.ID != user.ID {
+		return nil, errors.NewDuplicateEntryError(fmt.Sprintf("email %s has been registered again since the user was deleted", user.Email))
+	}
+
+	restored, err := u.repo.Restore(ctx, id)
+	if err != nil {
+		return nil, repositoryError("failed to restore user", err)
+	}
+
+	// Restored concurrently
+	if !restored {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("deleted user with id %s not found", id))
+	}
+
+	// Restoring is a write, so it advances the version as well
+	user.DeletedAt = nil
+	user.Version++
+	return user, nil
+}
+
+// HardDeleteUser permanently removes a user account. Only deleted users can
+// be removed, so that their sessions have already been revoked.
+func (u *UserUsecase) HardDeleteUser(ctx context.Context, id string) error {
+	if err := authorize(ctx, domains.PermissionUsersPurge); err != nil {
+		return err
+	}
+
+	if err := rejectImpersonation(ctx); err != nil {
+		return err
+	}
+
+	user, err := u.repo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return errors.NewDatabaseError("failed to fetch user", err)
+	}
+
+	if user == nil {
+		return errors.NewNotFoundError(fmt.Sprintf("deleted user with id %s not found", id))
+	}
+
+	deleted, err := u.repo.HardDelete(ctx, id)
+	if err != nil {
+		return errors.NewDatabaseError("failed to purge user", err)
+	}
+
+	// Restored concurrently
+	if !deleted {
+		return errors.NewNotFoundError(fmt.Sprintf("deleted user with id %s not found", id))
+	}
+
+	return nil
+}
+
+// normalizeUserListFilter validates a user list filter and returns a copy
+// with the search terms trimmed and the email domain lowercased
+func normalizeUserListFilter(filter *domains.UserListFilter) (*domains.UserListFilter, error) {
+	normalized := domains.UserListFilter{}
+	if filter != nil {
+		normalized = *filter
+	}
+
+	normalized.Search = strings.TrimSpace(normalized.Search)
+	if utf8.RuneCountInString(normalized.Search) > maxUserSearchLength {
+		return nil, errors.NewValidationError(fmt.Sprintf("search must be at most %d characters", maxUserSearchLength), nil)
+	}
+
+	normalized.EmailDomain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(normalized.EmailDomain), "@"))
+	if strings.ContainsAny(normalized.EmailDomain, "@ ") {
+		return nil, errors.NewValidationError("email_domain must be a domain such as example.com", nil)
+	}
+
+	if normalized.SortBy != "" && !slices.Contains(domains.UserSortFields, normalized.SortBy) {
+		return nil, errors.NewValidationError(fmt.Sprintf("sort must be one of %s", strings.Join(domains.UserSortFields, ", ")), nil)
+	}
+
+	if normalized.CreatedAfter != nil && normalized.CreatedBefore != nil && !normalized.CreatedAfter.Before(*normalized.CreatedBefore) {
+		return nil, errors.NewValidationError("created_after must be before created_before", nil)
+	}
+
+	return &normalized, nil
+}
+
+// repositoryError passes on the API errors repositories map driver errors
+// to, such as DUPLICATE_ENTRY for taken emails, and reports any other
+// failure as a database error
+func repositoryError(message string, err error) error {
+	if errors.IsAPIError(err) {
+		return err
+	}
+	return errors.NewDatabaseError(message, err)
+}
+
+// checkUserVersion fails with PRECONDITION_FAILED when the caller expects a
+// version of the user other than the current one; a nil version always passes
+func checkUserVersion(user *domains.User, version *int32) error {
+	if version != nil && *version != user.Version {
+		return errors.NewPreconditionFailedError("user has been modified since it was read")
+	}
+	return nil
+}
+
+// revokeSessions invalidates every access and refresh token issued to a user.
+// Cookie sessions honour the same cutoff, so they end as well.
+func revokeSessions(ctx context.Context, revocations domains.TokenRevocationStore, refreshTokens domains.RefreshTokenRepository, userID string) error {
+	if err := revocations.RevokeUserTokens(ctx, userID); err != nil {
+		return errors.NewDatabaseError("failed to revoke access tokens", err)
+	}
+
+	if err := refreshTokens.RevokeAllForUser(ctx, userID); err != nil {
+		return errors.NewDatabaseError("failed to revoke refresh tokens", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+)
+
+// retentionTenantPageSize is the number of tenants fetched at a time while
+// purging
+const retentionTenantPageSize = 100
+
+// UserRetentionConfig contains the retention of deleted users. A zero
+// retention disables purging.
+type UserRetentionConfig struct {
+	Retention time.Duration // how long deleted users are kept before they are purged
+}
+
+// UserRetentionUsecase permanently removes users that were deleted longer
+// ago than the retention period. User queries are scoped to a tenant, so
+// tenants are purged one at a time.
+type UserRetentionUsecase struct {
+	repo    domains.UserRepository
+	tenants domains.TenantRepository
+	logger  *slog.Logger
+	config  UserRetentionConfig
+}
+
+// NewUserRetentionUsecase creates a new user retention usecase
+func NewUserRetentionUsecase(
+	repo domains.UserRepository,
+	tenants domains.TenantRepository,
+	logger *slog.Logger,
+	config UserRetentionConfig,
+) domains.UserRetentionUsecase {
+	return &UserRetentionUsecase{
+		repo:    repo,
+		tenants: tenants,
+		logger:  logger,
+		config:  config,
+	}
+}
+
+// PurgeDeletedUsers purges the deleted users of every tenant. A failing
+// tenant does not stop the others; the first error is returned at the end.
+func (u *UserRetentionUsecase) PurgeDeletedUsers(ctx context.Context) (int64, error) {
+	if u.config.Retention <= 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-u.config.Retention)
+
+	var purged int64
+	var firstErr error
+	for offset := int32(0); ; offset += retentionTenantPageSize {
+		tenants, err := u.tenants.List(ctx, retentionTenantPageSize, offset)
+		if err != nil {
+			return purged, errors.NewDatabaseError("failed to fetch tenants", err)
+		}
+
+		for _, tenant := range tenants {
+			count, err := u.repo.PurgeDeleted(domains.WithTenant(ctx, tenant.ID), cutoff)
+			purged += count
+			if err != nil {
+				u.logger.ErrorContext(ctx, "failed to purge deleted users",
+					slog.String("tenant_id", tenant.ID),
+					slog.String("error", err.Error()),
+				)
+				if firstErr == nil {
+					firstErr = errors.NewDatabaseError("failed to purge deleted users", err)
+				}
+				continue
+			}
+
+			if count > 0 {
+				u.logger.InfoContext(ctx, "purged deleted users",
+					slog.String("tenant_id", tenant.ID),
+					slog.Int64("count", count),
+					slog.Time("deleted_before", cutoff),
+				)
+			}
+		}
+
+		if len(tenants) < retentionTenantPageSize {
+			return purged, firstErr
+		}
+	}
+}
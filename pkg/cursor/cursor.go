@@ -0,0 +1,71 @@
+// Package cursor encodes pagination positions as opaque, tamper-proof
+// strings. A cursor is the base64url JSON of the position followed by an
+// HMAC-SHA256 of that JSON, so clients can hand it back but not forge one.
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalid is returned when a cursor is malformed or was not signed by
+// this signer
+var ErrInvalid = errors.New("invalid cursor")
+
+// Signer encodes and decodes cursors with a secret key
+type Signer struct {
+	key []byte
+}
+
+// NewSigner creates a signer for the secret key
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// Encode returns the signed cursor for position, which must marshal to JSON
+func (s *Signer) Encode(position any) (string, error) {
+	payload, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload)), nil
+}
+
+// Decode verifies a cursor and unmarshals its position into position
+func (s *Signer) Decode(cursor string, position any) error {
+	encodedPayload, encodedSignature, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return ErrInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return ErrInvalid
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return ErrInvalid
+	}
+
+	if !hmac.Equal(signature, s.sign(payload)) {
+		return ErrInvalid
+	}
+
+	if err := json.Unmarshal(payload, position); err != nil {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+func (s *Signer) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,155 @@
+package jwtkeys
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+)
+
+// JWKS is a JSON Web Key Set (RFC 7517)
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is the public part of a key in JSON Web Key format
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC and OKP
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// JWK returns the public key in JSON Web Key format
+func (k *Key) JWK() (*JWK, error) {
+	jwk := &JWK{
+		KeyID:     k.ID,
+		Use:       "sig",
+		Algorithm: k.Algorithm,
+	}
+
+	switch pub := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encodeBase64URL(pub.N.Bytes())
+		jwk.E = encodeBase64URL(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdh, err := pub.ECDH()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedKey, err)
+		}
+
+		// Uncompressed point: 0x04 || X || Y, both padded to the curve size
+		point := ecdh.Bytes()[1:]
+		size := len(point) / 2
+
+		jwk.KeyType = "EC"
+		jwk.Curve = pub.Curve.Params().Name
+		jwk.X = encodeBase64URL(point[:size])
+		jwk.Y = encodeBase64URL(point[size:])
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = encodeBase64URL(pub)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, k.public)
+	}
+
+	return jwk, nil
+}
+
+// Key decodes the public key of a JWK into a verify-only key. When the JWK
+// does not name an algorithm it is inferred from the key type.
+func (j *JWK) Key() (*Key, error) {
+	var public any
+
+	switch j.KeyType {
+	case "RSA":
+		n, err := decodeBase64URL(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URL(j.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("%w: RSA exponent too large", ErrMalformedKey)
+		}
+		public = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	case "EC":
+		curve, ok := map[string]elliptic.Curve{
+			"P-256": elliptic.P256(),
+			"P-384": elliptic.P384(),
+			"P-521": elliptic.P521(),
+		}[j.Curve]
+		if !ok {
+			return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, j.Curve)
+		}
+		x, err := decodeBase64URL(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URL(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		point := append(append([]byte{4}, x...), y...)
+		public, err = ecdsa.ParseUncompressedPublicKey(curve, point)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedKey, err)
+		}
+	case "OKP":
+		if j.Curve != "Ed25519" {
+			return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, j.Curve)
+		}
+		x, err := decodeBase64URL(j.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid Ed25519 key size", ErrMalformedKey)
+		}
+		public = ed25519.PublicKey(x)
+	default:
+		return nil, fmt.Errorf("%w: key type %q", ErrUnsupportedKey, j.KeyType)
+	}
+
+	key, err := newAsymmetricKey(j.KeyID, public)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.Algorithm != "" {
+		key.Algorithm = j.Algorithm
+	}
+
+	return key, nil
+}
+
+// encodeBase64URL encodes bytes as unpadded base64url
+func encodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeBase64URL decodes unpadded base64url
+func decodeBase64URL(s string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, ErrMalformedKey
+	}
+	return b, nil
+}
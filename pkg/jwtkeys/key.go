@@ -0,0 +1,159 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmES384 = "ES384"
+	AlgorithmES512 = "ES512"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	// ErrUnsupportedKey is returned when a PEM block holds a key type or curve we cannot sign with
+	ErrUnsupportedKey = errors.New("unsupported key type")
+
+	// ErrMalformedKey is returned when a PEM file cannot be decoded
+	ErrMalformedKey = errors.New("malformed key")
+)
+
+// Key is a single signing or verification key identified by kid
+type Key struct {
+	ID        string
+	Algorithm string
+
+	// ActiveFrom is when the key starts signing new tokens. Keys are
+	// published in the JWKS before then so verifiers can pick them up early.
+	ActiveFrom time.Time
+
+	// signing is nil for verify-only keys loaded from a public key
+	signing any
+	public  any
+}
+
+// NewHMACKey creates a symmetric HS256 key from a shared secret
+func NewHMACKey(id string, secret []byte) *Key {
+	return &Key{
+		ID:        id,
+		Algorithm: AlgorithmHS256,
+		signing:   secret,
+		public:    secret,
+	}
+}
+
+// LoadPEMFile reads a private or public key from a PEM file
+func LoadPEMFile(id, path string, activeFrom time.Time) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key %s: %w", id, err)
+	}
+
+	key, err := ParsePEM(id, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %s: %w", id, err)
+	}
+
+	key.ActiveFrom = activeFrom
+
+	return key, nil
+}
+
+// ParsePEM decodes an RSA, ECDSA or Ed25519 key and infers its algorithm.
+// PKCS#8, PKCS#1 and SEC 1 private keys and PKIX public keys are accepted.
+func ParsePEM(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrMalformedKey
+	}
+
+	var parsed any
+	var err error
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedKey, err)
+	}
+
+	return newAsymmetricKey(id, parsed)
+}
+
+// newAsymmetricKey wraps a parsed private or public key
+func newAsymmetricKey(id string, parsed any) (*Key, error) {
+	key := &Key{ID: id}
+
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.signing = signer
+		key.public = signer.Public()
+	} else {
+		key.public = parsed
+	}
+
+	switch pub := key.public.(type) {
+	case *rsa.PublicKey:
+		key.Algorithm = AlgorithmRS256
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			key.Algorithm = AlgorithmES256
+		case elliptic.P384():
+			key.Algorithm = AlgorithmES384
+		case elliptic.P521():
+			key.Algorithm = AlgorithmES512
+		default:
+			return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, pub.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		key.Algorithm = AlgorithmEdDSA
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key.public)
+	}
+
+	return key, nil
+}
+
+// CanSign reports whether the key holds private material
+func (k *Key) CanSign() bool {
+	return k.signing != nil
+}
+
+// IsSymmetric reports whether the key is a shared secret that must never be published
+func (k *Key) IsSymmetric() bool {
+	return k.Algorithm == AlgorithmHS256
+}
+
+// SigningKey returns the value to pass to jwt.Token.SignedString
+func (k *Key) SigningKey() any {
+	return k.signing
+}
+
+// VerificationKey returns the value to return from a jwt.Keyfunc
+func (k *Key) VerificationKey() any {
+	return k.public
+}
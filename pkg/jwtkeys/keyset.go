@@ -0,0 +1,174 @@
+package jwtkeys
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrNoSigningKey is returned when no key with private material is active yet
+	ErrNoSigningKey = errors.New("no active signing key")
+
+	// ErrUnknownKey is returned when a token names a kid that is not in the set or has been retired
+	ErrUnknownKey = errors.New("unknown or retired key")
+)
+
+// KeySet holds the keys used to sign and verify tokens and implements
+// scheduled rotation. The signing key is the most recently activated key
+// with private material. When a newer key activates, the previous keys keep
+// verifying tokens for the grace period, which should be at least the access
+// token lifetime, and are then retired.
+type KeySet struct {
+	keys  []*Key // ordered by ActiveFrom
+	grace time.Duration
+}
+
+// NewKeySet creates a key set. Symmetric and asymmetric keys cannot be mixed,
+// otherwise a public key could be replayed as an HMAC secret.
+func NewKeySet(keys []*Key, grace time.Duration) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+
+	seen := make(map[string]bool, len(keys))
+	symmetric := 0
+	for _, key := range keys {
+		if seen[key.ID] {
+			return nil, fmt.Errorf("duplicate key id: %q", key.ID)
+		}
+		seen[key.ID] = true
+
+		if key.IsSymmetric() {
+			symmetric++
+		}
+	}
+
+	if symmetric > 0 && symmetric != len(keys) {
+		return nil, errors.New("symmetric and asymmetric keys cannot be combined")
+	}
+
+	ordered := make([]*Key, len(keys))
+	copy(ordered, keys)
+	sort.SliceStable(ordered, func(a, b int) bool {
+		return ordered[a].ActiveFrom.Before(ordered[b].ActiveFrom)
+	})
+
+	return &KeySet{
+		keys:  ordered,
+		grace: grace,
+	}, nil
+}
+
+// SigningKey returns the key new tokens should be signed with
+func (s *KeySet) SigningKey() (*Key, error) {
+	now := time.Now()
+
+	var current *Key
+	for _, key := range s.keys {
+		if key.ActiveFrom.After(now) {
+			break
+		}
+		if key.CanSign() {
+			current = key
+		}
+	}
+
+	if current == nil {
+		return nil, ErrNoSigningKey
+	}
+
+	return current, nil
+}
+
+// Lookup returns the published key with the given kid
+func (s *KeySet) Lookup(kid string) (*Key, error) {
+	for _, key := range s.Published() {
+		if key.ID == kid {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// Published returns every key that has not been retired, including keys
+// scheduled to activate in the future
+func (s *KeySet) Published() []*Key {
+	now := time.Now()
+
+	published := make([]*Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		if retiredAt, ok := s.retiredAt(key, now); ok && now.After(retiredAt) {
+			continue
+		}
+		published = append(published, key)
+	}
+
+	return published
+}
+
+// Algorithms returns the distinct algorithms of the configured keys
+func (s *KeySet) Algorithms() []string {
+	seen := make(map[string]bool)
+	algorithms := make([]string, 0, len(s.keys))
+	for _, key := range s.keys {
+		if !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return algorithms
+}
+
+// Keyfunc resolves the verification key for a token by its kid header and
+// rejects tokens whose algorithm does not match the key
+func (s *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, err := s.Lookup(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+
+	return key.VerificationKey(), nil
+}
+
+// JWKS returns the public keys for the /.well-known/jwks.json document.
+// Symmetric keys are never exposed.
+func (s *KeySet) JWKS() (*JWKS, error) {
+	set := &JWKS{Keys: []JWK{}}
+
+	for _, key := range s.Published() {
+		if key.IsSymmetric() {
+			continue
+		}
+
+		jwk, err := key.JWK()
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, *jwk)
+	}
+
+	return set, nil
+}
+
+// retiredAt returns when a key stops verifying: the grace period after the
+// first newer signing key activated. A key without an active successor that
+// can sign never retires, so verify-only keys cannot leave the set without a
+// signing key.
+func (s *KeySet) retiredAt(key *Key, now time.Time) (time.Time, bool) {
+	for _, next := range s.keys {
+		if next.CanSign() && next.ActiveFrom.After(key.ActiveFrom) && !next.ActiveFrom.After(now) {
+			return next.ActiveFrom.Add(s.grace), true
+		}
+	}
+	return time.Time{}, false
+}
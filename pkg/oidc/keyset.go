@@ -0,0 +1,158 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+)
+
+// RemoteKeySet fetches an issuer's JWKS and caches it. The set is refetched
+// when the cache expires, or earlier when a token names an unknown kid so
+// that keys rotated in by the issuer are picked up, at most once per
+// minRefresh to keep forged kids from hammering the issuer.
+type RemoteKeySet struct {
+	client     *http.Client
+	issuer     string
+	jwksURL    string
+	cacheTTL   time.Duration
+	minRefresh time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]*jwtkeys.Key
+	fetchedAt time.Time
+}
+
+// discoveryDocument is the subset of the OpenID provider metadata we use
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// NewRemoteKeySet creates a key set for the issuer. When jwksURL is empty
+// it is discovered from the issuer's /.well-known/openid-configuration.
+func NewRemoteKeySet(client *http.Client, issuer, jwksURL string, cacheTTL, minRefresh time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{
+		client:     client,
+		issuer:     issuer,
+		jwksURL:    jwksURL,
+		cacheTTL:   cacheTTL,
+		minRefresh: minRefresh,
+	}
+}
+
+// Lookup returns the issuer key with the given kid
+func (s *RemoteKeySet) Lookup(ctx context.Context, kid string) (*jwtkeys.Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	since := time.Since(s.fetchedAt)
+	expired := since >= s.cacheTTL
+
+	key, ok := s.find(kid)
+	if ok && !expired {
+		return key, nil
+	}
+
+	if expired || since >= s.minRefresh {
+		if err := s.refresh(ctx); err != nil {
+			// Keep serving the previous keys while the issuer is unreachable
+			if s.keys == nil {
+				return nil, err
+			}
+		}
+		key, ok = s.find(kid)
+	}
+
+	if !ok {
+		return nil, jwtkeys.ErrUnknownKey
+	}
+
+	return key, nil
+}
+
+// find looks a kid up in the cached keys. A token without a kid matches
+// when the issuer publishes a single key.
+func (s *RemoteKeySet) find(kid string) (*jwtkeys.Key, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// refresh fetches the JWKS, discovering its location first if needed.
+// Must be called with mu held.
+func (s *RemoteKeySet) refresh(ctx context.Context) error {
+	// Failed attempts also count towards the refresh throttle
+	s.fetchedAt = time.Now()
+
+	if s.jwksURL == "" {
+		var doc discoveryDocument
+		if err := s.getJSON(ctx, strings.TrimSuffix(s.issuer, "/")+"/.well-known/openid-configuration", &doc); err != nil {
+			return fmt.Errorf("failed to discover OIDC configuration: %w", err)
+		}
+
+		if doc.Issuer != s.issuer {
+			return fmt.Errorf("discovered issuer %q does not match %q", doc.Issuer, s.issuer)
+		}
+
+		if doc.JWKSURI == "" {
+			return fmt.Errorf("OIDC configuration has no jwks_uri")
+		}
+
+		s.jwksURL = doc.JWKSURI
+	}
+
+	var set jwtkeys.JWKS
+	if err := s.getJSON(ctx, s.jwksURL, &set); err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]*jwtkeys.Key, len(set.Keys))
+	for _, jwk := range set.Keys {
+		// Skip encryption keys and key types we cannot verify with
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.Key()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	s.keys = keys
+
+	return nil
+}
+
+// getJSON fetches a URL and decodes the JSON response
+func (s *RemoteKeySet) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
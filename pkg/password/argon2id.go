@@ -0,0 +1,136 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2idOptions configures the argon2id hasher
+type Argon2idOptions struct {
+	Time       uint32 // number of passes over memory
+	Memory     uint32 // memory in KiB
+	Threads    uint8
+	KeyLength  uint32
+	SaltLength uint32
+}
+
+// Argon2idHasher hashes passwords with argon2id using the PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+type Argon2idHasher struct {
+	opts Argon2idOptions
+}
+
+// argon2idParams holds the parameters decoded from an encoded hash
+type argon2idParams struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+// NewArgon2idHasher creates a new argon2id hasher
+func NewArgon2idHasher(opts Argon2idOptions) *Argon2idHasher {
+	if opts.Time == 0 {
+		opts.Time = 3
+	}
+	if opts.Memory == 0 {
+		opts.Memory = 64 * 1024
+	}
+	if opts.Threads == 0 {
+		opts.Threads = 2
+	}
+	if opts.KeyLength == 0 {
+		opts.KeyLength = 32
+	}
+	if opts.SaltLength == 0 {
+		opts.SaltLength = 16
+	}
+
+	return &Argon2idHasher{
+		opts: opts,
+	}
+}
+
+// Hash returns the PHC encoded argon2id hash of password
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.opts.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.opts.Time, h.opts.Memory, h.opts.Threads, h.opts.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.opts.Memory,
+		h.opts.Time,
+		h.opts.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify reports whether password matches the encoded hash using the parameters stored in it
+func (h *Argon2idHasher) Verify(password, encoded string) (bool, error) {
+	params, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	key := argon2.IDKey([]byte(password), params.salt, params.time, params.memory, params.threads, uint32(len(params.key)))
+
+	return subtle.ConstantTimeCompare(key, params.key) == 1, nil
+}
+
+// NeedsRehash reports whether the hash was produced with different parameters
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+
+	return params.memory != h.opts.Memory ||
+		params.time != h.opts.Time ||
+		params.threads != h.opts.Threads ||
+		uint32(len(params.key)) != h.opts.KeyLength ||
+		uint32(len(params.salt)) != h.opts.SaltLength
+}
+
+// decodeArgon2id parses a PHC encoded argon2id hash
+func decodeArgon2id(encoded string) (*argon2idParams, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrMalformedHash
+	}
+
+	params := &argon2idParams{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return nil, ErrMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, ErrMalformedHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, ErrMalformedHash
+	}
+
+	params.salt = salt
+	params.key = key
+
+	return params, nil
+}
@@ -0,0 +1,44 @@
+package password
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+)
+
+// legacyPrefix marks the placeholder hashes stored before real hashing was
+// introduced: the password itself behind "hashed_"
+const legacyPrefix = "hashed_"
+
+// ErrVerifyOnly is returned when hashing with an algorithm that is only
+// kept to verify existing hashes
+var ErrVerifyOnly = errors.New("password hash algorithm can only verify")
+
+// LegacyHasher verifies the placeholder hashes of accounts created before
+// real hashing was introduced, so those users can still log in and have
+// their hash upgraded. It never produces new hashes.
+type LegacyHasher struct{}
+
+// NewLegacyHasher creates a new legacy hasher
+func NewLegacyHasher() *LegacyHasher {
+	return &LegacyHasher{}
+}
+
+// Hash always fails; legacy hashes must not be created anymore
+func (h *LegacyHasher) Hash(password string) (string, error) {
+	return "", ErrVerifyOnly
+}
+
+// Verify reports whether password matches the legacy hash in constant time
+func (h *LegacyHasher) Verify(password, encoded string) (bool, error) {
+	stored, ok := strings.CutPrefix(encoded, legacyPrefix)
+	if !ok {
+		return false, ErrMalformedHash
+	}
+	return subtle.ConstantTimeCompare([]byte(password), []byte(stored)) == 1, nil
+}
+
+// NeedsRehash always reports true, as legacy hashes hold the plain password
+func (h *LegacyHasher) NeedsRehash(encoded string) bool {
+	return true
+}
@@ -0,0 +1,122 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Supported hashing algorithms
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+
+	// AlgorithmLegacy identifies the placeholder hashes of old accounts; it
+	// can verify them but cannot be configured for new hashes
+	AlgorithmLegacy = "legacy"
+)
+
+// MaxLength is the longest password accepted by every supported algorithm
+const MaxLength = 72
+
+var (
+	// ErrUnsupportedHash is returned when an encoded hash uses an unknown format
+	ErrUnsupportedHash = errors.New("unsupported password hash format")
+
+	// ErrMalformedHash is returned when an encoded hash cannot be decoded
+	ErrMalformedHash = errors.New("malformed password hash")
+)
+
+// Hasher hashes and verifies passwords using a self-describing encoding
+type Hasher interface {
+	// Hash returns the encoded hash of password, including algorithm parameters
+	Hash(password string) (string, error)
+
+	// Verify reports whether password matches the encoded hash in constant time
+	Verify(password, encoded string) (bool, error)
+
+	// NeedsRehash reports whether the encoded hash was produced with outdated parameters
+	NeedsRehash(encoded string) bool
+}
+
+// Options configures the hashers built by New
+type Options struct {
+	Algorithm string
+	Bcrypt    BcryptOptions
+	Argon2id  Argon2idOptions
+}
+
+// Manager hashes new passwords with the configured algorithm and verifies
+// hashes produced by any supported algorithm, so that changing the
+// configuration upgrades stored hashes on the next successful login.
+type Manager struct {
+	primary   string
+	algorithm map[string]Hasher
+}
+
+// New creates a password manager from options, defaulting to bcrypt
+func New(opts Options) (*Manager, error) {
+	if opts.Algorithm == "" {
+		opts.Algorithm = AlgorithmBcrypt
+	}
+
+	m := &Manager{
+		primary: opts.Algorithm,
+		algorithm: map[string]Hasher{
+			AlgorithmBcrypt:   NewBcryptHasher(opts.Bcrypt),
+			AlgorithmArgon2id: NewArgon2idHasher(opts.Argon2id),
+			AlgorithmLegacy:   NewLegacyHasher(),
+		},
+	}
+
+	if _, ok := m.algorithm[m.primary]; !ok || m.primary == AlgorithmLegacy {
+		return nil, fmt.Errorf("unsupported password hash algorithm: %s", opts.Algorithm)
+	}
+
+	return m, nil
+}
+
+// Hash returns the encoded hash of password using the configured algorithm
+func (m *Manager) Hash(password string) (string, error) {
+	return m.algorithm[m.primary].Hash(password)
+}
+
+// Verify reports whether password matches the encoded hash
+func (m *Manager) Verify(password, encoded string) (bool, error) {
+	hasher, err := m.hasherFor(encoded)
+	if err != nil {
+		return false, err
+	}
+	return hasher.Verify(password, encoded)
+}
+
+// NeedsRehash reports whether the encoded hash uses another algorithm or outdated parameters
+func (m *Manager) NeedsRehash(encoded string) bool {
+	if Identify(encoded) != m.primary {
+		return true
+	}
+	return m.algorithm[m.primary].NeedsRehash(encoded)
+}
+
+// hasherFor selects the hasher able to verify the encoded hash
+func (m *Manager) hasherFor(encoded string) (Hasher, error) {
+	hasher, ok := m.algorithm[Identify(encoded)]
+	if !ok {
+		return nil, ErrUnsupportedHash
+	}
+	return hasher, nil
+}
+
+// Identify returns the algorithm that produced the encoded hash, or an empty string
+func Identify(encoded string) string {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return AlgorithmArgon2id
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		return AlgorithmBcrypt
+	case strings.HasPrefix(encoded, legacyPrefix):
+		return AlgorithmLegacy
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,147 @@
+package response
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Page is a generic envelope for one page of a list. Offset is set for
+// offset pagination, the cursors for cursor pagination.
+type Page[T any] struct {
+	Items      []T       `json:"items"`
+	Total      int64     `json:"total"`
+	Limit      int       `json:"limit"`
+	Offset     *int      `json:"offset,omitempty"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+	PrevCursor string    `json:"prev_cursor,omitempty"`
+	HasMore    bool      `json:"has_more"`
+	Links      PageLinks `json:"links"`
+}
+
+// PageLinks are the URLs of the current page and its neighbours, relative
+// to the host. A link is empty when there is no such page.
+type PageLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// NewOffsetPage builds the page of items found at offset in a list of total
+// items. Links keep the query of the request and replace its offset.
+func NewOffsetPage[T any](c *fiber.Ctx, items []T, total int64, limit, offset int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	page := Page[T]{
+		Items:   items,
+		Total:   total,
+		Limit:   limit,
+		Offset:  &offset,
+		HasMore: int64(offset+len(items)) < total,
+		Links: PageLinks{
+			Self:  pageURL(c, nil),
+			First: pageURL(c, map[string]string{"offset": "0"}),
+		},
+	}
+
+	if limit <= 0 {
+		return page
+	}
+
+	if offset > 0 {
+		page.Links.Prev = pageURL(c, map[string]string{"offset": strconv.Itoa(max(0, offset-limit))})
+	}
+
+	if page.HasMore {
+		page.Links.Next = pageURL(c, map[string]string{"offset": strconv.Itoa(offset + limit)})
+	}
+
+	if total > 0 {
+		last := int((total - 1) / int64(limit) * int64(limit))
+		page.Links.Last = pageURL(c, map[string]string{"offset": strconv.Itoa(last)})
+	}
+
+	return page
+}
+
+// NewCursorPage builds a page of a cursor paginated list of total items.
+// Links keep the query of the request and replace its cursor; cursor lists
+// have no last page link.
+func NewCursorPage[T any](c *fiber.Ctx, items []T, total int64, limit int, nextCursor, prevCursor string) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	page := Page[T]{
+		Items:      items,
+		Total:      total,
+		Limit:      limit,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		HasMore:    nextCursor != "",
+		Links: PageLinks{
+			Self:  pageURL(c, nil),
+			First: pageURL(c, map[string]string{"pagination": "cursor", "cursor": ""}),
+		},
+	}
+
+	if prevCursor != "" {
+		page.Links.Prev = pageURL(c, map[string]string{"cursor": prevCursor})
+	}
+
+	if nextCursor != "" {
+		page.Links.Next = pageURL(c, map[string]string{"cursor": nextCursor})
+	}
+
+	return page
+}
+
+// SendPage sends a 200 OK response with the page, and its links in an
+// RFC 8288 Link header
+func SendPage[T any](c *fiber.Ctx, page Page[T]) error {
+	var links []string
+	for _, link := range []struct{ rel, url string }{
+		{"first", page.Links.First},
+		{"prev", page.Links.Prev},
+		{"next", page.Links.Next},
+		{"last", page.Links.Last},
+	} {
+		if link.url != "" {
+			links = append(links, "<"+link.url+`>; rel="`+link.rel+`"`)
+		}
+	}
+
+	if len(links) > 0 {
+		c.Set(fiber.HeaderLink, strings.Join(links, ", "))
+	}
+
+	return SendOK(c, page)
+}
+
+// pageURL returns the path and query of the request with the given query
+// parameters replaced; an empty value removes the parameter
+func pageURL(c *fiber.Ctx, params map[string]string) string {
+	query, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		query = url.Values{}
+	}
+
+	for name, value := range params {
+		if value == "" {
+			query.Del(name)
+		} else {
+			query.Set(name, value)
+		}
+	}
+
+	if len(query) == 0 {
+		return c.Path()
+	}
+	return c.Path() + "?" + query.Encode()
+}
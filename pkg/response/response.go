@@ -1,6 +1,8 @@
 package response
 
 import (
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -50,6 +52,10 @@ func SendNoContent(c *fiber.Ctx) error {
 
 // SendError sends an error response
 func SendError(c *fiber.Ctx, err *errors.APIError) error {
+	if err.RetryAfter > 0 {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(err.RetryAfter.Seconds()))))
+	}
+
 	return c.Status(err.StatusCode).JSON(ErrorResponse{
 		Success:   false,
 		Code:      string(err.Code),
@@ -0,0 +1,15 @@
+CREATE TABLE refresh_tokens (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'Owner of the refresh token',
+  family_id VARCHAR(36) NOT NULL COMMENT 'Rotation chain started by a single login',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque refresh token',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the token was rotated for a new one',
+  revoked_at TIMESTAMP NULL COMMENT 'When the token family was revoked',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id (user_id),
+  INDEX idx_family_id (family_id),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Opaque refresh tokens with rotation families';
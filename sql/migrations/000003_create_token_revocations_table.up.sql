@@ -0,0 +1,14 @@
+CREATE TABLE revoked_tokens (
+  jti VARCHAR(36) PRIMARY KEY COMMENT 'JWT ID of the revoked access token',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiry of the revoked token; the row can be purged afterwards',
+  revoked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Revocation timestamp',
+
+  INDEX idx_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Denylist of individually revoked access tokens';
+
+CREATE TABLE user_token_revocations (
+  user_id VARCHAR(36) PRIMARY KEY COMMENT 'User whose tokens were revoked',
+  revoked_before TIMESTAMP NOT NULL COMMENT 'Access tokens issued at or before this time are rejected',
+
+  CONSTRAINT fk_user_token_revocations_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Per-user cut-off for previously issued access tokens';
@@ -0,0 +1,11 @@
+CREATE TABLE user_identities (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'Local user the external identity is linked to',
+  issuer VARCHAR(255) NOT NULL COMMENT 'OIDC issuer URL',
+  subject VARCHAR(255) NOT NULL COMMENT 'Subject identifier assigned by the issuer',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  UNIQUE KEY uq_issuer_subject (issuer, subject),
+  INDEX idx_user_id (user_id),
+  CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='External OIDC identities linked to local users';
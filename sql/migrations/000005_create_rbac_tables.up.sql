@@ -0,0 +1,54 @@
+CREATE TABLE roles (
+  name VARCHAR(50) PRIMARY KEY COMMENT 'Role name',
+  description VARCHAR(255) COMMENT 'Human readable description',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Roles that group permissions';
+
+CREATE TABLE permissions (
+  name VARCHAR(100) PRIMARY KEY COMMENT 'Permission name, resource:action',
+  description VARCHAR(255) COMMENT 'Human readable description'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Permissions checked by the API';
+
+CREATE TABLE role_permissions (
+  role_name VARCHAR(50) NOT NULL COMMENT 'Role granting the permission',
+  permission_name VARCHAR(100) NOT NULL COMMENT 'Granted permission',
+
+  PRIMARY KEY (role_name, permission_name),
+  CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_name) REFERENCES roles (name) ON DELETE CASCADE,
+  CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_name) REFERENCES permissions (name) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Permissions granted by each role';
+
+CREATE TABLE user_roles (
+  user_id VARCHAR(36) NOT NULL COMMENT 'User holding the role',
+  role_name VARCHAR(50) NOT NULL COMMENT 'Assigned role',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Assignment timestamp',
+
+  PRIMARY KEY (user_id, role_name),
+  INDEX idx_role_name (role_name),
+  CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT fk_user_roles_role FOREIGN KEY (role_name) REFERENCES roles (name) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Roles assigned to users';
+
+INSERT INTO roles (name, description) VALUES
+  ('admin', 'Manages every user'),
+  ('user', 'Reads and updates their own account');
+
+INSERT INTO permissions (name, description) VALUES
+  ('users:read', 'Read and list any user'),
+  ('users:write', 'Update any user, including account status'),
+  ('users:delete', 'Delete any user'),
+  ('self:read', 'Read your own account'),
+  ('self:write', 'Update your own profile');
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+  ('admin', 'users:read'),
+  ('admin', 'users:write'),
+  ('admin', 'users:delete'),
+  ('admin', 'self:read'),
+  ('admin', 'self:write'),
+  ('user', 'self:read'),
+  ('user', 'self:write');
+
+-- Existing accounts become regular users
+INSERT INTO user_roles (user_id, role_name)
+SELECT id, 'user' FROM users;
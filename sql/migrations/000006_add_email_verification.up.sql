@@ -0,0 +1,18 @@
+ALTER TABLE users
+  ADD COLUMN email_verified_at TIMESTAMP NULL COMMENT 'When the email address was verified' AFTER is_active;
+
+-- Accounts created before verification existed are trusted as they are
+UPDATE users SET email_verified_at = created_at;
+
+CREATE TABLE email_verification_tokens (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User whose email address the token verifies',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque verification token',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the token was consumed or superseded',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id_created_at (user_id, created_at),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_email_verification_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Single-use email verification tokens';
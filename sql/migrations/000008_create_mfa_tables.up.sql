@@ -0,0 +1,35 @@
+CREATE TABLE user_mfa (
+  user_id VARCHAR(36) PRIMARY KEY COMMENT 'User the second factor belongs to',
+  secret VARCHAR(64) NOT NULL COMMENT 'Base32 encoded TOTP secret',
+  confirmed_at TIMESTAMP NULL COMMENT 'When enrollment was confirmed; NULL while pending',
+  last_used_step BIGINT NOT NULL DEFAULT 0 COMMENT 'Last accepted TOTP time step, to reject replayed codes',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp',
+
+  CONSTRAINT fk_user_mfa_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='TOTP second factors';
+
+CREATE TABLE mfa_recovery_codes (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User the recovery code belongs to',
+  code_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the normalized recovery code',
+  used_at TIMESTAMP NULL COMMENT 'When the code was consumed',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  UNIQUE KEY uq_user_id_code_hash (user_id, code_hash),
+  CONSTRAINT fk_mfa_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Single-use MFA recovery codes';
+
+CREATE TABLE mfa_challenges (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User who passed the first factor',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque challenge token',
+  attempts INT NOT NULL DEFAULT 0 COMMENT 'Failed second factor attempts',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the challenge was completed',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id (user_id),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_mfa_challenges_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Pending second factor logins';
@@ -0,0 +1,10 @@
+CREATE TABLE login_attempts (
+  account VARCHAR(255) NOT NULL COMMENT 'Normalized email the attempts were made against',
+  ip_address VARCHAR(45) NOT NULL DEFAULT '' COMMENT 'Client IP; empty for the account-wide counter',
+  failures INT NOT NULL DEFAULT 0 COMMENT 'Failed attempts in the current failure window',
+  last_failed_at TIMESTAMP NOT NULL COMMENT 'When the last failed attempt was made',
+  locked_until TIMESTAMP NULL COMMENT 'End of the current lockout; NULL when not locked',
+
+  PRIMARY KEY (account, ip_address),
+  INDEX idx_last_failed_at (last_failed_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Failed login counters for brute-force protection';
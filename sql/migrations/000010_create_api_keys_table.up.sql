@@ -0,0 +1,17 @@
+ALTER TABLE users
+  ADD COLUMN is_service_account BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether the user is a non-interactive service account' AFTER email_verified_at;
+
+CREATE TABLE api_keys (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User or service account the key acts as',
+  name VARCHAR(100) NOT NULL COMMENT 'Label chosen by the owner',
+  prefix VARCHAR(32) NOT NULL UNIQUE COMMENT 'Public part of the key, shown in listings and used for lookup',
+  secret_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the secret part of the key',
+  scopes VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Space separated permissions granted to the key',
+  expires_at TIMESTAMP NULL COMMENT 'Expiration timestamp; NULL for keys that do not expire',
+  last_used_at TIMESTAMP NULL COMMENT 'When the key last authenticated a request',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id (user_id),
+  CONSTRAINT fk_api_keys_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='API keys for machine-to-machine clients';
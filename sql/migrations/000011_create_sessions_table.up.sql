@@ -0,0 +1,16 @@
+CREATE TABLE sessions (
+  id CHAR(64) PRIMARY KEY COMMENT 'SHA-256 hash of the session cookie value',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User the session belongs to',
+  email VARCHAR(255) NOT NULL COMMENT 'Email of the user when the session was started',
+  roles VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Space separated roles, reloaded when the session is extended',
+  permissions VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Space separated permissions, reloaded when the session is extended',
+  csrf_token VARCHAR(64) NOT NULL COMMENT 'Token state-changing requests must echo in the X-CSRF-Token header',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Idle expiry, pushed back while the session is in use',
+  absolute_expires_at TIMESTAMP NOT NULL COMMENT 'Hard end of the session regardless of activity',
+  last_seen_at TIMESTAMP NOT NULL COMMENT 'When the session was last extended',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id (user_id),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Server-side sessions for cookie authentication';
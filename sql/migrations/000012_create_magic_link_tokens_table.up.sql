@@ -0,0 +1,13 @@
+CREATE TABLE magic_link_tokens (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User the link signs in',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque login token',
+  binding_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the value given to the client that asked for the link',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the token was consumed or superseded',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id_created_at (user_id, created_at),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_magic_link_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Single-use passwordless login tokens';
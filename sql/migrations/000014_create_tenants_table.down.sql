@@ -0,0 +1,18 @@
+DELETE FROM roles WHERE name = 'platform_admin';
+DELETE FROM permissions WHERE name IN ('tenants:read', 'tenants:write');
+
+DELETE FROM login_attempts WHERE account LIKE '____________________________________:%';
+
+ALTER TABLE login_attempts
+  MODIFY COLUMN account VARCHAR(255) NOT NULL COMMENT 'Normalized email the attempts were made against';
+
+ALTER TABLE sessions DROP COLUMN tenant_id;
+
+ALTER TABLE users
+  DROP FOREIGN KEY fk_users_tenant,
+  DROP INDEX uq_tenant_email,
+  DROP COLUMN tenant_id,
+  ADD UNIQUE KEY email (email),
+  ADD INDEX idx_email (email);
+
+DROP TABLE IF EXISTS tenants;
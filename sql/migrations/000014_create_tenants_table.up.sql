@@ -0,0 +1,41 @@
+CREATE TABLE tenants (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  slug VARCHAR(63) NOT NULL UNIQUE COMMENT 'DNS label naming the tenant in subdomains and the tenant header',
+  name VARCHAR(255) NOT NULL COMMENT 'Display name',
+  is_active BOOLEAN NOT NULL DEFAULT TRUE COMMENT 'Whether the tenant can be used',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Organizations whose users are isolated from each other';
+
+-- Requests that do not name a tenant, and every existing user, belong to the default tenant
+INSERT INTO tenants (id, slug, name) VALUES
+  ('00000000-0000-0000-0000-000000000000', 'default', 'Default');
+
+ALTER TABLE users
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' COMMENT 'Tenant the user belongs to' AFTER id,
+  DROP INDEX email,
+  DROP INDEX idx_email,
+  ADD UNIQUE KEY uq_tenant_email (tenant_id, email),
+  ADD CONSTRAINT fk_users_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id);
+
+ALTER TABLE users ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE sessions
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' COMMENT 'Tenant of the user the session belongs to' AFTER user_id;
+
+ALTER TABLE sessions ALTER COLUMN tenant_id DROP DEFAULT;
+
+-- Lockout counters of non-default tenants are keyed by tenant ID and email
+ALTER TABLE login_attempts
+  MODIFY COLUMN account VARCHAR(300) NOT NULL COMMENT 'Normalized email the attempts were made against, qualified by tenant';
+
+INSERT INTO roles (name, description) VALUES
+  ('platform_admin', 'Manages tenants');
+
+INSERT INTO permissions (name, description) VALUES
+  ('tenants:read', 'Read and list tenants'),
+  ('tenants:write', 'Create tenants');
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+  ('platform_admin', 'tenants:read'),
+  ('platform_admin', 'tenants:write');
@@ -0,0 +1,19 @@
+CREATE TABLE invitations (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  tenant_id VARCHAR(36) NOT NULL COMMENT 'Tenant the invitee joins',
+  email VARCHAR(255) NOT NULL COMMENT 'Invited email address',
+  role_name VARCHAR(50) NOT NULL COMMENT 'Role granted on acceptance',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque invitation token',
+  invited_by VARCHAR(36) NULL COMMENT 'User who sent the invitation',
+  status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending, accepted, declined or revoked',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  responded_at TIMESTAMP NULL COMMENT 'When the invitation was accepted, declined or revoked',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp',
+
+  INDEX idx_tenant_status_created_at (tenant_id, status, created_at),
+  INDEX idx_tenant_email (tenant_id, email),
+  CONSTRAINT fk_invitations_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id) ON DELETE CASCADE,
+  CONSTRAINT fk_invitations_role FOREIGN KEY (role_name) REFERENCES roles (name) ON DELETE CASCADE,
+  CONSTRAINT fk_invitations_inviter FOREIGN KEY (invited_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Invitations to join a tenant with a role';
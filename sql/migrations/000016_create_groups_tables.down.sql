@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name IN ('groups:read', 'groups:write');
+
+DROP TABLE IF EXISTS group_roles;
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS user_groups;
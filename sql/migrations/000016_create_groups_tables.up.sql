@@ -0,0 +1,41 @@
+CREATE TABLE user_groups (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  tenant_id VARCHAR(36) NOT NULL COMMENT 'Tenant the group belongs to',
+  name VARCHAR(100) NOT NULL COMMENT 'Group name, unique per tenant',
+  description VARCHAR(255) NULL COMMENT 'Human readable description',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp',
+
+  UNIQUE KEY uq_tenant_name (tenant_id, name),
+  CONSTRAINT fk_user_groups_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Groups of users that share roles';
+
+CREATE TABLE group_members (
+  group_id VARCHAR(36) NOT NULL COMMENT 'Group the user belongs to',
+  user_id VARCHAR(36) NOT NULL COMMENT 'Member',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Membership timestamp',
+
+  PRIMARY KEY (group_id, user_id),
+  INDEX idx_user_id (user_id),
+  CONSTRAINT fk_group_members_group FOREIGN KEY (group_id) REFERENCES user_groups (id) ON DELETE CASCADE,
+  CONSTRAINT fk_group_members_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Users belonging to each group';
+
+CREATE TABLE group_roles (
+  group_id VARCHAR(36) NOT NULL COMMENT 'Group holding the role',
+  role_name VARCHAR(50) NOT NULL COMMENT 'Role granted to every member',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Assignment timestamp',
+
+  PRIMARY KEY (group_id, role_name),
+  INDEX idx_role_name (role_name),
+  CONSTRAINT fk_group_roles_group FOREIGN KEY (group_id) REFERENCES user_groups (id) ON DELETE CASCADE,
+  CONSTRAINT fk_group_roles_role FOREIGN KEY (role_name) REFERENCES roles (name) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Roles granted to the members of each group';
+
+INSERT INTO permissions (name, description) VALUES
+  ('groups:read', 'Read and list groups and their members'),
+  ('groups:write', 'Manage groups, their members and their roles');
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+  ('admin', 'groups:read'),
+  ('admin', 'groups:write');
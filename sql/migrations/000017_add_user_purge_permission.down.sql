@@ -0,0 +1,2 @@
+DELETE FROM role_permissions WHERE permission_name = 'users:purge';
+DELETE FROM permissions WHERE name = 'users:purge';
@@ -0,0 +1,5 @@
+INSERT INTO permissions (name, description) VALUES
+  ('users:purge', 'Permanently remove deleted users');
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+  ('admin', 'users:purge');
@@ -0,0 +1,7 @@
+-- Fails while a deleted user shares an email address with a live user of
+-- the same tenant; purge one of them first
+ALTER TABLE users
+  DROP INDEX uq_tenant_live_email,
+  DROP INDEX idx_tenant_email,
+  DROP COLUMN live_email,
+  ADD UNIQUE KEY uq_tenant_email (tenant_id, email);
@@ -0,0 +1,36 @@
+-- Live users whose addresses only differ in case or surrounding spaces would
+-- share an email once normalized. List them and stop before anything
+-- changes; merge or delete them, then run the migration again.
+BEGIN NOT ATOMIC
+  DECLARE conflicts TEXT;
+
+  SELECT GROUP_CONCAT(CONCAT(tenant_id, ': ', emails) ORDER BY tenant_id SEPARATOR '; ') INTO conflicts
+  FROM (
+    SELECT tenant_id, GROUP_CONCAT(email ORDER BY email SEPARATOR ', ') AS emails
+    FROM users
+    WHERE deleted_at IS NULL
+    GROUP BY tenant_id, LOWER(TRIM(email))
+    HAVING COUNT(*) > 1
+  ) AS duplicates;
+
+  IF conflicts IS NOT NULL THEN
+    -- MESSAGE_TEXT holds at most 128 characters
+    SET conflicts = LEFT(CONCAT('live users share an email once normalized: ', conflicts), 128);
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = conflicts;
+  END IF;
+END;
+
+-- Deleted users may still share a normalized email with each other or with
+-- a live user, so the old key goes before the emails are rewritten
+ALTER TABLE users DROP INDEX uq_tenant_email;
+
+-- Emails are stored trimmed and lowercased from now on
+UPDATE users SET email = LOWER(TRIM(email));
+
+-- Only live users hold on to their email address, so that it can be
+-- registered again once its user is deleted. Deleted users are NULL in
+-- live_email, and NULLs never collide in a unique key.
+ALTER TABLE users
+  ADD COLUMN live_email VARCHAR(255) AS (IF(deleted_at IS NULL, email, NULL)) VIRTUAL COMMENT 'Email of live users, NULL once deleted; unique per tenant' AFTER deleted_at,
+  ADD UNIQUE KEY uq_tenant_live_email (tenant_id, live_email),
+  ADD INDEX idx_tenant_email (tenant_id, email);
@@ -0,0 +1,2 @@
+ALTER TABLE users
+  ADD COLUMN version INT NOT NULL DEFAULT 1 COMMENT 'Incremented by every write, for optimistic concurrency control' AFTER live_email;
@@ -0,0 +1,10 @@
+-- Refresh requests need not name the tenant of the user, so every token
+-- records it
+ALTER TABLE refresh_tokens
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' COMMENT 'Tenant of the user the token belongs to' AFTER user_id;
+
+UPDATE refresh_tokens rt
+JOIN users u ON u.id = rt.user_id
+SET rt.tenant_id = u.tenant_id;
+
+ALTER TABLE refresh_tokens ALTER COLUMN tenant_id DROP DEFAULT;
@@ -0,0 +1,7 @@
+ALTER TABLE mfa_challenges DROP COLUMN tenant_id;
+
+ALTER TABLE magic_link_tokens DROP COLUMN tenant_id;
+
+ALTER TABLE password_reset_tokens DROP COLUMN tenant_id;
+
+ALTER TABLE email_verification_tokens DROP COLUMN tenant_id;
@@ -0,0 +1,37 @@
+-- The links in verification, reset and magic link mails, and MFA challenges,
+-- need not name the tenant of their user, so each of them records it
+ALTER TABLE email_verification_tokens
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' COMMENT 'Tenant of the user the token belongs to' AFTER user_id;
+
+UPDATE email_verification_tokens t
+JOIN users u ON u.id = t.user_id
+SET t.tenant_id = u.tenant_id;
+
+ALTER TABLE email_verification_tokens ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE password_reset_tokens
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' COMMENT 'Tenant of the user the token belongs to' AFTER user_id;
+
+UPDATE password_reset_tokens t
+JOIN users u ON u.id = t.user_id
+SET t.tenant_id = u.tenant_id;
+
+ALTER TABLE password_reset_tokens ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE magic_link_tokens
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' COMMENT 'Tenant of the user the token belongs to' AFTER user_id;
+
+UPDATE magic_link_tokens t
+JOIN users u ON u.id = t.user_id
+SET t.tenant_id = u.tenant_id;
+
+ALTER TABLE magic_link_tokens ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE mfa_challenges
+  ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' COMMENT 'Tenant of the user the challenge belongs to' AFTER user_id;
+
+UPDATE mfa_challenges c
+JOIN users u ON u.id = c.user_id
+SET c.tenant_id = u.tenant_id;
+
+ALTER TABLE mfa_challenges ALTER COLUMN tenant_id DROP DEFAULT;
@@ -0,0 +1,33 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (id, user_id, name, prefix, secret_hash, scopes, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, NOW());
+
+-- name: GetAPIKeyByID :one
+SELECT id, user_id, name, prefix, secret_hash, scopes, expires_at, last_used_at, created_at
+FROM api_keys
+WHERE id = ?;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT id, user_id, name, prefix, secret_hash, scopes, expires_at, last_used_at, created_at
+FROM api_keys
+WHERE prefix = ?;
+
+-- name: ListAPIKeysByUser :many
+SELECT id, user_id, name, prefix, secret_hash, scopes, expires_at, last_used_at, created_at
+FROM api_keys
+WHERE user_id = ?
+ORDER BY created_at DESC;
+
+-- name: UpdateAPIKey :exec
+UPDATE api_keys
+SET name = ?, scopes = ?, expires_at = ?
+WHERE id = ?;
+
+-- name: TouchAPIKeyLastUsed :exec
+UPDATE api_keys
+SET last_used_at = sqlc.arg(used_at)
+WHERE id = sqlc.arg(id) AND (last_used_at IS NULL OR last_used_at < sqlc.arg(stale_before));
+
+-- name: DeleteAPIKey :exec
+DELETE FROM api_keys
+WHERE id = ?;
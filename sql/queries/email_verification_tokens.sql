@@ -0,0 +1,25 @@
+-- name: CreateEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (id, user_id, tenant_id, token_hash, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, NOW());
+
+-- name: GetEmailVerificationTokenByHash :one
+SELECT id, user_id, tenant_id, token_hash, expires_at, used_at, created_at
+FROM email_verification_tokens
+WHERE token_hash = ?;
+
+-- name: GetLatestEmailVerificationToken :one
+SELECT id, user_id, tenant_id, token_hash, expires_at, used_at, created_at
+FROM email_verification_tokens
+WHERE user_id = ?
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: MarkEmailVerificationTokenUsed :execrows
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE id = ? AND used_at IS NULL;
+
+-- name: InvalidateUserEmailVerificationTokens :exec
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE user_id = ? AND used_at IS NULL;
@@ -0,0 +1,68 @@
+-- name: CreateGroup :exec
+INSERT INTO user_groups (id, tenant_id, name, description, created_at, updated_at)
+VALUES (?, ?, ?, ?, NOW(), NOW());
+
+-- name: GetGroupByID :one
+SELECT id, tenant_id, name, description, created_at, updated_at
+FROM user_groups
+WHERE tenant_id = ? AND id = ?;
+
+-- name: GetGroupByName :one
+SELECT id, tenant_id, name, description, created_at, updated_at
+FROM user_groups
+WHERE tenant_id = ? AND name = ?;
+
+-- name: ListGroups :many
+SELECT id, tenant_id, name, description, created_at, updated_at
+FROM user_groups
+WHERE tenant_id = ?
+ORDER BY name
+LIMIT ? OFFSET ?;
+
+-- name: UpdateGroup :exec
+UPDATE user_groups
+SET name = ?, description = ?, updated_at = NOW()
+WHERE tenant_id = ? AND id = ?;
+
+-- name: DeleteGroup :exec
+DELETE FROM user_groups
+WHERE tenant_id = ? AND id = ?;
+
+-- name: AddGroupMember :exec
+INSERT IGNORE INTO group_members (group_id, user_id, created_at)
+VALUES (?, ?, NOW());
+
+-- name: RemoveGroupMember :execrows
+DELETE gm FROM group_members gm
+JOIN user_groups g ON g.id = gm.group_id
+WHERE g.tenant_id = ? AND gm.group_id = ? AND gm.user_id = ?;
+
+-- name: ListGroupMembers :many
+SELECT u.id, u.tenant_id, u.email, u.password_hash, u.first_name, u.last_name, u.is_active, u.email_verified_at, u.is_service_account, u.created_at, u.updated_at, u.deleted_at, u.live_email, u.version
+FROM group_members gm
+JOIN user_groups g ON g.id = gm.group_id
+JOIN users u ON u.id = gm.user_id
+WHERE g.tenant_id = ? AND gm.group_id = ? AND u.deleted_at IS NULL
+ORDER BY u.email
+LIMIT ? OFFSET ?;
+
+-- name: ListGroupMemberIDs :many
+SELECT gm.user_id
+FROM group_members gm
+JOIN user_groups g ON g.id = gm.group_id
+WHERE g.tenant_id = ? AND gm.group_id = ?;
+
+-- name: GetGroupRoles :many
+SELECT role_name
+FROM group_roles
+WHERE group_id = ?
+ORDER BY role_name;
+
+-- name: AddGroupRole :exec
+INSERT IGNORE INTO group_roles (group_id, role_name, created_at)
+VALUES (?, ?, NOW());
+
+-- name: RemoveGroupRole :execrows
+DELETE gr FROM group_roles gr
+JOIN user_groups g ON g.id = gr.group_id
+WHERE g.tenant_id = ? AND gr.group_id = ? AND gr.role_name = ?;
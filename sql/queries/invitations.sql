@@ -0,0 +1,36 @@
+-- name: CreateInvitation :exec
+INSERT INTO invitations (id, tenant_id, email, role_name, token_hash, invited_by, status, expires_at, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, 'pending', ?, NOW(), NOW());
+
+-- name: GetInvitationByID :one
+SELECT id, tenant_id, email, role_name, token_hash, invited_by, status, expires_at, responded_at, created_at, updated_at
+FROM invitations
+WHERE tenant_id = ? AND id = ?;
+
+-- name: GetInvitationByHash :one
+SELECT id, tenant_id, email, role_name, token_hash, invited_by, status, expires_at, responded_at, created_at, updated_at
+FROM invitations
+WHERE tenant_id = ? AND token_hash = ?;
+
+-- name: GetPendingInvitationByEmail :one
+SELECT id, tenant_id, email, role_name, token_hash, invited_by, status, expires_at, responded_at, created_at, updated_at
+FROM invitations
+WHERE tenant_id = ? AND email = ? AND status = 'pending'
+LIMIT 1;
+
+-- name: ListPendingInvitations :many
+SELECT id, tenant_id, email, role_name, token_hash, invited_by, status, expires_at, responded_at, created_at, updated_at
+FROM invitations
+WHERE tenant_id = ? AND status = 'pending'
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?;
+
+-- name: RenewInvitation :execrows
+UPDATE invitations
+SET token_hash = ?, expires_at = ?, updated_at = NOW()
+WHERE tenant_id = ? AND id = ? AND status = 'pending';
+
+-- name: RespondToInvitation :execrows
+UPDATE invitations
+SET status = ?, responded_at = NOW(), updated_at = NOW()
+WHERE tenant_id = ? AND id = ? AND status = 'pending';
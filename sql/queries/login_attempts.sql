@@ -0,0 +1,28 @@
+-- name: GetLoginAttempt :one
+SELECT account, ip_address, failures, last_failed_at, locked_until
+FROM login_attempts
+WHERE account = ? AND ip_address = ?;
+
+-- name: RecordLoginFailure :exec
+INSERT INTO login_attempts (account, ip_address, failures, last_failed_at)
+VALUES (sqlc.arg(account), sqlc.arg(ip_address), 1, sqlc.arg(failed_at))
+ON DUPLICATE KEY UPDATE
+  failures = IF(last_failed_at < sqlc.arg(window_start), 1, failures + 1),
+  last_failed_at = sqlc.arg(failed_at);
+
+-- name: LockLoginAttempt :exec
+UPDATE login_attempts
+SET locked_until = ?
+WHERE account = ? AND ip_address = ?;
+
+-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempts
+WHERE account = ? AND ip_address = ?;
+
+-- name: DeleteLoginAttemptsByAccount :exec
+DELETE FROM login_attempts
+WHERE account = ?;
+
+-- name: DeleteStaleLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE last_failed_at < ? AND (locked_until IS NULL OR locked_until < NOW());
@@ -0,0 +1,25 @@
+-- name: CreateMagicLinkToken :exec
+INSERT INTO magic_link_tokens (id, user_id, tenant_id, token_hash, binding_hash, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, NOW());
+
+-- name: GetMagicLinkTokenByHash :one
+SELECT id, user_id, tenant_id, token_hash, binding_hash, expires_at, used_at, created_at
+FROM magic_link_tokens
+WHERE token_hash = ?;
+
+-- name: GetLatestMagicLinkToken :one
+SELECT id, user_id, tenant_id, token_hash, binding_hash, expires_at, used_at, created_at
+FROM magic_link_tokens
+WHERE user_id = ?
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: MarkMagicLinkTokenUsed :execrows
+UPDATE magic_link_tokens
+SET used_at = NOW()
+WHERE id = ? AND used_at IS NULL;
+
+-- name: InvalidateUserMagicLinkTokens :exec
+UPDATE magic_link_tokens
+SET used_at = NOW()
+WHERE user_id = ? AND used_at IS NULL;
@@ -0,0 +1,60 @@
+-- name: GetUserMFA :one
+SELECT user_id, secret, confirmed_at, last_used_step, created_at, updated_at
+FROM user_mfa
+WHERE user_id = ?;
+
+-- name: UpsertUserMFA :exec
+INSERT INTO user_mfa (user_id, secret, confirmed_at, last_used_step, created_at, updated_at)
+VALUES (?, ?, NULL, 0, NOW(), NOW())
+ON DUPLICATE KEY UPDATE secret = VALUES(secret), confirmed_at = NULL, last_used_step = 0, updated_at = NOW();
+
+-- name: ConfirmUserMFA :execrows
+UPDATE user_mfa
+SET confirmed_at = NOW(), updated_at = NOW()
+WHERE user_id = ? AND confirmed_at IS NULL;
+
+-- name: AdvanceUserMFAStep :execrows
+UPDATE user_mfa
+SET last_used_step = sqlc.arg(step), updated_at = NOW()
+WHERE user_id = sqlc.arg(user_id) AND last_used_step < sqlc.arg(step);
+
+-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa
+WHERE user_id = ?;
+
+-- name: CreateMFARecoveryCode :exec
+INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at)
+VALUES (?, ?, ?, NOW());
+
+-- name: DeleteMFARecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE user_id = ?;
+
+-- name: UseMFARecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = NOW()
+WHERE user_id = ? AND code_hash = ? AND used_at IS NULL;
+
+-- name: CountUnusedMFARecoveryCodes :one
+SELECT COUNT(*) as count
+FROM mfa_recovery_codes
+WHERE user_id = ? AND used_at IS NULL;
+
+-- name: CreateMFAChallenge :exec
+INSERT INTO mfa_challenges (id, user_id, tenant_id, token_hash, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, NOW());
+
+-- name: GetMFAChallengeByHash :one
+SELECT id, user_id, tenant_id, token_hash, attempts, expires_at, used_at, created_at
+FROM mfa_challenges
+WHERE token_hash = ?;
+
+-- name: IncrementMFAChallengeAttempts :execrows
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE id = ? AND attempts < ?;
+
+-- name: MarkMFAChallengeUsed :execrows
+UPDATE mfa_challenges
+SET used_at = NOW()
+WHERE id = ? AND used_at IS NULL;
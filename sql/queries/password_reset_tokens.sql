@@ -0,0 +1,25 @@
+-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (id, user_id, tenant_id, token_hash, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, NOW());
+
+-- name: GetPasswordResetTokenByHash :one
+SELECT id, user_id, tenant_id, token_hash, expires_at, used_at, created_at
+FROM password_reset_tokens
+WHERE token_hash = ?;
+
+-- name: GetLatestPasswordResetToken :one
+SELECT id, user_id, tenant_id, token_hash, expires_at, used_at, created_at
+FROM password_reset_tokens
+WHERE user_id = ?
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: MarkPasswordResetTokenUsed :execrows
+UPDATE password_reset_tokens
+SET used_at = NOW()
+WHERE id = ? AND used_at IS NULL;
+
+-- name: InvalidateUserPasswordResetTokens :exec
+UPDATE password_reset_tokens
+SET used_at = NOW()
+WHERE user_id = ? AND used_at IS NULL;
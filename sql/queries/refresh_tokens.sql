@@ -0,0 +1,27 @@
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (id, user_id, tenant_id, family_id, token_hash, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, NOW());
+
+-- name: GetRefreshTokenByHash :one
+SELECT id, user_id, tenant_id, family_id, token_hash, expires_at, used_at, revoked_at, created_at
+FROM refresh_tokens
+WHERE token_hash = ?;
+
+-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+SET used_at = NOW()
+WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = NOW()
+WHERE family_id = ? AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = NOW()
+WHERE user_id = ? AND revoked_at IS NULL;
+
+-- name: DeleteExpiredRefreshTokens :exec
+DELETE FROM refresh_tokens
+WHERE expires_at < ?;
@@ -0,0 +1,36 @@
+-- name: GetUserRoles :many
+SELECT role_name
+FROM user_roles
+WHERE user_id = sqlc.arg(user_id)
+UNION
+SELECT gr.role_name
+FROM group_members gm
+JOIN group_roles gr ON gr.group_id = gm.group_id
+WHERE gm.user_id = sqlc.arg(user_id)
+ORDER BY role_name;
+
+-- name: GetUserPermissions :many
+SELECT DISTINCT rp.permission_name
+FROM role_permissions rp
+JOIN (
+  SELECT ur.role_name
+  FROM user_roles ur
+  WHERE ur.user_id = sqlc.arg(user_id)
+  UNION
+  SELECT gr.role_name
+  FROM group_members gm
+  JOIN group_roles gr ON gr.group_id = gm.group_id
+  WHERE gm.user_id = sqlc.arg(user_id)
+) held ON held.role_name = rp.role_name
+ORDER BY rp.permission_name;
+
+-- name: AssignUserRole :exec
+INSERT IGNORE INTO user_roles (user_id, role_name, created_at)
+VALUES (?, ?, NOW());
+
+
+-- name: GetRolePermissions :many
+SELECT permission_name
+FROM role_permissions
+WHERE role_name = ?
+ORDER BY permission_name;
@@ -0,0 +1,21 @@
+-- name: CreateSession :exec
+INSERT INTO sessions (id, user_id, tenant_id, email, roles, permissions, csrf_token, expires_at, absolute_expires_at, last_seen_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetSession :one
+SELECT id, user_id, tenant_id, email, roles, permissions, csrf_token, expires_at, absolute_expires_at, last_seen_at, created_at
+FROM sessions
+WHERE id = ?;
+
+-- name: UpdateSession :exec
+UPDATE sessions
+SET roles = ?, permissions = ?, expires_at = ?, last_seen_at = ?
+WHERE id = ?;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE id = ?;
+
+-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions
+WHERE expires_at < ?;
@@ -0,0 +1,19 @@
+-- name: CreateTenant :exec
+INSERT INTO tenants (id, slug, name, is_active, created_at, updated_at)
+VALUES (?, ?, ?, ?, NOW(), NOW());
+
+-- name: GetTenantByID :one
+SELECT id, slug, name, is_active, created_at, updated_at
+FROM tenants
+WHERE id = ?;
+
+-- name: GetTenantBySlug :one
+SELECT id, slug, name, is_active, created_at, updated_at
+FROM tenants
+WHERE slug = ?;
+
+-- name: ListTenants :many
+SELECT id, slug, name, is_active, created_at, updated_at
+FROM tenants
+ORDER BY slug
+LIMIT ? OFFSET ?;
@@ -0,0 +1,23 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (jti, expires_at, revoked_at)
+VALUES (?, ?, NOW())
+ON DUPLICATE KEY UPDATE expires_at = VALUES(expires_at);
+
+-- name: CountRevokedToken :one
+SELECT COUNT(*) as count
+FROM revoked_tokens
+WHERE jti = ?;
+
+-- name: DeleteExpiredRevokedTokens :exec
+DELETE FROM revoked_tokens
+WHERE expires_at < ?;
+
+-- name: RevokeUserTokens :exec
+INSERT INTO user_token_revocations (user_id, revoked_before)
+VALUES (?, ?)
+ON DUPLICATE KEY UPDATE revoked_before = VALUES(revoked_before);
+
+-- name: GetUserTokenRevocation :one
+SELECT user_id, revoked_before
+FROM user_token_revocations
+WHERE user_id = ?;
@@ -0,0 +1,8 @@
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (id, user_id, issuer, subject, created_at)
+VALUES (?, ?, ?, ?, NOW());
+
+-- name: GetUserIdentityByIssuerSubject :one
+SELECT id, user_id, issuer, subject, created_at
+FROM user_identities
+WHERE issuer = ? AND subject = ?;
@@ -1,39 +1,138 @@
 -- name: GetUserByID :one
-SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
 FROM users
-WHERE id = ? AND deleted_at IS NULL;
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NULL;
 
 -- name: GetUserByEmail :one
-SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
 FROM users
-WHERE email = ? AND deleted_at IS NULL;
+WHERE tenant_id = ? AND email = ? AND deleted_at IS NULL;
 
 -- name: ListUsers :many
-SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at
+-- Filters that are NULL do not apply. sort_key selects one of the whitelisted
+-- orders, so the ORDER BY never contains caller input.
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
 FROM users
-WHERE deleted_at IS NULL
-ORDER BY created_at DESC
+WHERE tenant_id = sqlc.arg(tenant_id) AND deleted_at IS NULL
+  AND (sqlc.narg(is_active) IS NULL OR is_active = sqlc.narg(is_active))
+  AND (sqlc.narg(created_after) IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before) IS NULL OR created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(email_pattern) IS NULL OR email LIKE sqlc.narg(email_pattern))
+  AND (sqlc.narg(search_pattern) IS NULL OR email LIKE sqlc.narg(search_pattern) OR first_name LIKE sqlc.narg(search_pattern) OR last_name LIKE sqlc.narg(search_pattern))
+ORDER BY
+  CASE WHEN sqlc.arg(sort_key) = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_key) = 'updated_at_asc' THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_key) = 'updated_at_desc' THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_key) = 'email_asc' THEN email END ASC,
+  CASE WHEN sqlc.arg(sort_key) = 'email_desc' THEN email END DESC,
+  CASE WHEN sqlc.arg(sort_key) = 'first_name_asc' THEN first_name END ASC,
+  CASE WHEN sqlc.arg(sort_key) = 'first_name_desc' THEN first_name END DESC,
+  CASE WHEN sqlc.arg(sort_key) = 'last_name_asc' THEN last_name END ASC,
+  CASE WHEN sqlc.arg(sort_key) = 'last_name_desc' THEN last_name END DESC,
+  created_at DESC, id DESC
 LIMIT ? OFFSET ?;
 
+-- name: ListUsersAfterCursor :many
+-- Keyset page of the users that follow (cursor_created_at, cursor_id) in
+-- ascending creation order; a NULL cursor starts at the oldest user.
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
+FROM users
+WHERE tenant_id = sqlc.arg(tenant_id) AND deleted_at IS NULL
+  AND (sqlc.narg(is_active) IS NULL OR is_active = sqlc.narg(is_active))
+  AND (sqlc.narg(created_after) IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before) IS NULL OR created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(email_pattern) IS NULL OR email LIKE sqlc.narg(email_pattern))
+  AND (sqlc.narg(search_pattern) IS NULL OR email LIKE sqlc.narg(search_pattern) OR first_name LIKE sqlc.narg(search_pattern) OR last_name LIKE sqlc.narg(search_pattern))
+  AND (sqlc.narg(cursor_created_at) IS NULL OR created_at > sqlc.narg(cursor_created_at) OR (created_at = sqlc.narg(cursor_created_at) AND id > sqlc.arg(cursor_id)))
+ORDER BY created_at ASC, id ASC
+LIMIT ?;
+
+-- name: ListUsersBeforeCursor :many
+-- Keyset page of the users that precede (cursor_created_at, cursor_id) in
+-- descending creation order; a NULL cursor starts at the newest user.
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
+FROM users
+WHERE tenant_id = sqlc.arg(tenant_id) AND deleted_at IS NULL
+  AND (sqlc.narg(is_active) IS NULL OR is_active = sqlc.narg(is_active))
+  AND (sqlc.narg(created_after) IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before) IS NULL OR created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(email_pattern) IS NULL OR email LIKE sqlc.narg(email_pattern))
+  AND (sqlc.narg(search_pattern) IS NULL OR email LIKE sqlc.narg(search_pattern) OR first_name LIKE sqlc.narg(search_pattern) OR last_name LIKE sqlc.narg(search_pattern))
+  AND (sqlc.narg(cursor_created_at) IS NULL OR created_at < sqlc.narg(cursor_created_at) OR (created_at = sqlc.narg(cursor_created_at) AND id < sqlc.arg(cursor_id)))
+ORDER BY created_at DESC, id DESC
+LIMIT ?;
+
 -- name: CreateUser :exec
-INSERT INTO users (id, email, password_hash, first_name, last_name, is_active, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW());
+INSERT INTO users (id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW());
+
+-- name: UpdateUser :execrows
+-- Only applies while the user is still at the version it was read at
+UPDATE users
+SET email = ?, password_hash = ?, first_name = ?, last_name = ?, is_active = ?, email_verified_at = ?, version = version + 1, updated_at = NOW()
+WHERE tenant_id = ? AND id = ? AND version = ? AND deleted_at IS NULL;
+
+-- name: UpdateUserPasswordHash :exec
+-- Upgrades the stored hash of a user's unchanged password, so the version
+-- stays as it is
+UPDATE users
+SET password_hash = ?
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NULL;
+
+-- name: MarkUserEmailVerified :exec
+-- Records proof of control of the address, which does not change the user
+-- as clients edit it, so the version stays as it is
+UPDATE users
+SET email_verified_at = ?
+WHERE tenant_id = ? AND id = ? AND email_verified_at IS NULL AND deleted_at IS NULL;
 
--- name: UpdateUser :exec
+-- name: DeleteUser :execrows
+-- Only applies while the user is still at the version it was read at
 UPDATE users
-SET email = ?, password_hash = ?, first_name = ?, last_name = ?, is_active = ?, updated_at = NOW()
-WHERE id = ? AND deleted_at IS NULL;
+SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+WHERE tenant_id = ? AND id = ? AND version = ? AND deleted_at IS NULL;
+
+-- name: HardDeleteUser :execrows
+-- Only removes users that are soft-deleted
+DELETE FROM users
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NOT NULL;
+
+-- name: GetDeletedUserByID :one
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
+FROM users
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedUsers :many
+SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version
+FROM users
+WHERE tenant_id = ? AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC
+LIMIT ? OFFSET ?;
+
+-- name: CountDeletedUsers :one
+SELECT COUNT(*) as count
+FROM users
+WHERE tenant_id = ? AND deleted_at IS NOT NULL;
 
--- name: DeleteUser :exec
+-- name: RestoreUser :execrows
 UPDATE users
-SET deleted_at = NOW(), updated_at = NOW()
-WHERE id = ? AND deleted_at IS NULL;
+SET deleted_at = NULL, version = version + 1, updated_at = NOW()
+WHERE tenant_id = ? AND id = ? AND deleted_at IS NOT NULL;
 
--- name: HardDeleteUser :exec
+-- name: PurgeDeletedUsers :execrows
+-- Removes at most limit users per call, so that large purges do not hold
+-- locks for long
 DELETE FROM users
-WHERE id = ?;
+WHERE tenant_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?
+LIMIT ?;
 
 -- name: CountUsers :one
+-- Counts the users ListUsers pages through, with the same filters
 SELECT COUNT(*) as count
 FROM users
-WHERE deleted_at IS NULL;
+WHERE tenant_id = sqlc.arg(tenant_id) AND deleted_at IS NULL
+  AND (sqlc.narg(is_active) IS NULL OR is_active = sqlc.narg(is_active))
+  AND (sqlc.narg(created_after) IS NULL OR created_at >= sqlc.narg(created_after))
+  AND (sqlc.narg(created_before) IS NULL OR created_at < sqlc.narg(created_before))
+  AND (sqlc.narg(email_pattern) IS NULL OR email LIKE sqlc.narg(email_pattern))
+  AND (sqlc.narg(search_pattern) IS NULL OR email LIKE sqlc.narg(search_pattern) OR first_name LIKE sqlc.narg(search_pattern) OR last_name LIKE sqlc.narg(search_pattern));
@@ -1,18 +1,282 @@
 -- This file is automatically generated from migrations
 -- It's kept for reference and sqlc code generation
 
+CREATE TABLE tenants (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  slug VARCHAR(63) NOT NULL UNIQUE COMMENT 'DNS label naming the tenant in subdomains and the tenant header',
+  name VARCHAR(255) NOT NULL COMMENT 'Display name',
+  is_active BOOLEAN NOT NULL DEFAULT TRUE COMMENT 'Whether the tenant can be used',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Organizations whose users are isolated from each other';
+
 CREATE TABLE users (
   id VARCHAR(36) PRIMARY KEY COMMENT 'UUID v7',
-  email VARCHAR(255) NOT NULL UNIQUE COMMENT 'User email address',
+  tenant_id VARCHAR(36) NOT NULL COMMENT 'Tenant the user belongs to',
+  email VARCHAR(255) NOT NULL COMMENT 'User email address',
   password_hash VARCHAR(255) NOT NULL COMMENT 'Bcrypt hashed password',
   first_name VARCHAR(100) COMMENT 'User first name',
   last_name VARCHAR(100) COMMENT 'User last name',
   is_active BOOLEAN DEFAULT TRUE COMMENT 'Whether the user is active',
+  email_verified_at TIMESTAMP NULL COMMENT 'When the email address was verified',
+  is_service_account BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether the user is a non-interactive service account',
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp',
   deleted_at TIMESTAMP NULL COMMENT 'Soft delete timestamp',
+  live_email VARCHAR(255) AS (IF(deleted_at IS NULL, email, NULL)) VIRTUAL COMMENT 'Email of live users, NULL once deleted; unique per tenant',
+  version INT NOT NULL DEFAULT 1 COMMENT 'Incremented by every write, for optimistic concurrency control',
 
-  INDEX idx_email (email),
+  UNIQUE KEY uq_tenant_live_email (tenant_id, live_email),
+  INDEX idx_tenant_email (tenant_id, email),
   INDEX idx_created_at (created_at),
-  INDEX idx_deleted_at (deleted_at)
+  INDEX idx_deleted_at (deleted_at),
+  CONSTRAINT fk_users_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Users table for authentication and basic user info';
+
+CREATE TABLE refresh_tokens (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'Owner of the refresh token',
+  family_id VARCHAR(36) NOT NULL COMMENT 'Rotation chain started by a single login',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque refresh token',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the token was rotated for a new one',
+  revoked_at TIMESTAMP NULL COMMENT 'When the token family was revoked',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id (user_id),
+  INDEX idx_family_id (family_id),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Opaque refresh tokens with rotation families';
+
+CREATE TABLE revoked_tokens (
+  jti VARCHAR(36) PRIMARY KEY COMMENT 'JWT ID of the revoked access token',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiry of the revoked token; the row can be purged afterwards',
+  revoked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Revocation timestamp',
+
+  INDEX idx_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Denylist of individually revoked access tokens';
+
+CREATE TABLE user_token_revocations (
+  user_id VARCHAR(36) PRIMARY KEY COMMENT 'User whose tokens were revoked',
+  revoked_before TIMESTAMP NOT NULL COMMENT 'Access tokens issued at or before this time are rejected',
+
+  CONSTRAINT fk_user_token_revocations_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Per-user cut-off for previously issued access tokens';
+
+CREATE TABLE user_identities (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'Local user the external identity is linked to',
+  issuer VARCHAR(255) NOT NULL COMMENT 'OIDC issuer URL',
+  subject VARCHAR(255) NOT NULL COMMENT 'Subject identifier assigned by the issuer',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  UNIQUE KEY uq_issuer_subject (issuer, subject),
+  INDEX idx_user_id (user_id),
+  CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='External OIDC identities linked to local users';
+
+CREATE TABLE roles (
+  name VARCHAR(50) PRIMARY KEY COMMENT 'Role name',
+  description VARCHAR(255) COMMENT 'Human readable description',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Roles that group permissions';
+
+CREATE TABLE permissions (
+  name VARCHAR(100) PRIMARY KEY COMMENT 'Permission name, resource:action',
+  description VARCHAR(255) COMMENT 'Human readable description'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Permissions checked by the API';
+
+CREATE TABLE role_permissions (
+  role_name VARCHAR(50) NOT NULL COMMENT 'Role granting the permission',
+  permission_name VARCHAR(100) NOT NULL COMMENT 'Granted permission',
+
+  PRIMARY KEY (role_name, permission_name),
+  CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_name) REFERENCES roles (name) ON DELETE CASCADE,
+  CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_name) REFERENCES permissions (name) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Permissions granted by each role';
+
+CREATE TABLE user_roles (
+  user_id VARCHAR(36) NOT NULL COMMENT 'User holding the role',
+  role_name VARCHAR(50) NOT NULL COMMENT 'Assigned role',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Assignment timestamp',
+
+  PRIMARY KEY (user_id, role_name),
+  INDEX idx_role_name (role_name),
+  CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT fk_user_roles_role FOREIGN KEY (role_name) REFERENCES roles (name) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Roles assigned to users';
+
+CREATE TABLE email_verification_tokens (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User whose email address the token verifies',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque verification token',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the token was consumed or superseded',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id_created_at (user_id, created_at),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_email_verification_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Single-use email verification tokens';
+
+CREATE TABLE password_reset_tokens (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User whose password the token resets',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque reset token',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the token was consumed or superseded',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id_created_at (user_id, created_at),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_password_reset_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Single-use password reset tokens';
+
+CREATE TABLE user_mfa (
+  user_id VARCHAR(36) PRIMARY KEY COMMENT 'User the second factor belongs to',
+  secret VARCHAR(64) NOT NULL COMMENT 'Base32 encoded TOTP secret',
+  confirmed_at TIMESTAMP NULL COMMENT 'When enrollment was confirmed; NULL while pending',
+  last_used_step BIGINT NOT NULL DEFAULT 0 COMMENT 'Last accepted TOTP time step, to reject replayed codes',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp',
+
+  CONSTRAINT fk_user_mfa_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='TOTP second factors';
+
+CREATE TABLE mfa_recovery_codes (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User the recovery code belongs to',
+  code_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the normalized recovery code',
+  used_at TIMESTAMP NULL COMMENT 'When the code was consumed',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  UNIQUE KEY uq_user_id_code_hash (user_id, code_hash),
+  CONSTRAINT fk_mfa_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Single-use MFA recovery codes';
+
+CREATE TABLE mfa_challenges (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User who passed the first factor',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque challenge token',
+  attempts INT NOT NULL DEFAULT 0 COMMENT 'Failed second factor attempts',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the challenge was completed',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id (user_id),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_mfa_challenges_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Pending second factor logins';
+
+CREATE TABLE login_attempts (
+  account VARCHAR(300) NOT NULL COMMENT 'Normalized email the attempts were made against, qualified by tenant',
+  ip_address VARCHAR(45) NOT NULL DEFAULT '' COMMENT 'Client IP; empty for the account-wide counter',
+  failures INT NOT NULL DEFAULT 0 COMMENT 'Failed attempts in the current failure window',
+  last_failed_at TIMESTAMP NOT NULL COMMENT 'When the last failed attempt was made',
+  locked_until TIMESTAMP NULL COMMENT 'End of the current lockout; NULL when not locked',
+
+  PRIMARY KEY (account, ip_address),
+  INDEX idx_last_failed_at (last_failed_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Failed login counters for brute-force protection';
+
+CREATE TABLE api_keys (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User or service account the key acts as',
+  name VARCHAR(100) NOT NULL COMMENT 'Label chosen by the owner',
+  prefix VARCHAR(32) NOT NULL UNIQUE COMMENT 'Public part of the key, shown in listings and used for lookup',
+  secret_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the secret part of the key',
+  scopes VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Space separated permissions granted to the key',
+  expires_at TIMESTAMP NULL COMMENT 'Expiration timestamp; NULL for keys that do not expire',
+  last_used_at TIMESTAMP NULL COMMENT 'When the key last authenticated a request',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id (user_id),
+  CONSTRAINT fk_api_keys_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='API keys for machine-to-machine clients';
+
+CREATE TABLE sessions (
+  id CHAR(64) PRIMARY KEY COMMENT 'SHA-256 hash of the session cookie value',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User the session belongs to',
+  tenant_id VARCHAR(36) NOT NULL COMMENT 'Tenant of the user the session belongs to',
+  email VARCHAR(255) NOT NULL COMMENT 'Email of the user when the session was started',
+  roles VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Space separated roles, reloaded when the session is extended',
+  permissions VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Space separated permissions, reloaded when the session is extended',
+  csrf_token VARCHAR(64) NOT NULL COMMENT 'Token state-changing requests must echo in the X-CSRF-Token header',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Idle expiry, pushed back while the session is in use',
+  absolute_expires_at TIMESTAMP NOT NULL COMMENT 'Hard end of the session regardless of activity',
+  last_seen_at TIMESTAMP NOT NULL COMMENT 'When the session was last extended',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id (user_id),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Server-side sessions for cookie authentication';
+
+CREATE TABLE magic_link_tokens (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  user_id VARCHAR(36) NOT NULL COMMENT 'User the link signs in',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque login token',
+  binding_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the value given to the client that asked for the link',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  used_at TIMESTAMP NULL COMMENT 'When the token was consumed or superseded',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+
+  INDEX idx_user_id_created_at (user_id, created_at),
+  INDEX idx_expires_at (expires_at),
+  CONSTRAINT fk_magic_link_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Single-use passwordless login tokens';
+
+CREATE TABLE invitations (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  tenant_id VARCHAR(36) NOT NULL COMMENT 'Tenant the invitee joins',
+  email VARCHAR(255) NOT NULL COMMENT 'Invited email address',
+  role_name VARCHAR(50) NOT NULL COMMENT 'Role granted on acceptance',
+  token_hash CHAR(64) NOT NULL UNIQUE COMMENT 'SHA-256 hash of the opaque invitation token',
+  invited_by VARCHAR(36) NULL COMMENT 'User who sent the invitation',
+  status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending, accepted, declined or revoked',
+  expires_at TIMESTAMP NOT NULL COMMENT 'Expiration timestamp',
+  responded_at TIMESTAMP NULL COMMENT 'When the invitation was accepted, declined or revoked',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp',
+
+  INDEX idx_tenant_status_created_at (tenant_id, status, created_at),
+  INDEX idx_tenant_email (tenant_id, email),
+  CONSTRAINT fk_invitations_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id) ON DELETE CASCADE,
+  CONSTRAINT fk_invitations_role FOREIGN KEY (role_name) REFERENCES roles (name) ON DELETE CASCADE,
+  CONSTRAINT fk_invitations_inviter FOREIGN KEY (invited_by) REFERENCES users (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Invitations to join a tenant with a role';
+
+CREATE TABLE user_groups (
+  id VARCHAR(36) PRIMARY KEY COMMENT 'UUID',
+  tenant_id VARCHAR(36) NOT NULL COMMENT 'Tenant the group belongs to',
+  name VARCHAR(100) NOT NULL COMMENT 'Group name, unique per tenant',
+  description VARCHAR(255) NULL COMMENT 'Human readable description',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Creation timestamp',
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Last update timestamp',
+
+  UNIQUE KEY uq_tenant_name (tenant_id, name),
+  CONSTRAINT fk_user_groups_tenant FOREIGN KEY (tenant_id) REFERENCES tenants (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Groups of users that share roles';
+
+CREATE TABLE group_members (
+  group_id VARCHAR(36) NOT NULL COMMENT 'Group the user belongs to',
+  user_id VARCHAR(36) NOT NULL COMMENT 'Member',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Membership timestamp',
+
+  PRIMARY KEY (group_id, user_id),
+  INDEX idx_user_id (user_id),
+  CONSTRAINT fk_group_members_group FOREIGN KEY (group_id) REFERENCES user_groups (id) ON DELETE CASCADE,
+  CONSTRAINT fk_group_members_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Users belonging to each group';
+
+CREATE TABLE group_roles (
+  group_id VARCHAR(36) NOT NULL COMMENT 'Group holding the role',
+  role_name VARCHAR(50) NOT NULL COMMENT 'Role granted to every member',
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Assignment timestamp',
+
+  PRIMARY KEY (group_id, role_name),
+  INDEX idx_role_name (role_name),
+  CONSTRAINT fk_group_roles_group FOREIGN KEY (group_id) REFERENCES user_groups (id) ON DELETE CASCADE,
+  CONSTRAINT fk_group_roles_role FOREIGN KEY (role_name) REFERENCES roles (name) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Roles granted to the members of each group';
@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestEmailVerificationFlow(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	sender := &RecordingMailSender{}
+	app := setupTestAppWithMail(mockRepo, sender)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	body := `{"email":"verify@example.com","password":"password123"}`
+	req := httptest.NewRequest("POST", "/api/users/register", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 201 {
+		t.Fatalf("expected status 201, got %d", resp.StatusCode)
+	}
+
+	var registered struct {
+		Data struct {
+			ID            string `json:"id"`
+			EmailVerified bool   `json:"email_verified"`
+		} `json:"data"`
+	}
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(bodyBytes, &registered)
+
+	if registered.Data.EmailVerified {
+		t.Error("expected a new user to be unverified")
+	}
+
+	if len(sender.messages) != 1 || sender.messages[0].To != "verify@example.com" {
+		t.Fatalf("expected one verification mail, got %d", len(sender.messages))
+	}
+
+	token := linkToken(t, sender.messages[0].Body, "http://localhost/verify-email")
+
+	verify := func() int {
+		req := httptest.NewRequest("POST", "/api/auth/verify-email", bytes.NewBufferString(`{"token":"`+token+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+		return resp.StatusCode
+	}
+
+	if status := verify(); status != 204 {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	if mockRepo.users[registered.Data.ID].EmailVerifiedAt == nil {
+		t.Error("expected email to be verified")
+	}
+
+	// Tokens are single use
+	if status := verify(); status != 400 {
+		t.Errorf("expected status 400 on reuse, got %d", status)
+	}
+}
+
+func TestResendVerificationEndpoint_UnknownEmail(t *testing.T) {
+	sender := &RecordingMailSender{}
+	app := setupTestAppWithMail(NewMockUserRepository(), sender)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	req := httptest.NewRequest("POST", "/api/auth/resend-verification", bytes.NewBufferString(`{"email":"nobody@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 204 {
+		t.Errorf("expected status 204, got %d", resp.StatusCode)
+	}
+
+	if len(sender.messages) != 0 {
+		t.Errorf("expected no mail for an unknown address, got %d", len(sender.messages))
+	}
+}
+
+// linkToken extracts the token query parameter from the link in a mail body
+func linkToken(t *testing.T, body, prefix string) string {
+	t.Helper()
+
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			link, err := url.Parse(line)
+			if err != nil {
+				t.Fatalf("invalid link %q: %v", line, err)
+			}
+			return link.Query().Get("token")
+		}
+	}
+
+	t.Fatalf("no link in mail body: %s", body)
+	return ""
+}
@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/handlers"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+)
+
+func TestJWKSEndpoint(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+
+	key, err := jwtkeys.ParsePEM("2026-01", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("ParsePEM failed: %v", err)
+	}
+
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{key}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", handlers.NewJWKSHandler(keys).GetJWKS)
+
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var set jwtkeys.JWKS
+	if err := json.Unmarshal(body, &set); err != nil {
+		t.Fatalf("failed to decode JWKS: %v", err)
+	}
+
+	if len(set.Keys) != 1 || set.Keys[0].KeyID != "2026-01" || set.Keys[0].KeyType != "OKP" {
+		t.Errorf("unexpected JWKS: %s", body)
+	}
+}
@@ -0,0 +1,255 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/handlers"
+	"github.com/zercle/template-go-fiber/internal/infrastructure/mail"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/pkg/password"
+)
+
+// MockMagicLinkTokenRepository is an in-memory login token store for testing
+type MockMagicLinkTokenRepository struct {
+	tokens map[string]*domains.MagicLinkToken
+}
+
+func NewMockMagicLinkTokenRepository() *MockMagicLinkTokenRepository {
+	return &MockMagicLinkTokenRepository{
+		tokens: make(map[string]*domains.MagicLinkToken),
+	}
+}
+
+func (m *MockMagicLinkTokenRepository) Create(ctx context.Context, token *domains.MagicLinkToken) error {
+	token.CreatedAt = time.Now()
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *MockMagicLinkTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.MagicLinkToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockMagicLinkTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.MagicLinkToken, error) {
+	var latest *domains.MagicLinkToken
+	for _, token := range m.tokens {
+		if token.UserID == userID && (latest == nil || token.CreatedAt.After(latest.CreatedAt)) {
+			latest = token
+		}
+	}
+	return latest, nil
+}
+
+func (m *MockMagicLinkTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	token, ok := m.tokens[id]
+	if !ok || token.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	return true, nil
+}
+
+func (m *MockMagicLinkTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+// MockMFARepository reports no MFA factor for any user; the remaining
+// methods are not used by these tests
+type MockMFARepository struct {
+	domains.MFARepository
+}
+
+func (m *MockMFARepository) GetFactor(ctx context.Context, userID string) (*domains.MFAFactor, error) {
+	return nil, nil
+}
+
+func setupMagicLinkTestApp(t *testing.T, repo *MockUserRepository, sender domains.MailSender) *fiber.App {
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{jwtkeys.NewHMACKey("", []byte("test-secret-key"))}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+
+	hasher, _ := password.New(password.Options{Bcrypt: password.BcryptOptions{Cost: 4}})
+	authUsecase := usecases.NewAuthUsecase(repo, &MockRefreshTokenRepository{}, repositories.NewMemoryTokenRevocationStore(), &MockRoleRepository{}, &MockMFARepository{}, nil, hasher, middleware.NewJWTIssuer(keys, time.Hour), nil, usecases.AuthConfig{
+		RefreshTokenTTL: time.Hour,
+	})
+	magicLinkUsecase := usecases.NewMagicLinkUsecase(repo, NewMockMagicLinkTokenRepository(), authUsecase, sender, slog.New(slog.DiscardHandler), usecases.MagicLinkConfig{
+		TokenTTL: 15 * time.Minute,
+		URL:      "http://localhost/magic-link",
+	})
+	magicLinkHandler := handlers.NewMagicLinkHandler(magicLinkUsecase, handlers.MagicLinkCookie{
+		Name:   "magic_link_binding",
+		Secure: true,
+		TTL:    15 * time.Minute,
+	})
+
+	app := fiber.New()
+	api := app.Group("/api")
+	api.Post("/auth/magic-link", magicLinkHandler.RequestLink)
+	api.Post("/auth/magic-link/verify", magicLinkHandler.Login)
+	return app
+}
+
+// requestMagicLink asks for a login link and returns the binding cookie
+func requestMagicLink(t *testing.T, app *fiber.App, email string) *http.Cookie {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", "/api/auth/magic-link", bytes.NewBufferString(`{"email":"`+email+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 204 {
+		t.Fatalf("expected status 204, got %d", resp.StatusCode)
+	}
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "magic_link_binding" {
+			if !cookie.HttpOnly || !cookie.Secure || cookie.Value == "" {
+				t.Errorf("expected a secure HttpOnly binding cookie, got %+v", cookie)
+			}
+			return cookie
+		}
+	}
+
+	t.Fatal("expected a binding cookie")
+	return nil
+}
+
+func verifyMagicLink(app *fiber.App, token string, binding *http.Cookie) *http.Response {
+	req := httptest.NewRequest("POST", "/api/auth/magic-link/verify", bytes.NewBufferString(`{"token":"`+token+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if binding != nil {
+		req.AddCookie(binding)
+	}
+	resp, _ := app.Test(req)
+	return resp
+}
+
+func TestMagicLinkFlow(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", IsActive: true}
+
+	sender := mail.NewCaptureSender()
+	app := setupMagicLinkTestApp(t, mockRepo, sender)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	binding := requestMagicLink(t, app, "user@example.com")
+
+	message := sender.Last()
+	if message == nil || message.To != "user@example.com" {
+		t.Fatalf("expected a login mail to user@example.com, got %+v", message)
+	}
+
+	token := linkToken(t, message.Body, "http://localhost/magic-link")
+
+	resp := verifyMagicLink(app, token, binding)
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data handlers.TokenResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if body.Data.AccessToken == "" || body.Data.RefreshToken == "" {
+		t.Errorf("expected a token pair, got %+v", body.Data)
+	}
+
+	if mockRepo.users["123"].EmailVerifiedAt == nil {
+		t.Error("expected the email address to be marked verified")
+	}
+
+	// Links are single use
+	if resp := verifyMagicLink(app, token, binding); resp.StatusCode != 401 {
+		t.Errorf("expected status 401 for a used link, got %d", resp.StatusCode)
+	}
+}
+
+func TestMagicLinkFlow_OtherClient(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", IsActive: true}
+
+	sender := mail.NewCaptureSender()
+	app := setupMagicLinkTestApp(t, mockRepo, sender)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	binding := requestMagicLink(t, app, "user@example.com")
+	token := linkToken(t, sender.Last().Body, "http://localhost/magic-link")
+
+	// A forwarded or intercepted link is useless without the binding cookie
+	if resp := verifyMagicLink(app, token, nil); resp.StatusCode != 401 {
+		t.Errorf("expected status 401 without the binding, got %d", resp.StatusCode)
+	}
+
+	if resp := verifyMagicLink(app, token, &http.Cookie{Name: binding.Name, Value: "forged"}); resp.StatusCode != 401 {
+		t.Errorf("expected status 401 with a forged binding, got %d", resp.StatusCode)
+	}
+
+	// The requesting client can still use it
+	if resp := verifyMagicLink(app, token, binding); resp.StatusCode != 200 {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestMagicLinkRequest_UnknownEmail(t *testing.T) {
+	sender := mail.NewCaptureSender()
+	app := setupMagicLinkTestApp(t, NewMockUserRepository(), sender)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	// Same response, cookie included, as for a registered address
+	requestMagicLink(t, app, "nobody@example.com")
+
+	if messages := sender.Messages(); len(messages) != 0 {
+		t.Errorf("expected no mail for an unknown address, got %d", len(messages))
+	}
+}
+
+func TestMagicLinkRequest_CookieScopedToMagicLinkRoutes(t *testing.T) {
+	app := setupMagicLinkTestApp(t, NewMockUserRepository(), mail.NewCaptureSender())
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	req := httptest.NewRequest("POST", "/api/auth/magic-link", bytes.NewBufferString(`{"email":"user@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	cookie := resp.Header.Get("Set-Cookie")
+	if !strings.Contains(cookie, "path=/api/auth/magic-link") || !strings.Contains(cookie, "SameSite=Lax") {
+		t.Errorf("unexpected binding cookie: %s", cookie)
+	}
+}
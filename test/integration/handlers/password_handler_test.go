@@ -0,0 +1,193 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/handlers"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/pkg/password"
+)
+
+// MockPasswordResetTokenRepository is an in-memory reset token store for testing
+type MockPasswordResetTokenRepository struct {
+	tokens map[string]*domains.PasswordResetToken
+}
+
+func NewMockPasswordResetTokenRepository() *MockPasswordResetTokenRepository {
+	return &MockPasswordResetTokenRepository{
+		tokens: make(map[string]*domains.PasswordResetToken),
+	}
+}
+
+func (m *MockPasswordResetTokenRepository) Create(ctx context.Context, token *domains.PasswordResetToken) error {
+	token.CreatedAt = time.Now()
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *MockPasswordResetTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.PasswordResetToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockPasswordResetTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.PasswordResetToken, error) {
+	var latest *domains.PasswordResetToken
+	for _, token := range m.tokens {
+		if token.UserID == userID && (latest == nil || token.CreatedAt.After(latest.CreatedAt)) {
+			latest = token
+		}
+	}
+	return latest, nil
+}
+
+func (m *MockPasswordResetTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	token, ok := m.tokens[id]
+	if !ok || token.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	return true, nil
+}
+
+func (m *MockPasswordResetTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+func setupPasswordTestApp(repo *MockUserRepository, hasher password.Hasher, sender *RecordingMailSender) *fiber.App {
+	app := fiber.New()
+	passwordUsecase := usecases.NewPasswordUsecase(repo, NewMockPasswordResetTokenRepository(), hasher, repositories.NewMemoryTokenRevocationStore(), &MockRefreshTokenRepository{}, sender, slog.New(slog.DiscardHandler), usecases.PasswordResetConfig{
+		TokenTTL: time.Hour,
+		URL:      "http://localhost/reset-password",
+	})
+	passwordHandler := handlers.NewPasswordHandler(passwordUsecase)
+
+	api := app.Group("/api")
+	api.Post("/auth/forgot-password", passwordHandler.ForgotPassword)
+	api.Post("/auth/reset-password", passwordHandler.ResetPassword)
+
+	// Stand-in for AuthMiddleware: the caller is named by a header
+	api.Post("/auth/change-password", func(c *fiber.Ctx) error {
+		if userID := c.Get("X-Test-User"); userID != "" {
+			c.Locals("user_id", userID)
+		}
+		return c.Next()
+	}, passwordHandler.ChangePassword)
+
+	return app
+}
+
+func postJSON(app *fiber.App, path, body string, headers ...string) int {
+	req := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, _ := app.Test(req)
+	return resp.StatusCode
+}
+
+func TestPasswordResetFlow(t *testing.T) {
+	hasher, _ := password.New(password.Options{Bcrypt: password.BcryptOptions{Cost: 4}})
+	oldHash, _ := hasher.Hash("password123")
+
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", PasswordHash: oldHash, IsActive: true}
+
+	sender := &RecordingMailSender{}
+	app := setupPasswordTestApp(mockRepo, hasher, sender)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	if status := postJSON(app, "/api/auth/forgot-password", `{"email":"user@example.com"}`); status != 204 {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	if len(sender.messages) != 1 {
+		t.Fatalf("expected one reset mail, got %d", len(sender.messages))
+	}
+
+	token := linkToken(t, sender.messages[0].Body, "http://localhost/reset-password")
+	reset := `{"token":"` + token + `","new_password":"new-password456"}`
+
+	if status := postJSON(app, "/api/auth/reset-password", reset); status != 204 {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	if match, _ := hasher.Verify("new-password456", mockRepo.users["123"].PasswordHash); !match {
+		t.Error("expected the new password to be stored")
+	}
+
+	// Tokens are single use
+	if status := postJSON(app, "/api/auth/reset-password", reset); status != 400 {
+		t.Errorf("expected status 400 on reuse, got %d", status)
+	}
+}
+
+func TestForgotPasswordEndpoint_UnknownEmail(t *testing.T) {
+	hasher, _ := password.New(password.Options{Bcrypt: password.BcryptOptions{Cost: 4}})
+	sender := &RecordingMailSender{}
+	app := setupPasswordTestApp(NewMockUserRepository(), hasher, sender)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	if status := postJSON(app, "/api/auth/forgot-password", `{"email":"nobody@example.com"}`); status != 204 {
+		t.Errorf("expected status 204, got %d", status)
+	}
+
+	if len(sender.messages) != 0 {
+		t.Errorf("expected no mail for an unknown address, got %d", len(sender.messages))
+	}
+}
+
+func TestChangePasswordEndpoint(t *testing.T) {
+	hasher, _ := password.New(password.Options{Bcrypt: password.BcryptOptions{Cost: 4}})
+	oldHash, _ := hasher.Hash("password123")
+
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", PasswordHash: oldHash, IsActive: true}
+
+	app := setupPasswordTestApp(mockRepo, hasher, &RecordingMailSender{})
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	tests := []struct {
+		name     string
+		user     string
+		body     string
+		expected int
+	}{
+		{"unauthenticated", "", `{"current_password":"password123","new_password":"new-password456"}`, 401},
+		{"wrong current password", "123", `{"current_password":"wrong","new_password":"new-password456"}`, 400},
+		{"success", "123", `{"current_password":"password123","new_password":"new-password456"}`, 204},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := postJSON(app, "/api/auth/change-password", tt.body, "X-Test-User", tt.user); status != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, status)
+			}
+		})
+	}
+}
@@ -5,14 +5,25 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"log/slog"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
 	"github.com/zercle/template-go-fiber/internal/handlers"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/internal/repositories"
 	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/pkg/password"
+	"github.com/zercle/template-go-fiber/pkg/response"
 )
 
 // MockUserRepository is a simple mock for testing
@@ -27,12 +38,15 @@ func NewMockUserRepository() *MockUserRepository {
 }
 
 func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*domains.User, error) {
-	return m.users[id], nil
+	if user, ok := m.users[id]; ok && user.DeletedAt == nil {
+		return user, nil
+	}
+	return nil, nil
 }
 
 func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domains.User, error) {
 	for _, user := range m.users {
-		if user.Email == email {
+		if user.Email == email && user.DeletedAt == nil {
 			return user, nil
 		}
 	}
@@ -48,50 +62,306 @@ func (m *MockUserRepository) Create(ctx context.Context, user *domains.User) err
 
 func (m *MockUserRepository) Update(ctx context.Context, user *domains.User) error {
 	user.UpdatedAt = time.Now()
+	user.Version++
 	m.users[user.ID] = user
 	return nil
 }
 
-func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+func (m *MockUserRepository) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	if user, ok := m.users[id]; ok && user.DeletedAt == nil {
+		user.PasswordHash = passwordHash
+	}
+	return nil
+}
+
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id string, verifiedAt time.Time) error {
+	if user, ok := m.users[id]; ok && user.DeletedAt == nil && user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &verifiedAt
+	}
+	return nil
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id string, version int32) error {
 	if user, ok := m.users[id]; ok {
+		if user.Version != version {
+			return errors.NewPreconditionFailedError("user has been modified since it was read")
+		}
 		now := time.Now()
 		user.DeletedAt = &now
+		user.Version++
 	}
 	return nil
 }
 
-func (m *MockUserRepository) List(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+func (m *MockUserRepository) List(ctx context.Context, filter *domains.UserListFilter, limit, offset int32) ([]*domains.User, error) {
+	var users []*domains.User
+	for _, user := range m.users {
+		if user.DeletedAt != nil {
+			continue
+		}
+		if filter != nil && filter.IsActive != nil && user.IsActive != *filter.IsActive {
+			continue
+		}
+		users = append(users, user)
+	}
+	slices.SortFunc(users, func(a, b *domains.User) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	start := min(len(users), int(offset))
+	return users[start:min(len(users), start+int(limit))], nil
+}
+
+// ListByCursor pages through the users newest first
+func (m *MockUserRepository) ListByCursor(ctx context.Context, filter *domains.UserListFilter, cursor *domains.UserCursor, limit int32) ([]*domains.User, error) {
 	var users []*domains.User
 	for _, user := range m.users {
 		if user.DeletedAt == nil {
 			users = append(users, user)
 		}
 	}
-	return users, nil
+	slices.SortFunc(users, func(a, b *domains.User) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	if cursor == nil {
+		return users[:min(len(users), int(limit))], nil
+	}
+
+	var page []*domains.User
+	for _, user := range users {
+		precedes := user.CreatedAt.After(cursor.CreatedAt) || (user.CreatedAt.Equal(cursor.CreatedAt) && user.ID > cursor.ID)
+		follows := user.CreatedAt.Before(cursor.CreatedAt) || (user.CreatedAt.Equal(cursor.CreatedAt) && user.ID < cursor.ID)
+		if (cursor.Backward && precedes) || (!cursor.Backward && follows) {
+			page = append(page, user)
+		}
+	}
+
+	if cursor.Backward {
+		return page[max(0, len(page)-int(limit)):], nil
+	}
+	return page[:min(len(page), int(limit))], nil
 }
 
-func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
+func (m *MockUserRepository) Count(ctx context.Context, filter *domains.UserListFilter) (int64, error) {
 	count := 0
 	for _, user := range m.users {
-		if user.DeletedAt == nil {
+		if user.DeletedAt != nil {
+			continue
+		}
+		if filter != nil && filter.IsActive != nil && user.IsActive != *filter.IsActive {
+			continue
+		}
+		count++
+	}
+	return int64(count), nil
+}
+
+func (m *MockUserRepository) GetDeletedByID(ctx context.Context, id string) (*domains.User, error) {
+	if user, ok := m.users[id]; ok && user.DeletedAt != nil {
+		return user, nil
+	}
+	return nil, nil
+}
+
+func (m *MockUserRepository) ListDeleted(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+	var users []*domains.User
+	for _, user := range m.users {
+		if user.DeletedAt != nil {
+			users = append(users, user)
+		}
+	}
+	slices.SortFunc(users, func(a, b *domains.User) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	start := min(len(users), int(offset))
+	return users[start:min(len(users), start+int(limit))], nil
+}
+
+func (m *MockUserRepository) CountDeleted(ctx context.Context) (int64, error) {
+	count := 0
+	for _, user := range m.users {
+		if user.DeletedAt != nil {
 			count++
 		}
 	}
 	return int64(count), nil
 }
 
+func (m *MockUserRepository) Restore(ctx context.Context, id string) (bool, error) {
+	user, ok := m.users[id]
+	if !ok || user.DeletedAt == nil {
+		return false, nil
+	}
+	user.DeletedAt = nil
+	user.Version++
+	return true, nil
+}
+
+func (m *MockUserRepository) HardDelete(ctx context.Context, id string) (bool, error) {
+	user, ok := m.users[id]
+	if !ok || user.DeletedAt == nil {
+		return false, nil
+	}
+	delete(m.users, id)
+	return true, nil
+}
+
+func (m *MockUserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	for id, user := range m.users {
+		if user.DeletedAt != nil && user.DeletedAt.Before(deletedBefore) {
+			delete(m.users, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// MockRefreshTokenRepository is a no-op refresh token store for testing
+type MockRefreshTokenRepository struct{}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *domains.RefreshToken) error {
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.RefreshToken, error) {
+	return nil, nil
+}
+
+func (m *MockRefreshTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	return true, nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	return nil
+}
+
+// MockRoleRepository grants no roles for testing
+type MockRoleRepository struct{}
+
+func (m *MockRoleRepository) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *MockRoleRepository) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *MockRoleRepository) AssignRole(ctx context.Context, userID, role string) error {
+	return nil
+}
+
+func (m *MockRoleRepository) GetRolePermissions(ctx context.Context, role string) ([]string, error) {
+	return nil, nil
+}
+
+// MockEmailVerificationTokenRepository is an in-memory verification token store for testing
+type MockEmailVerificationTokenRepository struct {
+	tokens map[string]*domains.EmailVerificationToken
+}
+
+func NewMockEmailVerificationTokenRepository() *MockEmailVerificationTokenRepository {
+	return &MockEmailVerificationTokenRepository{
+		tokens: make(map[string]*domains.EmailVerificationToken),
+	}
+}
+
+func (m *MockEmailVerificationTokenRepository) Create(ctx context.Context, token *domains.EmailVerificationToken) error {
+	token.CreatedAt = time.Now()
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *MockEmailVerificationTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.EmailVerificationToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockEmailVerificationTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.EmailVerificationToken, error) {
+	var latest *domains.EmailVerificationToken
+	for _, token := range m.tokens {
+		if token.UserID == userID && (latest == nil || token.CreatedAt.After(latest.CreatedAt)) {
+			latest = token
+		}
+	}
+	return latest, nil
+}
+
+func (m *MockEmailVerificationTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	token, ok := m.tokens[id]
+	if !ok || token.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	return true, nil
+}
+
+func (m *MockEmailVerificationTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+// RecordingMailSender keeps sent messages for inspection in tests
+type RecordingMailSender struct {
+	messages []*domains.MailMessage
+}
+
+func (m *RecordingMailSender) Send(ctx context.Context, message *domains.MailMessage) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
 func setupTestApp(repo *MockUserRepository) *fiber.App {
+	return setupTestAppWithMail(repo, &RecordingMailSender{})
+}
+
+func setupTestAppWithMail(repo *MockUserRepository, sender *RecordingMailSender) *fiber.App {
+	return setupTestAppWithConfig(repo, sender, handlers.UserHandlerConfig{})
+}
+
+func setupTestAppWithConfig(repo *MockUserRepository, sender *RecordingMailSender, config handlers.UserHandlerConfig) *fiber.App {
 	app := fiber.New()
-	userUsecase := usecases.NewUserUsecase(repo)
-	userHandler := handlers.NewUserHandler(userUsecase)
+	hasher, _ := password.New(password.Options{Bcrypt: password.BcryptOptions{Cost: 4}})
+	verificationUsecase := usecases.NewEmailVerificationUsecase(repo, NewMockEmailVerificationTokenRepository(), sender, slog.New(slog.DiscardHandler), usecases.EmailVerificationConfig{
+		TokenTTL: time.Hour,
+		URL:      "http://localhost/verify-email",
+	})
+	userUsecase := usecases.NewUserUsecase(repo, hasher, repositories.NewMemoryTokenRevocationStore(), &MockRefreshTokenRepository{}, &MockRoleRepository{}, verificationUsecase, usecases.UserConfig{})
+	userHandler := handlers.NewUserHandler(userUsecase, config)
+	verificationHandler := handlers.NewEmailVerificationHandler(verificationUsecase)
 
 	api := app.Group("/api")
+	api.Post("/auth/verify-email", verificationHandler.VerifyEmail)
+	api.Post("/auth/resend-verification", verificationHandler.ResendVerification)
 	api.Post("/users/register", userHandler.RegisterUser)
 	api.Get("/users", userHandler.ListUsers)
 	api.Get("/users/email", userHandler.GetUserByEmail)
+	api.Get("/users/deleted", userHandler.ListDeletedUsers)
 	api.Get("/users/:id", userHandler.GetUser)
 	api.Put("/users/:id", userHandler.UpdateUser)
+	api.Patch("/users/:id", userHandler.PatchUser)
 	api.Delete("/users/:id", userHandler.DeleteUser)
+	api.Post("/users/:id/restore", userHandler.RestoreUser)
+	api.Delete("/users/:id/purge", userHandler.PurgeUser)
 
 	return app
 }
@@ -171,6 +441,162 @@ func TestListUsersEndpoint(t *testing.T) {
 	}
 }
 
+func TestListUsersEndpoint_Envelope(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		mockRepo.users[id] = &domains.User{ID: id, Email: "user" + id + "@example.com", IsActive: true}
+	}
+
+	app := setupTestApp(mockRepo)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/api/users?limit=2&offset=2&is_active=true", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data response.Page[handlers.UserResponse] `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	page := body.Data
+	if page.Total != 5 || page.Limit != 2 || page.Offset == nil || *page.Offset != 2 || !page.HasMore {
+		t.Errorf("unexpected page metadata: %+v", page)
+	}
+
+	// Links keep the filters and move the offset
+	links := map[string]string{
+		"first": "/api/users?is_active=true&limit=2&offset=0",
+		"prev":  "/api/users?is_active=true&limit=2&offset=0",
+		"next":  "/api/users?is_active=true&limit=2&offset=4",
+		"last":  "/api/users?is_active=true&limit=2&offset=4",
+	}
+	got := map[string]string{"first": page.Links.First, "prev": page.Links.Prev, "next": page.Links.Next, "last": page.Links.Last}
+	for rel, want := range links {
+		if got[rel] != want {
+			t.Errorf("expected %s link %s, got %s", rel, want, got[rel])
+		}
+	}
+
+	header := resp.Header.Get("Link")
+	if !strings.Contains(header, `</api/users?is_active=true&limit=2&offset=4>; rel="next"`) {
+		t.Errorf("expected a next link in the Link header, got %q", header)
+	}
+}
+
+func TestListUsersEndpoint_Filters(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["1"] = &domains.User{ID: "1", Email: "user1@example.com", IsActive: true}
+	mockRepo.users["2"] = &domains.User{ID: "2", Email: "user2@example.com", IsActive: false}
+
+	app := setupTestApp(mockRepo)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	req := httptest.NewRequest("GET", "/api/users?is_active=false&sort=-email&created_after=2026-01-01", nil)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data response.Page[handlers.UserResponse] `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(body.Data.Items) != 1 || body.Data.Items[0].ID != "2" || body.Data.Total != 1 {
+		t.Errorf("expected only the inactive user, got %+v", body.Data)
+	}
+}
+
+func TestListUsersEndpoint_InvalidFilters(t *testing.T) {
+	app := setupTestApp(NewMockUserRepository())
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	for _, query := range []string{"is_active=maybe", "created_before=yesterday", "sort=password_hash", "sort=email%3BDROP%20TABLE%20users"} {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/users?"+query, nil))
+
+		if resp.StatusCode != 400 {
+			t.Errorf("%s: expected status 400, got %d", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestListUsersEndpoint_CursorPagination(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	now := time.Now()
+	for i, id := range []string{"1", "2", "3"} {
+		mockRepo.users[id] = &domains.User{ID: id, Email: "user" + id + "@example.com", IsActive: true, CreatedAt: now.Add(time.Duration(i) * time.Minute)}
+	}
+
+	app := setupTestApp(mockRepo)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	type page struct {
+		Data response.Page[handlers.UserResponse] `json:"data"`
+	}
+	get := func(query string) page {
+		t.Helper()
+
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/users?"+query, nil))
+		if resp.StatusCode != 200 {
+			t.Fatalf("%s: expected status 200, got %d", query, resp.StatusCode)
+		}
+
+		var body page
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return body
+	}
+
+	first := get("pagination=cursor&limit=2")
+	if len(first.Data.Items) != 2 || first.Data.Items[0].ID != "3" || first.Data.NextCursor == "" || first.Data.PrevCursor != "" {
+		t.Fatalf("unexpected first page: %+v", first.Data)
+	}
+
+	// A user registered meanwhile does not shift the following page
+	mockRepo.users["4"] = &domains.User{ID: "4", Email: "user4@example.com", CreatedAt: now.Add(time.Hour)}
+
+	second := get("limit=2&cursor=" + url.QueryEscape(first.Data.NextCursor))
+	if len(second.Data.Items) != 1 || second.Data.Items[0].ID != "1" || second.Data.NextCursor != "" || second.Data.PrevCursor == "" {
+		t.Fatalf("unexpected second page: %+v", second.Data)
+	}
+
+	back := get("limit=2&cursor=" + url.QueryEscape(second.Data.PrevCursor))
+	if len(back.Data.Items) != 2 || back.Data.Items[0].ID != "3" || back.Data.PrevCursor == "" {
+		t.Errorf("expected the first page with a cursor to the new user, got %+v", back.Data)
+	}
+}
+
+func TestListUsersEndpoint_InvalidCursorPagination(t *testing.T) {
+	app := setupTestApp(NewMockUserRepository())
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	for _, query := range []string{"pagination=pages", "cursor=forged", "pagination=cursor&offset=10", "pagination=cursor&sort=email"} {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/api/users?"+query, nil))
+
+		if resp.StatusCode != 400 {
+			t.Errorf("%s: expected status 400, got %d", query, resp.StatusCode)
+		}
+	}
+}
+
 func TestDeleteUserEndpoint(t *testing.T) {
 	mockRepo := NewMockUserRepository()
 	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com"}
@@ -188,6 +614,93 @@ func TestDeleteUserEndpoint(t *testing.T) {
 	}
 }
 
+func TestDeletedUsersEndpoints(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", IsActive: true}
+	mockRepo.users["456"] = &domains.User{ID: "456", Email: "other@example.com", IsActive: true}
+
+	app := setupTestApp(mockRepo)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	resp, _ := app.Test(httptest.NewRequest("DELETE", "/api/users/123", nil))
+	if resp.StatusCode != 204 {
+		t.Fatalf("expected status 204, got %d", resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/api/users/deleted", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data response.Page[handlers.UserResponse] `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if body.Data.Total != 1 || len(body.Data.Items) != 1 || body.Data.Items[0].ID != "123" || body.Data.Items[0].DeletedAt == "" {
+		t.Errorf("expected only the deleted user, got %+v", body.Data)
+	}
+
+	// Live users cannot be purged
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/api/users/456/purge", nil))
+	if resp.StatusCode != 404 {
+		t.Errorf("expected status 404 purging a live user, got %d", resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("POST", "/api/users/123/restore", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if user := mockRepo.users["123"]; user.DeletedAt != nil {
+		t.Error("expected the user to be restored")
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("POST", "/api/users/123/restore", nil))
+	if resp.StatusCode != 404 {
+		t.Errorf("expected status 404 restoring a live user, got %d", resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/api/users/123", nil))
+	if resp.StatusCode != 204 {
+		t.Fatalf("expected status 204, got %d", resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", "/api/users/123/purge", nil))
+	if resp.StatusCode != 204 {
+		t.Errorf("expected status 204, got %d", resp.StatusCode)
+	}
+
+	if _, ok := mockRepo.users["123"]; ok {
+		t.Error("expected the user to be purged")
+	}
+}
+
+func TestRestoreUserEndpoint_EmailTaken(t *testing.T) {
+	deletedAt := time.Now().Add(-time.Hour)
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", DeletedAt: &deletedAt}
+	mockRepo.users["456"] = &domains.User{ID: "456", Email: "user@example.com", IsActive: true}
+
+	app := setupTestApp(mockRepo)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	resp, _ := app.Test(httptest.NewRequest("POST", "/api/users/123/restore", nil))
+	if resp.StatusCode != 409 {
+		t.Errorf("expected status 409, got %d", resp.StatusCode)
+	}
+
+	if mockRepo.users["123"].DeletedAt == nil {
+		t.Error("expected the user to stay deleted")
+	}
+}
+
 func TestUpdateUserEndpoint(t *testing.T) {
 	mockRepo := NewMockUserRepository()
 	mockRepo.users["123"] = &domains.User{ID: "123", Email: "old@example.com"}
@@ -206,3 +719,194 @@ func TestUpdateUserEndpoint(t *testing.T) {
 		t.Errorf("expected status 200, got %d", resp.StatusCode)
 	}
 }
+
+func TestUpdateUserEndpoint_IfMatch(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", Version: 1}
+
+	app := setupTestApp(mockRepo)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/api/users/123", nil))
+	etag := resp.Header.Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("expected ETag \"1\", got %q", etag)
+	}
+
+	update := func(ifMatch, body string) *http.Response {
+		req := httptest.NewRequest("PUT", "/api/users/123", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+		resp, _ := app.Test(req)
+		return resp
+	}
+
+	resp = update(etag, `{"first_name":"First"}`)
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("ETag"); got != `"2"` {
+		t.Errorf("expected ETag \"2\" after the update, got %q", got)
+	}
+
+	// A second writer still holding the first ETag loses
+	resp = update(etag, `{"first_name":"Second"}`)
+	if resp.StatusCode != 412 {
+		t.Errorf("expected status 412, got %d", resp.StatusCode)
+	}
+	if name := mockRepo.users["123"].FirstName; name == nil || *name != "First" {
+		t.Errorf("expected the first update to be kept, got %v", name)
+	}
+
+	for _, ifMatch := range []string{`W/"2"`, `"2", "3"`} {
+		if resp = update(ifMatch, `{"first_name":"Third"}`); resp.StatusCode == 200 {
+			t.Errorf("If-Match %s: expected the update to be rejected", ifMatch)
+		}
+	}
+
+	resp = update("*", `{"first_name":"Any"}`)
+	if resp.StatusCode != 200 {
+		t.Errorf("expected status 200 for If-Match *, got %d", resp.StatusCode)
+	}
+}
+
+func TestLoginRehash_KeepsETag(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	// Accounts created before real hashing still hold the placeholder format
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", PasswordHash: "hashed_password123", IsActive: true, Version: 1}
+
+	app := setupTestApp(mockRepo)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{jwtkeys.NewHMACKey("", []byte("test-secret-key"))}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+
+	hasher, _ := password.New(password.Options{Bcrypt: password.BcryptOptions{Cost: 4}})
+	lockout := usecases.NewLockoutUsecase(mockRepo, repositories.NewMemoryLoginAttemptStore(), slog.New(slog.DiscardHandler), usecases.LockoutPolicy{
+		FailureWindow:        15 * time.Minute,
+		MaxAccountFailures:   10,
+		MaxIPAccountFailures: 3,
+		Duration:             15 * time.Minute,
+	})
+	authUsecase := usecases.NewAuthUsecase(mockRepo, &MockRefreshTokenRepository{}, repositories.NewMemoryTokenRevocationStore(), &MockRoleRepository{}, &MockMFARepository{}, lockout, hasher, middleware.NewJWTIssuer(keys, time.Hour), nil, usecases.AuthConfig{
+		RefreshTokenTTL: time.Hour,
+	})
+	app.Post("/api/auth/login", handlers.NewAuthHandler(authUsecase).Login)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/api/users/123", nil))
+	etag := resp.Header.Get("ETag")
+
+	req := httptest.NewRequest("POST", "/api/auth/login", bytes.NewBufferString(`{"email":"user@example.com","password":"password123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if hash := mockRepo.users["123"].PasswordHash; password.Identify(hash) != password.AlgorithmBcrypt {
+		t.Fatalf("expected the password to be rehashed, got %s", hash)
+	}
+
+	// Upgrading the hash is not an edit, so clients holding the ETag can
+	// still write
+	resp, _ = app.Test(httptest.NewRequest("GET", "/api/users/123", nil))
+	if got := resp.Header.Get("ETag"); got != etag {
+		t.Errorf("expected ETag %s to be kept, got %s", etag, got)
+	}
+}
+
+func TestDeleteUserEndpoint_IfMatch(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", Version: 3}
+
+	app := setupTestAppWithConfig(mockRepo, &RecordingMailSender{}, handlers.UserHandlerConfig{RequireIfMatch: true})
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	resp, _ := app.Test(httptest.NewRequest("DELETE", "/api/users/123", nil))
+	if resp.StatusCode != 428 {
+		t.Errorf("expected status 428 without If-Match, got %d", resp.StatusCode)
+	}
+
+	req := httptest.NewRequest("DELETE", "/api/users/123", nil)
+	req.Header.Set("If-Match", `"2"`)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != 412 {
+		t.Errorf("expected status 412 for a stale ETag, got %d", resp.StatusCode)
+	}
+
+	req = httptest.NewRequest("DELETE", "/api/users/123", nil)
+	req.Header.Set("If-Match", `"3"`)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != 204 {
+		t.Errorf("expected status 204, got %d", resp.StatusCode)
+	}
+}
+
+func TestPatchUserEndpoint(t *testing.T) {
+	firstName, lastName := "Jane", "Doe"
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["123"] = &domains.User{ID: "123", Email: "user@example.com", FirstName: &firstName, LastName: &lastName, IsActive: true, Version: 1}
+
+	app := setupTestApp(mockRepo)
+	defer func() {
+		_ = app.Shutdown()
+	}()
+
+	patch := func(contentType, body string) *http.Response {
+		req := httptest.NewRequest("PATCH", "/api/users/123", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		resp, _ := app.Test(req)
+		return resp
+	}
+
+	// null clears a name, and members the patch leaves out keep their value
+	resp := patch("application/merge-patch+json", `{"first_name":null}`)
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("ETag"); got != `"2"` {
+		t.Errorf("expected ETag \"2\", got %q", got)
+	}
+
+	user := mockRepo.users["123"]
+	if user.FirstName != nil || user.LastName == nil || *user.LastName != "Doe" || user.Email != "user@example.com" {
+		t.Errorf("expected only the first name to be cleared, got %+v", user)
+	}
+
+	resp = patch("application/json-patch+json; charset=utf-8", `[{"op":"test","path":"/last_name","value":"Doe"},{"op":"replace","path":"/last_name","value":"Smith"},{"op":"add","path":"/first_name","value":"Ann"}]`)
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if user.FirstName == nil || *user.FirstName != "Ann" || user.LastName == nil || *user.LastName != "Smith" {
+		t.Errorf("expected the names to be patched, got %v %v", user.FirstName, user.LastName)
+	}
+
+	for _, tt := range []struct {
+		name, contentType, body string
+		want                    int
+	}{
+		{"plain JSON", "application/json", `{"first_name":"Bob"}`, 415},
+		{"malformed patch", "application/merge-patch+json", `{"first_name":`, 400},
+		{"removed email", "application/merge-patch+json", `{"email":null}`, 400},
+		{"wrong type", "application/merge-patch+json", `{"is_active":"yes"}`, 400},
+		{"read-only field", "application/json-patch+json", `[{"op":"add","path":"/id","value":"456"}]`, 400},
+		{"missing path", "application/json-patch+json", `[{"op":"remove","path":"/middle_name"}]`, 400},
+		{"failed test", "application/json-patch+json", `[{"op":"test","path":"/last_name","value":"Doe"},{"op":"remove","path":"/last_name"}]`, 409},
+	} {
+		if resp := patch(tt.contentType, tt.body); resp.StatusCode != tt.want {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.want, resp.StatusCode)
+		}
+	}
+
+	if user.Version != 3 || user.LastName == nil {
+		t.Errorf("expected rejected patches to leave the user alone, got %+v", user)
+	}
+}
@@ -0,0 +1,104 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("INSERT INTO api_keys \\(id, user_id, name, prefix, secret_hash, scopes, expires_at, created_at\\)").
+		WithArgs("key-1", "user-123", "batch", "ak_0123456789abcdef", "hash", "users:read self:read", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewAPIKeyRepository(db.New(mockDB))
+
+	err = repo.Create(context.Background(), &domains.APIKey{
+		ID:         "key-1",
+		UserID:     "user-123",
+		Name:       "batch",
+		Prefix:     "ak_0123456789abcdef",
+		SecretHash: "hash",
+		Scopes:     []string{"users:read", "self:read"},
+	})
+
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAPIKeyRepository_GetByPrefix(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "name", "prefix", "secret_hash", "scopes", "expires_at", "last_used_at", "created_at",
+	}).AddRow("key-1", "user-123", "batch", "ak_0123456789abcdef", "hash", "users:read self:read", nil, time.Now(), time.Now())
+
+	mock.ExpectQuery("SELECT id, user_id, name, prefix, secret_hash, scopes, expires_at, last_used_at, created_at FROM api_keys WHERE prefix = .*").
+		WithArgs("ak_0123456789abcdef").
+		WillReturnRows(rows)
+
+	repo := repositories.NewAPIKeyRepository(db.New(mockDB))
+
+	key, err := repo.GetByPrefix(context.Background(), "ak_0123456789abcdef")
+
+	if err != nil {
+		t.Fatalf("GetByPrefix failed: %v", err)
+	}
+
+	if key == nil || len(key.Scopes) != 2 || key.Scopes[0] != "users:read" {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+
+	if key.ExpiresAt != nil || key.LastUsedAt == nil {
+		t.Errorf("expected non-expiring, used key, got %+v", key)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAPIKeyRepository_TouchLastUsed(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	usedAt := time.Now()
+	staleBefore := usedAt.Add(-time.Minute)
+
+	mock.ExpectExec("UPDATE api_keys SET last_used_at = .* WHERE id = .* AND \\(last_used_at IS NULL OR last_used_at < .*\\)").
+		WithArgs(usedAt, "key-1", staleBefore).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewAPIKeyRepository(db.New(mockDB))
+
+	if err := repo.TouchLastUsed(context.Background(), "key-1", usedAt, staleBefore); err != nil {
+		t.Fatalf("TouchLastUsed failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
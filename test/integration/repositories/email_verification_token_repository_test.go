@@ -0,0 +1,71 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestEmailVerificationTokenRepository_GetByHash(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "tenant_id", "token_hash", "expires_at", "used_at", "created_at",
+	}).AddRow("vt-1", "user-123", "tenant-1", "hash", time.Now().Add(time.Hour), nil, time.Now())
+
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, token_hash, expires_at, used_at, created_at FROM email_verification_tokens WHERE token_hash = .*").
+		WithArgs("hash").
+		WillReturnRows(rows)
+
+	repo := repositories.NewEmailVerificationTokenRepository(db.New(mockDB))
+
+	token, err := repo.GetByHash(context.Background(), "hash")
+
+	if err != nil {
+		t.Fatalf("GetByHash failed: %v", err)
+	}
+
+	if token == nil || token.UserID != "user-123" || token.TenantID != "tenant-1" || token.UsedAt != nil {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestEmailVerificationTokenRepository_MarkUsed_AlreadyUsed(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("UPDATE email_verification_tokens SET used_at = NOW\\(\\) WHERE id = .* AND used_at IS NULL").
+		WithArgs("vt-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewEmailVerificationTokenRepository(db.New(mockDB))
+
+	used, err := repo.MarkUsed(context.Background(), "vt-1")
+
+	if err != nil {
+		t.Fatalf("MarkUsed failed: %v", err)
+	}
+
+	if used {
+		t.Error("expected MarkUsed to report a token that was already consumed")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
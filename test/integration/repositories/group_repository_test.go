@@ -0,0 +1,170 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+var groupColumns = []string{"id", "tenant_id", "name", "description", "created_at", "updated_at"}
+
+func TestGroupRepository_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("INSERT INTO user_groups \\(id, tenant_id, name, description, created_at, updated_at\\)").
+		WithArgs("group-1", "tenant-1", "support", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewGroupRepository(db.New(mockDB))
+
+	group := &domains.Group{ID: "group-1", Name: "support"}
+	if err := repo.Create(tenantContext(), group); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if group.TenantID != "tenant-1" {
+		t.Errorf("expected tenant-1, got %s", group.TenantID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGroupRepository_GetByID(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT .* FROM user_groups WHERE tenant_id = \\? AND id = \\?").
+		WithArgs("tenant-1", "group-1").
+		WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("group-1", "tenant-1", "support", "Support staff", now, now))
+
+	mock.ExpectQuery("SELECT role_name FROM group_roles WHERE group_id = \\?").
+		WithArgs("group-1").
+		WillReturnRows(sqlmock.NewRows([]string{"role_name"}).AddRow(domains.RoleAdmin))
+
+	mock.ExpectQuery("SELECT .* FROM user_groups WHERE tenant_id = \\? AND id = \\?").
+		WithArgs("tenant-1", "unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewGroupRepository(db.New(mockDB))
+
+	group, err := repo.GetByID(tenantContext(), "group-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+
+	if group.Name != "support" || group.Description == nil || *group.Description != "Support staff" {
+		t.Errorf("unexpected group: %+v", group)
+	}
+
+	if !slices.Equal(group.Roles, []string{domains.RoleAdmin}) {
+		t.Errorf("unexpected roles: %v", group.Roles)
+	}
+
+	group, err = repo.GetByID(tenantContext(), "unknown")
+	if err != nil || group != nil {
+		t.Errorf("expected nil, nil for an unknown group, got %v, %v", group, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGroupRepository_ListMembers(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "tenant_id", "email", "password_hash", "first_name", "last_name", "is_active", "email_verified_at", "is_service_account", "created_at", "updated_at", "deleted_at", "live_email", "version"}).
+		AddRow("user-1", "tenant-1", "member@example.com", "hash", "Jane", nil, true, nil, false, now, now, nil, "member@example.com", 1)
+
+	mock.ExpectQuery("SELECT u.id, .* FROM group_members gm JOIN user_groups g .* JOIN users u .* WHERE g.tenant_id = \\? AND gm.group_id = \\? AND u.deleted_at IS NULL").
+		WithArgs("tenant-1", "group-1", int32(10), int32(0)).
+		WillReturnRows(rows)
+
+	repo := repositories.NewGroupRepository(db.New(mockDB))
+
+	members, err := repo.ListMembers(tenantContext(), "group-1", 10, 0)
+	if err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+
+	if len(members) != 1 || members[0].Email != "member@example.com" || members[0].FirstName == nil {
+		t.Errorf("unexpected members: %+v", members)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGroupRepository_RemoveMember(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("DELETE gm FROM group_members gm JOIN user_groups g ON g.id = gm.group_id WHERE g.tenant_id = \\? AND gm.group_id = \\? AND gm.user_id = \\?").
+		WithArgs("tenant-1", "group-1", "user-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec("DELETE gm FROM group_members gm").
+		WithArgs("tenant-1", "group-1", "user-2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewGroupRepository(db.New(mockDB))
+
+	removed, err := repo.RemoveMember(tenantContext(), "group-1", "user-1")
+	if err != nil || !removed {
+		t.Errorf("expected the member to be removed, got %v, %v", removed, err)
+	}
+
+	removed, err = repo.RemoveMember(tenantContext(), "group-1", "user-2")
+	if err != nil || removed {
+		t.Errorf("expected a non-member not to be removed, got %v, %v", removed, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGroupRepository_RequiresTenant(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	repo := repositories.NewGroupRepository(db.New(mockDB))
+
+	if err := repo.AddMember(context.Background(), "group-1", "user-1"); !errors.Is(err, domains.ErrNoTenant) {
+		t.Errorf("expected ErrNoTenant, got %v", err)
+	}
+
+	if _, err := repo.List(context.Background(), 10, 0); !errors.Is(err, domains.ErrNoTenant) {
+		t.Errorf("expected ErrNoTenant, got %v", err)
+	}
+}
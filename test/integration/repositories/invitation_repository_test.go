@@ -0,0 +1,144 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+var invitationColumns = []string{"id", "tenant_id", "email", "role_name", "token_hash", "invited_by", "status", "expires_at", "responded_at", "created_at", "updated_at"}
+
+func TestInvitationRepository_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	expiresAt := time.Now().Add(time.Hour)
+	inviter := "admin-1"
+
+	mock.ExpectExec("INSERT INTO invitations \\(id, tenant_id, email, role_name, token_hash, invited_by, status, expires_at, created_at, updated_at\\)").
+		WithArgs("inv-1", "tenant-1", "invitee@example.com", domains.RoleUser, "hash", inviter, expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewInvitationRepository(db.New(mockDB))
+
+	invitation := &domains.Invitation{
+		ID:        "inv-1",
+		Email:     "invitee@example.com",
+		Role:      domains.RoleUser,
+		TokenHash: "hash",
+		InvitedBy: &inviter,
+		ExpiresAt: expiresAt,
+	}
+
+	if err := repo.Create(tenantContext(), invitation); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if invitation.TenantID != "tenant-1" || invitation.Status != domains.InvitationPending {
+		t.Errorf("expected a pending invitation in tenant-1, got %+v", invitation)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestInvitationRepository_GetByHash(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	now := time.Now()
+	rows := sqlmock.NewRows(invitationColumns).
+		AddRow("inv-1", "tenant-1", "invitee@example.com", domains.RoleUser, "hash", nil, domains.InvitationPending, now.Add(time.Hour), nil, now, now)
+
+	mock.ExpectQuery("SELECT .* FROM invitations WHERE tenant_id = \\? AND token_hash = \\?").
+		WithArgs("tenant-1", "hash").
+		WillReturnRows(rows)
+
+	mock.ExpectQuery("SELECT .* FROM invitations WHERE tenant_id = \\? AND token_hash = \\?").
+		WithArgs("tenant-1", "unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewInvitationRepository(db.New(mockDB))
+
+	invitation, err := repo.GetByHash(tenantContext(), "hash")
+	if err != nil {
+		t.Fatalf("GetByHash failed: %v", err)
+	}
+
+	if invitation == nil || invitation.Role != domains.RoleUser || invitation.InvitedBy != nil || invitation.RespondedAt != nil {
+		t.Errorf("unexpected invitation: %+v", invitation)
+	}
+
+	invitation, err = repo.GetByHash(tenantContext(), "unknown")
+	if err != nil {
+		t.Fatalf("GetByHash failed: %v", err)
+	}
+
+	if invitation != nil {
+		t.Errorf("expected nil for unknown token, got %+v", invitation)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestInvitationRepository_Respond(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("UPDATE invitations SET status = \\?, responded_at = NOW\\(\\), updated_at = NOW\\(\\) WHERE tenant_id = \\? AND id = \\? AND status = 'pending'").
+		WithArgs(domains.InvitationAccepted, "tenant-1", "inv-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec("UPDATE invitations SET status = \\?").
+		WithArgs(domains.InvitationAccepted, "tenant-1", "inv-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewInvitationRepository(db.New(mockDB))
+
+	responded, err := repo.Respond(tenantContext(), "inv-1", domains.InvitationAccepted)
+	if err != nil || !responded {
+		t.Fatalf("expected first response to succeed, got %v, %v", responded, err)
+	}
+
+	responded, err = repo.Respond(tenantContext(), "inv-1", domains.InvitationAccepted)
+	if err != nil || responded {
+		t.Errorf("expected second response to be rejected, got %v, %v", responded, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestInvitationRepository_RequiresTenant(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	repo := repositories.NewInvitationRepository(db.New(mockDB))
+
+	if _, err := repo.ListPending(context.Background(), 10, 0); !errors.Is(err, domains.ErrNoTenant) {
+		t.Errorf("expected ErrNoTenant, got %v", err)
+	}
+}
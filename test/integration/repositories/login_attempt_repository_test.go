@@ -0,0 +1,100 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestLoginAttemptRepository_RecordFailure(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	windowStart := time.Now().Add(-15 * time.Minute)
+
+	mock.ExpectExec("DELETE FROM login_attempts WHERE last_failed_at < .* AND \\(locked_until IS NULL OR locked_until < NOW\\(\\)\\)").
+		WithArgs(windowStart).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectExec("INSERT INTO login_attempts .* ON DUPLICATE KEY UPDATE failures = IF\\(last_failed_at < .*, 1, failures \\+ 1\\)").
+		WithArgs("user@example.com", "10.0.0.1", sqlmock.AnyArg(), windowStart, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	rows := sqlmock.NewRows([]string{
+		"account", "ip_address", "failures", "last_failed_at", "locked_until",
+	}).AddRow("user@example.com", "10.0.0.1", 3, time.Now(), nil)
+
+	mock.ExpectQuery("SELECT account, ip_address, failures, last_failed_at, locked_until FROM login_attempts WHERE account = .* AND ip_address = .*").
+		WithArgs("user@example.com", "10.0.0.1").
+		WillReturnRows(rows)
+
+	repo := repositories.NewLoginAttemptRepository(db.New(mockDB))
+
+	attempt, err := repo.RecordFailure(context.Background(), "user@example.com", "10.0.0.1", windowStart)
+
+	if err != nil {
+		t.Fatalf("RecordFailure failed: %v", err)
+	}
+
+	if attempt.Failures != 3 || attempt.IPAddress != "10.0.0.1" || attempt.LockedUntil != nil {
+		t.Errorf("unexpected attempt: %+v", attempt)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestLoginAttemptRepository_Get_None(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectQuery("SELECT account, ip_address, failures, last_failed_at, locked_until FROM login_attempts").
+		WithArgs("user@example.com", "").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewLoginAttemptRepository(db.New(mockDB))
+
+	attempt, err := repo.Get(context.Background(), "user@example.com", "")
+
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if attempt != nil {
+		t.Errorf("expected no attempt, got %+v", attempt)
+	}
+}
+
+func TestLoginAttemptRepository_ResetAccount(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("DELETE FROM login_attempts WHERE account = .*").
+		WithArgs("user@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	repo := repositories.NewLoginAttemptRepository(db.New(mockDB))
+
+	if err := repo.ResetAccount(context.Background(), "user@example.com"); err != nil {
+		t.Fatalf("ResetAccount failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestMagicLinkTokenRepository_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	mock.ExpectExec("INSERT INTO magic_link_tokens \\(id, user_id, tenant_id, token_hash, binding_hash, expires_at, created_at\\)").
+		WithArgs("ml-1", "user-123", "tenant-1", "token-hash", "binding-hash", expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewMagicLinkTokenRepository(db.New(mockDB))
+
+	err = repo.Create(context.Background(), &domains.MagicLinkToken{
+		ID:          "ml-1",
+		UserID:      "user-123",
+		TenantID:    "tenant-1",
+		TokenHash:   "token-hash",
+		BindingHash: "binding-hash",
+		ExpiresAt:   expiresAt,
+	})
+
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMagicLinkTokenRepository_GetByHash(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "tenant_id", "token_hash", "binding_hash", "expires_at", "used_at", "created_at",
+	}).AddRow("ml-1", "user-123", "tenant-1", "token-hash", "binding-hash", time.Now().Add(time.Minute), nil, time.Now())
+
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, token_hash, binding_hash, expires_at, used_at, created_at FROM magic_link_tokens WHERE token_hash = .*").
+		WithArgs("token-hash").
+		WillReturnRows(rows)
+
+	repo := repositories.NewMagicLinkTokenRepository(db.New(mockDB))
+
+	token, err := repo.GetByHash(context.Background(), "token-hash")
+
+	if err != nil {
+		t.Fatalf("GetByHash failed: %v", err)
+	}
+
+	if token == nil || token.BindingHash != "binding-hash" || token.TenantID != "tenant-1" || token.UsedAt != nil {
+		t.Errorf("unexpected token: %+v", token)
+	}
+}
+
+func TestMagicLinkTokenRepository_GetByHash_NotFound(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, token_hash, binding_hash, expires_at, used_at, created_at FROM magic_link_tokens WHERE token_hash = .*").
+		WithArgs("unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewMagicLinkTokenRepository(db.New(mockDB))
+
+	token, err := repo.GetByHash(context.Background(), "unknown")
+
+	if err != nil {
+		t.Fatalf("GetByHash failed: %v", err)
+	}
+
+	if token != nil {
+		t.Errorf("expected no token, got %+v", token)
+	}
+}
+
+func TestMagicLinkTokenRepository_MarkUsed(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		want         bool
+	}{
+		{"unused token", 1, true},
+		{"already used token", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock db: %v", err)
+			}
+			defer func() { _ = mockDB.Close() }()
+
+			mock.ExpectExec("UPDATE magic_link_tokens SET used_at = NOW\\(\\) WHERE id = .* AND used_at IS NULL").
+				WithArgs("ml-1").
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			repo := repositories.NewMagicLinkTokenRepository(db.New(mockDB))
+
+			consumed, err := repo.MarkUsed(context.Background(), "ml-1")
+
+			if err != nil {
+				t.Fatalf("MarkUsed failed: %v", err)
+			}
+
+			if consumed != tt.want {
+				t.Errorf("expected consumed=%v, got %v", tt.want, consumed)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestMFARepository_GetFactor(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	confirmedAt := time.Now().Add(-time.Hour)
+	rows := sqlmock.NewRows([]string{
+		"user_id", "secret", "confirmed_at", "last_used_step", "created_at", "updated_at",
+	}).AddRow("user-123", "SECRET", confirmedAt, int64(42), confirmedAt, confirmedAt)
+
+	mock.ExpectQuery("SELECT user_id, secret, confirmed_at, last_used_step, created_at, updated_at FROM user_mfa WHERE user_id = .*").
+		WithArgs("user-123").
+		WillReturnRows(rows)
+
+	repo := repositories.NewMFARepository(db.New(mockDB))
+
+	factor, err := repo.GetFactor(context.Background(), "user-123")
+
+	if err != nil {
+		t.Fatalf("GetFactor failed: %v", err)
+	}
+
+	if factor == nil || factor.Secret != "SECRET" || factor.LastUsedStep != 42 || factor.ConfirmedAt == nil {
+		t.Errorf("unexpected factor: %+v", factor)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMFARepository_GetFactor_NotEnrolled(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectQuery("SELECT user_id, secret, confirmed_at, last_used_step, created_at, updated_at FROM user_mfa WHERE user_id = .*").
+		WithArgs("user-123").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewMFARepository(db.New(mockDB))
+
+	factor, err := repo.GetFactor(context.Background(), "user-123")
+
+	if err != nil {
+		t.Fatalf("GetFactor failed: %v", err)
+	}
+
+	if factor != nil {
+		t.Errorf("expected no factor, got %+v", factor)
+	}
+}
+
+func TestMFARepository_AdvanceStep(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("UPDATE user_mfa SET last_used_step = .* WHERE user_id = .* AND last_used_step < .*").
+		WithArgs(int64(100), "user-123", int64(100)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewMFARepository(db.New(mockDB))
+
+	advanced, err := repo.AdvanceStep(context.Background(), "user-123", 100)
+
+	if err != nil {
+		t.Fatalf("AdvanceStep failed: %v", err)
+	}
+
+	if advanced {
+		t.Error("expected a step that is not newer to be rejected")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMFARepository_IncrementChallengeAttempts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("UPDATE mfa_challenges SET attempts = attempts \\+ 1 WHERE id = .* AND attempts < .*").
+		WithArgs("challenge-1", int32(5)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewMFARepository(db.New(mockDB))
+
+	counted, err := repo.IncrementChallengeAttempts(context.Background(), "challenge-1", 5)
+
+	if err != nil {
+		t.Fatalf("IncrementChallengeAttempts failed: %v", err)
+	}
+
+	if counted {
+		t.Error("expected a challenge without attempts left to be rejected")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMFARepository_ReplaceRecoveryCodes(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("DELETE FROM mfa_recovery_codes WHERE user_id = .*").
+		WithArgs("user-123").
+		WillReturnResult(sqlmock.NewResult(0, 10))
+
+	for _, codeHash := range []string{"hash-1", "hash-2"} {
+		mock.ExpectExec("INSERT INTO mfa_recovery_codes").
+			WithArgs(sqlmock.AnyArg(), "user-123", codeHash).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	repo := repositories.NewMFARepository(db.New(mockDB))
+
+	if err := repo.ReplaceRecoveryCodes(context.Background(), "user-123", []string{"hash-1", "hash-2"}); err != nil {
+		t.Fatalf("ReplaceRecoveryCodes failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestPasswordResetTokenRepository_GetLatestForUser(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	createdAt := time.Now().Add(-time.Minute)
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "tenant_id", "token_hash", "expires_at", "used_at", "created_at",
+	}).AddRow("pr-1", "user-123", "tenant-1", "hash", time.Now().Add(time.Hour), nil, createdAt)
+
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, token_hash, expires_at, used_at, created_at FROM password_reset_tokens WHERE user_id = .* ORDER BY created_at DESC LIMIT 1").
+		WithArgs("user-123").
+		WillReturnRows(rows)
+
+	repo := repositories.NewPasswordResetTokenRepository(db.New(mockDB))
+
+	token, err := repo.GetLatestForUser(context.Background(), "user-123")
+
+	if err != nil {
+		t.Fatalf("GetLatestForUser failed: %v", err)
+	}
+
+	if token == nil || token.ID != "pr-1" || !token.CreatedAt.Equal(createdAt) {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPasswordResetTokenRepository_GetLatestForUser_None(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, token_hash, expires_at, used_at, created_at FROM password_reset_tokens WHERE user_id = .*").
+		WithArgs("user-123").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewPasswordResetTokenRepository(db.New(mockDB))
+
+	token, err := repo.GetLatestForUser(context.Background(), "user-123")
+
+	if err != nil {
+		t.Fatalf("GetLatestForUser failed: %v", err)
+	}
+
+	if token != nil {
+		t.Errorf("expected no token, got %+v", token)
+	}
+}
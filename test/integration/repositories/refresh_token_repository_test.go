@@ -0,0 +1,111 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestRefreshTokenRepository_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mock.ExpectExec("INSERT INTO refresh_tokens \\(id, user_id, tenant_id, family_id, token_hash, expires_at, created_at\\)").
+		WithArgs("rt-1", "user-123", "tenant-1", "family-1", "hash", expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewRefreshTokenRepository(db.New(mockDB))
+
+	err = repo.Create(context.Background(), &domains.RefreshToken{
+		ID:        "rt-1",
+		UserID:    "user-123",
+		TenantID:  "tenant-1",
+		FamilyID:  "family-1",
+		TokenHash: "hash",
+		ExpiresAt: expiresAt,
+	})
+
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRefreshTokenRepository_GetByHash(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	usedAt := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "tenant_id", "family_id", "token_hash", "expires_at", "used_at", "revoked_at", "created_at",
+	}).AddRow("rt-1", "user-123", "tenant-1", "family-1", "hash", time.Now().Add(time.Hour), usedAt, nil, time.Now())
+
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, family_id, token_hash, expires_at, used_at, revoked_at, created_at FROM refresh_tokens WHERE token_hash = .*").
+		WithArgs("hash").
+		WillReturnRows(rows)
+
+	repo := repositories.NewRefreshTokenRepository(db.New(mockDB))
+
+	token, err := repo.GetByHash(context.Background(), "hash")
+
+	if err != nil {
+		t.Fatalf("GetByHash failed: %v", err)
+	}
+
+	if token == nil || token.FamilyID != "family-1" || token.TenantID != "tenant-1" {
+		t.Fatalf("unexpected token: %+v", token)
+	}
+
+	if token.UsedAt == nil || token.RevokedAt != nil {
+		t.Errorf("expected used, unrevoked token, got %+v", token)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRefreshTokenRepository_MarkUsed_AlreadyUsed(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	// No row matched: another request rotated the token first
+	mock.ExpectExec("UPDATE refresh_tokens SET used_at = NOW\\(\\) WHERE id = .* AND used_at IS NULL AND revoked_at IS NULL").
+		WithArgs("rt-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewRefreshTokenRepository(db.New(mockDB))
+
+	rotated, err := repo.MarkUsed(context.Background(), "rt-1")
+
+	if err != nil {
+		t.Fatalf("MarkUsed failed: %v", err)
+	}
+
+	if rotated {
+		t.Error("expected MarkUsed to report false for an already used token")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
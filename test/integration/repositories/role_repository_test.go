@@ -0,0 +1,119 @@
+package repositories_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestRoleRepository_GetUserRoles(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	rows := sqlmock.NewRows([]string{"role_name"}).
+		AddRow(domains.RoleAdmin).
+		AddRow(domains.RoleUser)
+
+	// Direct roles and those of the user's groups
+	mock.ExpectQuery("SELECT role_name FROM user_roles WHERE user_id = .* UNION SELECT gr.role_name FROM group_members gm").
+		WithArgs("user-123", "user-123").
+		WillReturnRows(rows)
+
+	repo := repositories.NewRoleRepository(db.New(mockDB))
+
+	roles, err := repo.GetUserRoles(context.Background(), "user-123")
+
+	if err != nil {
+		t.Fatalf("GetUserRoles failed: %v", err)
+	}
+
+	if !slices.Equal(roles, []string{domains.RoleAdmin, domains.RoleUser}) {
+		t.Errorf("unexpected roles: %v", roles)
+	}
+}
+
+func TestRoleRepository_GetUserPermissions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	rows := sqlmock.NewRows([]string{"permission_name"}).
+		AddRow(domains.PermissionSelfRead).
+		AddRow(domains.PermissionSelfWrite)
+
+	mock.ExpectQuery("SELECT DISTINCT rp.permission_name FROM role_permissions rp JOIN \\( SELECT ur.role_name FROM user_roles ur .* UNION SELECT gr.role_name FROM group_members gm").
+		WithArgs("user-123", "user-123").
+		WillReturnRows(rows)
+
+	repo := repositories.NewRoleRepository(db.New(mockDB))
+
+	permissions, err := repo.GetUserPermissions(context.Background(), "user-123")
+
+	if err != nil {
+		t.Fatalf("GetUserPermissions failed: %v", err)
+	}
+
+	if !slices.Equal(permissions, []string{domains.PermissionSelfRead, domains.PermissionSelfWrite}) {
+		t.Errorf("unexpected permissions: %v", permissions)
+	}
+}
+
+func TestRoleRepository_AssignRole(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("INSERT IGNORE INTO user_roles \\(user_id, role_name, created_at\\)").
+		WithArgs("user-123", domains.RoleUser).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewRoleRepository(db.New(mockDB))
+
+	if err := repo.AssignRole(context.Background(), "user-123", domains.RoleUser); err != nil {
+		t.Fatalf("AssignRole failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRoleRepository_GetRolePermissions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	rows := sqlmock.NewRows([]string{"permission_name"}).
+		AddRow(domains.PermissionSelfRead).
+		AddRow(domains.PermissionSelfWrite)
+
+	mock.ExpectQuery("SELECT permission_name FROM role_permissions WHERE role_name = .*").
+		WithArgs(domains.RoleUser).
+		WillReturnRows(rows)
+
+	repo := repositories.NewRoleRepository(db.New(mockDB))
+
+	permissions, err := repo.GetRolePermissions(context.Background(), domains.RoleUser)
+
+	if err != nil {
+		t.Fatalf("GetRolePermissions failed: %v", err)
+	}
+
+	if !slices.Equal(permissions, []string{domains.PermissionSelfRead, domains.PermissionSelfWrite}) {
+		t.Errorf("unexpected permissions: %v", permissions)
+	}
+}
@@ -0,0 +1,123 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestSessionRepository_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	now := time.Now()
+
+	mock.ExpectExec("DELETE FROM sessions WHERE expires_at < .*").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectExec("INSERT INTO sessions \\(id, user_id, tenant_id, email, roles, permissions, csrf_token, expires_at, absolute_expires_at, last_seen_at, created_at\\)").
+		WithArgs("hash", "user-123", "tenant-1", "user@example.com", "user", "self:read self:write", "csrf", now.Add(time.Minute), now.Add(time.Hour), now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewSessionRepository(db.New(mockDB))
+
+	err = repo.Create(context.Background(), &domains.Session{
+		ID:                "hash",
+		UserID:            "user-123",
+		TenantID:          "tenant-1",
+		Email:             "user@example.com",
+		Roles:             []string{"user"},
+		Permissions:       []string{"self:read", "self:write"},
+		CSRFToken:         "csrf",
+		ExpiresAt:         now.Add(time.Minute),
+		AbsoluteExpiresAt: now.Add(time.Hour),
+		LastSeenAt:        now,
+		CreatedAt:         now,
+	})
+
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSessionRepository_Get(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "tenant_id", "email", "roles", "permissions", "csrf_token", "expires_at", "absolute_expires_at", "last_seen_at", "created_at",
+	}).AddRow("hash", "user-123", "tenant-1", "user@example.com", "", "self:read self:write", "csrf", now, now, now, now)
+
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, email, roles, permissions, csrf_token, expires_at, absolute_expires_at, last_seen_at, created_at FROM sessions WHERE id = .*").
+		WithArgs("hash").
+		WillReturnRows(rows)
+
+	repo := repositories.NewSessionRepository(db.New(mockDB))
+
+	session, err := repo.Get(context.Background(), "hash")
+
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if session == nil || session.TenantID != "tenant-1" || len(session.Roles) != 0 || len(session.Permissions) != 2 || session.CSRFToken != "csrf" {
+		t.Fatalf("unexpected session: %+v", session)
+	}
+
+	if !session.CreatedAt.Equal(now) {
+		t.Errorf("expected created at %s, got %s", now, session.CreatedAt)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSessionRepository_Update(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	now := time.Now()
+
+	mock.ExpectExec("UPDATE sessions SET roles = .*, permissions = .*, expires_at = .*, last_seen_at = .* WHERE id = .*").
+		WithArgs("admin", "users:read", now.Add(time.Minute), now, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewSessionRepository(db.New(mockDB))
+
+	err = repo.Update(context.Background(), &domains.Session{
+		ID:          "hash",
+		Roles:       []string{"admin"},
+		Permissions: []string{"users:read"},
+		ExpiresAt:   now.Add(time.Minute),
+		LastSeenAt:  now,
+	})
+
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestTenantRepository_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("INSERT INTO tenants \\(id, slug, name, is_active, created_at, updated_at\\)").
+		WithArgs("tenant-1", "acme", "Acme Corporation", true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewTenantRepository(db.New(mockDB))
+
+	err = repo.Create(context.Background(), &domains.Tenant{
+		ID:       "tenant-1",
+		Slug:     "acme",
+		Name:     "Acme Corporation",
+		IsActive: true,
+	})
+
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTenantRepository_GetBySlug(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "slug", "name", "is_active", "created_at", "updated_at"}).
+		AddRow("tenant-1", "acme", "Acme Corporation", true, now, now)
+
+	mock.ExpectQuery("SELECT id, slug, name, is_active, created_at, updated_at FROM tenants WHERE slug = \\?").
+		WithArgs("acme").
+		WillReturnRows(rows)
+
+	mock.ExpectQuery("SELECT id, slug, name, is_active, created_at, updated_at FROM tenants WHERE slug = \\?").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewTenantRepository(db.New(mockDB))
+
+	tenant, err := repo.GetBySlug(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("GetBySlug failed: %v", err)
+	}
+
+	if tenant == nil || tenant.ID != "tenant-1" || tenant.Name != "Acme Corporation" || !tenant.IsActive {
+		t.Errorf("unexpected tenant: %+v", tenant)
+	}
+
+	tenant, err = repo.GetBySlug(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("GetBySlug failed: %v", err)
+	}
+
+	if tenant != nil {
+		t.Errorf("expected nil for unknown slug, got %+v", tenant)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestTenantRepository_List(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "slug", "name", "is_active", "created_at", "updated_at"}).
+		AddRow("tenant-1", "acme", "Acme Corporation", true, now, now).
+		AddRow(domains.DefaultTenantID, "default", "Default", true, now, now)
+
+	mock.ExpectQuery("SELECT id, slug, name, is_active, created_at, updated_at FROM tenants ORDER BY slug").
+		WithArgs(10, 0).
+		WillReturnRows(rows)
+
+	repo := repositories.NewTenantRepository(db.New(mockDB))
+
+	tenants, err := repo.List(context.Background(), 10, 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if len(tenants) != 2 || tenants[1].Slug != "default" {
+		t.Errorf("unexpected tenants: %+v", tenants)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+)
+
+func TestUserIdentityRepository_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("INSERT INTO user_identities \\(id, user_id, issuer, subject, created_at\\)").
+		WithArgs("identity-1", "user-123", "https://idp.example.com", "external-123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewUserIdentityRepository(db.New(mockDB))
+
+	err = repo.Create(context.Background(), &domains.UserIdentity{
+		ID:      "identity-1",
+		UserID:  "user-123",
+		Issuer:  "https://idp.example.com",
+		Subject: "external-123",
+	})
+
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserIdentityRepository_GetByIssuerSubject(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "issuer", "subject", "created_at"}).
+		AddRow("identity-1", "user-123", "https://idp.example.com", "external-123", time.Now())
+
+	mock.ExpectQuery("SELECT id, user_id, issuer, subject, created_at FROM user_identities WHERE issuer = .* AND subject = .*").
+		WithArgs("https://idp.example.com", "external-123").
+		WillReturnRows(rows)
+
+	repo := repositories.NewUserIdentityRepository(db.New(mockDB))
+
+	identity, err := repo.GetByIssuerSubject(context.Background(), "https://idp.example.com", "external-123")
+
+	if err != nil {
+		t.Fatalf("GetByIssuerSubject failed: %v", err)
+	}
+
+	if identity == nil || identity.UserID != "user-123" {
+		t.Errorf("expected identity linked to user-123, got %+v", identity)
+	}
+}
+
+func TestUserIdentityRepository_GetByIssuerSubject_NotFound(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectQuery("SELECT id, user_id, issuer, subject, created_at FROM user_identities").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewUserIdentityRepository(db.New(mockDB))
+
+	identity, err := repo.GetByIssuerSubject(context.Background(), "https://idp.example.com", "unknown")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if identity != nil {
+		t.Errorf("expected nil identity, got %+v", identity)
+	}
+}
@@ -3,11 +3,15 @@ package repositories_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/zercle/template-go-fiber/internal/domains"
+	apperrors "github.com/zercle/template-go-fiber/internal/errors"
 	"github.com/zercle/template-go-fiber/internal/repositories"
 	db "github.com/zercle/template-go-fiber/internal/infrastructure/sqlc"
 )
@@ -21,27 +25,32 @@ func TestUserRepository_GetByID(t *testing.T) {
 
 	// Mock the database query
 	rows := sqlmock.NewRows([]string{
-		"id", "email", "password_hash", "first_name", "last_name", "is_active", "created_at", "updated_at", "deleted_at",
+		"id", "tenant_id", "email", "password_hash", "first_name", "last_name", "is_active", "email_verified_at", "is_service_account", "created_at", "updated_at", "deleted_at", "live_email", "version",
 	}).AddRow(
 		"user-123",
+		"tenant-1",
 		"test@example.com",
 		"hashed_password",
 		"John",
 		"Doe",
 		true,
+		nil,
+		false,
 		time.Now(),
 		time.Now(),
 		nil,
+		"test@example.com",
+		3,
 	)
 
-	mock.ExpectQuery("SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at FROM users WHERE id = .* AND deleted_at IS NULL").
-		WithArgs("user-123").
+	mock.ExpectQuery("SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version FROM users WHERE tenant_id = .* AND id = .* AND deleted_at IS NULL").
+		WithArgs("tenant-1", "user-123").
 		WillReturnRows(rows)
 
 	queries := db.New(mockDB)
 	repo := repositories.NewUserRepository(queries)
 
-	user, err := repo.GetByID(context.Background(), "user-123")
+	user, err := repo.GetByID(tenantContext(), "user-123")
 
 	if err != nil {
 		t.Fatalf("GetByID failed: %v", err)
@@ -59,6 +68,10 @@ func TestUserRepository_GetByID(t *testing.T) {
 		t.Errorf("expected email test@example.com, got %s", user.Email)
 	}
 
+	if user.Version != 3 {
+		t.Errorf("expected version 3, got %d", user.Version)
+	}
+
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %v", err)
 	}
@@ -72,14 +85,14 @@ func TestUserRepository_GetByID_NotFound(t *testing.T) {
 	defer func() { _ = mockDB.Close() }()
 
 	// Mock no rows returned
-	mock.ExpectQuery("SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at FROM users WHERE id = .* AND deleted_at IS NULL").
-		WithArgs("nonexistent").
+	mock.ExpectQuery("SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version FROM users WHERE tenant_id = .* AND id = .* AND deleted_at IS NULL").
+		WithArgs("tenant-1", "nonexistent").
 		WillReturnError(sql.ErrNoRows)
 
 	queries := db.New(mockDB)
 	repo := repositories.NewUserRepository(queries)
 
-	user, err := repo.GetByID(context.Background(), "nonexistent")
+	user, err := repo.GetByID(tenantContext(), "nonexistent")
 
 	// sqlmock returns error, but our code should handle it
 	if err != nil && err != sql.ErrNoRows {
@@ -105,8 +118,8 @@ func TestUserRepository_Create(t *testing.T) {
 	_ = err // Handle err
 
 	// Mock the INSERT query - Note: created_at and updated_at use NOW() in SQL, not parameters
-	mock.ExpectExec("INSERT INTO users \\(id, email, password_hash, first_name, last_name, is_active, created_at, updated_at\\)").
-		WithArgs("new-user", "new@example.com", "hashed", "Jane", "Smith", true).
+	mock.ExpectExec("INSERT INTO users \\(id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at\\)").
+		WithArgs("new-user", "tenant-1", "new@example.com", "hashed", "Jane", "Smith", true, nil, false).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	queries := db.New(mockDB)
@@ -121,12 +134,16 @@ func TestUserRepository_Create(t *testing.T) {
 		IsActive:     true,
 	}
 
-	err = repo.Create(context.Background(), user)
+	err = repo.Create(tenantContext(), user)
 
 	if err != nil {
 		t.Fatalf("Create failed: %v", err)
 	}
 
+	if user.TenantID != "tenant-1" {
+		t.Errorf("expected the user to be created in tenant-1, got %q", user.TenantID)
+	}
+
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %v", err)
 	}
@@ -140,8 +157,8 @@ func TestUserRepository_Update(t *testing.T) {
 	defer func() { _ = mockDB.Close() }()
 
 	// Mock the UPDATE query
-	mock.ExpectExec("UPDATE users SET email = .*, password_hash = .*, first_name = .*, last_name = .*, is_active = .*, updated_at = NOW\\(\\) WHERE id = .* AND deleted_at IS NULL").
-		WithArgs("updated@example.com", "new_hash", "Jane", "Smith", true, "user-123").
+	mock.ExpectExec("UPDATE users SET email = .*, password_hash = .*, first_name = .*, last_name = .*, is_active = .*, email_verified_at = .*, version = version \\+ 1, updated_at = NOW\\(\\) WHERE tenant_id = .* AND id = .* AND version = .* AND deleted_at IS NULL").
+		WithArgs("updated@example.com", "new_hash", "Jane", "Smith", true, nil, "tenant-1", "user-123", int32(4)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	queries := db.New(mockDB)
@@ -154,14 +171,126 @@ func TestUserRepository_Update(t *testing.T) {
 		FirstName:    stringPtr("Jane"),
 		LastName:     stringPtr("Smith"),
 		IsActive:     true,
+		Version:      4,
 	}
 
-	err = repo.Update(context.Background(), user)
+	err = repo.Update(tenantContext(), user)
 
 	if err != nil {
 		t.Fatalf("Update failed: %v", err)
 	}
 
+	if user.Version != 5 {
+		t.Errorf("expected version 5 after the update, got %d", user.Version)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserRepository_Update_Stale(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	// Another write got there first, so the version no longer matches
+	mock.ExpectExec("UPDATE users SET .* WHERE tenant_id = .* AND id = .* AND version = .* AND deleted_at IS NULL").
+		WithArgs("user@example.com", "hash", nil, nil, true, nil, "tenant-1", "user-123", int32(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	user := &domains.User{ID: "user-123", Email: "user@example.com", PasswordHash: "hash", IsActive: true, Version: 1}
+	err = repo.Update(tenantContext(), user)
+
+	var apiErr *apperrors.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != apperrors.ErrCodePreconditionFailed {
+		t.Errorf("expected a PRECONDITION_FAILED error, got %v", err)
+	}
+
+	if user.Version != 1 {
+		t.Errorf("expected the version to stay 1, got %d", user.Version)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserRepository_UpdatePasswordHash(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	// Neither the version nor any other column is touched
+	mock.ExpectExec("UPDATE users SET password_hash = \\? WHERE tenant_id = .* AND id = .* AND deleted_at IS NULL").
+		WithArgs("new-hash", "tenant-1", "user-123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	if err := repo.UpdatePasswordHash(tenantContext(), "user-123", "new-hash"); err != nil {
+		t.Fatalf("UpdatePasswordHash failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserRepository_Create_DuplicateEmail(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	// The email is stored normalized, and a live user already has it
+	mock.ExpectExec("INSERT INTO users").
+		WithArgs("new-user", "tenant-1", "taken@example.com", "hashed", nil, nil, true, nil, false).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'tenant-1-taken@example.com' for key 'uq_tenant_live_email'"})
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	err = repo.Create(tenantContext(), &domains.User{
+		ID:           "new-user",
+		Email:        "  Taken@Example.COM ",
+		PasswordHash: "hashed",
+		IsActive:     true,
+	})
+
+	var apiErr *apperrors.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != apperrors.ErrCodeDuplicateEntry {
+		t.Errorf("expected a DUPLICATE_ENTRY error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserRepository_GetByEmail_Normalized(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectQuery("SELECT .* FROM users WHERE tenant_id = .* AND email = .* AND deleted_at IS NULL").
+		WithArgs("tenant-1", "user@example.com").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	if _, err := repo.GetByEmail(tenantContext(), " User@Example.com"); err != nil {
+		t.Fatalf("GetByEmail failed: %v", err)
+	}
+
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %v", err)
 	}
@@ -175,14 +304,14 @@ func TestUserRepository_Delete(t *testing.T) {
 	defer func() { _ = mockDB.Close() }()
 
 	// Mock the soft DELETE query
-	mock.ExpectExec("UPDATE users SET deleted_at = NOW\\(\\), updated_at = NOW\\(\\) WHERE id = .* AND deleted_at IS NULL").
-		WithArgs("user-123").
+	mock.ExpectExec("UPDATE users SET deleted_at = NOW\\(\\), version = version \\+ 1, updated_at = NOW\\(\\) WHERE tenant_id = .* AND id = .* AND version = .* AND deleted_at IS NULL").
+		WithArgs("tenant-1", "user-123", int32(2)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	queries := db.New(mockDB)
 	repo := repositories.NewUserRepository(queries)
 
-	err = repo.Delete(context.Background(), "user-123")
+	err = repo.Delete(tenantContext(), "user-123", 2)
 
 	if err != nil {
 		t.Fatalf("Delete failed: %v", err)
@@ -193,6 +322,117 @@ func TestUserRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestUserRepository_Restore(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("UPDATE users SET deleted_at = NULL, version = version \\+ 1, updated_at = NOW\\(\\) WHERE tenant_id = .* AND id = .* AND deleted_at IS NOT NULL").
+		WithArgs("tenant-1", "user-123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE users SET deleted_at = NULL").
+		WithArgs("tenant-1", "user-456").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	restored, err := repo.Restore(tenantContext(), "user-123")
+	if err != nil || !restored {
+		t.Fatalf("expected the user to be restored, got %v, %v", restored, err)
+	}
+
+	// Users that are not deleted are left alone
+	restored, err = repo.Restore(tenantContext(), "user-456")
+	if err != nil || restored {
+		t.Fatalf("expected nothing to be restored, got %v, %v", restored, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserRepository_HardDelete(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	mock.ExpectExec("DELETE FROM users WHERE tenant_id = .* AND id = .* AND deleted_at IS NOT NULL").
+		WithArgs("tenant-1", "user-123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM users").
+		WithArgs("tenant-1", "user-456").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	deleted, err := repo.HardDelete(tenantContext(), "user-123")
+	if err != nil || !deleted {
+		t.Fatalf("expected the user to be removed, got %v, %v", deleted, err)
+	}
+
+	// Live users are left alone
+	deleted, err = repo.HardDelete(tenantContext(), "user-456")
+	if err != nil || deleted {
+		t.Fatalf("expected nothing to be removed, got %v, %v", deleted, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserRepository_PurgeDeleted(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+
+	// Full batches are followed by another until one comes back short
+	mock.ExpectExec("DELETE FROM users WHERE tenant_id = .* AND deleted_at IS NOT NULL AND deleted_at < .* LIMIT .*").
+		WithArgs("tenant-1", cutoff, 500).
+		WillReturnResult(sqlmock.NewResult(0, 500))
+	mock.ExpectExec("DELETE FROM users WHERE tenant_id = .* AND deleted_at IS NOT NULL AND deleted_at < .* LIMIT .*").
+		WithArgs("tenant-1", cutoff, 500).
+		WillReturnResult(sqlmock.NewResult(0, 12))
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	purged, err := repo.PurgeDeleted(tenantContext(), cutoff)
+	if err != nil {
+		t.Fatalf("PurgeDeleted failed: %v", err)
+	}
+
+	if purged != 512 {
+		t.Errorf("expected 512 purged users, got %d", purged)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserRepository_PurgeDeleted_RequiresTenant(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	if _, err := repo.PurgeDeleted(context.Background(), time.Now()); !errors.Is(err, domains.ErrNoTenant) {
+		t.Errorf("expected ErrNoTenant, got %v", err)
+	}
+}
+
 func TestUserRepository_List(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 	if err != nil {
@@ -202,39 +442,49 @@ func TestUserRepository_List(t *testing.T) {
 
 	// Mock the SELECT query for list
 	rows := sqlmock.NewRows([]string{
-		"id", "email", "password_hash", "first_name", "last_name", "is_active", "created_at", "updated_at", "deleted_at",
+		"id", "tenant_id", "email", "password_hash", "first_name", "last_name", "is_active", "email_verified_at", "is_service_account", "created_at", "updated_at", "deleted_at", "live_email", "version",
 	}).
 		AddRow(
 			"user-1",
+			"tenant-1",
 			"user1@example.com",
 			"hash1",
 			"John",
 			"Doe",
 			true,
+			nil,
+			false,
 			time.Now(),
 			time.Now(),
 			nil,
+			"user1@example.com",
+			1,
 		).
 		AddRow(
 			"user-2",
+			"tenant-1",
 			"user2@example.com",
 			"hash2",
 			"Jane",
 			"Smith",
 			true,
+			nil,
+			false,
 			time.Now(),
 			time.Now(),
 			nil,
+			"user2@example.com",
+			1,
 		)
 
-	mock.ExpectQuery("SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT .* OFFSET .*").
-		WithArgs(int64(10), int64(0)).
+	mock.ExpectQuery("SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version FROM users WHERE tenant_id = .* AND deleted_at IS NULL .* ORDER BY .* created_at DESC, id DESC LIMIT .* OFFSET .*").
+		WithArgs(listUsersArgs("tenant-1", nil, nil, nil, nil, nil, "created_at_desc", 10, 0)...).
 		WillReturnRows(rows)
 
 	queries := db.New(mockDB)
 	repo := repositories.NewUserRepository(queries)
 
-	users, err := repo.List(context.Background(), 10, 0)
+	users, err := repo.List(tenantContext(), nil, 10, 0)
 
 	if err != nil {
 		t.Fatalf("List failed: %v", err)
@@ -249,6 +499,132 @@ func TestUserRepository_List(t *testing.T) {
 	}
 }
 
+// listUsersArgs expands the ListUsers arguments into the order the query
+// binds them, repeating the filters and the sort key where they recur
+func listUsersArgs(tenantID string, isActive, createdAfter, createdBefore, emailPattern, searchPattern any, sortKey string, limit, offset int64) []driver.Value {
+	args := []driver.Value{tenantID, isActive, isActive, createdAfter, createdAfter, createdBefore, createdBefore, emailPattern, emailPattern}
+	for range 4 {
+		args = append(args, searchPattern)
+	}
+	for range 9 {
+		args = append(args, sortKey)
+	}
+	return append(args, limit, offset)
+}
+
+func TestUserRepository_ListWithFilter(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	isActive := true
+
+	// Wildcards in the search are matched literally
+	mock.ExpectQuery("SELECT .* FROM users WHERE tenant_id = \\? AND deleted_at IS NULL").
+		WithArgs(listUsersArgs("tenant-1", true, after, nil, "%@example.com", "%50\\%\\_off%", "email_desc", 20, 40)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "email", "password_hash", "first_name", "last_name", "is_active", "email_verified_at", "is_service_account", "created_at", "updated_at", "deleted_at", "live_email", "version"}))
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	users, err := repo.List(tenantContext(), &domains.UserListFilter{
+		IsActive:     &isActive,
+		CreatedAfter: &after,
+		EmailDomain:  "example.com",
+		Search:       "50%_off",
+		SortBy:       domains.UserSortEmail,
+		SortDesc:     true,
+	}, 20, 40)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if len(users) != 0 {
+		t.Errorf("expected no users, got %d", len(users))
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUserRepository_ListRejectsUnknownSort(t *testing.T) {
+	mockDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	if _, err := repo.List(tenantContext(), &domains.UserListFilter{SortBy: "password_hash"}, 10, 0); err == nil {
+		t.Error("expected an error for an unknown sort field")
+	}
+}
+
+// keysetArgs expands the keyset query arguments into the order they are
+// bound, for a page without filters
+func keysetArgs(tenantID string, cursorCreatedAt any, cursorID string, limit int64) []driver.Value {
+	args := []driver.Value{tenantID}
+	for range 12 {
+		args = append(args, nil)
+	}
+	return append(args, cursorCreatedAt, cursorCreatedAt, cursorCreatedAt, cursorID, limit)
+}
+
+func TestUserRepository_ListByCursor(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	columns := []string{"id", "tenant_id", "email", "password_hash", "first_name", "last_name", "is_active", "email_verified_at", "is_service_account", "created_at", "updated_at", "deleted_at", "live_email", "version"}
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// The first page of the default order descends from the newest user
+	mock.ExpectQuery("SELECT .* FROM users WHERE tenant_id = \\? AND deleted_at IS NULL .* ORDER BY created_at DESC, id DESC LIMIT \\?").
+		WithArgs(keysetArgs("tenant-1", nil, "", 2)...).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("user-2", "tenant-1", "b@example.com", "hash", nil, nil, true, nil, false, at, at, nil, "b@example.com", 1).
+			AddRow("user-1", "tenant-1", "a@example.com", "hash", nil, nil, true, nil, false, at, at, nil, "a@example.com", 1))
+
+	// Going back from a cursor ascends from it, and is returned newest first
+	mock.ExpectQuery("SELECT .* FROM users WHERE tenant_id = \\? AND deleted_at IS NULL .* ORDER BY created_at ASC, id ASC LIMIT \\?").
+		WithArgs(keysetArgs("tenant-1", at, "user-2", 2)...).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("user-3", "tenant-1", "c@example.com", "hash", nil, nil, true, nil, false, at, at, nil, "c@example.com", 1).
+			AddRow("user-4", "tenant-1", "d@example.com", "hash", nil, nil, true, nil, false, at, at, nil, "d@example.com", 1))
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	users, err := repo.ListByCursor(tenantContext(), nil, nil, 2)
+	if err != nil {
+		t.Fatalf("ListByCursor failed: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != "user-2" {
+		t.Errorf("expected the newest users first, got %+v", users)
+	}
+
+	users, err = repo.ListByCursor(tenantContext(), nil, &domains.UserCursor{CreatedAt: at, ID: "user-2", Backward: true}, 2)
+	if err != nil {
+		t.Fatalf("ListByCursor failed: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != "user-4" || users[1].ID != "user-3" {
+		t.Errorf("expected the preceding users in list order, got %+v", users)
+	}
+
+	if _, err := repo.ListByCursor(tenantContext(), &domains.UserListFilter{SortBy: domains.UserSortEmail}, nil, 2); err == nil {
+		t.Error("expected an error for a sort other than created_at")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
 func TestUserRepository_Count(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 	if err != nil {
@@ -259,13 +635,21 @@ func TestUserRepository_Count(t *testing.T) {
 	// Mock the COUNT query
 	rows := sqlmock.NewRows([]string{"count"}).AddRow(int64(5))
 
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) as count FROM users WHERE deleted_at IS NULL").
+	// The count applies the same filters as the list
+	isActive := false
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) as count FROM users WHERE tenant_id = .* AND deleted_at IS NULL").
+		WithArgs("tenant-1", false, false, nil, nil, nil, nil, "%@example.com", "%@example.com", "%jane%", "%jane%", "%jane%", "%jane%").
 		WillReturnRows(rows)
 
 	queries := db.New(mockDB)
 	repo := repositories.NewUserRepository(queries)
 
-	count, err := repo.Count(context.Background())
+	count, err := repo.Count(tenantContext(), &domains.UserListFilter{
+		IsActive:    &isActive,
+		EmailDomain: "example.com",
+		Search:      "jane",
+		SortBy:      domains.UserSortEmail,
+	})
 
 	if err != nil {
 		t.Fatalf("Count failed: %v", err)
@@ -289,27 +673,32 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 
 	// Mock the SELECT by email query
 	rows := sqlmock.NewRows([]string{
-		"id", "email", "password_hash", "first_name", "last_name", "is_active", "created_at", "updated_at", "deleted_at",
+		"id", "tenant_id", "email", "password_hash", "first_name", "last_name", "is_active", "email_verified_at", "is_service_account", "created_at", "updated_at", "deleted_at", "live_email", "version",
 	}).AddRow(
 		"user-123",
+		"tenant-1",
 		"test@example.com",
 		"hashed_password",
 		"John",
 		"Doe",
 		true,
+		nil,
+		false,
 		time.Now(),
 		time.Now(),
 		nil,
+		"test@example.com",
+		1,
 	)
 
-	mock.ExpectQuery("SELECT id, email, password_hash, first_name, last_name, is_active, created_at, updated_at, deleted_at FROM users WHERE email = .* AND deleted_at IS NULL").
-		WithArgs("test@example.com").
+	mock.ExpectQuery("SELECT id, tenant_id, email, password_hash, first_name, last_name, is_active, email_verified_at, is_service_account, created_at, updated_at, deleted_at, live_email, version FROM users WHERE tenant_id = .* AND email = .* AND deleted_at IS NULL").
+		WithArgs("tenant-1", "test@example.com").
 		WillReturnRows(rows)
 
 	queries := db.New(mockDB)
 	repo := repositories.NewUserRepository(queries)
 
-	user, err := repo.GetByEmail(context.Background(), "test@example.com")
+	user, err := repo.GetByEmail(tenantContext(), "test@example.com")
 
 	if err != nil {
 		t.Fatalf("GetByEmail failed: %v", err)
@@ -328,7 +717,39 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 	}
 }
 
-// Helper function
+func TestUserRepository_RequiresTenant(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+	defer func() { _ = mockDB.Close() }()
+
+	repo := repositories.NewUserRepository(db.New(mockDB))
+
+	// Without a tenant nothing is queried, rather than every tenant
+	if _, err := repo.List(context.Background(), nil, 10, 0); !errors.Is(err, domains.ErrNoTenant) {
+		t.Errorf("expected ErrNoTenant from List, got %v", err)
+	}
+
+	if _, err := repo.GetByEmail(context.Background(), "test@example.com"); !errors.Is(err, domains.ErrNoTenant) {
+		t.Errorf("expected ErrNoTenant from GetByEmail, got %v", err)
+	}
+
+	if err := repo.Create(context.Background(), &domains.User{ID: "new-user"}); !errors.Is(err, domains.ErrNoTenant) {
+		t.Errorf("expected ErrNoTenant from Create, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+// Helper functions
 func stringPtr(s string) *string {
 	return &s
 }
+
+// tenantContext returns a context scoped to the tenant the tests query
+func tenantContext() context.Context {
+	return domains.WithTenant(context.Background(), "tenant-1")
+}
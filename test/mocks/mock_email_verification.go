@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email_verification.go
+//
+// Generated by this command:
+//
+//	mockgen -source=email_verification.go -destination=../../test/mocks/mock_email_verification.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailVerificationTokenRepository is a mock of EmailVerificationTokenRepository interface.
+type MockEmailVerificationTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailVerificationTokenRepositoryMockRecorder is the mock recorder for MockEmailVerificationTokenRepository.
+type MockEmailVerificationTokenRepositoryMockRecorder struct {
+	mock *MockEmailVerificationTokenRepository
+}
+
+// NewMockEmailVerificationTokenRepository creates a new mock instance.
+func NewMockEmailVerificationTokenRepository(ctrl *gomock.Controller) *MockEmailVerificationTokenRepository {
+	mock := &MockEmailVerificationTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationTokenRepository) EXPECT() *MockEmailVerificationTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEmailVerificationTokenRepository) Create(ctx context.Context, token *domains.EmailVerificationToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).Create), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockEmailVerificationTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domains.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) GetByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// GetLatestForUser mocks base method.
+func (m *MockEmailVerificationTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestForUser", ctx, userID)
+	ret0, _ := ret[0].(*domains.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestForUser indicates an expected call of GetLatestForUser.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) GetLatestForUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestForUser", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).GetLatestForUser), ctx, userID)
+}
+
+// InvalidateForUser mocks base method.
+func (m *MockEmailVerificationTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateForUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateForUser indicates an expected call of InvalidateForUser.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) InvalidateForUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateForUser", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).InvalidateForUser), ctx, userID)
+}
+
+// MarkUsed mocks base method.
+func (m *MockEmailVerificationTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockEmailVerificationTokenRepositoryMockRecorder) MarkUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockEmailVerificationTokenRepository)(nil).MarkUsed), ctx, id)
+}
+
+// MockEmailVerificationUsecase is a mock of EmailVerificationUsecase interface.
+type MockEmailVerificationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailVerificationUsecaseMockRecorder is the mock recorder for MockEmailVerificationUsecase.
+type MockEmailVerificationUsecaseMockRecorder struct {
+	mock *MockEmailVerificationUsecase
+}
+
+// NewMockEmailVerificationUsecase creates a new mock instance.
+func NewMockEmailVerificationUsecase(ctrl *gomock.Controller) *MockEmailVerificationUsecase {
+	mock := &MockEmailVerificationUsecase{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationUsecase) EXPECT() *MockEmailVerificationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ResendVerification mocks base method.
+func (m *MockEmailVerificationUsecase) ResendVerification(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockEmailVerificationUsecaseMockRecorder) ResendVerification(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).ResendVerification), ctx, email)
+}
+
+// SendVerification mocks base method.
+func (m *MockEmailVerificationUsecase) SendVerification(ctx context.Context, user *domains.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockEmailVerificationUsecaseMockRecorder) SendVerification(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).SendVerification), ctx, user)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockEmailVerificationUsecase) VerifyEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockEmailVerificationUsecaseMockRecorder) VerifyEmail(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).VerifyEmail), ctx, token)
+}
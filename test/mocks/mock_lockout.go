@@ -0,0 +1,195 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lockout.go
+//
+// Generated by this command:
+//
+//	mockgen -source=lockout.go -destination=../../test/mocks/mock_lockout.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLoginAttemptStore is a mock of LoginAttemptStore interface.
+type MockLoginAttemptStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockLoginAttemptStoreMockRecorder is the mock recorder for MockLoginAttemptStore.
+type MockLoginAttemptStoreMockRecorder struct {
+	mock *MockLoginAttemptStore
+}
+
+// NewMockLoginAttemptStore creates a new mock instance.
+func NewMockLoginAttemptStore(ctrl *gomock.Controller) *MockLoginAttemptStore {
+	mock := &MockLoginAttemptStore{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptStore) EXPECT() *MockLoginAttemptStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockLoginAttemptStore) Get(ctx context.Context, account, ip string) (*domains.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, account, ip)
+	ret0, _ := ret[0].(*domains.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLoginAttemptStoreMockRecorder) Get(ctx, account, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoginAttemptStore)(nil).Get), ctx, account, ip)
+}
+
+// Lock mocks base method.
+func (m *MockLoginAttemptStore) Lock(ctx context.Context, account, ip string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, account, ip, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockLoginAttemptStoreMockRecorder) Lock(ctx, account, ip, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockLoginAttemptStore)(nil).Lock), ctx, account, ip, until)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLoginAttemptStore) RecordFailure(ctx context.Context, account, ip string, windowStart time.Time) (*domains.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, account, ip, windowStart)
+	ret0, _ := ret[0].(*domains.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLoginAttemptStoreMockRecorder) RecordFailure(ctx, account, ip, windowStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLoginAttemptStore)(nil).RecordFailure), ctx, account, ip, windowStart)
+}
+
+// Reset mocks base method.
+func (m *MockLoginAttemptStore) Reset(ctx context.Context, account, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, account, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLoginAttemptStoreMockRecorder) Reset(ctx, account, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLoginAttemptStore)(nil).Reset), ctx, account, ip)
+}
+
+// ResetAccount mocks base method.
+func (m *MockLoginAttemptStore) ResetAccount(ctx context.Context, account string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAccount", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAccount indicates an expected call of ResetAccount.
+func (mr *MockLoginAttemptStoreMockRecorder) ResetAccount(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAccount", reflect.TypeOf((*MockLoginAttemptStore)(nil).ResetAccount), ctx, account)
+}
+
+// MockLockoutUsecase is a mock of LockoutUsecase interface.
+type MockLockoutUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockLockoutUsecaseMockRecorder is the mock recorder for MockLockoutUsecase.
+type MockLockoutUsecaseMockRecorder struct {
+	mock *MockLockoutUsecase
+}
+
+// NewMockLockoutUsecase creates a new mock instance.
+func NewMockLockoutUsecase(ctrl *gomock.Controller) *MockLockoutUsecase {
+	mock := &MockLockoutUsecase{ctrl: ctrl}
+	mock.recorder = &MockLockoutUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutUsecase) EXPECT() *MockLockoutUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLockoutUsecase) Check(ctx context.Context, email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLockoutUsecaseMockRecorder) Check(ctx, email, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLockoutUsecase)(nil).Check), ctx, email, ip)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLockoutUsecase) RecordFailure(ctx context.Context, email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLockoutUsecaseMockRecorder) RecordFailure(ctx, email, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLockoutUsecase)(nil).RecordFailure), ctx, email, ip)
+}
+
+// RecordSuccess mocks base method.
+func (m *MockLockoutUsecase) RecordSuccess(ctx context.Context, email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSuccess", ctx, email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSuccess indicates an expected call of RecordSuccess.
+func (mr *MockLockoutUsecaseMockRecorder) RecordSuccess(ctx, email, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSuccess", reflect.TypeOf((*MockLockoutUsecase)(nil).RecordSuccess), ctx, email, ip)
+}
+
+// Unlock mocks base method.
+func (m *MockLockoutUsecase) Unlock(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockLockoutUsecaseMockRecorder) Unlock(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLockoutUsecase)(nil).Unlock), ctx, userID)
+}
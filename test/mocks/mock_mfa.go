@@ -0,0 +1,330 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mfa.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mfa.go -destination=../../test/mocks/mock_mfa.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMFARepository is a mock of MFARepository interface.
+type MockMFARepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFARepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMFARepositoryMockRecorder is the mock recorder for MockMFARepository.
+type MockMFARepositoryMockRecorder struct {
+	mock *MockMFARepository
+}
+
+// NewMockMFARepository creates a new mock instance.
+func NewMockMFARepository(ctrl *gomock.Controller) *MockMFARepository {
+	mock := &MockMFARepository{ctrl: ctrl}
+	mock.recorder = &MockMFARepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFARepository) EXPECT() *MockMFARepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdvanceStep mocks base method.
+func (m *MockMFARepository) AdvanceStep(ctx context.Context, userID string, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceStep", ctx, userID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceStep indicates an expected call of AdvanceStep.
+func (mr *MockMFARepositoryMockRecorder) AdvanceStep(ctx, userID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceStep", reflect.TypeOf((*MockMFARepository)(nil).AdvanceStep), ctx, userID, step)
+}
+
+// ConfirmFactor mocks base method.
+func (m *MockMFARepository) ConfirmFactor(ctx context.Context, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmFactor", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmFactor indicates an expected call of ConfirmFactor.
+func (mr *MockMFARepositoryMockRecorder) ConfirmFactor(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmFactor", reflect.TypeOf((*MockMFARepository)(nil).ConfirmFactor), ctx, userID)
+}
+
+// CountRecoveryCodes mocks base method.
+func (m *MockMFARepository) CountRecoveryCodes(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecoveryCodes", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecoveryCodes indicates an expected call of CountRecoveryCodes.
+func (mr *MockMFARepositoryMockRecorder) CountRecoveryCodes(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecoveryCodes", reflect.TypeOf((*MockMFARepository)(nil).CountRecoveryCodes), ctx, userID)
+}
+
+// CreateChallenge mocks base method.
+func (m *MockMFARepository) CreateChallenge(ctx context.Context, challenge *domains.MFAChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockMFARepositoryMockRecorder) CreateChallenge(ctx, challenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockMFARepository)(nil).CreateChallenge), ctx, challenge)
+}
+
+// DeleteFactor mocks base method.
+func (m *MockMFARepository) DeleteFactor(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFactor", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFactor indicates an expected call of DeleteFactor.
+func (mr *MockMFARepositoryMockRecorder) DeleteFactor(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFactor", reflect.TypeOf((*MockMFARepository)(nil).DeleteFactor), ctx, userID)
+}
+
+// GetChallengeByHash mocks base method.
+func (m *MockMFARepository) GetChallengeByHash(ctx context.Context, tokenHash string) (*domains.MFAChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallengeByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domains.MFAChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallengeByHash indicates an expected call of GetChallengeByHash.
+func (mr *MockMFARepositoryMockRecorder) GetChallengeByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallengeByHash", reflect.TypeOf((*MockMFARepository)(nil).GetChallengeByHash), ctx, tokenHash)
+}
+
+// GetFactor mocks base method.
+func (m *MockMFARepository) GetFactor(ctx context.Context, userID string) (*domains.MFAFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFactor", ctx, userID)
+	ret0, _ := ret[0].(*domains.MFAFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFactor indicates an expected call of GetFactor.
+func (mr *MockMFARepositoryMockRecorder) GetFactor(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFactor", reflect.TypeOf((*MockMFARepository)(nil).GetFactor), ctx, userID)
+}
+
+// IncrementChallengeAttempts mocks base method.
+func (m *MockMFARepository) IncrementChallengeAttempts(ctx context.Context, id string, limit int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementChallengeAttempts", ctx, id, limit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementChallengeAttempts indicates an expected call of IncrementChallengeAttempts.
+func (mr *MockMFARepositoryMockRecorder) IncrementChallengeAttempts(ctx, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementChallengeAttempts", reflect.TypeOf((*MockMFARepository)(nil).IncrementChallengeAttempts), ctx, id, limit)
+}
+
+// MarkChallengeUsed mocks base method.
+func (m *MockMFARepository) MarkChallengeUsed(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkChallengeUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkChallengeUsed indicates an expected call of MarkChallengeUsed.
+func (mr *MockMFARepositoryMockRecorder) MarkChallengeUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkChallengeUsed", reflect.TypeOf((*MockMFARepository)(nil).MarkChallengeUsed), ctx, id)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", ctx, userID, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockMFARepositoryMockRecorder) ReplaceRecoveryCodes(ctx, userID, codeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockMFARepository)(nil).ReplaceRecoveryCodes), ctx, userID, codeHashes)
+}
+
+// SaveFactor mocks base method.
+func (m *MockMFARepository) SaveFactor(ctx context.Context, userID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFactor", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFactor indicates an expected call of SaveFactor.
+func (mr *MockMFARepositoryMockRecorder) SaveFactor(ctx, userID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFactor", reflect.TypeOf((*MockMFARepository)(nil).SaveFactor), ctx, userID, secret)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMFARepositoryMockRecorder) UseRecoveryCode(ctx, userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMFARepository)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
+
+// MockMFAUsecase is a mock of MFAUsecase interface.
+type MockMFAUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockMFAUsecaseMockRecorder is the mock recorder for MockMFAUsecase.
+type MockMFAUsecaseMockRecorder struct {
+	mock *MockMFAUsecase
+}
+
+// NewMockMFAUsecase creates a new mock instance.
+func NewMockMFAUsecase(ctrl *gomock.Controller) *MockMFAUsecase {
+	mock := &MockMFAUsecase{ctrl: ctrl}
+	mock.recorder = &MockMFAUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAUsecase) EXPECT() *MockMFAUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEnrollment mocks base method.
+func (m *MockMFAUsecase) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEnrollment", ctx, userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEnrollment indicates an expected call of ConfirmEnrollment.
+func (mr *MockMFAUsecaseMockRecorder) ConfirmEnrollment(ctx, userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEnrollment", reflect.TypeOf((*MockMFAUsecase)(nil).ConfirmEnrollment), ctx, userID, code)
+}
+
+// Disable mocks base method.
+func (m *MockMFAUsecase) Disable(ctx context.Context, userID string, input *domains.DisableMFAInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, userID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockMFAUsecaseMockRecorder) Disable(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockMFAUsecase)(nil).Disable), ctx, userID, input)
+}
+
+// Enroll mocks base method.
+func (m *MockMFAUsecase) Enroll(ctx context.Context, userID string) (*domains.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, userID)
+	ret0, _ := ret[0].(*domains.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockMFAUsecaseMockRecorder) Enroll(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockMFAUsecase)(nil).Enroll), ctx, userID)
+}
+
+// GetStatus mocks base method.
+func (m *MockMFAUsecase) GetStatus(ctx context.Context, userID string) (*domains.MFAStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", ctx, userID)
+	ret0, _ := ret[0].(*domains.MFAStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockMFAUsecaseMockRecorder) GetStatus(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockMFAUsecase)(nil).GetStatus), ctx, userID)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockMFAUsecase) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", ctx, userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockMFAUsecaseMockRecorder) RegenerateRecoveryCodes(ctx, userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockMFAUsecase)(nil).RegenerateRecoveryCodes), ctx, userID, code)
+}
+
+// Reset mocks base method.
+func (m *MockMFAUsecase) Reset(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockMFAUsecaseMockRecorder) Reset(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockMFAUsecase)(nil).Reset), ctx, userID)
+}
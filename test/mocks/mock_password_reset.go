@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: password_reset.go
+//
+// Generated by this command:
+//
+//	mockgen -source=password_reset.go -destination=../../test/mocks/mock_password_reset.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasswordResetTokenRepository is a mock of PasswordResetTokenRepository interface.
+type MockPasswordResetTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordResetTokenRepositoryMockRecorder is the mock recorder for MockPasswordResetTokenRepository.
+type MockPasswordResetTokenRepositoryMockRecorder struct {
+	mock *MockPasswordResetTokenRepository
+}
+
+// NewMockPasswordResetTokenRepository creates a new mock instance.
+func NewMockPasswordResetTokenRepository(ctrl *gomock.Controller) *MockPasswordResetTokenRepository {
+	mock := &MockPasswordResetTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetTokenRepository) EXPECT() *MockPasswordResetTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPasswordResetTokenRepository) Create(ctx context.Context, token *domains.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).Create), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockPasswordResetTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domains.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) GetByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// GetLatestForUser mocks base method.
+func (m *MockPasswordResetTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestForUser", ctx, userID)
+	ret0, _ := ret[0].(*domains.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestForUser indicates an expected call of GetLatestForUser.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) GetLatestForUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestForUser", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).GetLatestForUser), ctx, userID)
+}
+
+// InvalidateForUser mocks base method.
+func (m *MockPasswordResetTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateForUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateForUser indicates an expected call of InvalidateForUser.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) InvalidateForUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateForUser", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).InvalidateForUser), ctx, userID)
+}
+
+// MarkUsed mocks base method.
+func (m *MockPasswordResetTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockPasswordResetTokenRepositoryMockRecorder) MarkUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockPasswordResetTokenRepository)(nil).MarkUsed), ctx, id)
+}
+
+// MockPasswordUsecase is a mock of PasswordUsecase interface.
+type MockPasswordUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordUsecaseMockRecorder is the mock recorder for MockPasswordUsecase.
+type MockPasswordUsecaseMockRecorder struct {
+	mock *MockPasswordUsecase
+}
+
+// NewMockPasswordUsecase creates a new mock instance.
+func NewMockPasswordUsecase(ctrl *gomock.Controller) *MockPasswordUsecase {
+	mock := &MockPasswordUsecase{ctrl: ctrl}
+	mock.recorder = &MockPasswordUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordUsecase) EXPECT() *MockPasswordUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockPasswordUsecase) ChangePassword(ctx context.Context, userID string, input *domains.ChangePasswordInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockPasswordUsecaseMockRecorder) ChangePassword(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockPasswordUsecase)(nil).ChangePassword), ctx, userID, input)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockPasswordUsecase) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockPasswordUsecaseMockRecorder) ForgotPassword(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockPasswordUsecase)(nil).ForgotPassword), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordUsecase) ResetPassword(ctx context.Context, input *domains.ResetPasswordInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordUsecaseMockRecorder) ResetPassword(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordUsecase)(nil).ResetPassword), ctx, input)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rbac.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rbac.go -destination=../../test/mocks/mock_role_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRoleRepository is a mock of RoleRepository interface.
+type MockRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRoleRepositoryMockRecorder is the mock recorder for MockRoleRepository.
+type MockRoleRepositoryMockRecorder struct {
+	mock *MockRoleRepository
+}
+
+// NewMockRoleRepository creates a new mock instance.
+func NewMockRoleRepository(ctrl *gomock.Controller) *MockRoleRepository {
+	mock := &MockRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleRepository) EXPECT() *MockRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignRole mocks base method.
+func (m *MockRoleRepository) AssignRole(ctx context.Context, userID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockRoleRepositoryMockRecorder) AssignRole(ctx, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockRoleRepository)(nil).AssignRole), ctx, userID, role)
+}
+
+// GetRolePermissions mocks base method.
+func (m *MockRoleRepository) GetRolePermissions(ctx context.Context, role string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermissions", ctx, role)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermissions indicates an expected call of GetRolePermissions.
+func (mr *MockRoleRepositoryMockRecorder) GetRolePermissions(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissions", reflect.TypeOf((*MockRoleRepository)(nil).GetRolePermissions), ctx, role)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockRoleRepository) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPermissions", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockRoleRepositoryMockRecorder) GetUserPermissions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockRoleRepository)(nil).GetUserPermissions), ctx, userID)
+}
+
+// GetUserRoles mocks base method.
+func (m *MockRoleRepository) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockRoleRepositoryMockRecorder) GetUserRoles(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockRoleRepository)(nil).GetUserRoles), ctx, userID)
+}
+
+// MockPermissionResolver is a mock of PermissionResolver interface.
+type MockPermissionResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockPermissionResolverMockRecorder is the mock recorder for MockPermissionResolver.
+type MockPermissionResolverMockRecorder struct {
+	mock *MockPermissionResolver
+}
+
+// NewMockPermissionResolver creates a new mock instance.
+func NewMockPermissionResolver(ctrl *gomock.Controller) *MockPermissionResolver {
+	mock := &MockPermissionResolver{ctrl: ctrl}
+	mock.recorder = &MockPermissionResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionResolver) EXPECT() *MockPermissionResolverMockRecorder {
+	return m.recorder
+}
+
+// Invalidate mocks base method.
+func (m *MockPermissionResolver) Invalidate(userIDs ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range userIDs {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Invalidate", varargs...)
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockPermissionResolverMockRecorder) Invalidate(userIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockPermissionResolver)(nil).Invalidate), userIDs...)
+}
+
+// Permissions mocks base method.
+func (m *MockPermissionResolver) Permissions(ctx context.Context, userID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Permissions", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Permissions indicates an expected call of Permissions.
+func (mr *MockPermissionResolverMockRecorder) Permissions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Permissions", reflect.TypeOf((*MockPermissionResolver)(nil).Permissions), ctx, userID)
+}
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: session.go
+//
+// Generated by this command:
+//
+//	mockgen -source=session.go -destination=../../test/mocks/mock_session.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSessionStore is a mock of SessionStore interface.
+type MockSessionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionStoreMockRecorder is the mock recorder for MockSessionStore.
+type MockSessionStoreMockRecorder struct {
+	mock *MockSessionStore
+}
+
+// NewMockSessionStore creates a new mock instance.
+func NewMockSessionStore(ctrl *gomock.Controller) *MockSessionStore {
+	mock := &MockSessionStore{ctrl: ctrl}
+	mock.recorder = &MockSessionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionStore) EXPECT() *MockSessionStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSessionStore) Create(ctx context.Context, session *domains.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionStoreMockRecorder) Create(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionStore)(nil).Create), ctx, session)
+}
+
+// Delete mocks base method.
+func (m *MockSessionStore) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSessionStoreMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSessionStore)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockSessionStore) Get(ctx context.Context, id string) (*domains.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*domains.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSessionStoreMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSessionStore)(nil).Get), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockSessionStore) Update(ctx context.Context, session *domains.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSessionStoreMockRecorder) Update(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSessionStore)(nil).Update), ctx, session)
+}
+
+// MockSessionUsecase is a mock of SessionUsecase interface.
+type MockSessionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionUsecaseMockRecorder is the mock recorder for MockSessionUsecase.
+type MockSessionUsecaseMockRecorder struct {
+	mock *MockSessionUsecase
+}
+
+// NewMockSessionUsecase creates a new mock instance.
+func NewMockSessionUsecase(ctrl *gomock.Controller) *MockSessionUsecase {
+	mock := &MockSessionUsecase{ctrl: ctrl}
+	mock.recorder = &MockSessionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionUsecase) EXPECT() *MockSessionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// End mocks base method.
+func (m *MockSessionUsecase) End(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "End", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// End indicates an expected call of End.
+func (mr *MockSessionUsecaseMockRecorder) End(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "End", reflect.TypeOf((*MockSessionUsecase)(nil).End), ctx, id)
+}
+
+// Resume mocks base method.
+func (m *MockSessionUsecase) Resume(ctx context.Context, token string) (*domains.Session, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", ctx, token)
+	ret0, _ := ret[0].(*domains.Session)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockSessionUsecaseMockRecorder) Resume(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockSessionUsecase)(nil).Resume), ctx, token)
+}
+
+// Start mocks base method.
+func (m *MockSessionUsecase) Start(ctx context.Context, user *domains.User) (*domains.IssuedSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, user)
+	ret0, _ := ret[0].(*domains.IssuedSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockSessionUsecaseMockRecorder) Start(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockSessionUsecase)(nil).Start), ctx, user)
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tenant.go
+//
+// Generated by this command:
+//
+//	mockgen -source=tenant.go -destination=../../test/mocks/mock_tenant.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTenantRepository is a mock of TenantRepository interface.
+type MockTenantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantRepositoryMockRecorder is the mock recorder for MockTenantRepository.
+type MockTenantRepositoryMockRecorder struct {
+	mock *MockTenantRepository
+}
+
+// NewMockTenantRepository creates a new mock instance.
+func NewMockTenantRepository(ctrl *gomock.Controller) *MockTenantRepository {
+	mock := &MockTenantRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantRepository) EXPECT() *MockTenantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTenantRepository) Create(ctx context.Context, tenant *domains.Tenant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, tenant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTenantRepositoryMockRecorder) Create(ctx, tenant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTenantRepository)(nil).Create), ctx, tenant)
+}
+
+// GetByID mocks base method.
+func (m *MockTenantRepository) GetByID(ctx context.Context, id string) (*domains.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTenantRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTenantRepository)(nil).GetByID), ctx, id)
+}
+
+// GetBySlug mocks base method.
+func (m *MockTenantRepository) GetBySlug(ctx context.Context, slug string) (*domains.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*domains.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockTenantRepositoryMockRecorder) GetBySlug(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockTenantRepository)(nil).GetBySlug), ctx, slug)
+}
+
+// List mocks base method.
+func (m *MockTenantRepository) List(ctx context.Context, limit, offset int32) ([]*domains.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].([]*domains.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTenantRepositoryMockRecorder) List(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTenantRepository)(nil).List), ctx, limit, offset)
+}
+
+// MockTenantUsecase is a mock of TenantUsecase interface.
+type MockTenantUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantUsecaseMockRecorder is the mock recorder for MockTenantUsecase.
+type MockTenantUsecaseMockRecorder struct {
+	mock *MockTenantUsecase
+}
+
+// NewMockTenantUsecase creates a new mock instance.
+func NewMockTenantUsecase(ctrl *gomock.Controller) *MockTenantUsecase {
+	mock := &MockTenantUsecase{ctrl: ctrl}
+	mock.recorder = &MockTenantUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantUsecase) EXPECT() *MockTenantUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateTenant mocks base method.
+func (m *MockTenantUsecase) CreateTenant(ctx context.Context, input *domains.CreateTenantInput) (*domains.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTenant", ctx, input)
+	ret0, _ := ret[0].(*domains.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTenant indicates an expected call of CreateTenant.
+func (mr *MockTenantUsecaseMockRecorder) CreateTenant(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenant", reflect.TypeOf((*MockTenantUsecase)(nil).CreateTenant), ctx, input)
+}
+
+// GetTenant mocks base method.
+func (m *MockTenantUsecase) GetTenant(ctx context.Context, id string) (*domains.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenant", ctx, id)
+	ret0, _ := ret[0].(*domains.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenant indicates an expected call of GetTenant.
+func (mr *MockTenantUsecaseMockRecorder) GetTenant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenant", reflect.TypeOf((*MockTenantUsecase)(nil).GetTenant), ctx, id)
+}
+
+// ListTenants mocks base method.
+func (m *MockTenantUsecase) ListTenants(ctx context.Context, limit, offset int32) ([]*domains.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenants", ctx, limit, offset)
+	ret0, _ := ret[0].([]*domains.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTenants indicates an expected call of ListTenants.
+func (mr *MockTenantUsecaseMockRecorder) ListTenants(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenants", reflect.TypeOf((*MockTenantUsecase)(nil).ListTenants), ctx, limit, offset)
+}
+
+// ResolveTenant mocks base method.
+func (m *MockTenantUsecase) ResolveTenant(ctx context.Context, slug string) (*domains.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveTenant", ctx, slug)
+	ret0, _ := ret[0].(*domains.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveTenant indicates an expected call of ResolveTenant.
+func (mr *MockTenantUsecaseMockRecorder) ResolveTenant(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTenant", reflect.TypeOf((*MockTenantUsecase)(nil).ResolveTenant), ctx, slug)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: token_revocation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=token_revocation.go -destination=../../test/mocks/mock_token_revocation_store.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTokenRevocationStore is a mock of TokenRevocationStore interface.
+type MockTokenRevocationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRevocationStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenRevocationStoreMockRecorder is the mock recorder for MockTokenRevocationStore.
+type MockTokenRevocationStoreMockRecorder struct {
+	mock *MockTokenRevocationStore
+}
+
+// NewMockTokenRevocationStore creates a new mock instance.
+func NewMockTokenRevocationStore(ctrl *gomock.Controller) *MockTokenRevocationStore {
+	mock := &MockTokenRevocationStore{ctrl: ctrl}
+	mock.recorder = &MockTokenRevocationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRevocationStore) EXPECT() *MockTokenRevocationStoreMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockTokenRevocationStore) IsRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenID, userID, issuedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockTokenRevocationStoreMockRecorder) IsRevoked(ctx, tokenID, userID, issuedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockTokenRevocationStore)(nil).IsRevoked), ctx, tokenID, userID, issuedAt)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenRevocationStoreMockRecorder) RevokeToken(ctx, tokenID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenRevocationStore)(nil).RevokeToken), ctx, tokenID, expiresAt)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockTokenRevocationStore) RevokeUserTokens(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockTokenRevocationStoreMockRecorder) RevokeUserTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockTokenRevocationStore)(nil).RevokeUserTokens), ctx, userID)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domains "github.com/zercle/template-go-fiber/internal/domains"
 	gomock "go.uber.org/mock/gomock"
@@ -42,18 +43,33 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 }
 
 // Count mocks base method.
-func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
+func (m *MockUserRepository) Count(ctx context.Context, filter *domains.UserListFilter) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count", ctx)
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Count indicates an expected call of Count.
-func (mr *MockUserRepositoryMockRecorder) Count(ctx any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Count(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUserRepository)(nil).Count), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUserRepository)(nil).Count), ctx, filter)
+}
+
+// CountDeleted mocks base method.
+func (m *MockUserRepository) CountDeleted(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeleted", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeleted indicates an expected call of CountDeleted.
+func (mr *MockUserRepositoryMockRecorder) CountDeleted(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeleted", reflect.TypeOf((*MockUserRepository)(nil).CountDeleted), ctx)
 }
 
 // Create mocks base method.
@@ -71,17 +87,17 @@ func (mr *MockUserRepositoryMockRecorder) Create(ctx, user any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id string, version int32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id, version)
 }
 
 // GetByEmail mocks base method.
@@ -114,19 +130,123 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, id)
 }
 
+// GetDeletedByID mocks base method.
+func (m *MockUserRepository) GetDeletedByID(ctx context.Context, id string) (*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedByID", ctx, id)
+	ret0, _ := ret[0].(*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedByID indicates an expected call of GetDeletedByID.
+func (mr *MockUserRepositoryMockRecorder) GetDeletedByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedByID", reflect.TypeOf((*MockUserRepository)(nil).GetDeletedByID), ctx, id)
+}
+
+// HardDelete mocks base method.
+func (m *MockUserRepository) HardDelete(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDelete", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HardDelete indicates an expected call of HardDelete.
+func (mr *MockUserRepositoryMockRecorder) HardDelete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDelete", reflect.TypeOf((*MockUserRepository)(nil).HardDelete), ctx, id)
+}
+
 // List mocks base method.
-func (m *MockUserRepository) List(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+func (m *MockUserRepository) List(ctx context.Context, filter *domains.UserListFilter, limit, offset int32) ([]*domains.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "List", ctx, filter, limit, offset)
 	ret0, _ := ret[0].([]*domains.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockUserRepositoryMockRecorder) List(ctx, limit, offset any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) List(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, filter, limit, offset)
+}
+
+// ListByCursor mocks base method.
+func (m *MockUserRepository) ListByCursor(ctx context.Context, filter *domains.UserListFilter, cursor *domains.UserCursor, limit int32) ([]*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", ctx, filter, cursor, limit)
+	ret0, _ := ret[0].([]*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockUserRepositoryMockRecorder) ListByCursor(ctx, filter, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockUserRepository)(nil).ListByCursor), ctx, filter, cursor, limit)
+}
+
+// ListDeleted mocks base method.
+func (m *MockUserRepository) ListDeleted(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", ctx, limit, offset)
+	ret0, _ := ret[0].([]*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockUserRepositoryMockRecorder) ListDeleted(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockUserRepository)(nil).ListDeleted), ctx, limit, offset)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id string, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockUserRepositoryMockRecorder) MarkEmailVerified(ctx, id, verifiedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockUserRepository)(nil).MarkEmailVerified), ctx, id, verifiedAt)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockUserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockUserRepositoryMockRecorder) PurgeDeleted(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockUserRepository)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// Restore mocks base method.
+func (m *MockUserRepository) Restore(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserRepository)(nil).Restore), ctx, id)
 }
 
 // Update mocks base method.
@@ -143,6 +263,20 @@ func (mr *MockUserRepositoryMockRecorder) Update(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), ctx, user)
 }
 
+// UpdatePasswordHash mocks base method.
+func (m *MockUserRepository) UpdatePasswordHash(ctx context.Context, id, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordHash", ctx, id, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockUserRepositoryMockRecorder) UpdatePasswordHash(ctx, id, passwordHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockUserRepository)(nil).UpdatePasswordHash), ctx, id, passwordHash)
+}
+
 // MockUserUsecase is a mock of UserUsecase interface.
 type MockUserUsecase struct {
 	ctrl     *gomock.Controller
@@ -167,18 +301,48 @@ func (m *MockUserUsecase) EXPECT() *MockUserUsecaseMockRecorder {
 	return m.recorder
 }
 
+// CountDeletedUsers mocks base method.
+func (m *MockUserUsecase) CountDeletedUsers(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeletedUsers", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeletedUsers indicates an expected call of CountDeletedUsers.
+func (mr *MockUserUsecaseMockRecorder) CountDeletedUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeletedUsers", reflect.TypeOf((*MockUserUsecase)(nil).CountDeletedUsers), ctx)
+}
+
+// CountUsers mocks base method.
+func (m *MockUserUsecase) CountUsers(ctx context.Context, filter *domains.UserListFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockUserUsecaseMockRecorder) CountUsers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockUserUsecase)(nil).CountUsers), ctx, filter)
+}
+
 // DeleteUser mocks base method.
-func (m *MockUserUsecase) DeleteUser(ctx context.Context, id string) error {
+func (m *MockUserUsecase) DeleteUser(ctx context.Context, id string, version *int32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserUsecaseMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+func (mr *MockUserUsecaseMockRecorder) DeleteUser(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserUsecase)(nil).DeleteUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserUsecase)(nil).DeleteUser), ctx, id, version)
 }
 
 // GetUserByEmail mocks base method.
@@ -211,19 +375,78 @@ func (mr *MockUserUsecaseMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserUsecase)(nil).GetUserByID), ctx, id)
 }
 
+// HardDeleteUser mocks base method.
+func (m *MockUserUsecase) HardDeleteUser(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteUser indicates an expected call of HardDeleteUser.
+func (mr *MockUserUsecaseMockRecorder) HardDeleteUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteUser", reflect.TypeOf((*MockUserUsecase)(nil).HardDeleteUser), ctx, id)
+}
+
+// ListDeletedUsers mocks base method.
+func (m *MockUserUsecase) ListDeletedUsers(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedUsers", ctx, limit, offset)
+	ret0, _ := ret[0].([]*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedUsers indicates an expected call of ListDeletedUsers.
+func (mr *MockUserUsecaseMockRecorder) ListDeletedUsers(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedUsers", reflect.TypeOf((*MockUserUsecase)(nil).ListDeletedUsers), ctx, limit, offset)
+}
+
 // ListUsers mocks base method.
-func (m *MockUserUsecase) ListUsers(ctx context.Context, limit, offset int32) ([]*domains.User, error) {
+func (m *MockUserUsecase) ListUsers(ctx context.Context, filter *domains.UserListFilter, limit, offset int32) ([]*domains.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter, limit, offset)
 	ret0, _ := ret[0].([]*domains.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockUserUsecaseMockRecorder) ListUsers(ctx, limit, offset any) *gomock.Call {
+func (mr *MockUserUsecaseMockRecorder) ListUsers(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserUsecase)(nil).ListUsers), ctx, filter, limit, offset)
+}
+
+// ListUsersByCursor mocks base method.
+func (m *MockUserUsecase) ListUsersByCursor(ctx context.Context, filter *domains.UserListFilter, cursor string, limit int32) (*domains.UserPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersByCursor", ctx, filter, cursor, limit)
+	ret0, _ := ret[0].(*domains.UserPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersByCursor indicates an expected call of ListUsersByCursor.
+func (mr *MockUserUsecaseMockRecorder) ListUsersByCursor(ctx, filter, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserUsecase)(nil).ListUsers), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByCursor", reflect.TypeOf((*MockUserUsecase)(nil).ListUsersByCursor), ctx, filter, cursor, limit)
+}
+
+// PatchUser mocks base method.
+func (m *MockUserUsecase) PatchUser(ctx context.Context, id string, input *domains.PatchUserInput) (*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUser", ctx, id, input)
+	ret0, _ := ret[0].(*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUser indicates an expected call of PatchUser.
+func (mr *MockUserUsecaseMockRecorder) PatchUser(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUser", reflect.TypeOf((*MockUserUsecase)(nil).PatchUser), ctx, id, input)
 }
 
 // RegisterUser mocks base method.
@@ -241,6 +464,21 @@ func (mr *MockUserUsecaseMockRecorder) RegisterUser(ctx, input any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserUsecase)(nil).RegisterUser), ctx, input)
 }
 
+// RestoreUser mocks base method.
+func (m *MockUserUsecase) RestoreUser(ctx context.Context, id string) (*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id)
+	ret0, _ := ret[0].(*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserUsecaseMockRecorder) RestoreUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUserUsecase)(nil).RestoreUser), ctx, id)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserUsecase) UpdateUser(ctx context.Context, id string, input *domains.UpdateUserInput) (*domains.User, error) {
 	m.ctrl.T.Helper()
@@ -255,3 +493,42 @@ func (mr *MockUserUsecaseMockRecorder) UpdateUser(ctx, id, input any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserUsecase)(nil).UpdateUser), ctx, id, input)
 }
+
+// MockUserRetentionUsecase is a mock of UserRetentionUsecase interface.
+type MockUserRetentionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRetentionUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUserRetentionUsecaseMockRecorder is the mock recorder for MockUserRetentionUsecase.
+type MockUserRetentionUsecaseMockRecorder struct {
+	mock *MockUserRetentionUsecase
+}
+
+// NewMockUserRetentionUsecase creates a new mock instance.
+func NewMockUserRetentionUsecase(ctrl *gomock.Controller) *MockUserRetentionUsecase {
+	mock := &MockUserRetentionUsecase{ctrl: ctrl}
+	mock.recorder = &MockUserRetentionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRetentionUsecase) EXPECT() *MockUserRetentionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// PurgeDeletedUsers mocks base method.
+func (m *MockUserRetentionUsecase) PurgeDeletedUsers(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedUsers", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedUsers indicates an expected call of PurgeDeletedUsers.
+func (mr *MockUserRetentionUsecaseMockRecorder) PurgeDeletedUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedUsers", reflect.TypeOf((*MockUserRetentionUsecase)(nil).PurgeDeletedUsers), ctx)
+}
@@ -2,7 +2,9 @@ package config_test
 
 import (
 	"os"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/zercle/template-go-fiber/internal/config"
 )
@@ -147,3 +149,210 @@ func TestConfig_Validate_DefaultJWTSecretInProduction(t *testing.T) {
 		t.Fatal("expected error for default JWT secret in production")
 	}
 }
+
+func TestConfig_Validate_DefaultCursorSecretInProduction(t *testing.T) {
+	cfg := &config.Config{
+		Server:     config.ServerConfig{Port: 3000, Environment: "production"},
+		Database:   config.DatabaseConfig{Host: "localhost"},
+		JWT:        config.JWTConfig{Secret: "changed"},
+		Pagination: config.PaginationConfig{CursorSecret: "your-super-secret-cursor-key"},
+	}
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for default cursor secret in production")
+	}
+}
+
+func TestLoadConfig_ParsesJWTKeys(t *testing.T) {
+	_ = os.Setenv("JWT_KEYS", "2026-01=/etc/keys/a.pem, 2026-07=/etc/keys/b.pem@2026-07-01T00:00:00Z")
+	defer func() {
+		_ = os.Unsetenv("JWT_KEYS")
+	}()
+
+	cfg, err := config.Load()
+
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(cfg.JWT.Keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(cfg.JWT.Keys))
+	}
+
+	if cfg.JWT.Keys[0].ID != "2026-01" || cfg.JWT.Keys[0].Path != "/etc/keys/a.pem" || !cfg.JWT.Keys[0].ActiveFrom.IsZero() {
+		t.Errorf("unexpected first key: %+v", cfg.JWT.Keys[0])
+	}
+
+	if cfg.JWT.Keys[1].Path != "/etc/keys/b.pem" || cfg.JWT.Keys[1].ActiveFrom.Format("2006-01-02") != "2026-07-01" {
+		t.Errorf("unexpected second key: %+v", cfg.JWT.Keys[1])
+	}
+}
+
+func TestLoadConfig_TenantHeaderAllowedByCORS(t *testing.T) {
+	_ = os.Setenv("TENANT_HEADER", "X-Org")
+	defer func() {
+		_ = os.Unsetenv("TENANT_HEADER")
+	}()
+
+	cfg, err := config.Load()
+
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Tenancy.Header != "X-Org" || cfg.Tenancy.BaseDomain != "" {
+		t.Errorf("unexpected tenancy config: %+v", cfg.Tenancy)
+	}
+
+	if !slices.Contains(cfg.CORS.AllowedHeaders, "X-Org") {
+		t.Errorf("expected X-Org in CORS allowed headers, got %v", cfg.CORS.AllowedHeaders)
+	}
+}
+
+func TestLoadConfig_RejectsMalformedJWTKeys(t *testing.T) {
+	_ = os.Setenv("JWT_KEYS", "missing-path")
+	defer func() {
+		_ = os.Unsetenv("JWT_KEYS")
+	}()
+
+	if _, err := config.Load(); err == nil {
+		t.Error("expected error for malformed JWT_KEYS")
+	}
+}
+
+func TestConfig_Validate_ShortRotationGrace(t *testing.T) {
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Port: 3000},
+		Database: config.DatabaseConfig{Host: "localhost"},
+		JWT: config.JWTConfig{
+			Expiration:    time.Hour,
+			RotationGrace: time.Minute,
+		},
+	}
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error when grace is shorter than the token lifetime")
+	}
+}
+
+func TestConfig_Validate_OIDCRequiresAudience(t *testing.T) {
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Port: 3000},
+		Database: config.DatabaseConfig{Host: "localhost"},
+		OIDC:     config.OIDCConfig{Issuer: "https://idp.example.com"},
+	}
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error when OIDC issuer has no audience")
+	}
+
+	cfg.OIDC.Audience = []string{"template-go-fiber"}
+	cfg.OIDC.UserMapping = "subject"
+	cfg.OIDC.JITProvisioning = true
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for provisioning with the subject mapping")
+	}
+}
+
+func TestConfig_Validate_LockoutRequiresDuration(t *testing.T) {
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Port: 3000},
+		Database: config.DatabaseConfig{Host: "localhost"},
+		Lockout:  config.LockoutConfig{MaxAccountFailures: 20, FailureWindow: 15 * time.Minute},
+	}
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error when lockouts are enabled without a duration")
+	}
+
+	cfg.Lockout.Duration = 15 * time.Minute
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+}
+
+func TestConfig_Validate_UserRetentionRequiresInterval(t *testing.T) {
+	cfg := &config.Config{
+		Server:        config.ServerConfig{Port: 3000},
+		Database:      config.DatabaseConfig{Host: "localhost"},
+		UserRetention: config.UserRetentionConfig{Retention: 30 * 24 * time.Hour},
+	}
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error when purging is enabled without an interval")
+	}
+
+	cfg.UserRetention.PurgeInterval = time.Hour
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+}
+
+func TestConfig_Validate_SessionMode(t *testing.T) {
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Port: 3000, Environment: "production"},
+		Database: config.DatabaseConfig{Host: "localhost"},
+		JWT:      config.JWTConfig{Secret: "changed"},
+		Auth:     config.AuthConfig{Mode: "session"},
+		Session: config.SessionConfig{
+			Store:           "memory",
+			IdleTimeout:     30 * time.Minute,
+			AbsoluteTimeout: 24 * time.Hour,
+			CookieName:      "session",
+			CSRFCookieName:  "csrf_token",
+			CookieSecure:    true,
+			CookieSameSite:  "Lax",
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	cfg.Session.CookieSecure = false
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for insecure session cookies in production")
+	}
+
+	cfg.Server.Environment = "development"
+	cfg.Session.CookieSameSite = "None"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for SameSite=None without Secure")
+	}
+
+	cfg.Session.CookieSameSite = "Lax"
+	cfg.Session.AbsoluteTimeout = time.Minute
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for an absolute timeout shorter than the idle timeout")
+	}
+
+	cfg.Auth.Mode = "cookie"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for an unsupported auth mode")
+	}
+}
+
+func TestConfig_Validate_MagicLink(t *testing.T) {
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Port: 3000},
+		Database: config.DatabaseConfig{Host: "localhost"},
+		MagicLink: config.MagicLinkConfig{
+			Enabled:    true,
+			TokenTTL:   15 * time.Minute,
+			URL:        "/magic-link",
+			CookieName: "magic_link_binding",
+		},
+	}
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for a relative magic link URL")
+	}
+
+	cfg.MagicLink.URL = "https://app.example.com/magic-link"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package cursor_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zercle/template-go-fiber/pkg/cursor"
+)
+
+type position struct {
+	ID   string `json:"id"`
+	Desc bool   `json:"desc"`
+}
+
+func TestSigner_RoundTrip(t *testing.T) {
+	signer := cursor.NewSigner([]byte("secret"))
+
+	encoded, err := signer.Encode(position{ID: "user-1", Desc: true})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	var decoded position
+	if err := signer.Decode(encoded, &decoded); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	if decoded.ID != "user-1" || !decoded.Desc {
+		t.Errorf("expected the encoded position, got %+v", decoded)
+	}
+}
+
+func TestSigner_RejectsTampering(t *testing.T) {
+	signer := cursor.NewSigner([]byte("secret"))
+
+	encoded, err := signer.Encode(position{ID: "user-1"})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	payload, signature, _ := strings.Cut(encoded, ".")
+	forged, err := cursor.NewSigner([]byte("other")).Encode(position{ID: "user-2"})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for _, value := range []string{
+		"",
+		"not-a-cursor",
+		payload,
+		forgedPayload + "." + signature,
+		forged,
+		payload + ".!!!",
+	} {
+		var decoded position
+		if err := signer.Decode(value, &decoded); !errors.Is(err, cursor.ErrInvalid) {
+			t.Errorf("%q: expected ErrInvalid, got %v", value, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package errors_test
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/zercle/template-go-fiber/internal/errors"
 )
@@ -47,6 +48,22 @@ func TestNewValidationError(t *testing.T) {
 	}
 }
 
+func TestNewTooManyAttemptsError(t *testing.T) {
+	err := errors.NewTooManyAttemptsError("too many failed login attempts", 30*time.Second)
+
+	if err.Code != errors.ErrCodeTooManyAttempts {
+		t.Errorf("expected code %s, got %s", errors.ErrCodeTooManyAttempts, err.Code)
+	}
+
+	if err.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, err.StatusCode)
+	}
+
+	if err.RetryAfter != 30*time.Second {
+		t.Errorf("expected retry after 30s, got %v", err.RetryAfter)
+	}
+}
+
 func TestNewDuplicateEntryError(t *testing.T) {
 	err := errors.NewDuplicateEntryError("email already exists")
 
@@ -59,6 +76,18 @@ func TestNewDuplicateEntryError(t *testing.T) {
 	}
 }
 
+func TestNewUnsupportedMediaTypeError(t *testing.T) {
+	err := errors.NewUnsupportedMediaTypeError("unsupported patch format")
+
+	if err.Code != errors.ErrCodeUnsupportedMediaType {
+		t.Errorf("expected code %s, got %s", errors.ErrCodeUnsupportedMediaType, err.Code)
+	}
+
+	if err.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status %d, got %d", http.StatusUnsupportedMediaType, err.StatusCode)
+	}
+}
+
 func TestIsAPIError(t *testing.T) {
 	apiErr := errors.NewNotFoundError("not found")
 
@@ -0,0 +1,121 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zercle/template-go-fiber/pkg/jsonpatch"
+)
+
+// assertJSON compares two JSON documents regardless of member order
+func assertJSON(t *testing.T, name string, got []byte, want string) {
+	t.Helper()
+
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("%s: invalid result %s: %v", name, got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("%s: invalid expectation: %v", name, err)
+	}
+
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("%s: expected %s, got %s", name, want, got)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7396, appendix A
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		got, err := jsonpatch.MergePatch([]byte(tt.doc), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s + %s: %v", tt.doc, tt.patch, err)
+			continue
+		}
+		assertJSON(t, tt.doc+" + "+tt.patch, got, tt.want)
+	}
+
+	if _, err := jsonpatch.MergePatch([]byte(`{}`), []byte(`{"a":`)); !errors.Is(err, jsonpatch.ErrInvalid) {
+		t.Errorf("expected ErrInvalid for malformed JSON, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	// Examples from RFC 6902, appendix A
+	tests := []struct {
+		name, doc, patch, want string
+	}{
+		{"add member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"add element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"append element", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{"remove member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move member", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"move element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"copy", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/baz/bar","value":2}]`, `{"foo":{"bar":1},"baz":{"bar":2}}`},
+		{"test then replace", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2},{"op":"replace","path":"/baz","value":null}]`, `{"baz":null,"foo":["a",2,"c"]}`},
+		{"numbers compare by value", `{"version":1,"limits":[10,{"max":2.5}]}`, `[{"op":"test","path":"/version","value":1.0},{"op":"test","path":"/limits","value":[1e1,{"max":25e-1}]}]`, `{"version":1,"limits":[10,{"max":2.5}]}`},
+		{"escaped pointer", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"remove","path":"/~1"}]`, `{"~1":10}`},
+		{"replace root", `{"foo":"bar"}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+		{"empty patch", `{"foo":"bar"}`, `[]`, `{"foo":"bar"}`},
+	}
+
+	for _, tt := range tests {
+		got, err := jsonpatch.Apply([]byte(tt.doc), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assertJSON(t, tt.name, got, tt.want)
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		name, doc, patch string
+		want             error
+	}{
+		{"not an array", `{}`, `{"op":"add","path":"/a","value":1}`, jsonpatch.ErrInvalid},
+		{"unknown operation", `{}`, `[{"op":"merge","path":"/a","value":1}]`, jsonpatch.ErrInvalid},
+		{"missing path", `{}`, `[{"op":"add","value":1}]`, jsonpatch.ErrInvalid},
+		{"missing value", `{}`, `[{"op":"add","path":"/a"}]`, jsonpatch.ErrInvalid},
+		{"relative path", `{}`, `[{"op":"add","path":"a","value":1}]`, jsonpatch.ErrInvalid},
+		{"missing parent", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, jsonpatch.ErrInvalid},
+		{"replace missing member", `{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, jsonpatch.ErrInvalid},
+		{"remove missing member", `{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, jsonpatch.ErrInvalid},
+		{"index out of range", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/2","value":1}]`, jsonpatch.ErrInvalid},
+		{"leading zero index", `{"foo":["bar","baz"]}`, `[{"op":"remove","path":"/foo/01"}]`, jsonpatch.ErrInvalid},
+		{"move into itself", `{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/baz"}]`, jsonpatch.ErrInvalid},
+		{"test mismatch", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, jsonpatch.ErrTestFailed},
+		{"different numbers", `{"version":1}`, `[{"op":"test","path":"/version","value":1.5}]`, jsonpatch.ErrTestFailed},
+		{"number is not string", `{"baz":"10"}`, `[{"op":"test","path":"/baz","value":10}]`, jsonpatch.ErrTestFailed},
+	}
+
+	for _, tt := range tests {
+		if _, err := jsonpatch.Apply([]byte(tt.doc), []byte(tt.patch)); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
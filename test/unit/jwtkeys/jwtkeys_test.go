@@ -0,0 +1,306 @@
+package jwtkeys_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+)
+
+func encodePKCS8(t *testing.T, key any) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func newEd25519Key(t *testing.T, id string, activeFrom time.Time) *jwtkeys.Key {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	key, err := jwtkeys.ParsePEM(id, encodePKCS8(t, priv))
+	if err != nil {
+		t.Fatalf("ParsePEM failed: %v", err)
+	}
+	key.ActiveFrom = activeFrom
+	return key
+}
+
+func TestParsePEM_InfersAlgorithm(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	ecDER, err := x509.MarshalECPrivateKey(p256Key)
+	if err != nil {
+		t.Fatalf("failed to marshal EC key: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		pem       []byte
+		algorithm string
+	}{
+		{"RSA PKCS#8", encodePKCS8(t, rsaKey), jwtkeys.AlgorithmRS256},
+		{"RSA PKCS#1", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}), jwtkeys.AlgorithmRS256},
+		{"EC P-256 SEC 1", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}), jwtkeys.AlgorithmES256},
+		{"EC P-384", encodePKCS8(t, p384Key), jwtkeys.AlgorithmES384},
+		{"Ed25519", encodePKCS8(t, edKey), jwtkeys.AlgorithmEdDSA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := jwtkeys.ParsePEM("kid", tt.pem)
+			if err != nil {
+				t.Fatalf("ParsePEM failed: %v", err)
+			}
+
+			if key.Algorithm != tt.algorithm {
+				t.Errorf("expected algorithm %s, got %s", tt.algorithm, key.Algorithm)
+			}
+
+			if !key.CanSign() {
+				t.Error("expected private key to be able to sign")
+			}
+		})
+	}
+}
+
+func TestParsePEM_PublicKeyIsVerifyOnly(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	key, err := jwtkeys.ParsePEM("kid", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("ParsePEM failed: %v", err)
+	}
+
+	if key.CanSign() {
+		t.Error("expected public key to be verify-only")
+	}
+
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{key}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+
+	if _, err := keys.SigningKey(); !errors.Is(err, jwtkeys.ErrNoSigningKey) {
+		t.Errorf("expected ErrNoSigningKey, got %v", err)
+	}
+}
+
+func TestParsePEM_RejectsGarbage(t *testing.T) {
+	if _, err := jwtkeys.ParsePEM("kid", []byte("not a pem file")); !errors.Is(err, jwtkeys.ErrMalformedKey) {
+		t.Errorf("expected ErrMalformedKey, got %v", err)
+	}
+}
+
+func TestLoadPEMFile(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	path := filepath.Join(t.TempDir(), "signing.pem")
+	if err := os.WriteFile(path, encodePKCS8(t, priv), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	activeFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	key, err := jwtkeys.LoadPEMFile("2026-01", path, activeFrom)
+	if err != nil {
+		t.Fatalf("LoadPEMFile failed: %v", err)
+	}
+
+	if key.ID != "2026-01" || !key.ActiveFrom.Equal(activeFrom) {
+		t.Errorf("unexpected key %s active from %v", key.ID, key.ActiveFrom)
+	}
+
+	if _, err := jwtkeys.LoadPEMFile("missing", filepath.Join(t.TempDir(), "missing.pem"), activeFrom); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestNewKeySet_Validation(t *testing.T) {
+	now := time.Now()
+
+	if _, err := jwtkeys.NewKeySet(nil, time.Hour); err == nil {
+		t.Error("expected error for empty key set")
+	}
+
+	duplicate := []*jwtkeys.Key{newEd25519Key(t, "a", now), newEd25519Key(t, "a", now)}
+	if _, err := jwtkeys.NewKeySet(duplicate, time.Hour); err == nil {
+		t.Error("expected error for duplicate kid")
+	}
+
+	mixed := []*jwtkeys.Key{newEd25519Key(t, "a", now), jwtkeys.NewHMACKey("b", []byte("secret"))}
+	if _, err := jwtkeys.NewKeySet(mixed, time.Hour); err == nil {
+		t.Error("expected error when mixing symmetric and asymmetric keys")
+	}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	now := time.Now()
+	old := newEd25519Key(t, "old", now.Add(-48*time.Hour))
+	current := newEd25519Key(t, "current", now.Add(-30*time.Minute))
+	next := newEd25519Key(t, "next", now.Add(24*time.Hour))
+
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{next, old, current}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+
+	signing, err := keys.SigningKey()
+	if err != nil {
+		t.Fatalf("SigningKey failed: %v", err)
+	}
+
+	if signing.ID != "current" {
+		t.Errorf("expected current key to sign, got %s", signing.ID)
+	}
+
+	// The superseded key is still within its grace period
+	if _, err := keys.Lookup("old"); err != nil {
+		t.Errorf("expected old key to verify during grace period: %v", err)
+	}
+
+	// The scheduled key is published ahead of activation
+	if _, err := keys.Lookup("next"); err != nil {
+		t.Errorf("expected next key to be published: %v", err)
+	}
+
+	set, err := keys.JWKS()
+	if err != nil {
+		t.Fatalf("JWKS failed: %v", err)
+	}
+
+	if len(set.Keys) != 3 {
+		t.Errorf("expected 3 published keys, got %d", len(set.Keys))
+	}
+}
+
+func TestKeySet_RetiresKeysAfterGrace(t *testing.T) {
+	now := time.Now()
+	old := newEd25519Key(t, "old", now.Add(-48*time.Hour))
+	current := newEd25519Key(t, "current", now.Add(-2*time.Hour))
+
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{old, current}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+
+	if _, err := keys.Lookup("old"); !errors.Is(err, jwtkeys.ErrUnknownKey) {
+		t.Errorf("expected old key to be retired, got %v", err)
+	}
+
+	set, err := keys.JWKS()
+	if err != nil {
+		t.Fatalf("JWKS failed: %v", err)
+	}
+
+	if len(set.Keys) != 1 || set.Keys[0].KeyID != "current" {
+		t.Errorf("expected only the current key to be published, got %+v", set.Keys)
+	}
+}
+
+func TestKeySet_VerifyOnlySuccessorDoesNotRetireSigningKey(t *testing.T) {
+	now := time.Now()
+	current := newEd25519Key(t, "current", now.Add(-48*time.Hour))
+
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	verifyOnly := mustParse(t, "verify-only", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	verifyOnly.ActiveFrom = now.Add(-2 * time.Hour)
+
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{current, verifyOnly}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+
+	signing, err := keys.SigningKey()
+	if err != nil || signing.ID != "current" {
+		t.Fatalf("expected the current key to keep signing, got %v, %v", signing, err)
+	}
+
+	// Tokens it signs must still verify
+	if _, err := keys.Lookup("current"); err != nil {
+		t.Errorf("expected the current key to stay published, got %v", err)
+	}
+}
+
+func TestKeySet_JWKSFormat(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	rsaJWK, err := mustParse(t, "rsa", encodePKCS8(t, rsaKey)).JWK()
+	if err != nil {
+		t.Fatalf("JWK failed: %v", err)
+	}
+
+	if rsaJWK.KeyType != "RSA" || rsaJWK.Algorithm != "RS256" || rsaJWK.E != "AQAB" || rsaJWK.N == "" {
+		t.Errorf("unexpected RSA JWK: %+v", rsaJWK)
+	}
+
+	ecJWK, err := mustParse(t, "ec", encodePKCS8(t, ecKey)).JWK()
+	if err != nil {
+		t.Fatalf("JWK failed: %v", err)
+	}
+
+	x, _ := base64.RawURLEncoding.DecodeString(ecJWK.X)
+	y, _ := base64.RawURLEncoding.DecodeString(ecJWK.Y)
+	if ecJWK.KeyType != "EC" || ecJWK.Curve != "P-256" || len(x) != 32 || len(y) != 32 {
+		t.Errorf("unexpected EC JWK: %+v", ecJWK)
+	}
+
+	if ecJWK.Use != "sig" || ecJWK.KeyID != "ec" {
+		t.Errorf("expected use sig and kid ec, got %s %s", ecJWK.Use, ecJWK.KeyID)
+	}
+}
+
+func TestKeySet_JWKSOmitsSymmetricKeys(t *testing.T) {
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{jwtkeys.NewHMACKey("", []byte("secret"))}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+
+	set, err := keys.JWKS()
+	if err != nil {
+		t.Fatalf("JWKS failed: %v", err)
+	}
+
+	if len(set.Keys) != 0 {
+		t.Errorf("expected no published keys, got %d", len(set.Keys))
+	}
+}
+
+func mustParse(t *testing.T, id string, data []byte) *jwtkeys.Key {
+	t.Helper()
+
+	key, err := jwtkeys.ParsePEM(id, data)
+	if err != nil {
+		t.Fatalf("ParsePEM failed: %v", err)
+	}
+	return key
+}
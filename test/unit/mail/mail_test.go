@@ -0,0 +1,91 @@
+package mail_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/infrastructure/mail"
+)
+
+func TestFileSender_WritesMessage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	sender := mail.NewFileSender(dir, "no-reply@example.com")
+
+	err := sender.Send(context.Background(), &domains.MailMessage{
+		To:      "user@example.com",
+		Subject: "Verify your email address",
+		Body:    "Line one\nLine two\n",
+	})
+
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %d", len(files))
+	}
+
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("failed to read mail file: %v", err)
+	}
+
+	content := string(data)
+	for _, want := range []string{
+		"From: no-reply@example.com\r\n",
+		"To: user@example.com\r\n",
+		"Subject: Verify your email address\r\n",
+		"\r\n\r\nLine one\r\nLine two\r\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected mail to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestFileSender_RejectsHeaderInjection(t *testing.T) {
+	dir := t.TempDir()
+	sender := mail.NewFileSender(dir, "no-reply@example.com")
+
+	err := sender.Send(context.Background(), &domains.MailMessage{
+		To:      "user@example.com\r\nBcc: victim@example.com",
+		Subject: "Hello",
+		Body:    "Body",
+	})
+
+	if err == nil {
+		t.Fatal("expected an error for a header containing a line break")
+	}
+
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.eml")); len(files) != 0 {
+		t.Errorf("expected no mail file, got %d", len(files))
+	}
+}
+
+func TestCaptureSender_KeepsMessages(t *testing.T) {
+	sender := mail.NewCaptureSender()
+
+	if sender.Last() != nil {
+		t.Fatal("expected no message before the first send")
+	}
+
+	for _, subject := range []string{"First", "Second"} {
+		if err := sender.Send(context.Background(), &domains.MailMessage{To: "user@example.com", Subject: subject}); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+
+	messages := sender.Messages()
+	if len(messages) != 2 || messages[0].Subject != "First" {
+		t.Fatalf("expected both messages in order, got %+v", messages)
+	}
+
+	if last := sender.Last(); last == nil || last.Subject != "Second" {
+		t.Errorf("expected the second message last, got %+v", last)
+	}
+}
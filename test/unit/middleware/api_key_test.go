@@ -0,0 +1,91 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+func setupAPIKeyApp(t *testing.T, apiKeys domains.APIKeyUsecase) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.AuthMiddleware(newTestKeys(t, "test-secret-key"), nil, nil, apiKeys))
+	app.Get("/users", middleware.RequirePermission(domains.PermissionUsersRead), func(c *fiber.Ctx) error {
+		principal, ok := domains.PrincipalFromContext(c.UserContext())
+		if !ok || principal.APIKeyID != c.Locals("api_key_id") {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendString(c.Locals("user_id").(string))
+	})
+	return app
+}
+
+func TestAuthMiddleware_AcceptsAPIKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		{"X-API-Key header", "X-API-Key", "ak_123.secret"},
+		{"ApiKey authorization scheme", "Authorization", "ApiKey ak_123.secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKeys := mocks.NewMockAPIKeyUsecase(ctrl)
+			apiKeys.EXPECT().
+				Authenticate(gomock.Any(), "ak_123.secret").
+				Return(&domains.Principal{
+					UserID:      "service-1",
+					Permissions: []string{domains.PermissionUsersRead},
+					APIKeyID:    "key-1",
+				}, nil).
+				Times(1)
+
+			req := httptest.NewRequest("GET", "/users", nil)
+			req.Header.Set(tt.header, tt.value)
+			resp, _ := setupAPIKeyApp(t, apiKeys).Test(req)
+
+			if resp.StatusCode != 200 {
+				t.Errorf("expected status 200, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RejectsInvalidAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiKeys := mocks.NewMockAPIKeyUsecase(ctrl)
+	apiKeys.EXPECT().
+		Authenticate(gomock.Any(), "ak_123.wrong").
+		Return(nil, errors.NewUnauthorizedError("invalid API key")).
+		Times(1)
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("X-API-Key", "ak_123.wrong")
+	resp, _ := setupAPIKeyApp(t, apiKeys).Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_APIKeysDisabled(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("X-API-Key", "ak_123.secret")
+	resp, _ := setupAPIKeyApp(t, nil).Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,350 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+)
+
+func newTestKeys(t *testing.T, secret string) *jwtkeys.KeySet {
+	t.Helper()
+
+	keys, err := jwtkeys.NewKeySet([]*jwtkeys.Key{jwtkeys.NewHMACKey("", []byte(secret))}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+	return keys
+}
+
+func setupProtectedApp(keys *jwtkeys.KeySet) *fiber.App {
+	return setupProtectedAppWithStore(keys, nil)
+}
+
+func setupProtectedAppWithStore(keys *jwtkeys.KeySet, revocations domains.TokenRevocationStore) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.AuthMiddleware(keys, revocations, nil, nil))
+	app.Get("/me", func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("user_id").(string))
+	})
+	return app
+}
+
+func TestJWTIssuer_TokenAcceptedByAuthMiddleware(t *testing.T) {
+	keys := newTestKeys(t, "test-secret-key")
+	issuer := middleware.NewJWTIssuer(keys, time.Hour)
+
+	token, err := issuer.Issue(&domains.IssueTokenInput{UserID: "user-123", Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	if token.TokenType != "Bearer" {
+		t.Errorf("expected token type Bearer, got %s", token.TokenType)
+	}
+
+	if time.Until(token.ExpiresAt) > time.Hour || time.Until(token.ExpiresAt) < 59*time.Minute {
+		t.Errorf("expected expiry about one hour from now, got %v", token.ExpiresAt)
+	}
+
+	app := setupProtectedApp(keys)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 200 {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_RejectsTokenSignedWithOtherSecret(t *testing.T) {
+	otherKeys := newTestKeys(t, "another-secret")
+
+	token, err := middleware.NewJWTIssuer(otherKeys, time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	app := setupProtectedApp(newTestKeys(t, "test-secret-key"))
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_RejectsExpiredToken(t *testing.T) {
+	token, err := middleware.NewJWTIssuer(newTestKeys(t, "test-secret-key"), -time.Minute).Issue(&domains.IssueTokenInput{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	app := setupProtectedApp(newTestKeys(t, "test-secret-key"))
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_RejectsRevokedToken(t *testing.T) {
+	keys := newTestKeys(t, "test-secret-key")
+	store := repositories.NewMemoryTokenRevocationStore()
+
+	token, err := middleware.NewJWTIssuer(keys, time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	claims := &middleware.JWTClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token.AccessToken, claims); err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+
+	if claims.ID == "" {
+		t.Fatal("expected token to carry a jti claim")
+	}
+
+	if err := store.RevokeToken(context.Background(), claims.ID, token.ExpiresAt); err != nil {
+		t.Fatalf("RevokeToken failed: %v", err)
+	}
+
+	app := setupProtectedAppWithStore(keys, store)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestAuthMiddleware_RejectsTokensOfRevokedUser(t *testing.T) {
+	keys := newTestKeys(t, "test-secret-key")
+	store := repositories.NewMemoryTokenRevocationStore()
+	issuer := middleware.NewJWTIssuer(keys, time.Hour)
+
+	revoked, err := issuer.Issue(&domains.IssueTokenInput{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	other, err := issuer.Issue(&domains.IssueTokenInput{UserID: "user-456"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	if err := store.RevokeUserTokens(context.Background(), "user-123"); err != nil {
+		t.Fatalf("RevokeUserTokens failed: %v", err)
+	}
+
+	app := setupProtectedAppWithStore(keys, store)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+revoked.AccessToken)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401 for revoked user, got %d", resp.StatusCode)
+	}
+
+	req = httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+other.AccessToken)
+	resp, _ = app.Test(req)
+
+	if resp.StatusCode != 200 {
+		t.Errorf("expected status 200 for other user, got %d", resp.StatusCode)
+	}
+}
+
+func TestOptionalAuthMiddleware_IgnoresRevokedToken(t *testing.T) {
+	keys := newTestKeys(t, "test-secret-key")
+	store := repositories.NewMemoryTokenRevocationStore()
+
+	token, err := middleware.NewJWTIssuer(keys, time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	if err := store.RevokeUserTokens(context.Background(), "user-123"); err != nil {
+		t.Fatalf("RevokeUserTokens failed: %v", err)
+	}
+
+	app := fiber.New()
+	app.Use(middleware.OptionalAuthMiddleware(keys, store, nil, nil))
+	app.Get("/me", func(c *fiber.Ctx) error {
+		if c.Locals("user_id") != nil {
+			return c.SendStatus(fiber.StatusOK)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 204 {
+		t.Errorf("expected anonymous request (204), got %d", resp.StatusCode)
+	}
+}
+
+func newAsymmetricKeys(t *testing.T, grace time.Duration, keys ...*jwtkeys.Key) *jwtkeys.KeySet {
+	t.Helper()
+
+	set, err := jwtkeys.NewKeySet(keys, grace)
+	if err != nil {
+		t.Fatalf("NewKeySet failed: %v", err)
+	}
+	return set
+}
+
+func generateKey(t *testing.T, id string, private any, activeFrom time.Time) *jwtkeys.Key {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	key, err := jwtkeys.ParsePEM(id, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("ParsePEM failed: %v", err)
+	}
+	key.ActiveFrom = activeFrom
+	return key
+}
+
+func TestAuthMiddleware_AcceptsAsymmetricTokens(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name      string
+		private   any
+		algorithm string
+	}{
+		{"RS256", rsaKey, "RS256"},
+		{"ES256", ecKey, "ES256"},
+		{"EdDSA", edKey, "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := newAsymmetricKeys(t, time.Hour, generateKey(t, "key-1", tt.private, time.Time{}))
+
+			token, err := middleware.NewJWTIssuer(keys, time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-123"})
+			if err != nil {
+				t.Fatalf("Issue failed: %v", err)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token.AccessToken, &middleware.JWTClaims{})
+			if err != nil {
+				t.Fatalf("failed to parse token: %v", err)
+			}
+
+			if parsed.Header["kid"] != "key-1" || parsed.Method.Alg() != tt.algorithm {
+				t.Errorf("expected kid key-1 and alg %s, got %v %s", tt.algorithm, parsed.Header["kid"], parsed.Method.Alg())
+			}
+
+			app := setupProtectedApp(keys)
+
+			req := httptest.NewRequest("GET", "/me", nil)
+			req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+			resp, _ := app.Test(req)
+
+			if resp.StatusCode != 200 {
+				t.Errorf("expected status 200, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_AcceptsSupersededKeyDuringGrace(t *testing.T) {
+	_, oldPriv, _ := ed25519.GenerateKey(rand.Reader)
+	_, newPriv, _ := ed25519.GenerateKey(rand.Reader)
+
+	// Before rotation only the old key exists
+	oldKey := generateKey(t, "old", oldPriv, time.Now().Add(-48*time.Hour))
+	token, err := middleware.NewJWTIssuer(newAsymmetricKeys(t, time.Hour, oldKey), time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	newKey := generateKey(t, "new", newPriv, time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name   string
+		grace  time.Duration
+		status int
+	}{
+		{"within grace", time.Hour, 200},
+		{"after grace", 30 * time.Second, 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupProtectedApp(newAsymmetricKeys(t, tt.grace, oldKey, newKey))
+
+			req := httptest.NewRequest("GET", "/me", nil)
+			req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+			resp, _ := app.Test(req)
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RejectsAlgorithmConfusion(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keys := newAsymmetricKeys(t, time.Hour, generateKey(t, "key-1", rsaKey, time.Time{}))
+
+	// HS256 signed with the public key bytes, as an attacker who read the JWKS could
+	publicDER, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &middleware.JWTClaims{
+		UserID: "attacker",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	forged.Header["kid"] = "key-1"
+
+	tokenString, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	app := setupProtectedApp(keys)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,139 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+)
+
+func issueImpersonationToken(t *testing.T, issuer *middleware.JWTIssuer) string {
+	t.Helper()
+
+	token, err := issuer.Issue(&domains.IssueTokenInput{
+		UserID:     "user-123",
+		Email:      "user@example.com",
+		ActorID:    "admin-1",
+		ActorEmail: "admin@example.com",
+		TTL:        15 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	if time.Until(token.ExpiresAt) > 15*time.Minute {
+		t.Errorf("expected the TTL to override the issuer lifetime, got %v", token.ExpiresAt)
+	}
+
+	return token.AccessToken
+}
+
+func TestJWTIssuer_ActorClaim(t *testing.T) {
+	issuer := middleware.NewJWTIssuer(newTestKeys(t, "test-secret-key"), time.Hour)
+
+	claims := &middleware.JWTClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(issueImpersonationToken(t, issuer), claims); err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+
+	if claims.Subject != "user-123" || claims.Actor == nil || claims.Actor.Subject != "admin-1" {
+		t.Errorf("expected user-123 acted on by admin-1, got sub=%s act=%+v", claims.Subject, claims.Actor)
+	}
+}
+
+func TestAuthMiddleware_ExposesActor(t *testing.T) {
+	keys := newTestKeys(t, "test-secret-key")
+
+	app := fiber.New()
+	app.Use(middleware.AuthMiddleware(keys, nil, nil, nil))
+	app.Get("/me", func(c *fiber.Ctx) error {
+		principal, _ := domains.PrincipalFromContext(c.UserContext())
+		return c.JSON(fiber.Map{
+			"user_id":         c.Locals("user_id"),
+			"actor_id":        c.Locals("actor_id"),
+			"principal_actor": principal.ActorID,
+		})
+	})
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+issueImpersonationToken(t, middleware.NewJWTIssuer(keys, time.Hour)))
+	resp, _ := app.Test(req)
+
+	var body map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if body["user_id"] != "user-123" || body["actor_id"] != "admin-1" || body["principal_actor"] != "admin-1" {
+		t.Errorf("expected the actor next to the user, got %v", body)
+	}
+}
+
+func TestDenyImpersonation(t *testing.T) {
+	keys := newTestKeys(t, "test-secret-key")
+	issuer := middleware.NewJWTIssuer(keys, time.Hour)
+
+	app := fiber.New()
+	app.Use(middleware.AuthMiddleware(keys, nil, nil, nil))
+	app.Post("/auth/change-password", middleware.DenyImpersonation(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	own, err := issuer.Issue(&domains.IssueTokenInput{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"account owner", own.AccessToken, 204},
+		{"impersonating admin", issueImpersonationToken(t, issuer), 403},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/auth/change-password", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			resp, _ := app.Test(req)
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestLoggerMiddleware_LogsImpersonatedRequests(t *testing.T) {
+	keys := newTestKeys(t, "test-secret-key")
+
+	var logs bytes.Buffer
+	app := fiber.New()
+	app.Use(middleware.LoggerMiddleware(slog.New(slog.NewJSONHandler(&logs, nil))))
+	app.Use(middleware.AuthMiddleware(keys, nil, nil, nil))
+	app.Get("/me", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+issueImpersonationToken(t, middleware.NewJWTIssuer(keys, time.Hour)))
+	_, _ = app.Test(req)
+
+	var event map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &event); err != nil {
+		t.Fatalf("expected one log event, got %q", logs.String())
+	}
+
+	if event["level"] != "WARN" || event["user_id"] != "user-123" || event["actor_id"] != "admin-1" || event["actor_email"] != "admin@example.com" {
+		t.Errorf("expected the request attributed to the admin, got %v", event)
+	}
+}
@@ -0,0 +1,312 @@
+package middleware_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/pkg/oidc"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+const testAudience = "template-go-fiber"
+
+// stubIssuer is a local OIDC provider serving discovery and JWKS documents
+type stubIssuer struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	keys      map[string]*ecdsa.PrivateKey
+	jwksCalls int
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	t.Helper()
+
+	issuer := &stubIssuer{keys: map[string]*ecdsa.PrivateKey{}}
+	issuer.addKey(t, "issuer-key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+
+		issuer.jwksCalls++
+
+		set := jwtkeys.JWKS{}
+		for kid, private := range issuer.keys {
+			key := generateKey(t, kid, private, time.Time{})
+			jwk, err := key.JWK()
+			if err != nil {
+				t.Errorf("JWK failed: %v", err)
+			}
+			set.Keys = append(set.Keys, *jwk)
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+func (s *stubIssuer) addKey(t *testing.T, kid string) {
+	t.Helper()
+
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	s.mu.Lock()
+	s.keys[kid] = private
+	s.mu.Unlock()
+}
+
+// sign issues a token with the given key, defaulting iss, aud, iat and exp
+func (s *stubIssuer) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	now := time.Now()
+	defaults := jwt.MapClaims{
+		"iss": s.server.URL,
+		"aud": testAudience,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		defaults[name] = value
+	}
+
+	s.mu.Lock()
+	private := s.keys[kid]
+	s.mu.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, defaults)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(private)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func newTestVerifier(t *testing.T, issuer *stubIssuer) *oidc.Verifier {
+	t.Helper()
+
+	verifier, err := oidc.NewVerifier(oidc.Options{
+		Issuer:             issuer.server.URL,
+		Audience:           []string{testAudience},
+		MinRefreshInterval: time.Nanosecond,
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier failed: %v", err)
+	}
+	return verifier
+}
+
+func setupOIDCApp(t *testing.T, external *middleware.OIDCAuthenticator) *fiber.App {
+	t.Helper()
+
+	app := fiber.New()
+	app.Use(middleware.AuthMiddleware(newTestKeys(t, "test-secret-key"), nil, external, nil))
+	app.Get("/me", func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("user_id").(string) + "|" + c.Locals("email").(string))
+	})
+	return app
+}
+
+func requestWithToken(t *testing.T, app *fiber.App, token string) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestOIDC_AcceptsExternalTokenMappedBySubject(t *testing.T) {
+	issuer := newStubIssuer(t)
+	app := setupOIDCApp(t, middleware.NewOIDCAuthenticator(newTestVerifier(t, issuer), nil, nil, middleware.OIDCClaimMapping{}))
+
+	status, body := requestWithToken(t, app, issuer.sign(t, "issuer-key-1", jwt.MapClaims{
+		"sub":   "external-123",
+		"email": "user@corp.example.com",
+	}))
+
+	if status != 200 {
+		t.Fatalf("expected status 200, got %d: %s", status, body)
+	}
+
+	if body != "external-123|user@corp.example.com" {
+		t.Errorf("unexpected mapped claims: %s", body)
+	}
+}
+
+func TestOIDC_CustomClaimMapping(t *testing.T) {
+	issuer := newStubIssuer(t)
+	app := setupOIDCApp(t, middleware.NewOIDCAuthenticator(newTestVerifier(t, issuer), nil, nil, middleware.OIDCClaimMapping{
+		SubjectClaim: "oid",
+		EmailClaim:   "upn",
+	}))
+
+	status, body := requestWithToken(t, app, issuer.sign(t, "issuer-key-1", jwt.MapClaims{
+		"sub": "pairwise-subject",
+		"oid": "object-id",
+		"upn": "user@corp.example.com",
+	}))
+
+	if status != 200 || body != "object-id|user@corp.example.com" {
+		t.Errorf("expected mapping from oid and upn, got %d: %s", status, body)
+	}
+}
+
+func TestOIDC_RejectsInvalidTokens(t *testing.T) {
+	issuer := newStubIssuer(t)
+	app := setupOIDCApp(t, middleware.NewOIDCAuthenticator(newTestVerifier(t, issuer), nil, nil, middleware.OIDCClaimMapping{}))
+
+	other := newStubIssuer(t)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong audience", issuer.sign(t, "issuer-key-1", jwt.MapClaims{"sub": "u", "aud": "someone-else"})},
+		{"not yet valid", issuer.sign(t, "issuer-key-1", jwt.MapClaims{"sub": "u", "nbf": time.Now().Add(time.Hour).Unix()})},
+		{"expired", issuer.sign(t, "issuer-key-1", jwt.MapClaims{"sub": "u", "exp": time.Now().Add(-time.Hour).Unix()})},
+		{"missing subject", issuer.sign(t, "issuer-key-1", jwt.MapClaims{})},
+		{"other issuer", other.sign(t, "issuer-key-1", jwt.MapClaims{"sub": "u"})},
+		{"forged issuer", other.sign(t, "issuer-key-1", jwt.MapClaims{"sub": "u", "iss": issuer.server.URL})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := requestWithToken(t, app, tt.token)
+			if status != 401 {
+				t.Errorf("expected status 401, got %d: %s", status, body)
+			}
+		})
+	}
+}
+
+func TestOIDC_LocalTokensStillAccepted(t *testing.T) {
+	issuer := newStubIssuer(t)
+	app := setupOIDCApp(t, middleware.NewOIDCAuthenticator(newTestVerifier(t, issuer), nil, nil, middleware.OIDCClaimMapping{}))
+
+	token, err := middleware.NewJWTIssuer(newTestKeys(t, "test-secret-key"), time.Hour).Issue(&domains.IssueTokenInput{
+		UserID: "local-123",
+		Email:  "local@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	status, body := requestWithToken(t, app, token.AccessToken)
+	if status != 200 || body != "local-123|local@example.com" {
+		t.Errorf("expected local token to be accepted, got %d: %s", status, body)
+	}
+}
+
+func TestOIDC_PicksUpRotatedIssuerKeys(t *testing.T) {
+	issuer := newStubIssuer(t)
+	app := setupOIDCApp(t, middleware.NewOIDCAuthenticator(newTestVerifier(t, issuer), nil, nil, middleware.OIDCClaimMapping{}))
+
+	first := issuer.sign(t, "issuer-key-1", jwt.MapClaims{"sub": "u", "email": "u@example.com"})
+	if status, body := requestWithToken(t, app, first); status != 200 {
+		t.Fatalf("expected status 200, got %d: %s", status, body)
+	}
+
+	// Cached keys serve the next request without another fetch
+	if status, _ := requestWithToken(t, app, first); status != 200 || issuer.jwksCalls != 1 {
+		t.Errorf("expected cached JWKS, got status %d after %d fetches", status, issuer.jwksCalls)
+	}
+
+	issuer.addKey(t, "issuer-key-2")
+	rotated := issuer.sign(t, "issuer-key-2", jwt.MapClaims{"sub": "u", "email": "u@example.com"})
+
+	if status, body := requestWithToken(t, app, rotated); status != 200 {
+		t.Errorf("expected token signed with the new key to be accepted, got %d: %s", status, body)
+	}
+
+	if issuer.jwksCalls != 2 {
+		t.Errorf("expected unknown kid to trigger a refresh, got %d fetches", issuer.jwksCalls)
+	}
+}
+
+func TestOIDC_ResolvesLocalUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuer := newStubIssuer(t)
+	identities := mocks.NewMockIdentityUsecase(ctrl)
+
+	identities.EXPECT().
+		ResolveExternalUser(gomock.Any(), &domains.ExternalIdentityInput{
+			Issuer:        issuer.server.URL,
+			Subject:       "external-123",
+			Email:         "user@corp.example.com",
+			EmailVerified: true,
+		}).
+		Return(&domains.User{ID: "local-uuid", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	app := setupOIDCApp(t, middleware.NewOIDCAuthenticator(newTestVerifier(t, issuer), identities, nil, middleware.OIDCClaimMapping{}))
+
+	status, body := requestWithToken(t, app, issuer.sign(t, "issuer-key-1", jwt.MapClaims{
+		"sub":            "external-123",
+		"email":          "user@corp.example.com",
+		"email_verified": true,
+	}))
+
+	if status != 200 || body != "local-uuid|user@example.com" {
+		t.Errorf("expected local user, got %d: %s", status, body)
+	}
+}
+
+func TestOIDC_UnlinkedIdentityRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuer := newStubIssuer(t)
+	identities := mocks.NewMockIdentityUsecase(ctrl)
+
+	identities.EXPECT().
+		ResolveExternalUser(gomock.Any(), gomock.Any()).
+		Return(nil, errors.NewForbiddenError("user account is inactive")).
+		Times(1)
+
+	app := setupOIDCApp(t, middleware.NewOIDCAuthenticator(newTestVerifier(t, issuer), identities, nil, middleware.OIDCClaimMapping{}))
+
+	status, _ := requestWithToken(t, app, issuer.sign(t, "issuer-key-1", jwt.MapClaims{"sub": "external-123"}))
+
+	if status != 403 {
+		t.Errorf("expected status 403, got %d", status)
+	}
+}
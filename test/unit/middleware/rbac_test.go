@@ -0,0 +1,146 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+func setupPermissionApp(keys *jwtkeys.KeySet, external *middleware.OIDCAuthenticator) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.AuthMiddleware(keys, nil, external, nil))
+	app.Get("/users", middleware.RequirePermission(domains.PermissionUsersRead), func(c *fiber.Ctx) error {
+		principal, ok := domains.PrincipalFromContext(c.UserContext())
+		if !ok {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendString(principal.UserID)
+	})
+	return app
+}
+
+func TestRequirePermission(t *testing.T) {
+	keys := newTestKeys(t, "test-secret-key")
+	issuer := middleware.NewJWTIssuer(keys, time.Hour)
+	app := setupPermissionApp(keys, nil)
+
+	tests := []struct {
+		name        string
+		permissions []string
+		status      int
+	}{
+		{"admin", []string{domains.PermissionUsersRead, domains.PermissionUsersWrite}, 200},
+		{"regular user", []string{domains.PermissionSelfRead, domains.PermissionSelfWrite}, 403},
+		{"no permissions", nil, 403},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := issuer.Issue(&domains.IssueTokenInput{
+				UserID:      "user-123",
+				Email:       "user@example.com",
+				Permissions: tt.permissions,
+			})
+			if err != nil {
+				t.Fatalf("Issue failed: %v", err)
+			}
+
+			req := httptest.NewRequest("GET", "/users", nil)
+			req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+			resp, _ := app.Test(req)
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRequirePermission_WithoutAuthentication(t *testing.T) {
+	app := fiber.New()
+	app.Get("/users", middleware.RequirePermission(domains.PermissionUsersRead), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/users", nil))
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestOIDC_LoadsLocalPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuer := newStubIssuer(t)
+	roles := mocks.NewMockRoleRepository(ctrl)
+
+	roles.EXPECT().
+		GetUserRoles(gomock.Any(), "external-123").
+		Return([]string{domains.RoleAdmin}, nil).
+		Times(1)
+
+	roles.EXPECT().
+		GetUserPermissions(gomock.Any(), "external-123").
+		Return([]string{domains.PermissionUsersRead}, nil).
+		Times(1)
+
+	external := middleware.NewOIDCAuthenticator(newTestVerifier(t, issuer), nil, roles, middleware.OIDCClaimMapping{})
+	app := setupPermissionApp(newTestKeys(t, "test-secret-key"), external)
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Authorization", "Bearer "+issuer.sign(t, "issuer-key-1", jwt.MapClaims{"sub": "external-123"}))
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 200 {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestRequirePermission_UsesResolvedPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys := newTestKeys(t, "test-secret-key")
+	issuer := middleware.NewJWTIssuer(keys, time.Hour)
+	resolver := mocks.NewMockPermissionResolver(ctrl)
+
+	// The token predates the group membership that grants users:read
+	resolver.EXPECT().
+		Permissions(gomock.Any(), "user-123").
+		Return([]string{domains.PermissionUsersRead}, nil).
+		Times(1)
+
+	app := fiber.New()
+	app.Use(middleware.ResolvePermissions(resolver))
+	app.Use(middleware.AuthMiddleware(keys, nil, nil, nil))
+	app.Get("/users", middleware.RequirePermission(domains.PermissionUsersRead), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	token, err := issuer.Issue(&domains.IssueTokenInput{
+		UserID:      "user-123",
+		Email:       "user@example.com",
+		Permissions: []string{domains.PermissionSelfRead},
+	})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != 200 {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
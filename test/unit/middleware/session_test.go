@@ -0,0 +1,168 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testSessionCookie = &middleware.SessionCookie{
+	Name:     "session",
+	CSRFName: "csrf_token",
+	Secure:   true,
+	SameSite: "Lax",
+}
+
+func setupSessionApp(t *testing.T, sessions domains.SessionUsecase) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.SessionMiddleware(sessions, testSessionCookie, middleware.AuthMiddleware(newTestKeys(t, "test-secret-key"), nil, nil, nil)))
+	handler := func(c *fiber.Ctx) error {
+		principal, ok := domains.PrincipalFromContext(c.UserContext())
+		if !ok || principal.UserID != c.Locals("user_id") {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendString(c.Locals("user_id").(string))
+	}
+	app.Get("/me", handler)
+	app.Post("/me", handler)
+	return app
+}
+
+func testSession() *domains.Session {
+	return &domains.Session{
+		ID:          "hash",
+		UserID:      "user-123",
+		Permissions: []string{domains.PermissionSelfRead},
+		CSRFToken:   "csrf-secret",
+		ExpiresAt:   time.Now().Add(30 * time.Minute),
+	}
+}
+
+func TestSessionMiddleware_CSRF(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		csrfHeader string
+		wantStatus int
+	}{
+		{"safe method needs no token", "GET", "", 200},
+		{"state-changing method with token", "POST", "csrf-secret", 200},
+		{"state-changing method without token", "POST", "", 403},
+		{"state-changing method with wrong token", "POST", "csrf-wrong", 403},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sessions := mocks.NewMockSessionUsecase(ctrl)
+			sessions.EXPECT().Resume(gomock.Any(), "cookie-token").Return(testSession(), false, nil).Times(1)
+
+			req := httptest.NewRequest(tt.method, "/me", nil)
+			req.Header.Set("Cookie", "session=cookie-token")
+			if tt.csrfHeader != "" {
+				req.Header.Set(middleware.CSRFHeader, tt.csrfHeader)
+			}
+
+			resp, _ := setupSessionApp(t, sessions).Test(req)
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+
+			if len(resp.Header.Values("Set-Cookie")) != 0 {
+				t.Error("expected no cookie for a session that was not renewed")
+			}
+		})
+	}
+}
+
+func TestSessionMiddleware_RenewalResendsCookies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessions := mocks.NewMockSessionUsecase(ctrl)
+	sessions.EXPECT().Resume(gomock.Any(), "cookie-token").Return(testSession(), true, nil).Times(1)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Cookie", "session=cookie-token")
+	resp, _ := setupSessionApp(t, sessions).Test(req)
+
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	cookies := strings.Join(resp.Header.Values("Set-Cookie"), "\n")
+	for _, want := range []string{"session=cookie-token", "HttpOnly", "secure", "SameSite=Lax", "csrf_token=csrf-secret"} {
+		if !strings.Contains(cookies, want) {
+			t.Errorf("expected %q in cookies, got:\n%s", want, cookies)
+		}
+	}
+}
+
+func TestSessionMiddleware_InvalidSessionClearsCookies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessions := mocks.NewMockSessionUsecase(ctrl)
+	sessions.EXPECT().
+		Resume(gomock.Any(), "cookie-token").
+		Return(nil, false, errors.NewUnauthorizedError("session has expired")).
+		Times(1)
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Cookie", "session=cookie-token")
+	resp, _ := setupSessionApp(t, sessions).Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+
+	if !strings.Contains(strings.Join(resp.Header.Values("Set-Cookie"), "\n"), "session=;") {
+		t.Error("expected the session cookie to be cleared")
+	}
+}
+
+func TestSessionMiddleware_FallsBackToBearerToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// A bearer token wins over a cookie and needs no CSRF token
+	sessions := mocks.NewMockSessionUsecase(ctrl)
+
+	keys := newTestKeys(t, "test-secret-key")
+	token, err := middleware.NewJWTIssuer(keys, time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-456"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("Cookie", "session=cookie-token")
+	resp, _ := setupSessionApp(t, sessions).Test(req)
+
+	if resp.StatusCode != 200 {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestSessionMiddleware_NoCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	resp, _ := setupSessionApp(t, mocks.NewMockSessionUsecase(ctrl)).Test(req)
+
+	if resp.StatusCode != 401 {
+		t.Errorf("expected status 401, got %d", resp.StatusCode)
+	}
+}
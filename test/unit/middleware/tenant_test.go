@@ -0,0 +1,186 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/middleware"
+	"github.com/zercle/template-go-fiber/pkg/jwtkeys"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testTenantResolution = middleware.TenantResolution{Header: "X-Tenant", BaseDomain: "example.com"}
+
+func setupTenantApp(tenants domains.TenantUsecase, keys *jwtkeys.KeySet) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.TenantMiddleware(tenants, testTenantResolution))
+	if keys != nil {
+		app.Use(middleware.AuthMiddleware(keys, nil, nil, nil))
+	}
+	app.Get("/tenant", func(c *fiber.Ctx) error {
+		tenantID, ok := domains.TenantFromContext(c.UserContext())
+		if !ok || tenantID != c.Locals("tenant_id") {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		return c.SendString(tenantID + " " + c.Locals("tenant_source").(string))
+	})
+	return app
+}
+
+func tenantResponse(t *testing.T, app *fiber.App, host, header, token string) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/tenant", nil)
+	if host != "" {
+		req.Host = host
+	}
+	if header != "" {
+		req.Header.Set("X-Tenant", header)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestTenantMiddleware_Resolution(t *testing.T) {
+	tests := []struct {
+		name   string
+		host   string
+		header string
+		slug   string
+		want   string
+	}{
+		{"header", "api.example.com", "acme", "acme", "tenant-acme header"},
+		{"header wins over subdomain", "globex.example.com", "acme", "acme", "tenant-acme header"},
+		{"subdomain", "acme.example.com", "", "acme", "tenant-acme subdomain"},
+		{"subdomain with port", "acme.example.com:8080", "", "acme", "tenant-acme subdomain"},
+		{"nested subdomain", "a.acme.example.com", "", "", domains.DefaultTenantID + " default"},
+		{"other domain", "acme.example.org", "", "", domains.DefaultTenantID + " default"},
+		{"neither", "example.com", "", "", domains.DefaultTenantID + " default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenants := mocks.NewMockTenantUsecase(ctrl)
+			if tt.slug != "" {
+				tenants.EXPECT().
+					ResolveTenant(gomock.Any(), tt.slug).
+					Return(&domains.Tenant{ID: "tenant-" + tt.slug, Slug: tt.slug, IsActive: true}, nil).
+					Times(1)
+			}
+
+			status, body := tenantResponse(t, setupTenantApp(tenants, nil), tt.host, tt.header, "")
+			if status != 200 || body != tt.want {
+				t.Errorf("expected 200 %q, got %d %q", tt.want, status, body)
+			}
+		})
+	}
+}
+
+func TestTenantMiddleware_RejectsUnknownTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenants := mocks.NewMockTenantUsecase(ctrl)
+	tenants.EXPECT().
+		ResolveTenant(gomock.Any(), "missing").
+		Return(nil, errors.NewNotFoundError("tenant missing not found")).
+		Times(1)
+
+	status, _ := tenantResponse(t, setupTenantApp(tenants, nil), "", "missing", "")
+	if status != 404 {
+		t.Errorf("expected status 404, got %d", status)
+	}
+}
+
+func TestTenantMiddleware_AdoptsTokenTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys := newTestKeys(t, "test-secret-key")
+	token, err := middleware.NewJWTIssuer(keys, time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-123", TenantID: "tenant-acme"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	status, body := tenantResponse(t, setupTenantApp(mocks.NewMockTenantUsecase(ctrl), keys), "", "", token.AccessToken)
+	if status != 200 || body != "tenant-acme token" {
+		t.Errorf("expected the token's tenant, got %d %q", status, body)
+	}
+}
+
+func TestTenantMiddleware_TokenWithoutTenantBelongsToDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys := newTestKeys(t, "test-secret-key")
+	token, err := middleware.NewJWTIssuer(keys, time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-123"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	tenants := mocks.NewMockTenantUsecase(ctrl)
+	tenants.EXPECT().
+		ResolveTenant(gomock.Any(), "acme").
+		Return(&domains.Tenant{ID: "tenant-acme", Slug: "acme", IsActive: true}, nil).
+		Times(1)
+
+	app := setupTenantApp(tenants, keys)
+
+	status, body := tenantResponse(t, app, "", "", token.AccessToken)
+	if status != 200 || body != domains.DefaultTenantID+" default" {
+		t.Errorf("expected the default tenant, got %d %q", status, body)
+	}
+
+	if status, _ := tenantResponse(t, app, "", "acme", token.AccessToken); status != 401 {
+		t.Errorf("expected status 401 for another tenant, got %d", status)
+	}
+}
+
+func TestTenantMiddleware_RejectsTokenOfOtherTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys := newTestKeys(t, "test-secret-key")
+	token, err := middleware.NewJWTIssuer(keys, time.Hour).Issue(&domains.IssueTokenInput{UserID: "user-123", TenantID: "tenant-acme"})
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	tenants := mocks.NewMockTenantUsecase(ctrl)
+	tenants.EXPECT().
+		ResolveTenant(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, slug string) (*domains.Tenant, error) {
+			return &domains.Tenant{ID: "tenant-" + slug, Slug: slug, IsActive: true}, nil
+		}).
+		Times(2)
+
+	app := setupTenantApp(tenants, keys)
+
+	if status, _ := tenantResponse(t, app, "", "globex", token.AccessToken); status != 401 {
+		t.Errorf("expected status 401, got %d", status)
+	}
+
+	status, body := tenantResponse(t, app, "acme.example.com", "", token.AccessToken)
+	if status != 200 || body != "tenant-acme subdomain" {
+		t.Errorf("expected the named tenant, got %d %q", status, body)
+	}
+}
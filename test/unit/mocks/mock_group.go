@@ -0,0 +1,379 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: group.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// MockGroupRepository is a mock of GroupRepository interface.
+type MockGroupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupRepositoryMockRecorder
+}
+
+// MockGroupRepositoryMockRecorder is the mock recorder for MockGroupRepository.
+type MockGroupRepositoryMockRecorder struct {
+	mock *MockGroupRepository
+}
+
+// NewMockGroupRepository creates a new mock instance.
+func NewMockGroupRepository(ctrl *gomock.Controller) *MockGroupRepository {
+	mock := &MockGroupRepository{ctrl: ctrl}
+	mock.recorder = &MockGroupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupRepository) EXPECT() *MockGroupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockGroupRepository) AddMember(ctx context.Context, groupID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockGroupRepositoryMockRecorder) AddMember(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockGroupRepository)(nil).AddMember), ctx, groupID, userID)
+}
+
+// AddRole mocks base method.
+func (m *MockGroupRepository) AddRole(ctx context.Context, groupID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRole", ctx, groupID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRole indicates an expected call of AddRole.
+func (mr *MockGroupRepositoryMockRecorder) AddRole(ctx, groupID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRole", reflect.TypeOf((*MockGroupRepository)(nil).AddRole), ctx, groupID, role)
+}
+
+// Create mocks base method.
+func (m *MockGroupRepository) Create(ctx context.Context, group *domains.Group) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGroupRepositoryMockRecorder) Create(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGroupRepository)(nil).Create), ctx, group)
+}
+
+// Delete mocks base method.
+func (m *MockGroupRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGroupRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGroupRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockGroupRepository) GetByID(ctx context.Context, id string) (*domains.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGroupRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGroupRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByName mocks base method.
+func (m *MockGroupRepository) GetByName(ctx context.Context, name string) (*domains.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].(*domains.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockGroupRepositoryMockRecorder) GetByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockGroupRepository)(nil).GetByName), ctx, name)
+}
+
+// List mocks base method.
+func (m *MockGroupRepository) List(ctx context.Context, limit, offset int32) ([]*domains.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset)
+	ret0, _ := ret[0].([]*domains.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockGroupRepositoryMockRecorder) List(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGroupRepository)(nil).List), ctx, limit, offset)
+}
+
+// ListMemberIDs mocks base method.
+func (m *MockGroupRepository) ListMemberIDs(ctx context.Context, groupID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberIDs", ctx, groupID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberIDs indicates an expected call of ListMemberIDs.
+func (mr *MockGroupRepositoryMockRecorder) ListMemberIDs(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberIDs", reflect.TypeOf((*MockGroupRepository)(nil).ListMemberIDs), ctx, groupID)
+}
+
+// ListMembers mocks base method.
+func (m *MockGroupRepository) ListMembers(ctx context.Context, groupID string, limit, offset int32) ([]*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, groupID, limit, offset)
+	ret0, _ := ret[0].([]*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockGroupRepositoryMockRecorder) ListMembers(ctx, groupID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockGroupRepository)(nil).ListMembers), ctx, groupID, limit, offset)
+}
+
+// RemoveMember mocks base method.
+func (m *MockGroupRepository) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockGroupRepositoryMockRecorder) RemoveMember(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupRepository)(nil).RemoveMember), ctx, groupID, userID)
+}
+
+// RemoveRole mocks base method.
+func (m *MockGroupRepository) RemoveRole(ctx context.Context, groupID, role string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRole", ctx, groupID, role)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveRole indicates an expected call of RemoveRole.
+func (mr *MockGroupRepositoryMockRecorder) RemoveRole(ctx, groupID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRole", reflect.TypeOf((*MockGroupRepository)(nil).RemoveRole), ctx, groupID, role)
+}
+
+// Update mocks base method.
+func (m *MockGroupRepository) Update(ctx context.Context, group *domains.Group) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGroupRepositoryMockRecorder) Update(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGroupRepository)(nil).Update), ctx, group)
+}
+
+// MockGroupUsecase is a mock of GroupUsecase interface.
+type MockGroupUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupUsecaseMockRecorder
+}
+
+// MockGroupUsecaseMockRecorder is the mock recorder for MockGroupUsecase.
+type MockGroupUsecaseMockRecorder struct {
+	mock *MockGroupUsecase
+}
+
+// NewMockGroupUsecase creates a new mock instance.
+func NewMockGroupUsecase(ctrl *gomock.Controller) *MockGroupUsecase {
+	mock := &MockGroupUsecase{ctrl: ctrl}
+	mock.recorder = &MockGroupUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupUsecase) EXPECT() *MockGroupUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockGroupUsecase) AddMember(ctx context.Context, groupID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockGroupUsecaseMockRecorder) AddMember(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockGroupUsecase)(nil).AddMember), ctx, groupID, userID)
+}
+
+// AddRole mocks base method.
+func (m *MockGroupUsecase) AddRole(ctx context.Context, groupID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRole", ctx, groupID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRole indicates an expected call of AddRole.
+func (mr *MockGroupUsecaseMockRecorder) AddRole(ctx, groupID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRole", reflect.TypeOf((*MockGroupUsecase)(nil).AddRole), ctx, groupID, role)
+}
+
+// CreateGroup mocks base method.
+func (m *MockGroupUsecase) CreateGroup(ctx context.Context, input *domains.CreateGroupInput) (*domains.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, input)
+	ret0, _ := ret[0].(*domains.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockGroupUsecaseMockRecorder) CreateGroup(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupUsecase)(nil).CreateGroup), ctx, input)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockGroupUsecase) DeleteGroup(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockGroupUsecaseMockRecorder) DeleteGroup(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupUsecase)(nil).DeleteGroup), ctx, id)
+}
+
+// GetGroup mocks base method.
+func (m *MockGroupUsecase) GetGroup(ctx context.Context, id string) (*domains.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", ctx, id)
+	ret0, _ := ret[0].(*domains.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup.
+func (mr *MockGroupUsecaseMockRecorder) GetGroup(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockGroupUsecase)(nil).GetGroup), ctx, id)
+}
+
+// ListGroups mocks base method.
+func (m *MockGroupUsecase) ListGroups(ctx context.Context, limit, offset int32) ([]*domains.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", ctx, limit, offset)
+	ret0, _ := ret[0].([]*domains.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockGroupUsecaseMockRecorder) ListGroups(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockGroupUsecase)(nil).ListGroups), ctx, limit, offset)
+}
+
+// ListMembers mocks base method.
+func (m *MockGroupUsecase) ListMembers(ctx context.Context, groupID string, limit, offset int32) ([]*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, groupID, limit, offset)
+	ret0, _ := ret[0].([]*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockGroupUsecaseMockRecorder) ListMembers(ctx, groupID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockGroupUsecase)(nil).ListMembers), ctx, groupID, limit, offset)
+}
+
+// RemoveMember mocks base method.
+func (m *MockGroupUsecase) RemoveMember(ctx context.Context, groupID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockGroupUsecaseMockRecorder) RemoveMember(ctx, groupID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockGroupUsecase)(nil).RemoveMember), ctx, groupID, userID)
+}
+
+// RemoveRole mocks base method.
+func (m *MockGroupUsecase) RemoveRole(ctx context.Context, groupID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRole", ctx, groupID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRole indicates an expected call of RemoveRole.
+func (mr *MockGroupUsecaseMockRecorder) RemoveRole(ctx, groupID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRole", reflect.TypeOf((*MockGroupUsecase)(nil).RemoveRole), ctx, groupID, role)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockGroupUsecase) UpdateGroup(ctx context.Context, id string, input *domains.UpdateGroupInput) (*domains.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", ctx, id, input)
+	ret0, _ := ret[0].(*domains.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockGroupUsecaseMockRecorder) UpdateGroup(ctx, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockGroupUsecase)(nil).UpdateGroup), ctx, id, input)
+}
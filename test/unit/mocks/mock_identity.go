@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: identity.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// MockUserIdentityRepository is a mock of UserIdentityRepository interface.
+type MockUserIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityRepositoryMockRecorder
+}
+
+// MockUserIdentityRepositoryMockRecorder is the mock recorder for MockUserIdentityRepository.
+type MockUserIdentityRepositoryMockRecorder struct {
+	mock *MockUserIdentityRepository
+}
+
+// NewMockUserIdentityRepository creates a new mock instance.
+func NewMockUserIdentityRepository(ctrl *gomock.Controller) *MockUserIdentityRepository {
+	mock := &MockUserIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentityRepository) EXPECT() *MockUserIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserIdentityRepository) Create(ctx context.Context, identity *domains.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserIdentityRepositoryMockRecorder) Create(ctx, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserIdentityRepository)(nil).Create), ctx, identity)
+}
+
+// GetByIssuerSubject mocks base method.
+func (m *MockUserIdentityRepository) GetByIssuerSubject(ctx context.Context, issuer, subject string) (*domains.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIssuerSubject", ctx, issuer, subject)
+	ret0, _ := ret[0].(*domains.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIssuerSubject indicates an expected call of GetByIssuerSubject.
+func (mr *MockUserIdentityRepositoryMockRecorder) GetByIssuerSubject(ctx, issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIssuerSubject", reflect.TypeOf((*MockUserIdentityRepository)(nil).GetByIssuerSubject), ctx, issuer, subject)
+}
+
+// MockIdentityUsecase is a mock of IdentityUsecase interface.
+type MockIdentityUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityUsecaseMockRecorder
+}
+
+// MockIdentityUsecaseMockRecorder is the mock recorder for MockIdentityUsecase.
+type MockIdentityUsecaseMockRecorder struct {
+	mock *MockIdentityUsecase
+}
+
+// NewMockIdentityUsecase creates a new mock instance.
+func NewMockIdentityUsecase(ctrl *gomock.Controller) *MockIdentityUsecase {
+	mock := &MockIdentityUsecase{ctrl: ctrl}
+	mock.recorder = &MockIdentityUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityUsecase) EXPECT() *MockIdentityUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ResolveExternalUser mocks base method.
+func (m *MockIdentityUsecase) ResolveExternalUser(ctx context.Context, input *domains.ExternalIdentityInput) (*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveExternalUser", ctx, input)
+	ret0, _ := ret[0].(*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveExternalUser indicates an expected call of ResolveExternalUser.
+func (mr *MockIdentityUsecaseMockRecorder) ResolveExternalUser(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveExternalUser", reflect.TypeOf((*MockIdentityUsecase)(nil).ResolveExternalUser), ctx, input)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: impersonation.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// MockImpersonationUsecase is a mock of ImpersonationUsecase interface.
+type MockImpersonationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockImpersonationUsecaseMockRecorder
+}
+
+// MockImpersonationUsecaseMockRecorder is the mock recorder for MockImpersonationUsecase.
+type MockImpersonationUsecaseMockRecorder struct {
+	mock *MockImpersonationUsecase
+}
+
+// NewMockImpersonationUsecase creates a new mock instance.
+func NewMockImpersonationUsecase(ctrl *gomock.Controller) *MockImpersonationUsecase {
+	mock := &MockImpersonationUsecase{ctrl: ctrl}
+	mock.recorder = &MockImpersonationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImpersonationUsecase) EXPECT() *MockImpersonationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Impersonate mocks base method.
+func (m *MockImpersonationUsecase) Impersonate(ctx context.Context, input *domains.ImpersonateInput) (*domains.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Impersonate", ctx, input)
+	ret0, _ := ret[0].(*domains.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Impersonate indicates an expected call of Impersonate.
+func (mr *MockImpersonationUsecaseMockRecorder) Impersonate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Impersonate", reflect.TypeOf((*MockImpersonationUsecase)(nil).Impersonate), ctx, input)
+}
@@ -0,0 +1,252 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invitation.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// MockInvitationRepository is a mock of InvitationRepository interface.
+type MockInvitationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationRepositoryMockRecorder
+}
+
+// MockInvitationRepositoryMockRecorder is the mock recorder for MockInvitationRepository.
+type MockInvitationRepositoryMockRecorder struct {
+	mock *MockInvitationRepository
+}
+
+// NewMockInvitationRepository creates a new mock instance.
+func NewMockInvitationRepository(ctrl *gomock.Controller) *MockInvitationRepository {
+	mock := &MockInvitationRepository{ctrl: ctrl}
+	mock.recorder = &MockInvitationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationRepository) EXPECT() *MockInvitationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockInvitationRepository) Create(ctx context.Context, invitation *domains.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInvitationRepositoryMockRecorder) Create(ctx, invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvitationRepository)(nil).Create), ctx, invitation)
+}
+
+// GetByHash mocks base method.
+func (m *MockInvitationRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domains.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockInvitationRepositoryMockRecorder) GetByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockInvitationRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// GetByID mocks base method.
+func (m *MockInvitationRepository) GetByID(ctx context.Context, id string) (*domains.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domains.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockInvitationRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockInvitationRepository)(nil).GetByID), ctx, id)
+}
+
+// GetPendingByEmail mocks base method.
+func (m *MockInvitationRepository) GetPendingByEmail(ctx context.Context, email string) (*domains.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingByEmail", ctx, email)
+	ret0, _ := ret[0].(*domains.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingByEmail indicates an expected call of GetPendingByEmail.
+func (mr *MockInvitationRepositoryMockRecorder) GetPendingByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingByEmail", reflect.TypeOf((*MockInvitationRepository)(nil).GetPendingByEmail), ctx, email)
+}
+
+// ListPending mocks base method.
+func (m *MockInvitationRepository) ListPending(ctx context.Context, limit, offset int32) ([]*domains.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, limit, offset)
+	ret0, _ := ret[0].([]*domains.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockInvitationRepositoryMockRecorder) ListPending(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockInvitationRepository)(nil).ListPending), ctx, limit, offset)
+}
+
+// Renew mocks base method.
+func (m *MockInvitationRepository) Renew(ctx context.Context, id, tokenHash string, expiresAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, id, tokenHash, expiresAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockInvitationRepositoryMockRecorder) Renew(ctx, id, tokenHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockInvitationRepository)(nil).Renew), ctx, id, tokenHash, expiresAt)
+}
+
+// Respond mocks base method.
+func (m *MockInvitationRepository) Respond(ctx context.Context, id, status string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Respond", ctx, id, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Respond indicates an expected call of Respond.
+func (mr *MockInvitationRepositoryMockRecorder) Respond(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Respond", reflect.TypeOf((*MockInvitationRepository)(nil).Respond), ctx, id, status)
+}
+
+// MockInvitationUsecase is a mock of InvitationUsecase interface.
+type MockInvitationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationUsecaseMockRecorder
+}
+
+// MockInvitationUsecaseMockRecorder is the mock recorder for MockInvitationUsecase.
+type MockInvitationUsecaseMockRecorder struct {
+	mock *MockInvitationUsecase
+}
+
+// NewMockInvitationUsecase creates a new mock instance.
+func NewMockInvitationUsecase(ctrl *gomock.Controller) *MockInvitationUsecase {
+	mock := &MockInvitationUsecase{ctrl: ctrl}
+	mock.recorder = &MockInvitationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationUsecase) EXPECT() *MockInvitationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockInvitationUsecase) AcceptInvitation(ctx context.Context, input *domains.AcceptInvitationInput) (*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, input)
+	ret0, _ := ret[0].(*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockInvitationUsecaseMockRecorder) AcceptInvitation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockInvitationUsecase)(nil).AcceptInvitation), ctx, input)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockInvitationUsecase) CreateInvitation(ctx context.Context, input *domains.CreateInvitationInput) (*domains.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, input)
+	ret0, _ := ret[0].(*domains.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockInvitationUsecaseMockRecorder) CreateInvitation(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockInvitationUsecase)(nil).CreateInvitation), ctx, input)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockInvitationUsecase) DeclineInvitation(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockInvitationUsecaseMockRecorder) DeclineInvitation(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockInvitationUsecase)(nil).DeclineInvitation), ctx, token)
+}
+
+// ListPendingInvitations mocks base method.
+func (m *MockInvitationUsecase) ListPendingInvitations(ctx context.Context, limit, offset int32) ([]*domains.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingInvitations", ctx, limit, offset)
+	ret0, _ := ret[0].([]*domains.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingInvitations indicates an expected call of ListPendingInvitations.
+func (mr *MockInvitationUsecaseMockRecorder) ListPendingInvitations(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingInvitations", reflect.TypeOf((*MockInvitationUsecase)(nil).ListPendingInvitations), ctx, limit, offset)
+}
+
+// ResendInvitation mocks base method.
+func (m *MockInvitationUsecase) ResendInvitation(ctx context.Context, id string) (*domains.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendInvitation", ctx, id)
+	ret0, _ := ret[0].(*domains.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendInvitation indicates an expected call of ResendInvitation.
+func (mr *MockInvitationUsecaseMockRecorder) ResendInvitation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendInvitation", reflect.TypeOf((*MockInvitationUsecase)(nil).ResendInvitation), ctx, id)
+}
+
+// RevokeInvitation mocks base method.
+func (m *MockInvitationUsecase) RevokeInvitation(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvitation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInvitation indicates an expected call of RevokeInvitation.
+func (mr *MockInvitationUsecaseMockRecorder) RevokeInvitation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitation", reflect.TypeOf((*MockInvitationUsecase)(nil).RevokeInvitation), ctx, id)
+}
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: magic_link.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domains "github.com/zercle/template-go-fiber/internal/domains"
+)
+
+// MockMagicLinkTokenRepository is a mock of MagicLinkTokenRepository interface.
+type MockMagicLinkTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMagicLinkTokenRepositoryMockRecorder
+}
+
+// MockMagicLinkTokenRepositoryMockRecorder is the mock recorder for MockMagicLinkTokenRepository.
+type MockMagicLinkTokenRepositoryMockRecorder struct {
+	mock *MockMagicLinkTokenRepository
+}
+
+// NewMockMagicLinkTokenRepository creates a new mock instance.
+func NewMockMagicLinkTokenRepository(ctrl *gomock.Controller) *MockMagicLinkTokenRepository {
+	mock := &MockMagicLinkTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockMagicLinkTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMagicLinkTokenRepository) EXPECT() *MockMagicLinkTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMagicLinkTokenRepository) Create(ctx context.Context, token *domains.MagicLinkToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMagicLinkTokenRepositoryMockRecorder) Create(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMagicLinkTokenRepository)(nil).Create), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockMagicLinkTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domains.MagicLinkToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domains.MagicLinkToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockMagicLinkTokenRepositoryMockRecorder) GetByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockMagicLinkTokenRepository)(nil).GetByHash), ctx, tokenHash)
+}
+
+// GetLatestForUser mocks base method.
+func (m *MockMagicLinkTokenRepository) GetLatestForUser(ctx context.Context, userID string) (*domains.MagicLinkToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestForUser", ctx, userID)
+	ret0, _ := ret[0].(*domains.MagicLinkToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestForUser indicates an expected call of GetLatestForUser.
+func (mr *MockMagicLinkTokenRepositoryMockRecorder) GetLatestForUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestForUser", reflect.TypeOf((*MockMagicLinkTokenRepository)(nil).GetLatestForUser), ctx, userID)
+}
+
+// InvalidateForUser mocks base method.
+func (m *MockMagicLinkTokenRepository) InvalidateForUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateForUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateForUser indicates an expected call of InvalidateForUser.
+func (mr *MockMagicLinkTokenRepositoryMockRecorder) InvalidateForUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateForUser", reflect.TypeOf((*MockMagicLinkTokenRepository)(nil).InvalidateForUser), ctx, userID)
+}
+
+// MarkUsed mocks base method.
+func (m *MockMagicLinkTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockMagicLinkTokenRepositoryMockRecorder) MarkUsed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockMagicLinkTokenRepository)(nil).MarkUsed), ctx, id)
+}
+
+// MockMagicLinkUsecase is a mock of MagicLinkUsecase interface.
+type MockMagicLinkUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMagicLinkUsecaseMockRecorder
+}
+
+// MockMagicLinkUsecaseMockRecorder is the mock recorder for MockMagicLinkUsecase.
+type MockMagicLinkUsecaseMockRecorder struct {
+	mock *MockMagicLinkUsecase
+}
+
+// NewMockMagicLinkUsecase creates a new mock instance.
+func NewMockMagicLinkUsecase(ctrl *gomock.Controller) *MockMagicLinkUsecase {
+	mock := &MockMagicLinkUsecase{ctrl: ctrl}
+	mock.recorder = &MockMagicLinkUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMagicLinkUsecase) EXPECT() *MockMagicLinkUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Login mocks base method.
+func (m *MockMagicLinkUsecase) Login(ctx context.Context, input *domains.MagicLinkLoginInput) (*domains.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, input)
+	ret0, _ := ret[0].(*domains.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockMagicLinkUsecaseMockRecorder) Login(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockMagicLinkUsecase)(nil).Login), ctx, input)
+}
+
+// RequestLink mocks base method.
+func (m *MockMagicLinkUsecase) RequestLink(ctx context.Context, email string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestLink", ctx, email)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestLink indicates an expected call of RequestLink.
+func (mr *MockMagicLinkUsecaseMockRecorder) RequestLink(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestLink", reflect.TypeOf((*MockMagicLinkUsecase)(nil).RequestLink), ctx, email)
+}
@@ -0,0 +1,136 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zercle/template-go-fiber/pkg/password"
+)
+
+func newManager(t *testing.T, algorithm string) *password.Manager {
+	t.Helper()
+
+	manager, err := password.New(password.Options{
+		Algorithm: algorithm,
+		Bcrypt:    password.BcryptOptions{Cost: 4},
+		Argon2id:  password.Argon2idOptions{Time: 1, Memory: 1024, Threads: 1},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return manager
+}
+
+func TestManager_HashAndVerify(t *testing.T) {
+	for _, algorithm := range []string{password.AlgorithmBcrypt, password.AlgorithmArgon2id} {
+		t.Run(algorithm, func(t *testing.T) {
+			manager := newManager(t, algorithm)
+
+			hash, err := manager.Hash("password123")
+			if err != nil {
+				t.Fatalf("Hash failed: %v", err)
+			}
+
+			if password.Identify(hash) != algorithm {
+				t.Errorf("expected %s hash, got %s", algorithm, hash)
+			}
+
+			match, err := manager.Verify("password123", hash)
+			if err != nil || !match {
+				t.Errorf("expected password to match, got match=%v err=%v", match, err)
+			}
+
+			match, err = manager.Verify("wrong-password", hash)
+			if err != nil || match {
+				t.Errorf("expected password mismatch, got match=%v err=%v", match, err)
+			}
+
+			if manager.NeedsRehash(hash) {
+				t.Error("expected fresh hash not to need rehash")
+			}
+		})
+	}
+}
+
+func TestManager_Argon2idEncodesParameters(t *testing.T) {
+	hash, err := newManager(t, password.AlgorithmArgon2id).Hash("password123")
+	if err != nil {
+		t.Fatalf("Hash failed: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("unexpected encoded hash: %s", hash)
+	}
+}
+
+func TestManager_VerifiesOtherAlgorithmAndRequestsRehash(t *testing.T) {
+	bcryptHash, err := newManager(t, password.AlgorithmBcrypt).Hash("password123")
+	if err != nil {
+		t.Fatalf("Hash failed: %v", err)
+	}
+
+	manager := newManager(t, password.AlgorithmArgon2id)
+
+	match, err := manager.Verify("password123", bcryptHash)
+	if err != nil || !match {
+		t.Errorf("expected bcrypt hash to verify, got match=%v err=%v", match, err)
+	}
+
+	if !manager.NeedsRehash(bcryptHash) {
+		t.Error("expected bcrypt hash to need rehash under argon2id")
+	}
+}
+
+func TestManager_NeedsRehashOnCostChange(t *testing.T) {
+	hash, err := newManager(t, password.AlgorithmBcrypt).Hash("password123")
+	if err != nil {
+		t.Fatalf("Hash failed: %v", err)
+	}
+
+	stronger, err := password.New(password.Options{Bcrypt: password.BcryptOptions{Cost: 5}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if !stronger.NeedsRehash(hash) {
+		t.Error("expected hash to need rehash after cost increase")
+	}
+}
+
+func TestManager_RejectsUnknownFormat(t *testing.T) {
+	manager := newManager(t, password.AlgorithmBcrypt)
+
+	match, err := manager.Verify("password123", "$md5$password123")
+	if err != password.ErrUnsupportedHash {
+		t.Errorf("expected ErrUnsupportedHash, got %v", err)
+	}
+
+	if match {
+		t.Error("expected no match for unknown format")
+	}
+}
+
+func TestManager_VerifiesLegacyHashAndRequestsRehash(t *testing.T) {
+	manager := newManager(t, password.AlgorithmBcrypt)
+
+	match, err := manager.Verify("password123", "hashed_password123")
+	if err != nil || !match {
+		t.Errorf("expected legacy hash to verify, got match=%v err=%v", match, err)
+	}
+
+	if match, _ := manager.Verify("password124", "hashed_password123"); match {
+		t.Error("expected wrong password not to match a legacy hash")
+	}
+
+	if !manager.NeedsRehash("hashed_password123") {
+		t.Error("expected legacy hash to need rehash")
+	}
+}
+
+func TestNew_UnsupportedAlgorithm(t *testing.T) {
+	for _, algorithm := range []string{"md5", password.AlgorithmLegacy} {
+		if _, err := password.New(password.Options{Algorithm: algorithm}); err == nil {
+			t.Errorf("expected error for unsupported algorithm %s", algorithm)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/zercle/template-go-fiber/pkg/totp"
+)
+
+// rfcSecret is the SHA1 test key from RFC 6238 appendix B
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8 digit codes; 6 digit codes are their last six digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := totp.Code(rfcSecret, totp.Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code failed: %v", err)
+		}
+		if code != tt.code {
+			t.Errorf("at %d: expected %s, got %s", tt.unix, tt.code, code)
+		}
+	}
+}
+
+func TestValidate_AllowsSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	previous, _ := totp.Code(rfcSecret, totp.Step(now)-1)
+
+	step, ok := totp.Validate(rfcSecret, previous, now, 1)
+	if !ok || step != totp.Step(now)-1 {
+		t.Errorf("expected previous step to validate, got %d %v", step, ok)
+	}
+
+	if _, ok := totp.Validate(rfcSecret, previous, now, 0); ok {
+		t.Error("expected previous step to be rejected without skew")
+	}
+
+	if _, ok := totp.Validate(rfcSecret, "12345", now, 1); ok {
+		t.Error("expected short code to be rejected")
+	}
+}
+
+func TestGenerateSecret_RoundTrips(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret failed: %v", err)
+	}
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("Code failed: %v", err)
+	}
+
+	if _, ok := totp.Validate(secret, code, time.Now(), 1); !ok {
+		t.Error("expected generated secret to validate its own code")
+	}
+
+	if _, err := totp.Code("not base32!", 1); err == nil {
+		t.Error("expected invalid secret to be rejected")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(totp.URI("Example", "user@example.com", "JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("invalid URI: %v", err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Example:user@example.com" {
+		t.Errorf("unexpected URI: %s", uri)
+	}
+
+	if uri.Query().Get("secret") != "JBSWY3DPEHPK3PXP" || uri.Query().Get("issuer") != "Example" {
+		t.Errorf("unexpected query: %s", uri.RawQuery)
+	}
+}
@@ -0,0 +1,394 @@
+package usecases_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testAPIKeyConfig = usecases.APIKeyConfig{LastUsedInterval: time.Minute}
+
+func TestCreateAPIKey_ReturnsKeyOnceAndStoresHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockKeys := mocks.NewMockAPIKeyRepository(ctrl)
+	usecase := usecases.NewAPIKeyUsecase(mockRepo, mockKeys, mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", IsActive: true}, nil).
+		Times(1)
+
+	var stored *domains.APIKey
+	mockKeys.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, key *domains.APIKey) error {
+			stored = key
+			return nil
+		}).
+		Times(1)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	issued, err := usecase.CreateAPIKey(ctx, &domains.CreateAPIKeyInput{
+		UserID: "123",
+		Name:   "batch",
+		Scopes: []string{domains.PermissionSelfRead, domains.PermissionSelfRead},
+	})
+	if err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+
+	prefix, secret, ok := strings.Cut(issued.Key, ".")
+	if !ok || !strings.HasPrefix(prefix, "ak_") || secret == "" {
+		t.Fatalf("unexpected key format: %s", issued.Key)
+	}
+
+	if stored.Prefix != prefix {
+		t.Errorf("expected stored prefix %s, got %s", prefix, stored.Prefix)
+	}
+
+	if stored.SecretHash == "" || strings.Contains(stored.SecretHash, secret) {
+		t.Error("expected only a hash of the secret to be stored")
+	}
+
+	if !slices.Equal(stored.Scopes, []string{domains.PermissionSelfRead}) {
+		t.Errorf("expected deduplicated scopes, got %v", stored.Scopes)
+	}
+}
+
+func TestCreateAPIKey_ScopeNotHeldByCaller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewAPIKeyUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockAPIKeyRepository(ctrl), mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	_, err := usecase.CreateAPIKey(ctx, &domains.CreateAPIKeyInput{
+		UserID: "123",
+		Name:   "batch",
+		Scopes: []string{domains.PermissionUsersDelete},
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestCreateAPIKey_ForOtherUserRequiresUsersWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewAPIKeyUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockAPIKeyRepository(ctrl), mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	_, err := usecase.CreateAPIKey(ctx, &domains.CreateAPIKeyInput{
+		UserID: "456",
+		Name:   "batch",
+		Scopes: []string{domains.PermissionSelfRead},
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestCreateAPIKey_RejectedWhenAuthenticatedWithAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewAPIKeyUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockAPIKeyRepository(ctrl), mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+		APIKeyID:    "key-1",
+	})
+
+	_, err := usecase.CreateAPIKey(ctx, &domains.CreateAPIKeyInput{
+		UserID: "123",
+		Name:   "batch",
+		Scopes: []string{domains.PermissionSelfRead},
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestAuthenticateAPIKey_LimitsScopesToOwnerPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockKeys := mocks.NewMockAPIKeyRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewAPIKeyUsecase(mockRepo, mockKeys, mockRoles, testAPIKeyConfig)
+
+	key := issueTestAPIKey(t, ctrl, []string{domains.PermissionUsersRead, domains.PermissionSelfRead}, nil)
+
+	mockKeys.EXPECT().GetByPrefix(gomock.Any(), key.apiKey.Prefix).Return(key.apiKey, nil).Times(1)
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+	mockRoles.EXPECT().GetUserRoles(gomock.Any(), "123").Return([]string{domains.RoleUser}, nil).Times(1)
+	mockRoles.EXPECT().
+		GetUserPermissions(gomock.Any(), "123").
+		Return([]string{domains.PermissionSelfRead, domains.PermissionSelfWrite}, nil).
+		Times(1)
+	mockKeys.EXPECT().TouchLastUsed(gomock.Any(), key.apiKey.ID, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	principal, err := usecase.Authenticate(context.Background(), key.value)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+
+	if principal.UserID != "123" || principal.APIKeyID != key.apiKey.ID {
+		t.Errorf("unexpected principal: %+v", principal)
+	}
+
+	if !slices.Equal(principal.Permissions, []string{domains.PermissionSelfRead}) {
+		t.Errorf("expected only scopes the owner holds, got %v", principal.Permissions)
+	}
+}
+
+func TestAuthenticateAPIKey_ServiceAccountHoldsScopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockKeys := mocks.NewMockAPIKeyRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewAPIKeyUsecase(mockRepo, mockKeys, mockRoles, testAPIKeyConfig)
+
+	key := issueTestAPIKey(t, ctrl, []string{domains.PermissionUsersRead}, nil)
+
+	mockKeys.EXPECT().GetByPrefix(gomock.Any(), key.apiKey.Prefix).Return(key.apiKey, nil).Times(1)
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", IsActive: true, IsServiceAccount: true}, nil).
+		Times(1)
+	mockRoles.EXPECT().GetUserRoles(gomock.Any(), "123").Return(nil, nil).Times(1)
+	mockKeys.EXPECT().TouchLastUsed(gomock.Any(), key.apiKey.ID, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	principal, err := usecase.Authenticate(context.Background(), key.value)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+
+	if !slices.Equal(principal.Permissions, []string{domains.PermissionUsersRead}) {
+		t.Errorf("expected the key scopes, got %v", principal.Permissions)
+	}
+}
+
+func TestAuthenticateAPIKey_Rejected(t *testing.T) {
+	expired := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		tamper    bool
+		owner     *domains.User
+	}{
+		{"wrong secret", nil, true, nil},
+		{"expired", &expired, false, nil},
+		{"inactive owner", nil, false, &domains.User{ID: "123", IsActive: false}},
+		{"deleted owner", nil, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockKeys := mocks.NewMockAPIKeyRepository(ctrl)
+			usecase := usecases.NewAPIKeyUsecase(mockRepo, mockKeys, mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+			key := issueTestAPIKey(t, ctrl, []string{domains.PermissionSelfRead}, tt.expiresAt)
+			value := key.value
+			if tt.tamper {
+				value += "x"
+			}
+
+			mockKeys.EXPECT().GetByPrefix(gomock.Any(), key.apiKey.Prefix).Return(key.apiKey, nil).Times(1)
+			if !tt.tamper && tt.expiresAt == nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), "123").Return(tt.owner, nil).Times(1)
+			}
+
+			_, err := usecase.Authenticate(context.Background(), value)
+
+			assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+		})
+	}
+}
+
+func TestAuthenticateAPIKey_MalformedKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewAPIKeyUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockAPIKeyRepository(ctrl), mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	for _, value := range []string{"", "not-a-key", "ak_123.", "xx_123.secret"} {
+		_, err := usecase.Authenticate(context.Background(), value)
+		assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+	}
+}
+
+func TestDeleteAPIKey_OtherUsersKeyForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockKeys := mocks.NewMockAPIKeyRepository(ctrl)
+	usecase := usecases.NewAPIKeyUsecase(mockRepo, mockKeys, mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	mockKeys.EXPECT().GetByID(gomock.Any(), "key-1").Return(&domains.APIKey{ID: "key-1", UserID: "456"}, nil).Times(1)
+	mockRepo.EXPECT().GetByID(gomock.Any(), "456").Return(&domains.User{ID: "456", IsActive: true}, nil).Times(1)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	err := usecase.DeleteAPIKey(ctx, "key-1")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestDeleteAPIKey_OtherTenantsKeyNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockKeys := mocks.NewMockAPIKeyRepository(ctrl)
+	usecase := usecases.NewAPIKeyUsecase(mockRepo, mockKeys, mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	// The owner is not visible from the caller's tenant
+	mockKeys.EXPECT().GetByID(gomock.Any(), "key-1").Return(&domains.APIKey{ID: "key-1", UserID: "456"}, nil).Times(1)
+	mockRepo.EXPECT().GetByID(gomock.Any(), "456").Return(nil, nil).Times(1)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Permissions: []string{domains.PermissionUsersWrite},
+	})
+
+	err := usecase.DeleteAPIKey(ctx, "key-1")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestCreateServiceAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewAPIKeyUsecase(mockRepo, mocks.NewMockAPIKeyRepository(ctrl), mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	mockRepo.EXPECT().GetByEmail(gomock.Any(), "export@example.com").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, user *domains.User) error {
+			if !user.IsServiceAccount || user.PasswordHash != "" {
+				t.Errorf("expected a service account without a password, got %+v", user)
+			}
+			return nil
+		}).
+		Times(1)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin",
+		Permissions: []string{domains.PermissionUsersWrite},
+	})
+
+	user, err := usecase.CreateServiceAccount(ctx, &domains.CreateServiceAccountInput{Email: "export@example.com", Name: "Export"})
+	if err != nil {
+		t.Fatalf("CreateServiceAccount failed: %v", err)
+	}
+
+	if user.FirstName == nil || *user.FirstName != "Export" {
+		t.Errorf("expected the name to be stored, got %v", user.FirstName)
+	}
+}
+
+func TestCreateServiceAccount_RequiresUsersWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewAPIKeyUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockAPIKeyRepository(ctrl), mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfWrite},
+	})
+
+	_, err := usecase.CreateServiceAccount(ctx, &domains.CreateServiceAccountInput{Email: "export@example.com"})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestCreateServiceAccount_RejectedWhileImpersonating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewAPIKeyUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockAPIKeyRepository(ctrl), mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		ActorID:     "admin-1",
+		Permissions: []string{domains.PermissionUsersWrite},
+	})
+
+	_, err := usecase.CreateServiceAccount(ctx, &domains.CreateServiceAccountInput{Email: "export@example.com"})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+// testAPIKey is a key owned by user 123 together with the value a client sends
+type testAPIKey struct {
+	apiKey *domains.APIKey
+	value  string
+}
+
+// issueTestAPIKey creates a key through the usecase so tests authenticate
+// with a real value and the hash derived from it
+func issueTestAPIKey(t *testing.T, ctrl *gomock.Controller, scopes []string, expiresAt *time.Time) testAPIKey {
+	t.Helper()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockKeys := mocks.NewMockAPIKeyRepository(ctrl)
+	usecase := usecases.NewAPIKeyUsecase(mockRepo, mockKeys, mocks.NewMockRoleRepository(ctrl), testAPIKeyConfig)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), "123").Return(&domains.User{ID: "123", IsActive: true}, nil).Times(1)
+	mockKeys.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	issued, err := usecase.CreateAPIKey(context.Background(), &domains.CreateAPIKeyInput{
+		UserID: "123",
+		Name:   "test",
+		Scopes: scopes,
+	})
+	if err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+
+	// Set afterwards, as keys cannot be created already expired
+	issued.APIKey.ExpiresAt = expiresAt
+
+	return testAPIKey{apiKey: issued.APIKey, value: issued.Key}
+}
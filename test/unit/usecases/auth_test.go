@@ -0,0 +1,1130 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/pkg/password"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+func TestLogin_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordSuccess(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	existingUser := &domains.User{
+		ID:           "123",
+		Email:        "user@example.com",
+		PasswordHash: mustHash(t, hasher, "password123"),
+		IsActive:     true,
+	}
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(existingUser, nil).
+		Times(1)
+
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(nil, nil).
+		Times(1)
+
+	mockRoles.EXPECT().
+		GetUserRoles(gomock.Any(), "123").
+		Return([]string{domains.RoleUser}, nil).
+		Times(1)
+
+	mockRoles.EXPECT().
+		GetUserPermissions(gomock.Any(), "123").
+		Return([]string{domains.PermissionSelfRead, domains.PermissionSelfWrite}, nil).
+		Times(1)
+
+	mockIssuer.EXPECT().
+		Issue(&domains.IssueTokenInput{
+			UserID:      "123",
+			Email:       "user@example.com",
+			Roles:       []string{domains.RoleUser},
+			Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+		}).
+		Return(&domains.AuthToken{AccessToken: "token", TokenType: "Bearer", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+
+	var stored *domains.RefreshToken
+	mockRefresh.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *domains.RefreshToken) error {
+			stored = token
+			return nil
+		}).
+		Times(1)
+
+	token, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "password123",
+	})
+
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	if token.AccessToken != "token" {
+		t.Errorf("expected access token %s, got %s", "token", token.AccessToken)
+	}
+
+	if token.RefreshToken == "" {
+		t.Fatal("expected refresh token")
+	}
+
+	if stored.UserID != "123" || stored.FamilyID == "" || stored.TokenHash == token.RefreshToken {
+		t.Errorf("unexpected stored refresh token: %+v", stored)
+	}
+}
+
+func TestLogin_WrongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordFailure(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: mustHash(t, hasher, "password123"), IsActive: true}, nil).
+		Times(1)
+
+	token, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "wrong-password",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+
+	if token != nil {
+		t.Fatal("expected nil token")
+	}
+}
+
+func TestLogin_UnknownEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "missing@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordFailure(gomock.Any(), "missing@example.com", gomock.Any()).Return(nil).Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "missing@example.com").
+		Return(nil, nil).
+		Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "missing@example.com",
+		Password: "password123",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestLogin_InactiveUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: mustHash(t, hasher, "password123"), IsActive: false}, nil).
+		Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "password123",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestLogin_UnverifiedEmailRejectedWhenRequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	hasher := newTestHasher(t)
+	config := testAuthConfig
+	config.RequireVerifiedEmail = true
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, config)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: mustHash(t, hasher, "password123"), IsActive: true}, nil).
+		Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "password123",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestLogin_SoftDeletedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordFailure(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	deletedAt := time.Now()
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: mustHash(t, hasher, "password123"), IsActive: true, DeletedAt: &deletedAt}, nil).
+		Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "password123",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestLogin_ServiceAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "batch@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordFailure(gomock.Any(), "batch@example.com", gomock.Any()).Return(nil).Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "batch@example.com").
+		Return(&domains.User{ID: "123", Email: "batch@example.com", IsActive: true, IsServiceAccount: true}, nil).
+		Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "batch@example.com",
+		Password: "password123",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestLogin_MissingCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, testAuthConfig)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{Email: "user@example.com"})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+}
+
+func TestLogin_RehashesOutdatedHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+
+	// Stored with bcrypt, but the service is now configured for argon2id
+	legacyHash := mustHash(t, newTestHasher(t), "password123")
+	hasher, err := password.New(password.Options{
+		Algorithm: password.AlgorithmArgon2id,
+		Argon2id:  password.Argon2idOptions{Time: 1, Memory: 1024, Threads: 1},
+	})
+	if err != nil {
+		t.Fatalf("failed to create hasher: %v", err)
+	}
+
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordSuccess(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: legacyHash, IsActive: true}, nil).
+		Times(1)
+
+	// Only the hash is replaced, so the version and ETag stay as they are
+	mockRepo.EXPECT().
+		UpdatePasswordHash(gomock.Any(), "123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, passwordHash string) error {
+			if password.Identify(passwordHash) != password.AlgorithmArgon2id {
+				t.Errorf("expected argon2id rehash, got %s", passwordHash)
+			}
+			return nil
+		}).
+		Times(1)
+
+	mockMFA.EXPECT().GetFactor(gomock.Any(), "123").Return(nil, nil).Times(1)
+
+	expectNoRoles(mockRoles)
+
+	mockIssuer.EXPECT().
+		Issue(gomock.Any()).
+		Return(&domains.AuthToken{AccessToken: "token"}, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	_, err = usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "password123",
+	})
+
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+}
+
+func TestLogin_LegacyHashUpgraded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordSuccess(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	// Accounts created before real hashing still hold the placeholder format
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: "hashed_password123", IsActive: true}, nil).
+		Times(1)
+
+	// Only the hash is replaced, so the version and ETag stay as they are
+	mockRepo.EXPECT().
+		UpdatePasswordHash(gomock.Any(), "123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, passwordHash string) error {
+			if password.Identify(passwordHash) != password.AlgorithmBcrypt {
+				t.Errorf("expected bcrypt rehash, got %s", passwordHash)
+			}
+			return nil
+		}).
+		Times(1)
+
+	mockMFA.EXPECT().GetFactor(gomock.Any(), "123").Return(nil, nil).Times(1)
+
+	expectNoRoles(mockRoles)
+
+	mockIssuer.EXPECT().
+		Issue(gomock.Any()).
+		Return(&domains.AuthToken{AccessToken: "token"}, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "password123",
+	})
+
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+}
+
+func TestLogin_UnsupportedHashIsInvalidCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+
+	usecase := usecases.NewAuthUsecase(mockRepo, mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockMFARepository(ctrl), mockLockout, newTestHasher(t), mocks.NewMockTokenIssuer(ctrl), nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordFailure(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: "$md5$password123", IsActive: true}, nil).
+		Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "password123",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestLogin_LockedOutBeforePasswordCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	// The user is never looked up, so even the right password gets no answer
+	mockLockout.EXPECT().
+		Check(gomock.Any(), "user@example.com", "10.0.0.1").
+		Return(errors.NewTooManyAttemptsError("too many failed login attempts, try again later", time.Minute)).
+		Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:     "user@example.com",
+		Password:  "password123",
+		IPAddress: "10.0.0.1",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeTooManyAttempts)
+}
+
+func TestLogin_MFAEnabledReturnsChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, hasher, mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	confirmedAt := time.Now()
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: mustHash(t, hasher, "password123"), IsActive: true}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil).
+		Times(1)
+
+	var stored *domains.MFAChallenge
+	mockMFA.EXPECT().
+		CreateChallenge(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, challenge *domains.MFAChallenge) error {
+			stored = challenge
+			return nil
+		}).
+		Times(1)
+
+	token, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:    "user@example.com",
+		Password: "password123",
+	})
+
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	if token.AccessToken != "" || token.RefreshToken != "" {
+		t.Error("expected no tokens before the second factor")
+	}
+
+	if token.MFAToken == "" || stored.TokenHash == token.MFAToken || stored.UserID != "123" {
+		t.Errorf("unexpected MFA challenge: %+v", stored)
+	}
+}
+
+func TestVerifyMFA_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordSuccess(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	confirmedAt := time.Now()
+	mockMFA.EXPECT().
+		GetChallengeByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.MFAChallenge{ID: "challenge-1", UserID: "123", TenantID: "tenant-2", ExpiresAt: time.Now().Add(time.Minute)}, nil).
+		Times(1)
+
+	// The second step need not name the tenant of the first one
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		DoAndReturn(userInTenant(&domains.User{ID: "123", TenantID: "tenant-2", Email: "user@example.com", IsActive: true})).
+		Times(1)
+
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().IncrementChallengeAttempts(gomock.Any(), "challenge-1", 5).Return(true, nil).Times(1)
+	mockMFA.EXPECT().AdvanceStep(gomock.Any(), "123", gomock.Any()).Return(true, nil).Times(1)
+	mockMFA.EXPECT().MarkChallengeUsed(gomock.Any(), "challenge-1").Return(true, nil).Times(1)
+
+	expectNoRoles(mockRoles)
+
+	mockIssuer.EXPECT().
+		Issue(gomock.Any()).
+		Return(&domains.AuthToken{AccessToken: "token"}, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	token, err := usecase.VerifyMFA(context.Background(), &domains.MFALoginInput{
+		MFAToken: "mfa-token",
+		Code:     currentTOTPCode(t),
+	})
+
+	if err != nil {
+		t.Fatalf("VerifyMFA failed: %v", err)
+	}
+
+	if token.AccessToken != "token" || token.RefreshToken == "" {
+		t.Errorf("expected a token pair, got %+v", token)
+	}
+}
+
+func TestVerifyMFA_WrongCodeCountsAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordFailure(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	confirmedAt := time.Now()
+	mockMFA.EXPECT().
+		GetChallengeByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.MFAChallenge{ID: "challenge-1", UserID: "123", ExpiresAt: time.Now().Add(time.Minute)}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().IncrementChallengeAttempts(gomock.Any(), "challenge-1", 5).Return(true, nil).Times(1)
+	mockMFA.EXPECT().UseRecoveryCode(gomock.Any(), "123", gomock.Any()).Return(false, nil).Times(1)
+
+	_, err := usecase.VerifyMFA(context.Background(), &domains.MFALoginInput{
+		MFAToken: "mfa-token",
+		Code:     "aaaaa-bbbbb",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestVerifyMFA_AttemptsExhausted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockMFA.EXPECT().
+		GetChallengeByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.MFAChallenge{ID: "challenge-1", UserID: "123", Attempts: 5, ExpiresAt: time.Now().Add(time.Minute)}, nil).
+		Times(1)
+
+	_, err := usecase.VerifyMFA(context.Background(), &domains.MFALoginInput{
+		MFAToken: "mfa-token",
+		Code:     currentTOTPCode(t),
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestVerifyMFA_AttemptsExhaustedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+
+	// The challenge still had attempts left when it was read, but parallel
+	// requests used them up before this one was counted
+	confirmedAt := time.Now()
+	mockMFA.EXPECT().
+		GetChallengeByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.MFAChallenge{ID: "challenge-1", UserID: "123", Attempts: 4, ExpiresAt: time.Now().Add(time.Minute)}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().IncrementChallengeAttempts(gomock.Any(), "challenge-1", 5).Return(false, nil).Times(1)
+
+	_, err := usecase.VerifyMFA(context.Background(), &domains.MFALoginInput{
+		MFAToken: "mfa-token",
+		Code:     currentTOTPCode(t),
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockRefresh.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.RefreshToken{ID: "rt-1", UserID: "123", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		MarkUsed(gomock.Any(), "rt-1").
+		Return(true, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	expectNoRoles(mockRoles)
+
+	mockIssuer.EXPECT().
+		Issue(gomock.Any()).
+		Return(&domains.AuthToken{AccessToken: "token"}, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *domains.RefreshToken) error {
+			if token.FamilyID != "family-1" {
+				t.Errorf("expected rotated token to stay in family-1, got %s", token.FamilyID)
+			}
+			return nil
+		}).
+		Times(1)
+
+	token, err := usecase.Refresh(context.Background(), "opaque-refresh-token")
+
+	if err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	if token.RefreshToken == "" || token.RefreshToken == "opaque-refresh-token" {
+		t.Errorf("expected a new refresh token, got %q", token.RefreshToken)
+	}
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	usedAt := time.Now().Add(-time.Minute)
+	mockRefresh.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.RefreshToken{ID: "rt-1", UserID: "123", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		RevokeFamily(gomock.Any(), "family-1").
+		Return(nil).
+		Times(1)
+
+	_, err := usecase.Refresh(context.Background(), "opaque-refresh-token")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestRefresh_UsesTenantOfToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockRefresh.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.RefreshToken{ID: "rt-1", UserID: "123", TenantID: "tenant-2", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+
+	// The user is looked up in the tenant of the token, not the one of the request
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		DoAndReturn(userInTenant(&domains.User{ID: "123", TenantID: "tenant-2", Email: "user@example.com", IsActive: true})).
+		Times(1)
+
+	mockRefresh.EXPECT().MarkUsed(gomock.Any(), "rt-1").Return(true, nil).Times(1)
+
+	expectNoRoles(mockRoles)
+
+	mockIssuer.EXPECT().
+		Issue(gomock.Any()).
+		DoAndReturn(func(input *domains.IssueTokenInput) (*domains.AuthToken, error) {
+			if input.TenantID != "tenant-2" {
+				t.Errorf("expected an access token for tenant-2, got %q", input.TenantID)
+			}
+			return &domains.AuthToken{AccessToken: "token"}, nil
+		}).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *domains.RefreshToken) error {
+			if token.TenantID != "tenant-2" {
+				t.Errorf("expected the rotated token to stay in tenant-2, got %q", token.TenantID)
+			}
+			return nil
+		}).
+		Times(1)
+
+	// Without a tenant header, requests are scoped to the default tenant
+	ctx := domains.WithTenant(context.Background(), domains.DefaultTenantID)
+
+	if _, err := usecase.Refresh(ctx, "opaque-refresh-token"); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+}
+
+func TestRefresh_ConcurrentRotationRevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockRefresh.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.RefreshToken{ID: "rt-1", UserID: "123", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		MarkUsed(gomock.Any(), "rt-1").
+		Return(false, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		RevokeFamily(gomock.Any(), "family-1").
+		Return(nil).
+		Times(1)
+
+	_, err := usecase.Refresh(context.Background(), "opaque-refresh-token")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestRefresh_ExpiredToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockRefresh.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.RefreshToken{ID: "rt-1", UserID: "123", FamilyID: "family-1", ExpiresAt: time.Now().Add(-time.Minute)}, nil).
+		Times(1)
+
+	_, err := usecase.Refresh(context.Background(), "opaque-refresh-token")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestLogout_RevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockRefresh.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.RefreshToken{ID: "rt-1", UserID: "123", FamilyID: "family-1"}, nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		RevokeFamily(gomock.Any(), "family-1").
+		Return(nil).
+		Times(1)
+
+	expiresAt := time.Now().Add(time.Hour)
+	mockRevocations.EXPECT().
+		RevokeToken(gomock.Any(), "jti-1", expiresAt).
+		Return(nil).
+		Times(1)
+
+	err := usecase.Logout(context.Background(), &domains.LogoutInput{
+		RefreshToken:         "opaque-refresh-token",
+		AccessTokenID:        "jti-1",
+		AccessTokenExpiresAt: expiresAt,
+	})
+
+	if err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+}
+
+func TestLogout_RequiresToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mockRepo, mockRefresh, mockRevocations, mockRoles, mockMFA, mockLockout, newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	err := usecase.Logout(context.Background(), &domains.LogoutInput{})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+}
+
+func TestLogin_StartSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockLockout := mocks.NewMockLockoutUsecase(ctrl)
+	mockSessions := mocks.NewMockSessionUsecase(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewAuthUsecase(mockRepo, mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRoleRepository(ctrl), mockMFA, mockLockout, hasher, mocks.NewMockTokenIssuer(ctrl), mockSessions, testAuthConfig)
+
+	user := &domains.User{
+		ID:           "123",
+		Email:        "user@example.com",
+		PasswordHash: mustHash(t, hasher, "password123"),
+		IsActive:     true,
+	}
+
+	mockLockout.EXPECT().Check(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockLockout.EXPECT().RecordSuccess(gomock.Any(), "user@example.com", gomock.Any()).Return(nil).Times(1)
+	mockRepo.EXPECT().GetByEmail(gomock.Any(), "user@example.com").Return(user, nil).Times(1)
+	mockMFA.EXPECT().GetFactor(gomock.Any(), "123").Return(nil, nil).Times(1)
+
+	// No access or refresh token is issued for a session login
+	issued := &domains.IssuedSession{
+		Session: &domains.Session{ID: "hash", UserID: "123", ExpiresAt: time.Now().Add(30 * time.Minute)},
+		Token:   "session-token",
+	}
+	mockSessions.EXPECT().Start(gomock.Any(), user).Return(issued, nil).Times(1)
+
+	token, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:        "user@example.com",
+		Password:     "password123",
+		StartSession: true,
+	})
+
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	if token.Session != issued || token.AccessToken != "" || token.RefreshToken != "" {
+		t.Errorf("expected only a session, got %+v", token)
+	}
+}
+
+func TestLogin_SessionsDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewAuthUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockMFARepository(ctrl), mocks.NewMockLockoutUsecase(ctrl), newTestHasher(t), mocks.NewMockTokenIssuer(ctrl), nil, testAuthConfig)
+
+	_, err := usecase.Login(context.Background(), &domains.LoginInput{
+		Email:        "user@example.com",
+		Password:     "password123",
+		StartSession: true,
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+}
+
+func TestLoginWithoutPassword_IssuesTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+	usecase := usecases.NewAuthUsecase(mocks.NewMockUserRepository(ctrl), mockRefresh, mocks.NewMockTokenRevocationStore(ctrl), mockRoles, mockMFA, mocks.NewMockLockoutUsecase(ctrl), newTestHasher(t), mockIssuer, nil, testAuthConfig)
+
+	mockMFA.EXPECT().GetFactor(gomock.Any(), "123").Return(nil, nil).Times(1)
+	expectNoRoles(mockRoles)
+	mockIssuer.EXPECT().
+		Issue(gomock.Any()).
+		Return(&domains.AuthToken{AccessToken: "token", TokenType: "Bearer", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+	mockRefresh.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	// No password is checked and no lockout counter is touched
+	token, err := usecase.LoginWithoutPassword(context.Background(), &domains.User{ID: "123", Email: "user@example.com", IsActive: true})
+	if err != nil {
+		t.Fatalf("LoginWithoutPassword failed: %v", err)
+	}
+
+	if token.AccessToken != "token" || token.RefreshToken == "" {
+		t.Errorf("expected a token pair, got %+v", token)
+	}
+}
+
+func TestLoginWithoutPassword_RejectsUsers(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name string
+		user *domains.User
+		code errors.ErrorCode
+	}{
+		{"deleted", &domains.User{ID: "123", IsActive: true, DeletedAt: &deletedAt}, errors.ErrCodeUnauthorized},
+		{"service account", &domains.User{ID: "123", IsActive: true, IsServiceAccount: true}, errors.ErrCodeUnauthorized},
+		{"inactive", &domains.User{ID: "123"}, errors.ErrCodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := usecases.NewAuthUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockMFARepository(ctrl), mocks.NewMockLockoutUsecase(ctrl), newTestHasher(t), mocks.NewMockTokenIssuer(ctrl), nil, testAuthConfig)
+
+			_, err := usecase.LoginWithoutPassword(context.Background(), tt.user)
+			assertAPIErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+// Helper functions
+var testAuthConfig = usecases.AuthConfig{RefreshTokenTTL: 24 * time.Hour, MFAChallengeTTL: 5 * time.Minute}
+
+// expectNoRoles stubs the role lookup done for every issued token
+// userInTenant stubs GetByID so that it only finds user in the tenant it belongs to
+func userInTenant(user *domains.User) func(context.Context, string) (*domains.User, error) {
+	return func(ctx context.Context, id string) (*domains.User, error) {
+		if tenantID, _ := domains.TenantFromContext(ctx); tenantID != user.TenantID || id != user.ID {
+			return nil, nil
+		}
+		return user, nil
+	}
+}
+
+func expectNoRoles(roles *mocks.MockRoleRepository) {
+	roles.EXPECT().GetUserRoles(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	roles.EXPECT().GetUserPermissions(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+}
+
+func mustHash(t *testing.T, hasher password.Hasher, plain string) string {
+	t.Helper()
+
+	hash, err := hasher.Hash(plain)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	return hash
+}
+
+func assertAPIErrorCode(t *testing.T, err error, code errors.ErrorCode) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("expected %s error, got nil", code)
+	}
+
+	apiErr, ok := err.(*errors.APIError)
+	if !ok {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+
+	if apiErr.Code != code {
+		t.Errorf("expected code %s, got %s", code, apiErr.Code)
+	}
+}
@@ -0,0 +1,281 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testVerificationConfig = usecases.EmailVerificationConfig{
+	TokenTTL:       time.Hour,
+	ResendInterval: time.Minute,
+	URL:            "https://app.example.com/verify-email",
+}
+
+func TestSendVerification_MailsSingleUseLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockEmailVerificationTokenRepository(ctrl)
+	mockSender := mocks.NewMockMailSender(ctrl)
+	usecase := usecases.NewEmailVerificationUsecase(mockRepo, mockTokens, mockSender, slog.New(slog.DiscardHandler), testVerificationConfig)
+
+	// Earlier links stop working
+	mockTokens.EXPECT().
+		InvalidateForUser(gomock.Any(), "123").
+		Return(nil).
+		Times(1)
+
+	var stored *domains.EmailVerificationToken
+	mockTokens.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *domains.EmailVerificationToken) error {
+			stored = token
+			return nil
+		}).
+		Times(1)
+
+	var sent *domains.MailMessage
+	mockSender.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, message *domains.MailMessage) error {
+			sent = message
+			return nil
+		}).
+		Times(1)
+
+	err := usecase.SendVerification(context.Background(), &domains.User{ID: "123", Email: "user@example.com"})
+
+	if err != nil {
+		t.Fatalf("SendVerification failed: %v", err)
+	}
+
+	if sent.To != "user@example.com" {
+		t.Errorf("expected mail to user@example.com, got %s", sent.To)
+	}
+
+	token := tokenFromMail(t, sent)
+	sum := sha256.Sum256([]byte(token))
+
+	if stored.UserID != "123" || stored.TokenHash != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected the hash of the mailed token to be stored, got %+v", stored)
+	}
+
+	if time.Until(stored.ExpiresAt) > time.Hour || time.Until(stored.ExpiresAt) < 59*time.Minute {
+		t.Errorf("expected expiry about one hour from now, got %v", stored.ExpiresAt)
+	}
+}
+
+func TestVerifyEmail_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockEmailVerificationTokenRepository(ctrl)
+	usecase := usecases.NewEmailVerificationUsecase(mockRepo, mockTokens, mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testVerificationConfig)
+
+	mockTokens.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.EmailVerificationToken{ID: "vt-1", UserID: "123", TenantID: "tenant-2", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+
+	// The link need not name the tenant of the user
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		DoAndReturn(userInTenant(&domains.User{ID: "123", TenantID: "tenant-2", Email: "user@example.com", IsActive: true})).
+		Times(1)
+
+	mockTokens.EXPECT().
+		MarkUsed(gomock.Any(), "vt-1").
+		Return(true, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		MarkEmailVerified(gomock.Any(), "123", gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	if err := usecase.VerifyEmail(context.Background(), "opaque-token"); err != nil {
+		t.Fatalf("VerifyEmail failed: %v", err)
+	}
+}
+
+func TestVerifyEmail_RejectsUnusableTokens(t *testing.T) {
+	usedAt := time.Now()
+
+	tests := []struct {
+		name  string
+		token *domains.EmailVerificationToken
+	}{
+		{"unknown", nil},
+		{"expired", &domains.EmailVerificationToken{ID: "vt-1", UserID: "123", ExpiresAt: time.Now().Add(-time.Minute)}},
+		{"used", &domains.EmailVerificationToken{ID: "vt-1", UserID: "123", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTokens := mocks.NewMockEmailVerificationTokenRepository(ctrl)
+			usecase := usecases.NewEmailVerificationUsecase(mocks.NewMockUserRepository(ctrl), mockTokens, mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testVerificationConfig)
+
+			mockTokens.EXPECT().
+				GetByHash(gomock.Any(), gomock.Any()).
+				Return(tt.token, nil).
+				Times(1)
+
+			err := usecase.VerifyEmail(context.Background(), "opaque-token")
+
+			assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+		})
+	}
+}
+
+func TestVerifyEmail_ConcurrentUseRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockEmailVerificationTokenRepository(ctrl)
+	usecase := usecases.NewEmailVerificationUsecase(mockRepo, mockTokens, mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testVerificationConfig)
+
+	mockTokens.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.EmailVerificationToken{ID: "vt-1", UserID: "123", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	// Another request consumed the token between the lookup and the update
+	mockTokens.EXPECT().
+		MarkUsed(gomock.Any(), "vt-1").
+		Return(false, nil).
+		Times(1)
+
+	err := usecase.VerifyEmail(context.Background(), "opaque-token")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+}
+
+func TestResendVerification_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockEmailVerificationTokenRepository(ctrl)
+	usecase := usecases.NewEmailVerificationUsecase(mockRepo, mockTokens, mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testVerificationConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockTokens.EXPECT().
+		GetLatestForUser(gomock.Any(), "123").
+		Return(&domains.EmailVerificationToken{ID: "vt-1", CreatedAt: time.Now().Add(-10 * time.Second)}, nil).
+		Times(1)
+
+	// No new token and no mail within the resend interval
+	if err := usecase.ResendVerification(context.Background(), "user@example.com"); err != nil {
+		t.Fatalf("ResendVerification failed: %v", err)
+	}
+}
+
+func TestResendVerification_MailFailureIsSilent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockEmailVerificationTokenRepository(ctrl)
+	mockSender := mocks.NewMockMailSender(ctrl)
+	var logs bytes.Buffer
+	usecase := usecases.NewEmailVerificationUsecase(mockRepo, mockTokens, mockSender, slog.New(slog.NewJSONHandler(&logs, nil)), testVerificationConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockTokens.EXPECT().GetLatestForUser(gomock.Any(), "123").Return(nil, nil).Times(1)
+	mockTokens.EXPECT().InvalidateForUser(gomock.Any(), "123").Return(nil).Times(1)
+	mockTokens.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(stderrors.New("smtp unavailable")).Times(1)
+
+	// Unknown addresses succeed, so registered ones must as well
+	if err := usecase.ResendVerification(context.Background(), "user@example.com"); err != nil {
+		t.Fatalf("expected silent success, got %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "smtp unavailable") {
+		t.Errorf("expected the failure to be logged, got %q", logs.String())
+	}
+}
+
+func TestResendVerification_IgnoresUnknownAndVerified(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name string
+		user *domains.User
+	}{
+		{"unknown", nil},
+		{"verified", &domains.User{ID: "123", Email: "user@example.com", EmailVerifiedAt: &verifiedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			usecase := usecases.NewEmailVerificationUsecase(mockRepo, mocks.NewMockEmailVerificationTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testVerificationConfig)
+
+			mockRepo.EXPECT().
+				GetByEmail(gomock.Any(), "user@example.com").
+				Return(tt.user, nil).
+				Times(1)
+
+			if err := usecase.ResendVerification(context.Background(), "user@example.com"); err != nil {
+				t.Fatalf("expected silent success, got %v", err)
+			}
+		})
+	}
+}
+
+// tokenFromMail extracts the token from the verification link in a mail body
+func tokenFromMail(t *testing.T, message *domains.MailMessage) string {
+	t.Helper()
+
+	for _, line := range strings.Split(message.Body, "\n") {
+		if !strings.HasPrefix(line, testVerificationConfig.URL) {
+			continue
+		}
+
+		link, err := url.Parse(line)
+		if err != nil {
+			t.Fatalf("invalid verification link %q: %v", line, err)
+		}
+		return link.Query().Get("token")
+	}
+
+	t.Fatalf("no verification link in mail body: %s", message.Body)
+	return ""
+}
@@ -0,0 +1,324 @@
+package usecases_test
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+func groupAdminContext() context.Context {
+	return domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Roles:       []string{domains.RoleAdmin},
+		Permissions: []string{domains.PermissionGroupsRead, domains.PermissionGroupsWrite, domains.PermissionUsersRead, domains.PermissionUsersWrite},
+	})
+}
+
+func supportGroup(roles ...string) *domains.Group {
+	return &domains.Group{ID: "group-1", TenantID: "tenant-1", Name: "support", Roles: roles}
+}
+
+func TestCreateGroup_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	mockGroups.EXPECT().GetByName(gomock.Any(), "support").Return(nil, nil).Times(1)
+	mockGroups.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	group, err := usecase.CreateGroup(groupAdminContext(), &domains.CreateGroupInput{Name: "  support  "})
+	if err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+
+	if group.ID == "" || group.Name != "support" {
+		t.Errorf("unexpected group: %+v", group)
+	}
+}
+
+func TestCreateGroup_DuplicateName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	mockGroups.EXPECT().GetByName(gomock.Any(), "support").Return(supportGroup(), nil).Times(1)
+
+	_, err := usecase.CreateGroup(groupAdminContext(), &domains.CreateGroupInput{Name: "support"})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeDuplicateEntry)
+}
+
+func TestCreateGroup_EmptyName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewGroupUsecase(mocks.NewMockGroupRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	_, err := usecase.CreateGroup(groupAdminContext(), &domains.CreateGroupInput{Name: "   "})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+}
+
+func TestCreateGroup_RequiresPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewGroupUsecase(mocks.NewMockGroupRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "user-1",
+		Permissions: []string{domains.PermissionGroupsRead},
+	})
+
+	_, err := usecase.CreateGroup(ctx, &domains.CreateGroupInput{Name: "support"})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestUpdateGroup_KeepsOwnName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	name := "support"
+	description := "First line support staff"
+
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(), nil).Times(1)
+	mockGroups.EXPECT().GetByName(gomock.Any(), "support").Return(supportGroup(), nil).Times(1)
+	mockGroups.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	group, err := usecase.UpdateGroup(groupAdminContext(), "group-1", &domains.UpdateGroupInput{Name: &name, Description: &description})
+	if err != nil {
+		t.Fatalf("UpdateGroup failed: %v", err)
+	}
+
+	if group.Description == nil || *group.Description != description {
+		t.Errorf("expected the description to be updated, got %+v", group)
+	}
+}
+
+func TestDeleteGroup_InvalidatesMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	mockPermissions := mocks.NewMockPermissionResolver(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mockPermissions)
+
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(domains.RoleAdmin), nil).Times(1)
+	mockGroups.EXPECT().ListMemberIDs(gomock.Any(), "group-1").Return([]string{"user-1", "user-2"}, nil).Times(1)
+	mockGroups.EXPECT().Delete(gomock.Any(), "group-1").Return(nil).Times(1)
+	mockPermissions.EXPECT().Invalidate("user-1", "user-2").Times(1)
+
+	if err := usecase.DeleteGroup(groupAdminContext(), "group-1"); err != nil {
+		t.Fatalf("DeleteGroup failed: %v", err)
+	}
+}
+
+func TestDeleteGroup_RejectedWhileImpersonating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewGroupUsecase(mocks.NewMockGroupRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		ActorID:     "admin-1",
+		Permissions: []string{domains.PermissionGroupsWrite},
+	})
+
+	err := usecase.DeleteGroup(ctx, "group-1")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestAddMember_InvalidatesUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockPermissions := mocks.NewMockPermissionResolver(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mockUsers, mockRoles, mockPermissions)
+
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(domains.RoleUser), nil).Times(1)
+	mockUsers.EXPECT().GetByID(gomock.Any(), "user-1").Return(&domains.User{ID: "user-1"}, nil).Times(1)
+	mockRoles.EXPECT().
+		GetRolePermissions(gomock.Any(), domains.RoleUser).
+		Return([]string{domains.PermissionUsersRead}, nil).
+		Times(1)
+	mockGroups.EXPECT().AddMember(gomock.Any(), "group-1", "user-1").Return(nil).Times(1)
+	mockPermissions.EXPECT().Invalidate("user-1").Times(1)
+
+	if err := usecase.AddMember(groupAdminContext(), "group-1", "user-1"); err != nil {
+		t.Fatalf("AddMember failed: %v", err)
+	}
+}
+
+func TestAddMember_CannotGrantUnheldPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mockUsers, mockRoles, mocks.NewMockPermissionResolver(ctrl))
+
+	// The caller lacks users:delete, which the group's admin role grants
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(domains.RoleAdmin), nil).Times(1)
+	mockUsers.EXPECT().GetByID(gomock.Any(), "user-1").Return(&domains.User{ID: "user-1"}, nil).Times(1)
+	mockRoles.EXPECT().
+		GetRolePermissions(gomock.Any(), domains.RoleAdmin).
+		Return([]string{domains.PermissionUsersDelete, domains.PermissionUsersRead}, nil).
+		Times(1)
+
+	err := usecase.AddMember(groupAdminContext(), "group-1", "user-1")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestAddMember_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	mockUsers := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mockUsers, mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(), nil).Times(1)
+	mockUsers.EXPECT().GetByID(gomock.Any(), "unknown").Return(nil, nil).Times(1)
+
+	err := usecase.AddMember(groupAdminContext(), "group-1", "unknown")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestRemoveMember_NotAMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(), nil).Times(1)
+	mockGroups.EXPECT().RemoveMember(gomock.Any(), "group-1", "user-1").Return(false, nil).Times(1)
+
+	err := usecase.RemoveMember(groupAdminContext(), "group-1", "user-1")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestAddRole_InvalidatesMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockPermissions := mocks.NewMockPermissionResolver(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mockRoles, mockPermissions)
+
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(), nil).Times(1)
+	mockRoles.EXPECT().
+		GetRolePermissions(gomock.Any(), domains.RoleUser).
+		Return([]string{domains.PermissionUsersRead}, nil).
+		Times(1)
+	mockGroups.EXPECT().ListMemberIDs(gomock.Any(), "group-1").Return([]string{"user-1"}, nil).Times(1)
+	mockGroups.EXPECT().AddRole(gomock.Any(), "group-1", domains.RoleUser).Return(nil).Times(1)
+	mockPermissions.EXPECT().Invalidate("user-1").Times(1)
+
+	if err := usecase.AddRole(groupAdminContext(), "group-1", domains.RoleUser); err != nil {
+		t.Fatalf("AddRole failed: %v", err)
+	}
+}
+
+func TestAddRole_UnknownRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mockRoles, mocks.NewMockPermissionResolver(ctrl))
+
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(), nil).Times(1)
+	mockRoles.EXPECT().GetRolePermissions(gomock.Any(), "superuser").Return(nil, nil).Times(1)
+
+	err := usecase.AddRole(groupAdminContext(), "group-1", "superuser")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+}
+
+func TestRemoveRole_NotHeld(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockPermissionResolver(ctrl))
+
+	mockGroups.EXPECT().GetByID(gomock.Any(), "group-1").Return(supportGroup(), nil).Times(1)
+	mockGroups.EXPECT().ListMemberIDs(gomock.Any(), "group-1").Return(nil, nil).Times(1)
+	mockGroups.EXPECT().RemoveRole(gomock.Any(), "group-1", domains.RoleAdmin).Return(false, nil).Times(1)
+
+	err := usecase.RemoveRole(groupAdminContext(), "group-1", domains.RoleAdmin)
+
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestListGroups_UsesCurrentPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGroups := mocks.NewMockGroupRepository(ctrl)
+	mockPermissions := mocks.NewMockPermissionResolver(ctrl)
+	usecase := usecases.NewGroupUsecase(mockGroups, mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mockPermissions)
+
+	// The token predates the group that grants groups:read
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{UserID: "user-1"})
+	ctx = domains.WithPermissionResolver(ctx, mockPermissions)
+
+	mockPermissions.EXPECT().
+		Permissions(gomock.Any(), "user-1").
+		Return([]string{domains.PermissionGroupsRead}, nil).
+		Times(1)
+	mockGroups.EXPECT().List(gomock.Any(), int32(10), int32(0)).Return([]*domains.Group{supportGroup()}, nil).Times(1)
+
+	groups, err := usecase.ListGroups(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ListGroups failed: %v", err)
+	}
+
+	if len(groups) != 1 {
+		t.Errorf("expected 1 group, got %d", len(groups))
+	}
+}
+
+func TestListGroups_PermissionLookupFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPermissions := mocks.NewMockPermissionResolver(ctrl)
+	usecase := usecases.NewGroupUsecase(mocks.NewMockGroupRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mockPermissions)
+
+	ctx := domains.WithPermissionResolver(groupAdminContext(), mockPermissions)
+
+	mockPermissions.EXPECT().
+		Permissions(gomock.Any(), "admin-1").
+		Return(nil, stderrors.New("connection refused")).
+		Times(1)
+
+	_, err := usecase.ListGroups(ctx, 10, 0)
+
+	assertAPIErrorCode(t, err, errors.ErrCodeDatabaseError)
+}
@@ -0,0 +1,207 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testExternalIdentity = &domains.ExternalIdentityInput{
+	Issuer:        "https://idp.example.com",
+	Subject:       "external-123",
+	Email:         "user@example.com",
+	EmailVerified: true,
+}
+
+func TestResolveExternalUser_LinkedIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockIdentities := mocks.NewMockUserIdentityRepository(ctrl)
+	usecase := usecases.NewIdentityUsecase(mockRepo, mockIdentities, mocks.NewMockRoleRepository(ctrl), newTestHasher(t), usecases.IdentityConfig{})
+
+	mockIdentities.EXPECT().
+		GetByIssuerSubject(gomock.Any(), "https://idp.example.com", "external-123").
+		Return(&domains.UserIdentity{UserID: "local-1"}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "local-1").
+		Return(&domains.User{ID: "local-1", IsActive: true}, nil).
+		Times(1)
+
+	user, err := usecase.ResolveExternalUser(context.Background(), testExternalIdentity)
+
+	if err != nil {
+		t.Fatalf("ResolveExternalUser failed: %v", err)
+	}
+
+	if user.ID != "local-1" {
+		t.Errorf("expected local-1, got %s", user.ID)
+	}
+}
+
+func TestResolveExternalUser_InactiveUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockIdentities := mocks.NewMockUserIdentityRepository(ctrl)
+	usecase := usecases.NewIdentityUsecase(mockRepo, mockIdentities, mocks.NewMockRoleRepository(ctrl), newTestHasher(t), usecases.IdentityConfig{})
+
+	mockIdentities.EXPECT().
+		GetByIssuerSubject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&domains.UserIdentity{UserID: "local-1"}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "local-1").
+		Return(&domains.User{ID: "local-1", IsActive: false}, nil).
+		Times(1)
+
+	_, err := usecase.ResolveExternalUser(context.Background(), testExternalIdentity)
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestResolveExternalUser_UnlinkedWithoutProvisioning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockIdentities := mocks.NewMockUserIdentityRepository(ctrl)
+	usecase := usecases.NewIdentityUsecase(mockRepo, mockIdentities, mocks.NewMockRoleRepository(ctrl), newTestHasher(t), usecases.IdentityConfig{})
+
+	mockIdentities.EXPECT().
+		GetByIssuerSubject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	_, err := usecase.ResolveExternalUser(context.Background(), testExternalIdentity)
+
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestResolveExternalUser_ProvisionsNewUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockIdentities := mocks.NewMockUserIdentityRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewIdentityUsecase(mockRepo, mockIdentities, mockRoles, newTestHasher(t), usecases.IdentityConfig{Provision: true})
+
+	mockIdentities.EXPECT().
+		GetByIssuerSubject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(nil, nil).
+		Times(1)
+
+	mockRoles.EXPECT().
+		AssignRole(gomock.Any(), gomock.Any(), domains.RoleUser).
+		Return(nil).
+		Times(1)
+
+	var created *domains.User
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, user *domains.User) error {
+			created = user
+			return nil
+		}).
+		Times(1)
+
+	mockIdentities.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, identity *domains.UserIdentity) error {
+			if identity.UserID != created.ID || identity.Subject != "external-123" {
+				t.Errorf("unexpected identity link: %+v", identity)
+			}
+			return nil
+		}).
+		Times(1)
+
+	user, err := usecase.ResolveExternalUser(context.Background(), testExternalIdentity)
+
+	if err != nil {
+		t.Fatalf("ResolveExternalUser failed: %v", err)
+	}
+
+	if user.Email != "user@example.com" || !user.IsActive {
+		t.Errorf("unexpected provisioned user: %+v", user)
+	}
+
+	if user.PasswordHash == "" {
+		t.Error("expected an unusable password hash to be stored")
+	}
+}
+
+func TestResolveExternalUser_LinksVerifiedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockIdentities := mocks.NewMockUserIdentityRepository(ctrl)
+	usecase := usecases.NewIdentityUsecase(mockRepo, mockIdentities, mocks.NewMockRoleRepository(ctrl), newTestHasher(t), usecases.IdentityConfig{Provision: true})
+
+	mockIdentities.EXPECT().
+		GetByIssuerSubject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "existing", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockIdentities.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	user, err := usecase.ResolveExternalUser(context.Background(), testExternalIdentity)
+
+	if err != nil {
+		t.Fatalf("ResolveExternalUser failed: %v", err)
+	}
+
+	if user.ID != "existing" {
+		t.Errorf("expected existing user to be linked, got %s", user.ID)
+	}
+}
+
+func TestResolveExternalUser_UnverifiedEmailConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockIdentities := mocks.NewMockUserIdentityRepository(ctrl)
+	usecase := usecases.NewIdentityUsecase(mockRepo, mockIdentities, mocks.NewMockRoleRepository(ctrl), newTestHasher(t), usecases.IdentityConfig{Provision: true})
+
+	mockIdentities.EXPECT().
+		GetByIssuerSubject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "existing", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	input := *testExternalIdentity
+	input.EmailVerified = false
+
+	_, err := usecase.ResolveExternalUser(context.Background(), &input)
+
+	assertAPIErrorCode(t, err, errors.ErrCodeConflict)
+}
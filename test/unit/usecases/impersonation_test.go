@@ -0,0 +1,177 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testImpersonationConfig = usecases.ImpersonationConfig{TokenTTL: 15 * time.Minute}
+
+// adminContext returns a context whose principal may impersonate users
+func adminContext() context.Context {
+	return domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Email:       "admin@example.com",
+		Roles:       []string{domains.RoleAdmin},
+		Permissions: []string{domains.PermissionUsersImpersonate},
+	})
+}
+
+func TestImpersonate_IssuesActorToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockIssuer := mocks.NewMockTokenIssuer(ctrl)
+
+	var logs bytes.Buffer
+	usecase := usecases.NewImpersonationUsecase(mockRepo, mockRoles, mockIssuer, slog.New(slog.NewJSONHandler(&logs, nil)), testImpersonationConfig)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+	mockRoles.EXPECT().GetUserRoles(gomock.Any(), "123").Return([]string{domains.RoleUser}, nil).Times(1)
+	mockRoles.EXPECT().GetUserPermissions(gomock.Any(), "123").Return([]string{domains.PermissionSelfRead}, nil).Times(1)
+
+	// The token is the user's own, plus the actor and a short lifetime
+	mockIssuer.EXPECT().
+		Issue(&domains.IssueTokenInput{
+			UserID:      "123",
+			Email:       "user@example.com",
+			Roles:       []string{domains.RoleUser},
+			Permissions: []string{domains.PermissionSelfRead},
+			ActorID:     "admin-1",
+			ActorEmail:  "admin@example.com",
+			TTL:         testImpersonationConfig.TokenTTL,
+		}).
+		Return(&domains.AuthToken{AccessToken: "token", TokenType: "Bearer", ExpiresAt: time.Now().Add(15 * time.Minute)}, nil).
+		Times(1)
+
+	token, err := usecase.Impersonate(adminContext(), &domains.ImpersonateInput{UserID: "123", Reason: "ticket #42"})
+	if err != nil {
+		t.Fatalf("Impersonate failed: %v", err)
+	}
+
+	if token.AccessToken != "token" || token.RefreshToken != "" {
+		t.Errorf("expected an access token only, got %+v", token)
+	}
+
+	var event map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &event); err != nil {
+		t.Fatalf("expected one audit log event, got %q", logs.String())
+	}
+
+	if event["actor_id"] != "admin-1" || event["user_id"] != "123" || event["reason"] != "ticket #42" {
+		t.Errorf("unexpected audit log event: %v", event)
+	}
+}
+
+func TestImpersonate_RejectsCallers(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code errors.ErrorCode
+	}{
+		{"anonymous", context.Background(), errors.ErrCodeUnauthorized},
+		{"without permission", domains.WithPrincipal(context.Background(), &domains.Principal{
+			UserID:      "user-1",
+			Permissions: []string{domains.PermissionUsersRead},
+		}), errors.ErrCodeForbidden},
+		{"already impersonating", domains.WithPrincipal(context.Background(), &domains.Principal{
+			UserID:      "user-1",
+			Permissions: []string{domains.PermissionUsersImpersonate},
+			ActorID:     "admin-1",
+		}), errors.ErrCodeForbidden},
+		{"API key", domains.WithPrincipal(context.Background(), &domains.Principal{
+			UserID:      "svc-1",
+			Permissions: []string{domains.PermissionUsersImpersonate},
+			APIKeyID:    "key-1",
+		}), errors.ErrCodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := usecases.NewImpersonationUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTokenIssuer(ctrl), slog.New(slog.DiscardHandler), testImpersonationConfig)
+
+			_, err := usecase.Impersonate(tt.ctx, &domains.ImpersonateInput{UserID: "123", Reason: "ticket #42"})
+			assertAPIErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestImpersonate_RejectsTargets(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name string
+		user *domains.User
+		code errors.ErrorCode
+	}{
+		{"unknown", nil, errors.ErrCodeNotFound},
+		{"deleted", &domains.User{ID: "123", IsActive: true, DeletedAt: &deletedAt}, errors.ErrCodeNotFound},
+		{"service account", &domains.User{ID: "123", IsActive: true, IsServiceAccount: true}, errors.ErrCodeBadRequest},
+		{"inactive", &domains.User{ID: "123"}, errors.ErrCodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			mockRepo.EXPECT().GetByID(gomock.Any(), "123").Return(tt.user, nil).Times(1)
+
+			usecase := usecases.NewImpersonationUsecase(mockRepo, mocks.NewMockRoleRepository(ctrl), mocks.NewMockTokenIssuer(ctrl), slog.New(slog.DiscardHandler), testImpersonationConfig)
+
+			_, err := usecase.Impersonate(adminContext(), &domains.ImpersonateInput{UserID: "123", Reason: "ticket #42"})
+			assertAPIErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestImpersonate_RejectsOtherAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewImpersonationUsecase(mockRepo, mockRoles, mocks.NewMockTokenIssuer(ctrl), slog.New(slog.DiscardHandler), testImpersonationConfig)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "admin-2").
+		Return(&domains.User{ID: "admin-2", Email: "other-admin@example.com", IsActive: true}, nil).
+		Times(1)
+	mockRoles.EXPECT().GetUserRoles(gomock.Any(), "admin-2").Return([]string{domains.RoleAdmin}, nil).Times(1)
+	mockRoles.EXPECT().GetUserPermissions(gomock.Any(), "admin-2").Return([]string{domains.PermissionUsersImpersonate}, nil).Times(1)
+
+	_, err := usecase.Impersonate(adminContext(), &domains.ImpersonateInput{UserID: "admin-2", Reason: "ticket #42"})
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestImpersonate_RequiresReason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewImpersonationUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTokenIssuer(ctrl), slog.New(slog.DiscardHandler), testImpersonationConfig)
+
+	_, err := usecase.Impersonate(adminContext(), &domains.ImpersonateInput{UserID: "123"})
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+
+	_, err = usecase.Impersonate(adminContext(), &domains.ImpersonateInput{UserID: "admin-1", Reason: "ticket #42"})
+	assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+}
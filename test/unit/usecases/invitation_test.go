@@ -0,0 +1,520 @@
+package usecases_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testInvitationConfig = usecases.InvitationConfig{
+	TokenTTL: 7 * 24 * time.Hour,
+	URL:      "https://app.example.com/invitation",
+}
+
+// expectInvitationMail expects the invitation mail and returns the link it carried
+func expectInvitationMail(t *testing.T, tenants *mocks.MockTenantRepository, sender *mocks.MockMailSender) *url.URL {
+	t.Helper()
+
+	tenants.EXPECT().
+		GetByID(gomock.Any(), "tenant-1").
+		Return(&domains.Tenant{ID: "tenant-1", Slug: "acme", Name: "Acme", IsActive: true}, nil).
+		Times(1)
+
+	link := &url.URL{}
+	sender.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, message *domains.MailMessage) error {
+			for _, line := range strings.Split(message.Body, "\n") {
+				if strings.HasPrefix(line, testInvitationConfig.URL) {
+					parsed, err := url.Parse(line)
+					if err != nil {
+						t.Fatalf("invalid invitation link %q: %v", line, err)
+					}
+					*link = *parsed
+				}
+			}
+			return nil
+		}).
+		Times(1)
+
+	return link
+}
+
+func inviterContext() context.Context {
+	return domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Roles:       []string{domains.RoleAdmin},
+		Permissions: []string{domains.PermissionUsersRead, domains.PermissionUsersWrite, domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+}
+
+func pendingInvitation() *domains.Invitation {
+	return &domains.Invitation{
+		ID:        "inv-1",
+		TenantID:  "tenant-1",
+		Email:     "invitee@example.com",
+		Role:      domains.RoleUser,
+		TokenHash: sha256Hex("invitation-token"),
+		Status:    domains.InvitationPending,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestCreateInvitation_MailsLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockTenants := mocks.NewMockTenantRepository(ctrl)
+	mockSender := mocks.NewMockMailSender(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mockRoles, mockTenants, mockSender, testInvitationConfig)
+
+	mockRoles.EXPECT().
+		GetRolePermissions(gomock.Any(), domains.RoleUser).
+		Return([]string{domains.PermissionSelfRead, domains.PermissionSelfWrite}, nil).
+		Times(1)
+
+	mockInvitations.EXPECT().
+		GetPendingByEmail(gomock.Any(), "invitee@example.com").
+		Return(nil, nil).
+		Times(1)
+
+	var stored *domains.Invitation
+	mockInvitations.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, invitation *domains.Invitation) error {
+			invitation.TenantID = "tenant-1"
+			stored = invitation
+			return nil
+		}).
+		Times(1)
+
+	link := expectInvitationMail(t, mockTenants, mockSender)
+
+	invitation, err := usecase.CreateInvitation(inviterContext(), &domains.CreateInvitationInput{
+		Email: " invitee@example.com ",
+		Role:  domains.RoleUser,
+	})
+	if err != nil {
+		t.Fatalf("CreateInvitation failed: %v", err)
+	}
+
+	if invitation.InvitedBy == nil || *invitation.InvitedBy != "admin-1" {
+		t.Errorf("expected the inviter to be recorded, got %v", invitation.InvitedBy)
+	}
+
+	if time.Until(invitation.ExpiresAt) < testInvitationConfig.TokenTTL-time.Minute {
+		t.Errorf("unexpected expiry: %v", invitation.ExpiresAt)
+	}
+
+	// The link names the tenant, and only the hash of its token is stored
+	token := link.Query().Get("token")
+	if token == "" || stored.TokenHash != sha256Hex(token) {
+		t.Errorf("expected the hash of the mailed token to be stored, got %+v", stored)
+	}
+
+	if link.Query().Get("tenant") != "acme" {
+		t.Errorf("expected the tenant slug in the link, got %s", link.String())
+	}
+}
+
+func TestCreateInvitation_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *domains.CreateInvitationInput
+	}{
+		{"nil input", nil},
+		{"missing email", &domains.CreateInvitationInput{Role: domains.RoleUser}},
+		{"missing role", &domains.CreateInvitationInput{Email: "invitee@example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := usecases.NewInvitationUsecase(mocks.NewMockInvitationRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+			_, err := usecase.CreateInvitation(inviterContext(), tt.input)
+			assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+		})
+	}
+}
+
+func TestCreateInvitation_UnknownRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mocks.NewMockInvitationRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mockRoles, mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	mockRoles.EXPECT().GetRolePermissions(gomock.Any(), "owner").Return(nil, nil).Times(1)
+
+	_, err := usecase.CreateInvitation(inviterContext(), &domains.CreateInvitationInput{
+		Email: "invitee@example.com",
+		Role:  "owner",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+}
+
+func TestCreateInvitation_CannotGrantUnheldPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mocks.NewMockInvitationRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mockRoles, mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	// The inviter lacks users:delete, which the admin role grants
+	mockRoles.EXPECT().
+		GetRolePermissions(gomock.Any(), domains.RoleAdmin).
+		Return([]string{domains.PermissionUsersDelete, domains.PermissionUsersRead, domains.PermissionUsersWrite}, nil).
+		Times(1)
+
+	_, err := usecase.CreateInvitation(inviterContext(), &domains.CreateInvitationInput{
+		Email: "invitee@example.com",
+		Role:  domains.RoleAdmin,
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestCreateInvitation_DuplicatePending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mockRoles, mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	mockRoles.EXPECT().
+		GetRolePermissions(gomock.Any(), domains.RoleUser).
+		Return([]string{domains.PermissionSelfRead}, nil).
+		Times(1)
+
+	mockInvitations.EXPECT().
+		GetPendingByEmail(gomock.Any(), "invitee@example.com").
+		Return(pendingInvitation(), nil).
+		Times(1)
+
+	_, err := usecase.CreateInvitation(inviterContext(), &domains.CreateInvitationInput{
+		Email: "invitee@example.com",
+		Role:  domains.RoleUser,
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeDuplicateEntry)
+}
+
+func TestCreateInvitation_RequiresPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewInvitationUsecase(mocks.NewMockInvitationRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	_, err := usecase.CreateInvitation(ctx, &domains.CreateInvitationInput{
+		Email: "invitee@example.com",
+		Role:  domains.RoleUser,
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestListPendingInvitations_PaginationDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	mockInvitations.EXPECT().
+		ListPending(gomock.Any(), int32(10), int32(0)).
+		Return([]*domains.Invitation{pendingInvitation()}, nil).
+		Times(1)
+
+	invitations, err := usecase.ListPendingInvitations(inviterContext(), 0, -5)
+	if err != nil {
+		t.Fatalf("ListPendingInvitations failed: %v", err)
+	}
+
+	if len(invitations) != 1 {
+		t.Errorf("expected 1 invitation, got %d", len(invitations))
+	}
+}
+
+func TestResendInvitation_ReplacesToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	mockTenants := mocks.NewMockTenantRepository(ctrl)
+	mockSender := mocks.NewMockMailSender(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mockTenants, mockSender, testInvitationConfig)
+
+	expired := pendingInvitation()
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+
+	mockInvitations.EXPECT().GetByID(gomock.Any(), "inv-1").Return(expired, nil).Times(1)
+
+	var renewedHash string
+	mockInvitations.EXPECT().
+		Renew(gomock.Any(), "inv-1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, tokenHash string, expiresAt time.Time) (bool, error) {
+			renewedHash = tokenHash
+			if !expiresAt.After(time.Now()) {
+				t.Errorf("expected a fresh expiry, got %v", expiresAt)
+			}
+			return true, nil
+		}).
+		Times(1)
+
+	link := expectInvitationMail(t, mockTenants, mockSender)
+
+	if _, err := usecase.ResendInvitation(inviterContext(), "inv-1"); err != nil {
+		t.Fatalf("ResendInvitation failed: %v", err)
+	}
+
+	if renewedHash == sha256Hex("invitation-token") || renewedHash != sha256Hex(link.Query().Get("token")) {
+		t.Errorf("expected a new token to replace the old one, got %s", renewedHash)
+	}
+}
+
+func TestRevokeInvitation_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	mockInvitations.EXPECT().GetByID(gomock.Any(), "inv-1").Return(pendingInvitation(), nil).Times(1)
+	mockInvitations.EXPECT().Respond(gomock.Any(), "inv-1", domains.InvitationRevoked).Return(true, nil).Times(1)
+
+	if err := usecase.RevokeInvitation(inviterContext(), "inv-1"); err != nil {
+		t.Fatalf("RevokeInvitation failed: %v", err)
+	}
+}
+
+func TestRevokeInvitation_NotPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	accepted := pendingInvitation()
+	accepted.Status = domains.InvitationAccepted
+	mockInvitations.EXPECT().GetByID(gomock.Any(), "inv-1").Return(accepted, nil).Times(1)
+
+	err := usecase.RevokeInvitation(inviterContext(), "inv-1")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeConflict)
+}
+
+func TestRevokeInvitation_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	mockInvitations.EXPECT().GetByID(gomock.Any(), "missing").Return(nil, nil).Times(1)
+
+	err := usecase.RevokeInvitation(inviterContext(), "missing")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestAcceptInvitation_RegistersNewUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockUsers := mocks.NewMockUserUsecase(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mockRepo, mockUsers, mockRoles, mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	invitation := pendingInvitation()
+	invitation.Role = domains.RoleAdmin
+
+	mockInvitations.EXPECT().GetByHash(gomock.Any(), sha256Hex("invitation-token")).Return(invitation, nil).Times(1)
+	mockRepo.EXPECT().GetByEmail(gomock.Any(), "invitee@example.com").Return(nil, nil).Times(1)
+	mockInvitations.EXPECT().Respond(gomock.Any(), "inv-1", domains.InvitationAccepted).Return(true, nil).Times(1)
+
+	mockUsers.EXPECT().
+		RegisterUser(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *domains.RegisterUserInput) (*domains.User, error) {
+			if input.Email != "invitee@example.com" || input.Password != "password123" || !input.EmailVerified {
+				t.Errorf("unexpected registration input: %+v", input)
+			}
+			return &domains.User{ID: "new-user", Email: input.Email, IsActive: true}, nil
+		}).
+		Times(1)
+
+	mockRoles.EXPECT().AssignRole(gomock.Any(), "new-user", domains.RoleAdmin).Return(nil).Times(1)
+
+	user, err := usecase.AcceptInvitation(context.Background(), &domains.AcceptInvitationInput{
+		Token:    "invitation-token",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("AcceptInvitation failed: %v", err)
+	}
+
+	if user.ID != "new-user" {
+		t.Errorf("expected the registered user, got %+v", user)
+	}
+}
+
+func TestAcceptInvitation_AttachesExistingUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mockRepo, mocks.NewMockUserUsecase(ctrl), mockRoles, mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	existing := &domains.User{ID: "123", Email: "invitee@example.com", IsActive: true}
+
+	mockInvitations.EXPECT().GetByHash(gomock.Any(), sha256Hex("invitation-token")).Return(pendingInvitation(), nil).Times(1)
+	mockRepo.EXPECT().GetByEmail(gomock.Any(), "invitee@example.com").Return(existing, nil).Times(1)
+	mockInvitations.EXPECT().Respond(gomock.Any(), "inv-1", domains.InvitationAccepted).Return(true, nil).Times(1)
+
+	// Following the mailed link verifies the address
+	mockRepo.EXPECT().
+		MarkEmailVerified(gomock.Any(), "123", gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	mockRoles.EXPECT().AssignRole(gomock.Any(), "123", domains.RoleUser).Return(nil).Times(1)
+
+	// No password is needed for an existing account
+	user, err := usecase.AcceptInvitation(context.Background(), &domains.AcceptInvitationInput{Token: "invitation-token"})
+	if err != nil {
+		t.Fatalf("AcceptInvitation failed: %v", err)
+	}
+
+	if user.ID != "123" {
+		t.Errorf("expected the existing user, got %+v", user)
+	}
+}
+
+func TestAcceptInvitation_NewUserRequiresPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mockRepo, mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	// The invitation is not consumed, so the invitee can try again
+	mockInvitations.EXPECT().GetByHash(gomock.Any(), sha256Hex("invitation-token")).Return(pendingInvitation(), nil).Times(1)
+	mockRepo.EXPECT().GetByEmail(gomock.Any(), "invitee@example.com").Return(nil, nil).Times(1)
+
+	_, err := usecase.AcceptInvitation(context.Background(), &domains.AcceptInvitationInput{Token: "invitation-token"})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+}
+
+func TestAcceptInvitation_RejectsUnusableInvitations(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored func() *domains.Invitation
+	}{
+		{"unknown", func() *domains.Invitation { return nil }},
+		{"expired", func() *domains.Invitation {
+			invitation := pendingInvitation()
+			invitation.ExpiresAt = time.Now().Add(-time.Minute)
+			return invitation
+		}},
+		{"revoked", func() *domains.Invitation {
+			invitation := pendingInvitation()
+			invitation.Status = domains.InvitationRevoked
+			return invitation
+		}},
+		{"already accepted", func() *domains.Invitation {
+			invitation := pendingInvitation()
+			invitation.Status = domains.InvitationAccepted
+			return invitation
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+			usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+			mockInvitations.EXPECT().GetByHash(gomock.Any(), sha256Hex("invitation-token")).Return(tt.stored(), nil).Times(1)
+
+			_, err := usecase.AcceptInvitation(context.Background(), &domains.AcceptInvitationInput{
+				Token:    "invitation-token",
+				Password: "password123",
+			})
+
+			assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+		})
+	}
+}
+
+func TestAcceptInvitation_ConcurrentlyConsumed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mockRepo, mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	mockInvitations.EXPECT().GetByHash(gomock.Any(), sha256Hex("invitation-token")).Return(pendingInvitation(), nil).Times(1)
+	mockRepo.EXPECT().GetByEmail(gomock.Any(), "invitee@example.com").Return(nil, nil).Times(1)
+	mockInvitations.EXPECT().Respond(gomock.Any(), "inv-1", domains.InvitationAccepted).Return(false, nil).Times(1)
+
+	_, err := usecase.AcceptInvitation(context.Background(), &domains.AcceptInvitationInput{
+		Token:    "invitation-token",
+		Password: "password123",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+}
+
+func TestDeclineInvitation_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInvitations := mocks.NewMockInvitationRepository(ctrl)
+	usecase := usecases.NewInvitationUsecase(mockInvitations, mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	mockInvitations.EXPECT().GetByHash(gomock.Any(), sha256Hex("invitation-token")).Return(pendingInvitation(), nil).Times(1)
+	mockInvitations.EXPECT().Respond(gomock.Any(), "inv-1", domains.InvitationDeclined).Return(true, nil).Times(1)
+
+	if err := usecase.DeclineInvitation(context.Background(), "invitation-token"); err != nil {
+		t.Fatalf("DeclineInvitation failed: %v", err)
+	}
+}
+
+func TestDeclineInvitation_MissingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewInvitationUsecase(mocks.NewMockInvitationRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockUserUsecase(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockTenantRepository(ctrl), mocks.NewMockMailSender(ctrl), testInvitationConfig)
+
+	err := usecase.DeclineInvitation(context.Background(), "")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+}
@@ -0,0 +1,209 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testLockoutPolicy = usecases.LockoutPolicy{
+	FailureWindow:        15 * time.Minute,
+	MaxAccountFailures:   10,
+	MaxIPAccountFailures: 3,
+	Duration:             15 * time.Minute,
+}
+
+func TestLockout_LocksIPAfterMaxFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	usecase := usecases.NewLockoutUsecase(mocks.NewMockUserRepository(ctrl), repositories.NewMemoryLoginAttemptStore(), logger, testLockoutPolicy)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := usecase.Check(ctx, "user@example.com", "10.0.0.1"); err != nil {
+			t.Fatalf("attempt %d rejected: %v", i+1, err)
+		}
+		if err := usecase.RecordFailure(ctx, "user@example.com", "10.0.0.1"); err != nil {
+			t.Fatalf("RecordFailure failed: %v", err)
+		}
+	}
+
+	// Case does not matter
+	err := usecase.Check(ctx, "User@Example.com", "10.0.0.1")
+	assertAPIErrorCode(t, err, errors.ErrCodeTooManyAttempts)
+
+	if retry := err.(*errors.APIError).RetryAfter; retry <= 0 || retry > 15*time.Minute {
+		t.Errorf("unexpected retry after: %v", retry)
+	}
+
+	// The owner on another address is not affected
+	if err := usecase.Check(ctx, "user@example.com", "10.0.0.2"); err != nil {
+		t.Errorf("expected other addresses to be allowed, got %v", err)
+	}
+
+	var event map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &event); err != nil {
+		t.Fatalf("expected one security log event, got %q", logs.String())
+	}
+
+	if event["event"] != "ip_account_locked" || event["account"] != "user@example.com" || event["ip"] != "10.0.0.1" {
+		t.Errorf("unexpected log event: %v", event)
+	}
+}
+
+func TestLockout_LocksAccountAcrossIPs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := testLockoutPolicy
+	policy.MaxAccountFailures = 4
+	usecase := usecases.NewLockoutUsecase(mocks.NewMockUserRepository(ctrl), repositories.NewMemoryLoginAttemptStore(), slog.New(slog.DiscardHandler), policy)
+	ctx := context.Background()
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"} {
+		if err := usecase.RecordFailure(ctx, "user@example.com", ip); err != nil {
+			t.Fatalf("RecordFailure failed: %v", err)
+		}
+	}
+
+	err := usecase.Check(ctx, "user@example.com", "10.0.0.5")
+	assertAPIErrorCode(t, err, errors.ErrCodeTooManyAttempts)
+}
+
+func TestLockout_CountersArePerTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := testLockoutPolicy
+	policy.MaxAccountFailures = 2
+	usecase := usecases.NewLockoutUsecase(mocks.NewMockUserRepository(ctrl), repositories.NewMemoryLoginAttemptStore(), slog.New(slog.DiscardHandler), policy)
+	acme := domains.WithTenant(context.Background(), "tenant-acme")
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		if err := usecase.RecordFailure(acme, "user@example.com", ip); err != nil {
+			t.Fatalf("RecordFailure failed: %v", err)
+		}
+	}
+
+	err := usecase.Check(acme, "user@example.com", "10.0.0.3")
+	assertAPIErrorCode(t, err, errors.ErrCodeTooManyAttempts)
+
+	// The same address in another tenant is another account
+	other := domains.WithTenant(context.Background(), domains.DefaultTenantID)
+	if err := usecase.Check(other, "user@example.com", "10.0.0.3"); err != nil {
+		t.Errorf("expected other tenants to be allowed, got %v", err)
+	}
+}
+
+func TestLockout_ProgressiveDelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := usecases.LockoutPolicy{
+		FailureWindow: 15 * time.Minute,
+		DelayAfter:    2,
+		BaseDelay:     time.Minute,
+		MaxDelay:      3 * time.Minute,
+	}
+	usecase := usecases.NewLockoutUsecase(mocks.NewMockUserRepository(ctrl), repositories.NewMemoryLoginAttemptStore(), slog.New(slog.DiscardHandler), policy)
+	ctx := context.Background()
+
+	_ = usecase.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+	if err := usecase.Check(ctx, "user@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("expected no delay after the first failure, got %v", err)
+	}
+
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute}
+	for i, want := range expected {
+		_ = usecase.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+
+		err := usecase.Check(ctx, "user@example.com", "10.0.0.1")
+		assertAPIErrorCode(t, err, errors.ErrCodeTooManyAttempts)
+
+		retry := err.(*errors.APIError).RetryAfter
+		if retry > want || retry < want-time.Second {
+			t.Errorf("failure %d: expected a delay of %v, got %v", i+2, want, retry)
+		}
+	}
+}
+
+func TestLockout_SuccessClearsCounters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := repositories.NewMemoryLoginAttemptStore()
+	usecase := usecases.NewLockoutUsecase(mocks.NewMockUserRepository(ctrl), store, slog.New(slog.DiscardHandler), testLockoutPolicy)
+	ctx := context.Background()
+
+	_ = usecase.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+	_ = usecase.RecordFailure(ctx, "user@example.com", "10.0.0.2")
+
+	if err := usecase.RecordSuccess(ctx, "user@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("RecordSuccess failed: %v", err)
+	}
+
+	if attempt, _ := store.Get(ctx, "user@example.com", ""); attempt != nil {
+		t.Errorf("expected the account counter to be cleared, got %+v", attempt)
+	}
+
+	if attempt, _ := store.Get(ctx, "user@example.com", "10.0.0.2"); attempt == nil {
+		t.Error("expected counters of other addresses to be kept")
+	}
+}
+
+func TestLockout_Unlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewLockoutUsecase(mockRepo, repositories.NewMemoryLoginAttemptStore(), slog.New(slog.DiscardHandler), testLockoutPolicy)
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin",
+		Permissions: []string{domains.PermissionUsersWrite},
+	})
+
+	for i := 0; i < 3; i++ {
+		_ = usecase.RecordFailure(ctx, "user@example.com", "10.0.0.1")
+	}
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com"}, nil).
+		Times(1)
+
+	if err := usecase.Unlock(ctx, "123"); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	if err := usecase.Check(ctx, "user@example.com", "10.0.0.1"); err != nil {
+		t.Errorf("expected the lockout to be lifted, got %v", err)
+	}
+}
+
+func TestLockout_UnlockRequiresPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewLockoutUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockLoginAttemptStore(ctrl), slog.New(slog.DiscardHandler), testLockoutPolicy)
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "456",
+		Permissions: []string{domains.PermissionSelfWrite},
+	})
+
+	err := usecase.Unlock(ctx, "123")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
@@ -0,0 +1,308 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testMagicLinkConfig = usecases.MagicLinkConfig{
+	TokenTTL:        15 * time.Minute,
+	RequestInterval: time.Minute,
+	URL:             "https://app.example.com/magic-link",
+}
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRequestMagicLink_MailsBoundLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockMagicLinkTokenRepository(ctrl)
+	mockSender := mocks.NewMockMailSender(ctrl)
+	usecase := usecases.NewMagicLinkUsecase(mockRepo, mockTokens, mocks.NewMockAuthUsecase(ctrl), mockSender, slog.New(slog.DiscardHandler), testMagicLinkConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockTokens.EXPECT().GetLatestForUser(gomock.Any(), "123").Return(nil, nil).Times(1)
+	mockTokens.EXPECT().InvalidateForUser(gomock.Any(), "123").Return(nil).Times(1)
+
+	var stored *domains.MagicLinkToken
+	mockTokens.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *domains.MagicLinkToken) error {
+			stored = token
+			return nil
+		}).
+		Times(1)
+
+	var sent *domains.MailMessage
+	mockSender.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, message *domains.MailMessage) error {
+			sent = message
+			return nil
+		}).
+		Times(1)
+
+	binding, err := usecase.RequestLink(context.Background(), "user@example.com")
+	if err != nil {
+		t.Fatalf("RequestLink failed: %v", err)
+	}
+
+	if sent == nil || sent.To != "user@example.com" {
+		t.Fatalf("expected login mail to user@example.com, got %+v", sent)
+	}
+
+	var token string
+	for _, line := range strings.Split(sent.Body, "\n") {
+		if strings.HasPrefix(line, testMagicLinkConfig.URL+"?token=") {
+			link, _ := url.Parse(line)
+			token = link.Query().Get("token")
+		}
+	}
+
+	// Only hashes are stored, and the binding never leaves in the mail
+	if token == "" || stored.TokenHash != sha256Hex(token) {
+		t.Errorf("expected the hash of the mailed token to be stored, got %+v", stored)
+	}
+
+	if binding == "" || stored.BindingHash != sha256Hex(binding) || strings.Contains(sent.Body, binding) {
+		t.Errorf("expected the hash of the returned binding to be stored, got %+v", stored)
+	}
+
+	if time.Until(stored.ExpiresAt) > testMagicLinkConfig.TokenTTL {
+		t.Errorf("expected expiry within %s, got %v", testMagicLinkConfig.TokenTTL, stored.ExpiresAt)
+	}
+}
+
+func TestRequestMagicLink_MailFailureIsSilent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockMagicLinkTokenRepository(ctrl)
+	mockSender := mocks.NewMockMailSender(ctrl)
+	var logs bytes.Buffer
+	usecase := usecases.NewMagicLinkUsecase(mockRepo, mockTokens, mocks.NewMockAuthUsecase(ctrl), mockSender, slog.New(slog.NewJSONHandler(&logs, nil)), testMagicLinkConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockTokens.EXPECT().GetLatestForUser(gomock.Any(), "123").Return(nil, nil).Times(1)
+	mockTokens.EXPECT().InvalidateForUser(gomock.Any(), "123").Return(nil).Times(1)
+	mockTokens.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(stderrors.New("smtp unavailable")).Times(1)
+
+	// Unknown addresses get a binding, so registered ones must as well
+	binding, err := usecase.RequestLink(context.Background(), "user@example.com")
+	if err != nil || binding == "" {
+		t.Fatalf("expected silent success, got %q, %v", binding, err)
+	}
+
+	if !strings.Contains(logs.String(), "smtp unavailable") {
+		t.Errorf("expected the failure to be logged, got %q", logs.String())
+	}
+}
+
+func TestRequestMagicLink_IsSilentForUnusableAccounts(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name string
+		user *domains.User
+	}{
+		{"unknown", nil},
+		{"deleted", &domains.User{ID: "123", IsActive: true, DeletedAt: &deletedAt}},
+		{"inactive", &domains.User{ID: "123"}},
+		{"service account", &domains.User{ID: "123", IsActive: true, IsServiceAccount: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			usecase := usecases.NewMagicLinkUsecase(mockRepo, mocks.NewMockMagicLinkTokenRepository(ctrl), mocks.NewMockAuthUsecase(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testMagicLinkConfig)
+
+			mockRepo.EXPECT().
+				GetByEmail(gomock.Any(), "user@example.com").
+				Return(tt.user, nil).
+				Times(1)
+
+			// The caller still gets a binding, so the response looks the same
+			binding, err := usecase.RequestLink(context.Background(), "user@example.com")
+			if err != nil || binding == "" {
+				t.Fatalf("expected silent success, got %q, %v", binding, err)
+			}
+		})
+	}
+}
+
+func TestRequestMagicLink_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockMagicLinkTokenRepository(ctrl)
+	usecase := usecases.NewMagicLinkUsecase(mockRepo, mockTokens, mocks.NewMockAuthUsecase(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testMagicLinkConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockTokens.EXPECT().
+		GetLatestForUser(gomock.Any(), "123").
+		Return(&domains.MagicLinkToken{ID: "ml-1", CreatedAt: time.Now().Add(-10 * time.Second)}, nil).
+		Times(1)
+
+	// No new token and no mail within the request interval
+	if _, err := usecase.RequestLink(context.Background(), "user@example.com"); err != nil {
+		t.Fatalf("RequestLink failed: %v", err)
+	}
+}
+
+func TestMagicLinkLogin_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockMagicLinkTokenRepository(ctrl)
+	mockAuth := mocks.NewMockAuthUsecase(ctrl)
+	usecase := usecases.NewMagicLinkUsecase(mockRepo, mockTokens, mockAuth, mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testMagicLinkConfig)
+
+	mockTokens.EXPECT().
+		GetByHash(gomock.Any(), sha256Hex("opaque-token")).
+		Return(&domains.MagicLinkToken{ID: "ml-1", UserID: "123", TenantID: "tenant-2", BindingHash: sha256Hex("binding"), ExpiresAt: time.Now().Add(time.Minute)}, nil).
+		Times(1)
+
+	// The link need not name the tenant of the user
+	user := &domains.User{ID: "123", TenantID: "tenant-2", Email: "user@example.com", IsActive: true}
+	mockRepo.EXPECT().GetByID(gomock.Any(), "123").DoAndReturn(userInTenant(user)).Times(1)
+
+	mockTokens.EXPECT().MarkUsed(gomock.Any(), "ml-1").Return(true, nil).Times(1)
+
+	// Following the link proves control of the address
+	mockRepo.EXPECT().
+		MarkEmailVerified(gomock.Any(), "123", gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	issued := &domains.AuthToken{AccessToken: "token", RefreshToken: "refresh"}
+	mockAuth.EXPECT().
+		LoginWithoutPassword(gomock.Any(), user).
+		DoAndReturn(func(ctx context.Context, _ *domains.User) (*domains.AuthToken, error) {
+			if tenantID, _ := domains.TenantFromContext(ctx); tenantID != "tenant-2" {
+				t.Errorf("expected the login to complete in tenant-2, got %q", tenantID)
+			}
+			return issued, nil
+		}).
+		Times(1)
+
+	token, err := usecase.Login(context.Background(), &domains.MagicLinkLoginInput{Token: "opaque-token", Binding: "binding"})
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+
+	if token != issued {
+		t.Errorf("expected the issued tokens, got %+v", token)
+	}
+
+	if user.EmailVerifiedAt == nil {
+		t.Error("expected the email address to be marked verified")
+	}
+}
+
+func TestMagicLinkLogin_WrongBinding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokens := mocks.NewMockMagicLinkTokenRepository(ctrl)
+	usecase := usecases.NewMagicLinkUsecase(mocks.NewMockUserRepository(ctrl), mockTokens, mocks.NewMockAuthUsecase(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testMagicLinkConfig)
+
+	mockTokens.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.MagicLinkToken{ID: "ml-1", UserID: "123", BindingHash: sha256Hex("binding"), ExpiresAt: time.Now().Add(time.Minute)}, nil).
+		Times(1)
+
+	// The token stays usable for the client that requested it
+	_, err := usecase.Login(context.Background(), &domains.MagicLinkLoginInput{Token: "opaque-token", Binding: "other-binding"})
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
+
+func TestMagicLinkLogin_RejectsUnusableTokens(t *testing.T) {
+	usedAt := time.Now()
+
+	tests := []struct {
+		name  string
+		token *domains.MagicLinkToken
+	}{
+		{"unknown", nil},
+		{"expired", &domains.MagicLinkToken{ID: "ml-1", UserID: "123", BindingHash: sha256Hex("binding"), ExpiresAt: time.Now().Add(-time.Minute)}},
+		{"used", &domains.MagicLinkToken{ID: "ml-1", UserID: "123", BindingHash: sha256Hex("binding"), ExpiresAt: time.Now().Add(time.Minute), UsedAt: &usedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTokens := mocks.NewMockMagicLinkTokenRepository(ctrl)
+			usecase := usecases.NewMagicLinkUsecase(mocks.NewMockUserRepository(ctrl), mockTokens, mocks.NewMockAuthUsecase(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testMagicLinkConfig)
+
+			mockTokens.EXPECT().
+				GetByHash(gomock.Any(), gomock.Any()).
+				Return(tt.token, nil).
+				Times(1)
+
+			_, err := usecase.Login(context.Background(), &domains.MagicLinkLoginInput{Token: "opaque-token", Binding: "binding"})
+			assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+		})
+	}
+}
+
+func TestMagicLinkLogin_ConcurrentUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockMagicLinkTokenRepository(ctrl)
+	usecase := usecases.NewMagicLinkUsecase(mockRepo, mockTokens, mocks.NewMockAuthUsecase(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testMagicLinkConfig)
+
+	mockTokens.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.MagicLinkToken{ID: "ml-1", UserID: "123", BindingHash: sha256Hex("binding"), ExpiresAt: time.Now().Add(time.Minute)}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), "123").Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).Times(1)
+
+	// Another request consumed the token between the read and the update
+	mockTokens.EXPECT().MarkUsed(gomock.Any(), "ml-1").Return(false, nil).Times(1)
+
+	_, err := usecase.Login(context.Background(), &domains.MagicLinkLoginInput{Token: "opaque-token", Binding: "binding"})
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
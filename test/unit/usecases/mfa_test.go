@@ -0,0 +1,338 @@
+package usecases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/pkg/totp"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+// testTOTPSecret is the RFC 6238 test secret, base32 encoded
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var testMFAConfig = usecases.MFAConfig{Issuer: "Test"}
+
+func TestMFAEnroll_ReturnsSecretAndURI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, newTestHasher(t), testMFAConfig)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com"}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().GetFactor(gomock.Any(), "123").Return(nil, nil).Times(1)
+
+	var savedSecret string
+	mockMFA.EXPECT().
+		SaveFactor(gomock.Any(), "123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, secret string) error {
+			savedSecret = secret
+			return nil
+		}).
+		Times(1)
+
+	enrollment, err := usecase.Enroll(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+
+	if enrollment.Secret == "" || enrollment.Secret != savedSecret {
+		t.Errorf("expected the stored secret to be returned, got %q and %q", enrollment.Secret, savedSecret)
+	}
+
+	if !strings.HasPrefix(enrollment.URI, "otpauth://totp/Test:user@example.com?") {
+		t.Errorf("unexpected otpauth URI: %s", enrollment.URI)
+	}
+}
+
+func TestMFAEnroll_AlreadyEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, newTestHasher(t), testMFAConfig)
+
+	confirmedAt := time.Now()
+	mockRepo.EXPECT().GetByID(gomock.Any(), "123").Return(&domains.User{ID: "123"}, nil).Times(1)
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil).
+		Times(1)
+
+	_, err := usecase.Enroll(context.Background(), "123")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeConflict)
+}
+
+func TestMFAConfirmEnrollment_IssuesRecoveryCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, newTestHasher(t), testMFAConfig)
+
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().AdvanceStep(gomock.Any(), "123", gomock.Any()).Return(true, nil).Times(1)
+	mockMFA.EXPECT().ConfirmFactor(gomock.Any(), "123").Return(true, nil).Times(1)
+
+	var storedHashes []string
+	mockMFA.EXPECT().
+		ReplaceRecoveryCodes(gomock.Any(), "123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, hashes []string) error {
+			storedHashes = hashes
+			return nil
+		}).
+		Times(1)
+
+	codes, err := usecase.ConfirmEnrollment(context.Background(), "123", currentTOTPCode(t))
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment failed: %v", err)
+	}
+
+	if len(codes) != 10 || len(storedHashes) != len(codes) {
+		t.Fatalf("expected 10 recovery codes, got %d codes and %d hashes", len(codes), len(storedHashes))
+	}
+
+	for i, code := range codes {
+		if storedHashes[i] == code {
+			t.Error("recovery codes must not be stored in plain text")
+		}
+	}
+}
+
+func TestMFAConfirmEnrollment_RejectsReplayedCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, newTestHasher(t), testMFAConfig)
+
+	// The current step was already used
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, LastUsedStep: totp.Step(time.Now()) + 1}, nil).
+		Times(1)
+
+	_, err := usecase.ConfirmEnrollment(context.Background(), "123", currentTOTPCode(t))
+
+	assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+}
+
+func TestMFARegenerateRecoveryCodes_AcceptsRecoveryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, newTestHasher(t), testMFAConfig)
+
+	confirmedAt := time.Now()
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil).
+		Times(1)
+
+	// Case and separators do not matter
+	var usedHash string
+	mockMFA.EXPECT().
+		UseRecoveryCode(gomock.Any(), "123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, codeHash string) (bool, error) {
+			usedHash = codeHash
+			return true, nil
+		}).
+		Times(2)
+
+	mockMFA.EXPECT().ReplaceRecoveryCodes(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
+
+	if _, err := usecase.RegenerateRecoveryCodes(context.Background(), "123", "ABCDE-FGHIJ"); err != nil {
+		t.Fatalf("RegenerateRecoveryCodes failed: %v", err)
+	}
+	firstHash := usedHash
+
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil).
+		Times(1)
+	mockMFA.EXPECT().ReplaceRecoveryCodes(gomock.Any(), "123", gomock.Any()).Return(nil).Times(1)
+
+	if _, err := usecase.RegenerateRecoveryCodes(context.Background(), "123", "abcdefghij"); err != nil {
+		t.Fatalf("RegenerateRecoveryCodes failed: %v", err)
+	}
+
+	if usedHash != firstHash {
+		t.Error("expected recovery codes to be normalized before hashing")
+	}
+}
+
+func TestMFADisable_WrongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, hasher, testMFAConfig)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", PasswordHash: mustHash(t, hasher, "password123")}, nil).
+		Times(1)
+
+	err := usecase.Disable(context.Background(), "123", &domains.DisableMFAInput{
+		Password: "wrong-password",
+		Code:     currentTOTPCode(t),
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+}
+
+func TestMFADisable_UnsupportedHashIsIncorrect(t *testing.T) {
+	tests := []struct {
+		name         string
+		passwordHash string
+	}{
+		{"unknown format", "$md5$password123"},
+		{"service account", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			usecase := usecases.NewMFAUsecase(mockRepo, mocks.NewMockMFARepository(ctrl), newTestHasher(t), testMFAConfig)
+
+			mockRepo.EXPECT().
+				GetByID(gomock.Any(), "123").
+				Return(&domains.User{ID: "123", PasswordHash: tt.passwordHash}, nil).
+				Times(1)
+
+			err := usecase.Disable(context.Background(), "123", &domains.DisableMFAInput{
+				Password: "password123",
+				Code:     currentTOTPCode(t),
+			})
+
+			assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+		})
+	}
+}
+
+func TestMFADisable_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, hasher, testMFAConfig)
+
+	confirmedAt := time.Now()
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", PasswordHash: mustHash(t, hasher, "password123")}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().
+		GetFactor(gomock.Any(), "123").
+		Return(&domains.MFAFactor{UserID: "123", Secret: testTOTPSecret, ConfirmedAt: &confirmedAt}, nil).
+		Times(1)
+
+	mockMFA.EXPECT().AdvanceStep(gomock.Any(), "123", gomock.Any()).Return(true, nil).Times(1)
+	mockMFA.EXPECT().DeleteFactor(gomock.Any(), "123").Return(nil).Times(1)
+
+	err := usecase.Disable(context.Background(), "123", &domains.DisableMFAInput{
+		Password: "password123",
+		Code:     currentTOTPCode(t),
+	})
+
+	if err != nil {
+		t.Fatalf("Disable failed: %v", err)
+	}
+}
+
+func TestMFAReset_RequiresPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, newTestHasher(t), testMFAConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "456",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	err := usecase.Reset(ctx, "123")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestMFAReset_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockMFA := mocks.NewMockMFARepository(ctrl)
+	usecase := usecases.NewMFAUsecase(mockRepo, mockMFA, newTestHasher(t), testMFAConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin",
+		Permissions: []string{domains.PermissionUsersWrite},
+	})
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), "123").Return(&domains.User{ID: "123"}, nil).Times(1)
+	mockMFA.EXPECT().DeleteFactor(gomock.Any(), "123").Return(nil).Times(1)
+
+	if err := usecase.Reset(ctx, "123"); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+}
+
+func TestMFAReset_RejectedWhileImpersonating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewMFAUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockMFARepository(ctrl), newTestHasher(t), testMFAConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "456",
+		ActorID:     "admin-1",
+		Permissions: []string{domains.PermissionUsersWrite},
+	})
+
+	err := usecase.Reset(ctx, "123")
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+// currentTOTPCode returns the code an authenticator app shows right now for testTOTPSecret
+func currentTOTPCode(t *testing.T) string {
+	t.Helper()
+
+	code, err := totp.Code(testTOTPSecret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("failed to generate TOTP code: %v", err)
+	}
+	return code
+}
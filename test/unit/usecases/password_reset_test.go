@@ -0,0 +1,396 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/pkg/password"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testPasswordResetConfig = usecases.PasswordResetConfig{
+	TokenTTL:        time.Hour,
+	RequestInterval: time.Minute,
+	URL:             "https://app.example.com/reset-password",
+}
+
+// expectSessionsRevoked expects every reset token and session of the user to be revoked
+func expectSessionsRevoked(tokens *mocks.MockPasswordResetTokenRepository, revocations *mocks.MockTokenRevocationStore, refreshTokens *mocks.MockRefreshTokenRepository, userID string) {
+	tokens.EXPECT().InvalidateForUser(gomock.Any(), userID).Return(nil).Times(1)
+	revocations.EXPECT().RevokeUserTokens(gomock.Any(), userID).Return(nil).Times(1)
+	refreshTokens.EXPECT().RevokeAllForUser(gomock.Any(), userID).Return(nil).Times(1)
+}
+
+func TestChangePassword_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockPasswordResetTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRefreshTokens := mocks.NewMockRefreshTokenRepository(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewPasswordUsecase(mockRepo, mockTokens, hasher, mockRevocations, mockRefreshTokens, mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: mustHash(t, hasher, "password123"), IsActive: true}, nil).
+		Times(1)
+
+	// Expect the new password to be stored
+	mockRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, user *domains.User) error {
+			if match, _ := hasher.Verify("new-password456", user.PasswordHash); !match {
+				t.Error("expected the new password to be stored")
+			}
+			return nil
+		}).
+		Times(1)
+
+	expectSessionsRevoked(mockTokens, mockRevocations, mockRefreshTokens, "123")
+
+	err := usecase.ChangePassword(context.Background(), "123", &domains.ChangePasswordInput{
+		CurrentPassword: "password123",
+		NewPassword:     "new-password456",
+	})
+
+	if err != nil {
+		t.Fatalf("ChangePassword failed: %v", err)
+	}
+}
+
+func TestChangePassword_WrongCurrentPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewPasswordUsecase(mockRepo, mocks.NewMockPasswordResetTokenRepository(ctrl), hasher, mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: mustHash(t, hasher, "password123"), IsActive: true}, nil).
+		Times(1)
+
+	err := usecase.ChangePassword(context.Background(), "123", &domains.ChangePasswordInput{
+		CurrentPassword: "wrong-password",
+		NewPassword:     "new-password456",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+}
+
+func TestChangePassword_UnsupportedHashIsIncorrect(t *testing.T) {
+	tests := []struct {
+		name         string
+		passwordHash string
+	}{
+		{"unknown format", "$md5$password123"},
+		{"service account", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			usecase := usecases.NewPasswordUsecase(mockRepo, mocks.NewMockPasswordResetTokenRepository(ctrl), newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+			mockRepo.EXPECT().
+				GetByID(gomock.Any(), "123").
+				Return(&domains.User{ID: "123", Email: "user@example.com", PasswordHash: tt.passwordHash, IsActive: true}, nil).
+				Times(1)
+
+			err := usecase.ChangePassword(context.Background(), "123", &domains.ChangePasswordInput{
+				CurrentPassword: "password123",
+				NewPassword:     "new-password456",
+			})
+
+			assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+		})
+	}
+}
+
+func TestChangePassword_RejectedWhileImpersonating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewPasswordUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockPasswordResetTokenRepository(ctrl), newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{UserID: "123", ActorID: "admin-1"})
+
+	err := usecase.ChangePassword(ctx, "123", &domains.ChangePasswordInput{
+		CurrentPassword: "password123",
+		NewPassword:     "new-password456",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestChangePassword_RejectsOverlongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewPasswordUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockPasswordResetTokenRepository(ctrl), newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	err := usecase.ChangePassword(context.Background(), "123", &domains.ChangePasswordInput{
+		CurrentPassword: "password123",
+		NewPassword:     strings.Repeat("a", password.MaxLength+1),
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+}
+
+func TestForgotPassword_MailsResetLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockPasswordResetTokenRepository(ctrl)
+	mockSender := mocks.NewMockMailSender(ctrl)
+	usecase := usecases.NewPasswordUsecase(mockRepo, mockTokens, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mockSender, slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockTokens.EXPECT().
+		GetLatestForUser(gomock.Any(), "123").
+		Return(nil, nil).
+		Times(1)
+
+	mockTokens.EXPECT().
+		InvalidateForUser(gomock.Any(), "123").
+		Return(nil).
+		Times(1)
+
+	var stored *domains.PasswordResetToken
+	mockTokens.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *domains.PasswordResetToken) error {
+			stored = token
+			return nil
+		}).
+		Times(1)
+
+	var sent *domains.MailMessage
+	mockSender.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, message *domains.MailMessage) error {
+			sent = message
+			return nil
+		}).
+		Times(1)
+
+	if err := usecase.ForgotPassword(context.Background(), "user@example.com"); err != nil {
+		t.Fatalf("ForgotPassword failed: %v", err)
+	}
+
+	if sent == nil || sent.To != "user@example.com" {
+		t.Fatalf("expected reset mail to user@example.com, got %+v", sent)
+	}
+
+	if !strings.Contains(sent.Body, testPasswordResetConfig.URL+"?token=") {
+		t.Errorf("expected reset link in mail body, got %s", sent.Body)
+	}
+
+	// Only the hash of the mailed token is stored
+	if stored == nil || strings.Contains(sent.Body, stored.TokenHash) {
+		t.Errorf("expected hashed token to be stored, got %+v", stored)
+	}
+}
+
+func TestForgotPassword_MailFailureIsSilent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockPasswordResetTokenRepository(ctrl)
+	mockSender := mocks.NewMockMailSender(ctrl)
+	var logs bytes.Buffer
+	usecase := usecases.NewPasswordUsecase(mockRepo, mockTokens, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mockSender, slog.New(slog.NewJSONHandler(&logs, nil)), testPasswordResetConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockTokens.EXPECT().GetLatestForUser(gomock.Any(), "123").Return(nil, nil).Times(1)
+	mockTokens.EXPECT().InvalidateForUser(gomock.Any(), "123").Return(nil).Times(1)
+	mockTokens.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(stderrors.New("smtp unavailable")).Times(1)
+
+	// Unknown addresses succeed, so registered ones must as well
+	if err := usecase.ForgotPassword(context.Background(), "user@example.com"); err != nil {
+		t.Fatalf("expected silent success, got %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "smtp unavailable") {
+		t.Errorf("expected the failure to be logged, got %q", logs.String())
+	}
+}
+
+func TestForgotPassword_UnknownEmailIsSilent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewPasswordUsecase(mockRepo, mocks.NewMockPasswordResetTokenRepository(ctrl), newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "nobody@example.com").
+		Return(nil, nil).
+		Times(1)
+
+	if err := usecase.ForgotPassword(context.Background(), "nobody@example.com"); err != nil {
+		t.Fatalf("expected silent success, got %v", err)
+	}
+}
+
+func TestForgotPassword_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockPasswordResetTokenRepository(ctrl)
+	usecase := usecases.NewPasswordUsecase(mockRepo, mockTokens, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockTokens.EXPECT().
+		GetLatestForUser(gomock.Any(), "123").
+		Return(&domains.PasswordResetToken{ID: "pr-1", CreatedAt: time.Now().Add(-10 * time.Second)}, nil).
+		Times(1)
+
+	// No new token and no mail within the request interval
+	if err := usecase.ForgotPassword(context.Background(), "user@example.com"); err != nil {
+		t.Fatalf("ForgotPassword failed: %v", err)
+	}
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockPasswordResetTokenRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRefreshTokens := mocks.NewMockRefreshTokenRepository(ctrl)
+	hasher := newTestHasher(t)
+	usecase := usecases.NewPasswordUsecase(mockRepo, mockTokens, hasher, mockRevocations, mockRefreshTokens, mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	mockTokens.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.PasswordResetToken{ID: "pr-1", UserID: "123", TenantID: "tenant-2", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+
+	// The reset page need not name the tenant of the user
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		DoAndReturn(userInTenant(&domains.User{ID: "123", TenantID: "tenant-2", Email: "user@example.com", PasswordHash: "old", IsActive: true})).
+		Times(1)
+
+	mockTokens.EXPECT().
+		MarkUsed(gomock.Any(), "pr-1").
+		Return(true, nil).
+		Times(1)
+
+	// Expect the new password to be stored
+	mockRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, user *domains.User) error {
+			if match, _ := hasher.Verify("new-password456", user.PasswordHash); !match {
+				t.Error("expected the new password to be stored")
+			}
+			return nil
+		}).
+		Times(1)
+
+	expectSessionsRevoked(mockTokens, mockRevocations, mockRefreshTokens, "123")
+
+	err := usecase.ResetPassword(context.Background(), &domains.ResetPasswordInput{
+		Token:       "opaque-token",
+		NewPassword: "new-password456",
+	})
+
+	if err != nil {
+		t.Fatalf("ResetPassword failed: %v", err)
+	}
+}
+
+func TestResetPassword_KeepsTokenWhenUserLookupFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokens := mocks.NewMockPasswordResetTokenRepository(ctrl)
+	usecase := usecases.NewPasswordUsecase(mockRepo, mockTokens, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+	mockTokens.EXPECT().
+		GetByHash(gomock.Any(), gomock.Any()).
+		Return(&domains.PasswordResetToken{ID: "pr-1", UserID: "123", ExpiresAt: time.Now().Add(time.Hour)}, nil).
+		Times(1)
+
+	// The token is not consumed, so that the link can be followed again
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(nil, stderrors.New("connection refused")).
+		Times(1)
+
+	err := usecase.ResetPassword(context.Background(), &domains.ResetPasswordInput{
+		Token:       "opaque-token",
+		NewPassword: "new-password456",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeDatabaseError)
+}
+
+func TestResetPassword_RejectsUnusableTokens(t *testing.T) {
+	usedAt := time.Now()
+
+	tests := []struct {
+		name  string
+		token *domains.PasswordResetToken
+	}{
+		{"unknown", nil},
+		{"expired", &domains.PasswordResetToken{ID: "pr-1", UserID: "123", ExpiresAt: time.Now().Add(-time.Minute)}},
+		{"used", &domains.PasswordResetToken{ID: "pr-1", UserID: "123", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTokens := mocks.NewMockPasswordResetTokenRepository(ctrl)
+			usecase := usecases.NewPasswordUsecase(mocks.NewMockUserRepository(ctrl), mockTokens, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockMailSender(ctrl), slog.New(slog.DiscardHandler), testPasswordResetConfig)
+
+			mockTokens.EXPECT().
+				GetByHash(gomock.Any(), gomock.Any()).
+				Return(tt.token, nil).
+				Times(1)
+
+			err := usecase.ResetPassword(context.Background(), &domains.ResetPasswordInput{
+				Token:       "opaque-token",
+				NewPassword: "new-password456",
+			})
+
+			assertAPIErrorCode(t, err, errors.ErrCodeBadRequest)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+func TestPermissionCache_ReusesLookups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roles := mocks.NewMockRoleRepository(ctrl)
+	cache := usecases.NewPermissionCache(roles, usecases.PermissionCacheConfig{TTL: time.Minute})
+
+	roles.EXPECT().
+		GetUserPermissions(gomock.Any(), "user-1").
+		Return([]string{domains.PermissionUsersRead}, nil).
+		Times(1)
+
+	for range 3 {
+		permissions, err := cache.Permissions(context.Background(), "user-1")
+		if err != nil {
+			t.Fatalf("Permissions failed: %v", err)
+		}
+
+		if len(permissions) != 1 || permissions[0] != domains.PermissionUsersRead {
+			t.Errorf("unexpected permissions: %v", permissions)
+		}
+	}
+}
+
+func TestPermissionCache_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roles := mocks.NewMockRoleRepository(ctrl)
+	cache := usecases.NewPermissionCache(roles, usecases.PermissionCacheConfig{TTL: time.Minute})
+
+	gomock.InOrder(
+		roles.EXPECT().
+			GetUserPermissions(gomock.Any(), "user-1").
+			Return([]string{domains.PermissionSelfRead}, nil),
+		roles.EXPECT().
+			GetUserPermissions(gomock.Any(), "user-1").
+			Return([]string{domains.PermissionSelfRead, domains.PermissionGroupsRead}, nil),
+	)
+
+	if _, err := cache.Permissions(context.Background(), "user-1"); err != nil {
+		t.Fatalf("Permissions failed: %v", err)
+	}
+
+	cache.Invalidate("user-1")
+
+	permissions, err := cache.Permissions(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Permissions failed: %v", err)
+	}
+
+	if len(permissions) != 2 {
+		t.Errorf("expected the new permissions after invalidation, got %v", permissions)
+	}
+}
+
+func TestPermissionCache_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roles := mocks.NewMockRoleRepository(ctrl)
+	cache := usecases.NewPermissionCache(roles, usecases.PermissionCacheConfig{})
+
+	roles.EXPECT().
+		GetUserPermissions(gomock.Any(), "user-1").
+		Return([]string{domains.PermissionSelfRead}, nil).
+		Times(2)
+
+	for range 2 {
+		if _, err := cache.Permissions(context.Background(), "user-1"); err != nil {
+			t.Fatalf("Permissions failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/repositories"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+var testSessionConfig = usecases.SessionConfig{
+	IdleTimeout:     30 * time.Minute,
+	AbsoluteTimeout: 24 * time.Hour,
+}
+
+func expectSessionAccess(roles *mocks.MockRoleRepository, userID string, times int) {
+	roles.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]string{domains.RoleUser}, nil).Times(times)
+	roles.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]string{domains.PermissionSelfRead}, nil).Times(times)
+}
+
+func TestStartSession_StoresHashedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := repositories.NewMemorySessionStore()
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	expectSessionAccess(mockRoles, "123", 1)
+
+	usecase := usecases.NewSessionUsecase(store, mockRoles, nil, testSessionConfig)
+
+	issued, err := usecase.Start(context.Background(), &domains.User{ID: "123", Email: "test@example.com"})
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if issued.Token == "" || issued.Session.CSRFToken == "" || issued.Session.ID == issued.Token {
+		t.Fatalf("expected a token stored only as its hash, got %+v", issued)
+	}
+
+	stored, _ := store.Get(context.Background(), issued.Session.ID)
+	if stored == nil || stored.UserID != "123" || len(stored.Permissions) != 1 {
+		t.Fatalf("unexpected stored session: %+v", stored)
+	}
+
+	if got := issued.Session.ExpiresAt.Sub(issued.Session.CreatedAt); got != testSessionConfig.IdleTimeout {
+		t.Errorf("expected idle expiry of %s, got %s", testSessionConfig.IdleTimeout, got)
+	}
+}
+
+func TestResumeSession(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		expiresAt   time.Time
+		absolute    time.Time
+		wantRenewed bool
+		wantErr     bool
+	}{
+		{"fresh session is not written", now.Add(25 * time.Minute), now.Add(time.Hour), false, false},
+		{"session past half its idle timeout is extended", now.Add(10 * time.Minute), now.Add(time.Hour), true, false},
+		{"extension stops at the absolute expiry", now.Add(5 * time.Minute), now.Add(5 * time.Minute), false, false},
+		{"idle session is rejected", now.Add(-time.Second), now.Add(time.Hour), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := repositories.NewMemorySessionStore()
+			mockRoles := mocks.NewMockRoleRepository(ctrl)
+			if tt.wantRenewed {
+				expectSessionAccess(mockRoles, "123", 1)
+			}
+
+			usecase := usecases.NewSessionUsecase(store, mockRoles, nil, testSessionConfig)
+
+			// The ID is the SHA-256 hex digest of the token "token"
+			id := "3c469e9d6c5875d37a43f353d4f88e61fcf812c66eee3457465a40b0da4153e0"
+			_ = store.Create(context.Background(), &domains.Session{
+				ID:                id,
+				UserID:            "123",
+				CSRFToken:         "csrf",
+				ExpiresAt:         tt.expiresAt,
+				AbsoluteExpiresAt: tt.absolute,
+				CreatedAt:         now.Add(-time.Hour),
+			})
+
+			session, renewed, err := usecase.Resume(context.Background(), "token")
+
+			if tt.wantErr {
+				assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+				if stored, _ := store.Get(context.Background(), id); stored != nil {
+					t.Error("expected the expired session to be removed")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Resume failed: %v", err)
+			}
+
+			if renewed != tt.wantRenewed {
+				t.Errorf("expected renewed=%v, got %v", tt.wantRenewed, renewed)
+			}
+
+			if session.ExpiresAt.After(tt.absolute) {
+				t.Errorf("session extended past its absolute expiry: %s", session.ExpiresAt)
+			}
+
+			if stored, _ := store.Get(context.Background(), id); !stored.ExpiresAt.Equal(session.ExpiresAt) {
+				t.Errorf("expected stored expiry %s, got %s", session.ExpiresAt, stored.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestResumeSession_RevokedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := repositories.NewMemorySessionStore()
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	expectSessionAccess(mockRoles, "123", 1)
+
+	revocations := repositories.NewMemoryTokenRevocationStore()
+	usecase := usecases.NewSessionUsecase(store, mockRoles, revocations, testSessionConfig)
+
+	issued, err := usecase.Start(context.Background(), &domains.User{ID: "123"})
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// Password changes and deactivation revoke every credential of the user
+	_ = revocations.RevokeUserTokens(context.Background(), "123")
+
+	_, _, err = usecase.Resume(context.Background(), issued.Token)
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+
+	if stored, _ := store.Get(context.Background(), issued.Session.ID); stored != nil {
+		t.Error("expected the revoked session to be removed")
+	}
+}
+
+func TestResumeSession_UnknownToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewSessionUsecase(repositories.NewMemorySessionStore(), mocks.NewMockRoleRepository(ctrl), nil, testSessionConfig)
+
+	_, _, err := usecase.Resume(context.Background(), "unknown")
+	assertAPIErrorCode(t, err, errors.ErrCodeUnauthorized)
+}
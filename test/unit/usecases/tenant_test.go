@@ -0,0 +1,181 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+func platformAdminContext() context.Context {
+	return domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "platform-admin-1",
+		Permissions: []string{domains.PermissionTenantsRead, domains.PermissionTenantsWrite},
+	})
+}
+
+func TestCreateTenant_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTenantRepository(ctrl)
+	usecase := usecases.NewTenantUsecase(mockRepo)
+
+	mockRepo.EXPECT().GetBySlug(gomock.Any(), "acme").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, tenant *domains.Tenant) error {
+			if tenant.ID == "" || tenant.Slug != "acme" || tenant.Name != "Acme Corporation" || !tenant.IsActive {
+				t.Errorf("unexpected tenant: %+v", tenant)
+			}
+			return nil
+		}).
+		Times(1)
+
+	tenant, err := usecase.CreateTenant(platformAdminContext(), &domains.CreateTenantInput{
+		Slug: " Acme ",
+		Name: "Acme Corporation",
+	})
+	if err != nil {
+		t.Fatalf("CreateTenant failed: %v", err)
+	}
+
+	if tenant.Slug != "acme" {
+		t.Errorf("expected normalized slug, got %s", tenant.Slug)
+	}
+}
+
+func TestCreateTenant_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *domains.CreateTenantInput
+	}{
+		{"nil input", nil},
+		{"empty slug", &domains.CreateTenantInput{Name: "Acme"}},
+		{"slug with dot", &domains.CreateTenantInput{Slug: "acme.corp", Name: "Acme"}},
+		{"leading hyphen", &domains.CreateTenantInput{Slug: "-acme", Name: "Acme"}},
+		{"empty name", &domains.CreateTenantInput{Slug: "acme", Name: " "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := usecases.NewTenantUsecase(mocks.NewMockTenantRepository(ctrl))
+
+			_, err := usecase.CreateTenant(platformAdminContext(), tt.input)
+			assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+		})
+	}
+}
+
+func TestCreateTenant_DuplicateSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTenantRepository(ctrl)
+	usecase := usecases.NewTenantUsecase(mockRepo)
+
+	mockRepo.EXPECT().GetBySlug(gomock.Any(), "acme").Return(&domains.Tenant{ID: "tenant-1", Slug: "acme"}, nil).Times(1)
+
+	_, err := usecase.CreateTenant(platformAdminContext(), &domains.CreateTenantInput{Slug: "acme", Name: "Acme"})
+	assertAPIErrorCode(t, err, errors.ErrCodeDuplicateEntry)
+}
+
+func TestCreateTenant_RequiresPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewTenantUsecase(mocks.NewMockTenantRepository(ctrl))
+
+	// Tenant admins manage users, not tenants
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Roles:       []string{domains.RoleAdmin},
+		Permissions: []string{domains.PermissionUsersWrite, domains.PermissionTenantsRead},
+	})
+
+	_, err := usecase.CreateTenant(ctx, &domains.CreateTenantInput{Slug: "acme", Name: "Acme"})
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestListTenants_DefaultLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTenantRepository(ctrl)
+	usecase := usecases.NewTenantUsecase(mockRepo)
+
+	mockRepo.EXPECT().List(gomock.Any(), int32(10), int32(0)).Return([]*domains.Tenant{{ID: "tenant-1"}}, nil).Times(1)
+
+	tenants, err := usecase.ListTenants(platformAdminContext(), 500, -1)
+	if err != nil {
+		t.Fatalf("ListTenants failed: %v", err)
+	}
+
+	if len(tenants) != 1 {
+		t.Errorf("expected 1 tenant, got %d", len(tenants))
+	}
+}
+
+func TestGetTenant_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTenantRepository(ctrl)
+	usecase := usecases.NewTenantUsecase(mockRepo)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), "missing").Return(nil, nil).Times(1)
+
+	_, err := usecase.GetTenant(platformAdminContext(), "missing")
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestResolveTenant(t *testing.T) {
+	tests := []struct {
+		name    string
+		slug    string
+		tenant  *domains.Tenant
+		lookup  bool
+		wantErr bool
+	}{
+		{"active tenant", "Acme", &domains.Tenant{ID: "tenant-1", Slug: "acme", IsActive: true}, true, false},
+		{"unknown tenant", "acme", nil, true, true},
+		{"inactive tenant", "acme", &domains.Tenant{ID: "tenant-1", Slug: "acme"}, true, true},
+		{"malformed slug", "acme.corp", nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTenantRepository(ctrl)
+			usecase := usecases.NewTenantUsecase(mockRepo)
+
+			if tt.lookup {
+				mockRepo.EXPECT().GetBySlug(gomock.Any(), "acme").Return(tt.tenant, nil).Times(1)
+			}
+
+			// Resolution happens before authentication, without a principal
+			tenant, err := usecase.ResolveTenant(context.Background(), tt.slug)
+			if tt.wantErr {
+				assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResolveTenant failed: %v", err)
+			}
+
+			if tenant.ID != "tenant-1" {
+				t.Errorf("expected tenant-1, got %s", tenant.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/test/unit/mocks"
+)
+
+func TestPurgeDeletedUsers_EveryTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTenants := mocks.NewMockTenantRepository(ctrl)
+	usecase := usecases.NewUserRetentionUsecase(mockRepo, mockTenants, slog.New(slog.DiscardHandler), usecases.UserRetentionConfig{
+		Retention: 30 * 24 * time.Hour,
+	})
+
+	// A full page of tenants is followed by another page
+	firstPage := make([]*domains.Tenant, 100)
+	for i := range firstPage {
+		firstPage[i] = &domains.Tenant{ID: fmt.Sprintf("tenant-%d", i)}
+	}
+	mockTenants.EXPECT().List(gomock.Any(), int32(100), int32(0)).Return(firstPage, nil)
+	mockTenants.EXPECT().List(gomock.Any(), int32(100), int32(100)).Return([]*domains.Tenant{{ID: "tenant-last"}}, nil)
+
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	purgedTenants := map[string]bool{}
+	mockRepo.EXPECT().
+		PurgeDeleted(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, deletedBefore time.Time) (int64, error) {
+			tenantID, _ := domains.TenantFromContext(ctx)
+			purgedTenants[tenantID] = true
+
+			if deletedBefore.Sub(cutoff).Abs() > time.Minute {
+				t.Errorf("expected a cutoff of 30 days ago, got %v", deletedBefore)
+			}
+			return 2, nil
+		}).
+		Times(101)
+
+	purged, err := usecase.PurgeDeletedUsers(context.Background())
+	if err != nil {
+		t.Fatalf("PurgeDeletedUsers failed: %v", err)
+	}
+
+	if purged != 202 {
+		t.Errorf("expected 202 purged users, got %d", purged)
+	}
+
+	if len(purgedTenants) != 101 || !purgedTenants["tenant-0"] || !purgedTenants["tenant-last"] {
+		t.Errorf("expected every tenant to be purged, got %d", len(purgedTenants))
+	}
+}
+
+func TestPurgeDeletedUsers_ContinuesAfterFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTenants := mocks.NewMockTenantRepository(ctrl)
+	usecase := usecases.NewUserRetentionUsecase(mockRepo, mockTenants, slog.New(slog.DiscardHandler), usecases.UserRetentionConfig{
+		Retention: time.Hour,
+	})
+
+	mockTenants.EXPECT().List(gomock.Any(), gomock.Any(), int32(0)).Return([]*domains.Tenant{{ID: "tenant-1"}, {ID: "tenant-2"}}, nil)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).Return(int64(0), fmt.Errorf("lock wait timeout")),
+		mockRepo.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).Return(int64(3), nil),
+	)
+
+	purged, err := usecase.PurgeDeletedUsers(context.Background())
+	if err == nil {
+		t.Error("expected the failure to be reported")
+	}
+
+	if purged != 3 {
+		t.Errorf("expected the second tenant to be purged, got %d", purged)
+	}
+}
+
+func TestPurgeDeletedUsers_DisabledWithoutRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Neither repository may be called
+	usecase := usecases.NewUserRetentionUsecase(mocks.NewMockUserRepository(ctrl), mocks.NewMockTenantRepository(ctrl), slog.New(slog.DiscardHandler), usecases.UserRetentionConfig{})
+
+	purged, err := usecase.PurgeDeletedUsers(context.Background())
+	if err != nil || purged != 0 {
+		t.Errorf("expected nothing to be purged, got %d, %v", purged, err)
+	}
+}
@@ -2,12 +2,15 @@ package usecases_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/zercle/template-go-fiber/internal/domains"
+	"github.com/zercle/template-go-fiber/internal/errors"
 	"github.com/zercle/template-go-fiber/internal/usecases"
+	"github.com/zercle/template-go-fiber/pkg/password"
 	"github.com/zercle/template-go-fiber/test/unit/mocks"
 )
 
@@ -16,7 +19,9 @@ func TestRegisterUser_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	mockVerification := mocks.NewMockEmailVerificationUsecase(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mockRoles, mockVerification, usecases.UserConfig{})
 
 	// Expect GetByEmail to be called to check for duplicates
 	mockRepo.EXPECT().
@@ -30,6 +35,18 @@ func TestRegisterUser_Success(t *testing.T) {
 		Return(nil).
 		Times(1)
 
+	// Expect the default role to be granted
+	mockRoles.EXPECT().
+		AssignRole(gomock.Any(), gomock.Any(), domains.RoleUser).
+		Return(nil).
+		Times(1)
+
+	// Expect a verification email to be sent
+	mockVerification.EXPECT().
+		SendVerification(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
 	input := &domains.RegisterUserInput{
 		Email:    "newuser@example.com",
 		Password: "password123",
@@ -52,6 +69,65 @@ func TestRegisterUser_Success(t *testing.T) {
 	if !user.IsActive {
 		t.Error("expected user to be active")
 	}
+
+	if user.EmailVerifiedAt != nil {
+		t.Error("expected email to be unverified")
+	}
+
+	if user.PasswordHash == "password123" || password.Identify(user.PasswordHash) != password.AlgorithmBcrypt {
+		t.Errorf("expected bcrypt password hash, got %s", user.PasswordHash)
+	}
+}
+
+func TestRegisterUser_PreverifiedEmailSkipsVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRoles := mocks.NewMockRoleRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mockRoles, mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	mockRepo.EXPECT().GetByEmail(gomock.Any(), "invitee@example.com").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockRoles.EXPECT().AssignRole(gomock.Any(), gomock.Any(), domains.RoleUser).Return(nil).Times(1)
+
+	// No verification email is expected
+	user, err := usecase.RegisterUser(context.Background(), &domains.RegisterUserInput{
+		Email:         "invitee@example.com",
+		Password:      "password123",
+		EmailVerified: true,
+	})
+
+	if err != nil {
+		t.Fatalf("RegisterUser failed: %v", err)
+	}
+
+	if user.EmailVerifiedAt == nil {
+		t.Error("expected email to be verified")
+	}
+}
+
+func TestRegisterUser_PasswordTooLong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	input := &domains.RegisterUserInput{
+		Email:    "user@example.com",
+		Password: string(make([]byte, password.MaxLength+1)),
+	}
+
+	user, err := usecase.RegisterUser(context.Background(), input)
+
+	if err == nil {
+		t.Fatal("expected error for overlong password")
+	}
+
+	if user != nil {
+		t.Fatal("expected nil user")
+	}
 }
 
 func TestRegisterUser_DuplicateEmail(t *testing.T) {
@@ -59,7 +135,7 @@ func TestRegisterUser_DuplicateEmail(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	existingUser := &domains.User{
 		ID:    "123",
@@ -88,12 +164,37 @@ func TestRegisterUser_DuplicateEmail(t *testing.T) {
 	}
 }
 
+func TestRegisterUser_ConcurrentDuplicateEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	// The email is normalized before the lookup
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "new@example.com").
+		Return(nil, nil)
+
+	// Another registration took the address between the check and the insert
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(errors.NewDuplicateEntryError("email is already registered to another user"))
+
+	_, err := usecase.RegisterUser(context.Background(), &domains.RegisterUserInput{
+		Email:    " New@Example.com ",
+		Password: "password123",
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeDuplicateEntry)
+}
+
 func TestRegisterUser_MissingEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	input := &domains.RegisterUserInput{
 		Email:    "",
@@ -116,7 +217,7 @@ func TestRegisterUser_MissingPassword(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	input := &domains.RegisterUserInput{
 		Email:    "user@example.com",
@@ -139,7 +240,7 @@ func TestGetUserByID_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	expectedUser := &domains.User{
 		ID:       "123",
@@ -169,7 +270,7 @@ func TestGetUserByID_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	mockRepo.EXPECT().
 		GetByID(gomock.Any(), "nonexistent").
@@ -192,7 +293,7 @@ func TestGetUserByEmail_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	expectedUser := &domains.User{
 		ID:       "123",
@@ -221,12 +322,15 @@ func TestUpdateUser_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	mockVerification := mocks.NewMockEmailVerificationUsecase(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mockVerification, usecases.UserConfig{})
 
+	verifiedAt := time.Now()
 	existingUser := &domains.User{
-		ID:       "123",
-		Email:    "user@example.com",
-		IsActive: true,
+		ID:              "123",
+		Email:           "user@example.com",
+		IsActive:        true,
+		EmailVerifiedAt: &verifiedAt,
 	}
 
 	mockRepo.EXPECT().
@@ -245,6 +349,12 @@ func TestUpdateUser_Success(t *testing.T) {
 		Return(nil).
 		Times(1)
 
+	// The new address has to be verified again
+	mockVerification.EXPECT().
+		SendVerification(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
 	input := &domains.UpdateUserInput{
 		Email: &newEmail,
 	}
@@ -258,6 +368,51 @@ func TestUpdateUser_Success(t *testing.T) {
 	if user.Email != newEmail {
 		t.Errorf("expected email %s, got %s", newEmail, user.Email)
 	}
+
+	if user.EmailVerifiedAt != nil {
+		t.Error("expected changed email to be unverified")
+	}
+}
+
+func TestUpdateUser_DeactivationRevokesSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mockRevocations, mockRefresh, mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	mockRevocations.EXPECT().
+		RevokeUserTokens(gomock.Any(), "123").
+		Return(nil).
+		Times(1)
+
+	mockRefresh.EXPECT().
+		RevokeAllForUser(gomock.Any(), "123").
+		Return(nil).
+		Times(1)
+
+	inactive := false
+	user, err := usecase.UpdateUser(context.Background(), "123", &domains.UpdateUserInput{IsActive: &inactive})
+
+	if err != nil {
+		t.Fatalf("UpdateUser failed: %v", err)
+	}
+
+	if user.IsActive {
+		t.Error("expected user to be inactive")
+	}
 }
 
 func TestUpdateUser_NotFound(t *testing.T) {
@@ -265,7 +420,7 @@ func TestUpdateUser_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	mockRepo.EXPECT().
 		GetByID(gomock.Any(), "nonexistent").
@@ -285,12 +440,60 @@ func TestUpdateUser_NotFound(t *testing.T) {
 	}
 }
 
+func TestUpdateUser_StaleVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", Version: 3}, nil).
+		Times(1)
+
+	// The caller read version 2, so nothing is written
+	version := int32(2)
+	firstName := "Jane"
+	_, err := usecase.UpdateUser(context.Background(), "123", &domains.UpdateUserInput{FirstName: &firstName, Version: &version})
+
+	assertAPIErrorCode(t, err, errors.ErrCodePreconditionFailed)
+}
+
+func TestPatchUser_SelfCannotChangeStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", IsActive: true}, nil).
+		Times(1)
+
+	// Users may patch their own names, but not reactivate or deactivate themselves
+	_, err := usecase.PatchUser(ctx, "123", &domains.PatchUserInput{
+		ContentType: "application/json-patch+json",
+		Patch:       []byte(`[{"op":"replace","path":"/is_active","value":false}]`),
+	})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
 func TestDeleteUser_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	mockRevocations := mocks.NewMockTokenRevocationStore(ctrl)
+	mockRefresh := mocks.NewMockRefreshTokenRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mockRevocations, mockRefresh, mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	existingUser := &domains.User{
 		ID:    "123",
@@ -303,30 +506,57 @@ func TestDeleteUser_Success(t *testing.T) {
 		Times(1)
 
 	mockRepo.EXPECT().
-		Delete(gomock.Any(), "123").
+		Delete(gomock.Any(), "123", int32(0)).
+		Return(nil).
+		Times(1)
+
+	// Existing sessions must not outlive the account
+	mockRevocations.EXPECT().
+		RevokeUserTokens(gomock.Any(), "123").
 		Return(nil).
 		Times(1)
 
-	err := usecase.DeleteUser(context.Background(), "123")
+	mockRefresh.EXPECT().
+		RevokeAllForUser(gomock.Any(), "123").
+		Return(nil).
+		Times(1)
+
+	err := usecase.DeleteUser(context.Background(), "123", nil)
 
 	if err != nil {
 		t.Fatalf("DeleteUser failed: %v", err)
 	}
 }
 
+func TestDeleteUser_RejectedWhileImpersonating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewUserUsecase(mocks.NewMockUserRepository(ctrl), newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-2",
+		Permissions: []string{domains.PermissionUsersDelete},
+		ActorID:     "admin-1",
+	})
+
+	err := usecase.DeleteUser(ctx, "123", nil)
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
 func TestDeleteUser_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	mockRepo.EXPECT().
 		GetByID(gomock.Any(), "nonexistent").
 		Return(nil, nil).
 		Times(1)
 
-	err := usecase.DeleteUser(context.Background(), "nonexistent")
+	err := usecase.DeleteUser(context.Background(), "nonexistent", nil)
 
 	if err == nil {
 		t.Fatal("expected error for user not found")
@@ -338,7 +568,7 @@ func TestListUsers_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	expectedUsers := []*domains.User{
 		{ID: "1", Email: "user1@example.com"},
@@ -346,11 +576,11 @@ func TestListUsers_Success(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		List(gomock.Any(), int32(10), int32(0)).
+		List(gomock.Any(), &domains.UserListFilter{}, int32(10), int32(0)).
 		Return(expectedUsers, nil).
 		Times(1)
 
-	users, err := usecase.ListUsers(context.Background(), 10, 0)
+	users, err := usecase.ListUsers(context.Background(), nil, 10, 0)
 
 	if err != nil {
 		t.Fatalf("ListUsers failed: %v", err)
@@ -366,16 +596,482 @@ func TestListUsers_PaginationDefaults(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	usecase := usecases.NewUserUsecase(mockRepo)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
 
 	mockRepo.EXPECT().
-		List(gomock.Any(), int32(10), int32(0)).
+		List(gomock.Any(), &domains.UserListFilter{}, int32(10), int32(0)).
 		Return([]*domains.User{}, nil).
 		Times(1)
 
 	// Test that default limit is applied
-	_, err := usecase.ListUsers(context.Background(), 0, -1)
+	_, err := usecase.ListUsers(context.Background(), nil, 0, -1)
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+}
+
+func TestGetUserByID_SelfAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", IsActive: true}, nil).
+		Times(1)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead},
+	})
+
+	if _, err := usecase.GetUserByID(ctx, "123"); err != nil {
+		t.Fatalf("GetUserByID failed: %v", err)
+	}
+
+	// The self permission does not extend to other accounts
+	_, err := usecase.GetUserByID(ctx, "456")
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestGetUserByEmail_ForbiddenForOtherUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Email:       "user@example.com",
+		Permissions: []string{domains.PermissionSelfRead},
+	})
+
+	// Rejected before the lookup so the response does not reveal whether the email exists
+	_, err := usecase.GetUserByEmail(ctx, "other@example.com")
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestUpdateUser_SelfCannotChangeStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	isActive := true
+	_, err := usecase.UpdateUser(ctx, "123", &domains.UpdateUserInput{IsActive: &isActive})
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestDeleteUser_RequiresPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead, domains.PermissionSelfWrite},
+	})
+
+	err := usecase.DeleteUser(ctx, "123", nil)
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestListUsers_AdminAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	mockRepo.EXPECT().
+		List(gomock.Any(), &domains.UserListFilter{}, int32(10), int32(0)).
+		Return([]*domains.User{}, nil).
+		Times(1)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Roles:       []string{domains.RoleAdmin},
+		Permissions: []string{domains.PermissionUsersRead},
+	})
+
+	if _, err := usecase.ListUsers(ctx, nil, 10, 0); err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+}
+
+func TestListUsers_NormalizesFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	mockRepo.EXPECT().
+		List(gomock.Any(), &domains.UserListFilter{EmailDomain: "example.com", Search: "jane", SortBy: domains.UserSortEmail, SortDesc: true}, int32(10), int32(0)).
+		Return([]*domains.User{}, nil).
+		Times(1)
+
+	_, err := usecase.ListUsers(context.Background(), &domains.UserListFilter{
+		EmailDomain: " @Example.COM ",
+		Search:      "  jane ",
+		SortBy:      domains.UserSortEmail,
+		SortDesc:    true,
+	}, 10, 0)
 	if err != nil {
 		t.Fatalf("ListUsers failed: %v", err)
 	}
 }
+
+func TestListUsers_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		filter *domains.UserListFilter
+	}{
+		{"unknown sort field", &domains.UserListFilter{SortBy: "password_hash"}},
+		{"inverted date range", &domains.UserListFilter{CreatedAfter: &now, CreatedBefore: &earlier}},
+		{"email as domain", &domains.UserListFilter{EmailDomain: "jane@example.com"}},
+		{"search too long", &domains.UserListFilter{Search: strings.Repeat("a", 101)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := usecase.ListUsers(context.Background(), tt.filter, 10, 0)
+
+			assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+		})
+	}
+}
+
+func TestCountUsers_AppliesListFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	// The count is normalized like the list, so both describe the same users
+	mockRepo.EXPECT().
+		Count(gomock.Any(), &domains.UserListFilter{EmailDomain: "example.com", Search: "jane"}).
+		Return(int64(7), nil).
+		Times(1)
+
+	count, err := usecase.CountUsers(context.Background(), &domains.UserListFilter{EmailDomain: "@Example.com", Search: " jane "})
+	if err != nil {
+		t.Fatalf("CountUsers failed: %v", err)
+	}
+
+	if count != 7 {
+		t.Errorf("expected count 7, got %d", count)
+	}
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "123",
+		Permissions: []string{domains.PermissionSelfRead},
+	})
+
+	_, err = usecase.CountUsers(ctx, nil)
+
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+func TestListUsersByCursor_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{CursorSecret: []byte("cursor-secret")})
+
+	now := time.Now()
+	newest := &domains.User{ID: "3", CreatedAt: now}
+	middle := &domains.User{ID: "2", CreatedAt: now.Add(-time.Minute)}
+	oldest := &domains.User{ID: "1", CreatedAt: now.Add(-2 * time.Minute)}
+
+	// One user more than the limit is asked for to detect the next page
+	mockRepo.EXPECT().
+		ListByCursor(gomock.Any(), gomock.Any(), (*domains.UserCursor)(nil), int32(3)).
+		Return([]*domains.User{newest, middle, oldest}, nil).
+		Times(1)
+
+	first, err := usecase.ListUsersByCursor(context.Background(), nil, "", 2)
+	if err != nil {
+		t.Fatalf("ListUsersByCursor failed: %v", err)
+	}
+
+	if len(first.Users) != 2 || first.NextCursor == "" || first.PrevCursor != "" {
+		t.Fatalf("expected a first page of two users with only a next cursor, got %+v", first)
+	}
+
+	mockRepo.EXPECT().
+		ListByCursor(gomock.Any(), gomock.Any(), gomock.Any(), int32(3)).
+		DoAndReturn(func(ctx context.Context, filter *domains.UserListFilter, cursor *domains.UserCursor, limit int32) ([]*domains.User, error) {
+			if cursor.ID != middle.ID || !cursor.CreatedAt.Equal(middle.CreatedAt) || cursor.Backward {
+				t.Errorf("expected a forward cursor at the last user of the first page, got %+v", cursor)
+			}
+			return []*domains.User{oldest}, nil
+		}).
+		Times(1)
+
+	last, err := usecase.ListUsersByCursor(context.Background(), nil, first.NextCursor, 2)
+	if err != nil {
+		t.Fatalf("ListUsersByCursor failed: %v", err)
+	}
+
+	if len(last.Users) != 1 || last.NextCursor != "" || last.PrevCursor == "" {
+		t.Fatalf("expected a last page of one user with only a previous cursor, got %+v", last)
+	}
+
+	mockRepo.EXPECT().
+		ListByCursor(gomock.Any(), gomock.Any(), gomock.Any(), int32(3)).
+		DoAndReturn(func(ctx context.Context, filter *domains.UserListFilter, cursor *domains.UserCursor, limit int32) ([]*domains.User, error) {
+			if cursor.ID != oldest.ID || !cursor.Backward {
+				t.Errorf("expected a backward cursor at the first user of the last page, got %+v", cursor)
+			}
+			return []*domains.User{newest, middle}, nil
+		}).
+		Times(1)
+
+	back, err := usecase.ListUsersByCursor(context.Background(), nil, last.PrevCursor, 2)
+	if err != nil {
+		t.Fatalf("ListUsersByCursor failed: %v", err)
+	}
+
+	if len(back.Users) != 2 || back.NextCursor == "" || back.PrevCursor != "" {
+		t.Errorf("expected to be back on the first page, got %+v", back)
+	}
+}
+
+func TestListUsersByCursor_InvalidCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := usecases.UserConfig{CursorSecret: []byte("cursor-secret")}
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), config)
+
+	// A cursor of a page sorted oldest first
+	mockRepo.EXPECT().
+		ListByCursor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*domains.User{{ID: "1"}, {ID: "2"}}, nil).
+		Times(1)
+
+	page, err := usecase.ListUsersByCursor(context.Background(), &domains.UserListFilter{SortBy: domains.UserSortCreatedAt}, "", 1)
+	if err != nil {
+		t.Fatalf("ListUsersByCursor failed: %v", err)
+	}
+
+	// A cursor signed with another secret
+	other := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{CursorSecret: []byte("other-secret")})
+	mockRepo.EXPECT().
+		ListByCursor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*domains.User{{ID: "1"}, {ID: "2"}}, nil).
+		Times(1)
+
+	foreign, err := other.ListUsersByCursor(context.Background(), nil, "", 1)
+	if err != nil {
+		t.Fatalf("ListUsersByCursor failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter *domains.UserListFilter
+		cursor string
+	}{
+		{"malformed cursor", nil, "garbage"},
+		{"foreign cursor", nil, foreign.NextCursor},
+		{"cursor of another sort", nil, page.NextCursor},
+		{"sort other than created_at", &domains.UserListFilter{SortBy: domains.UserSortEmail}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := usecase.ListUsersByCursor(context.Background(), tt.filter, tt.cursor, 1)
+
+			assertAPIErrorCode(t, err, errors.ErrCodeValidation)
+		})
+	}
+}
+
+func TestRestoreUser_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	deletedAt := time.Now()
+	mockRepo.EXPECT().
+		GetDeletedByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", DeletedAt: &deletedAt}, nil)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(nil, nil)
+
+	mockRepo.EXPECT().
+		Restore(gomock.Any(), "123").
+		Return(true, nil)
+
+	user, err := usecase.RestoreUser(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("RestoreUser failed: %v", err)
+	}
+
+	if user.DeletedAt != nil {
+		t.Error("expected the restored user to have no deletion time")
+	}
+}
+
+func TestRestoreUser_EmailRegisteredAgain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	deletedAt := time.Now()
+	mockRepo.EXPECT().
+		GetDeletedByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", Email: "user@example.com", DeletedAt: &deletedAt}, nil)
+
+	mockRepo.EXPECT().
+		GetByEmail(gomock.Any(), "user@example.com").
+		Return(&domains.User{ID: "456", Email: "user@example.com"}, nil)
+
+	// Restore must not be called
+	_, err := usecase.RestoreUser(context.Background(), "123")
+	assertAPIErrorCode(t, err, errors.ErrCodeDuplicateEntry)
+}
+
+func TestRestoreUser_NotDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	mockRepo.EXPECT().
+		GetDeletedByID(gomock.Any(), "123").
+		Return(nil, nil)
+
+	_, err := usecase.RestoreUser(context.Background(), "123")
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestHardDeleteUser_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	deletedAt := time.Now()
+	mockRepo.EXPECT().
+		GetDeletedByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", DeletedAt: &deletedAt}, nil)
+
+	mockRepo.EXPECT().
+		HardDelete(gomock.Any(), "123").
+		Return(true, nil)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Permissions: []string{domains.PermissionUsersPurge},
+	})
+
+	if err := usecase.HardDeleteUser(ctx, "123"); err != nil {
+		t.Fatalf("HardDeleteUser failed: %v", err)
+	}
+}
+
+func TestHardDeleteUser_LiveUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	// Only soft-deleted users are found; HardDelete must not be called
+	mockRepo.EXPECT().
+		GetDeletedByID(gomock.Any(), "123").
+		Return(nil, nil)
+
+	err := usecase.HardDeleteUser(context.Background(), "123")
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestHardDeleteUser_RestoredConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	usecase := usecases.NewUserUsecase(mockRepo, newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	deletedAt := time.Now()
+	mockRepo.EXPECT().
+		GetDeletedByID(gomock.Any(), "123").
+		Return(&domains.User{ID: "123", DeletedAt: &deletedAt}, nil)
+
+	// The user was restored between the read and the delete
+	mockRepo.EXPECT().
+		HardDelete(gomock.Any(), "123").
+		Return(false, nil)
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Permissions: []string{domains.PermissionUsersPurge},
+	})
+
+	err := usecase.HardDeleteUser(ctx, "123")
+	assertAPIErrorCode(t, err, errors.ErrCodeNotFound)
+}
+
+func TestHardDeleteUser_RequiresPurgePermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := usecases.NewUserUsecase(mocks.NewMockUserRepository(ctrl), newTestHasher(t), mocks.NewMockTokenRevocationStore(ctrl), mocks.NewMockRefreshTokenRepository(ctrl), mocks.NewMockRoleRepository(ctrl), mocks.NewMockEmailVerificationUsecase(ctrl), usecases.UserConfig{})
+
+	ctx := domains.WithPrincipal(context.Background(), &domains.Principal{
+		UserID:      "admin-1",
+		Permissions: []string{domains.PermissionUsersDelete},
+	})
+
+	err := usecase.HardDeleteUser(ctx, "123")
+	assertAPIErrorCode(t, err, errors.ErrCodeForbidden)
+}
+
+// Helper function
+func newTestHasher(t *testing.T) password.Hasher {
+	t.Helper()
+
+	hasher, err := password.New(password.Options{
+		Algorithm: password.AlgorithmBcrypt,
+		Bcrypt:    password.BcryptOptions{Cost: 4},
+	})
+	if err != nil {
+		t.Fatalf("failed to create hasher: %v", err)
+	}
+	return hasher
+}